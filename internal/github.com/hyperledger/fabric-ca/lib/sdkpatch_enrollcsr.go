@@ -0,0 +1,66 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+/*
+Notice: This file has been modified for Hyperledger Fabric SDK Go usage.
+Please review third_party pinning scripts and patches for more details.
+*/
+
+package lib
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/hyperledger/fabric-sdk-go/internal/github.com/hyperledger/fabric-ca/api"
+	log "github.com/hyperledger/fabric-sdk-go/internal/github.com/hyperledger/fabric-ca/sdkpatch/logbridge"
+	"github.com/hyperledger/fabric-sdk-go/internal/github.com/hyperledger/fabric-ca/util"
+)
+
+// EnrollWithCSR enrolls a new identity using a pre-built (PEM encoded) CSR.
+// The private key of the CSR is not known to the client and the identity of the
+// response therefore has no key.
+func (c *Client) EnrollWithCSR(req *api.EnrollmentRequest, csrPEM []byte) (*EnrollmentResponse, error) {
+	log.Debugf("Enrolling with CSR %+v", req)
+
+	if len(csrPEM) == 0 {
+		return nil, errors.New("CSR is required")
+	}
+
+	err := c.Init()
+	if err != nil {
+		return nil, err
+	}
+
+	reqNet := &api.EnrollmentRequestNet{
+		CAName:   req.CAName,
+		AttrReqs: req.AttrReqs,
+	}
+
+	if req.CSR != nil {
+		reqNet.SignRequest.Hosts = req.CSR.Hosts
+	}
+	reqNet.SignRequest.Request = string(csrPEM)
+	reqNet.SignRequest.Profile = req.Profile
+	reqNet.SignRequest.Label = req.Label
+
+	body, err := util.Marshal(reqNet, "SignRequest")
+	if err != nil {
+		return nil, err
+	}
+
+	// Send the CSR to the fabric-ca server with basic auth header
+	post, err := c.newPost("enroll", body)
+	if err != nil {
+		return nil, err
+	}
+	post.SetBasicAuth(req.Name, req.Secret)
+	var result enrollmentResponseNet
+	err = c.SendReq(post, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.newEnrollmentResponse(&result, req.Name, nil)
+}
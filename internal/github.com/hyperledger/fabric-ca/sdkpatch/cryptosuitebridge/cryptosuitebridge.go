@@ -117,3 +117,8 @@ func GetX509PublicKeyImportOpts(ephemeral bool) core.KeyImportOpts {
 func GetECDSAPrivateKeyImportOpts(ephemeral bool) core.KeyImportOpts {
 	return &bccsp.ECDSAPrivateKeyImportOpts{Temporary: ephemeral}
 }
+
+//GetED25519PrivateKeyImportOpts options for Ed25519 secret key importation in PKCS#8 format.
+func GetED25519PrivateKeyImportOpts(ephemeral bool) core.KeyImportOpts {
+	return &bccsp.ED25519PrivateKeyImportOpts{Temporary: ephemeral}
+}
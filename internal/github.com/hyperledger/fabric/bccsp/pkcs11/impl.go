@@ -71,7 +71,7 @@ func New(opts PKCS11Opts, keyStore bccsp.KeyStore) (bccsp.BCCSP, error) {
 	}
 
 	sessions := make(chan pkcs11.SessionHandle, sessionCacheSize)
-	csp := &impl{swCSP, conf, keyStore, ctx, sessions, slot, lib, opts.Sensitive, opts.SoftVerify}
+	csp := &impl{swCSP, conf, keyStore, ctx, sessions, slot, lib, opts.Sensitive, opts.SoftVerify, label}
 	csp.returnSession(*session)
 	return csp, nil
 }
@@ -89,6 +89,7 @@ type impl struct {
 	lib          string
 	noPrivImport bool
 	softVerify   bool
+	label        string
 }
 
 // KeyGen generates a key using opts.
@@ -123,6 +124,10 @@ func (csp *impl) KeyGen(opts bccsp.KeyGenOpts) (k bccsp.Key, err error) {
 
 		k = &ecdsaPrivateKey{ski, ecdsaPublicKey{ski, pub}}
 
+	case *bccsp.ED25519KeyGenOpts:
+		// Don't fall back to software keys since the private key is expected to be held by the HSM
+		return nil, errors.New("Ed25519 keys are not supported by the PKCS11 BCCSP")
+
 	default:
 		return csp.BCCSP.KeyGen(opts)
 	}
@@ -427,6 +432,9 @@ func (csp *impl) KeyImport(raw interface{}, opts bccsp.KeyImportOpts) (k bccsp.K
 			return nil, errors.New("Certificate's public key type not recognized. Supported keys: [ECDSA, RSA]")
 		}
 
+	case *bccsp.ED25519PrivateKeyImportOpts:
+		return nil, errors.New("Ed25519 keys are not supported by the PKCS11 BCCSP")
+
 	default:
 		return csp.BCCSP.KeyImport(raw, opts)
 
@@ -0,0 +1,16 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+/*
+Notice: This file has been modified for Hyperledger Fabric SDK Go usage.
+Please review third_party pinning scripts and patches for more details.
+*/
+
+package pkcs11
+
+// TokenLabel returns the label of the token whose slot was selected when the library was loaded
+func (csp *impl) TokenLabel() string {
+	return csp.label
+}
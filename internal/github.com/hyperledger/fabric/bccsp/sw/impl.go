@@ -140,6 +140,8 @@ func New(securityLevel int, hashFamily string, keyStore bccsp.KeyStore) (bccsp.B
 
 	impl.keyImporters = keyImporters
 
+	addEd25519Support(impl)
+
 	return impl, nil
 }
 
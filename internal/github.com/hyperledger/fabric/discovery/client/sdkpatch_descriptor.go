@@ -0,0 +1,51 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+/*
+Notice: This file has been modified for Hyperledger Fabric SDK Go usage.
+Please review third_party pinning scripts and patches for more details.
+*/
+
+package discovery
+
+import (
+	"github.com/hyperledger/fabric-sdk-go/internal/github.com/hyperledger/fabric/protos/discovery"
+)
+
+// EndorsementDescriptor contains the endorsement layouts of a chaincode and the
+// endorsers of each of the groups referenced by the layouts. Each layout maps a
+// group to the number of endorsements that are required from that group.
+type EndorsementDescriptor struct {
+	EndorsersByGroups map[string][]*Peer
+	Layouts           []map[string]int
+}
+
+// EndorsementDescriptor returns the endorsement descriptor for the given chaincode
+// in the channel context, or error if something went wrong.
+func (cr *channelResponse) EndorsementDescriptor(cc string) (*EndorsementDescriptor, error) {
+	// If we have a key that has no chaincode field,
+	// it means it's an error returned from the service
+	if err, exists := cr.response[key{
+		queryType: discovery.ChaincodeQueryType,
+		channel:   cr.channel,
+	}]; exists {
+		return nil, err.(error)
+	}
+
+	res, exists := cr.response[key{
+		queryType: discovery.ChaincodeQueryType,
+		channel:   cr.channel,
+		chaincode: cc,
+	}]
+	if !exists {
+		return nil, ErrNotFound
+	}
+
+	desc := res.(*endorsementDescriptor)
+	return &EndorsementDescriptor{
+		EndorsersByGroups: desc.endorsersByGroups,
+		Layouts:           desc.layouts,
+	}, nil
+}
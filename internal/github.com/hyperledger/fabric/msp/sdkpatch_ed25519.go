@@ -0,0 +1,47 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+/*
+Notice: This file has been modified for Hyperledger Fabric SDK Go usage.
+Please review third_party pinning scripts and patches for more details.
+*/
+
+package msp
+
+import (
+	"crypto/ed25519"
+
+	"github.com/pkg/errors"
+)
+
+// isEd25519 returns true if the certificate of the identity holds an Ed25519 public key.
+// Ed25519 signs (and verifies) the message itself rather than a digest of it.
+func (id *identity) isEd25519() bool {
+	if id.cert == nil {
+		return false
+	}
+	_, ok := id.cert.PublicKey.(ed25519.PublicKey)
+	return ok
+}
+
+// digest returns the digest of the message which is signed (and verified) with the key of the identity,
+// that is the hash of the message or, for Ed25519, the message itself
+func (id *identity) digest(msg []byte) ([]byte, error) {
+	if id.isEd25519() {
+		return msg, nil
+	}
+
+	hashOpt, err := id.getHashOpt(id.msp.cryptoConfig.SignatureHashFamily)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed getting hash function options")
+	}
+
+	digest, err := id.msp.bccsp.Hash(msg, hashOpt)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed computing digest")
+	}
+
+	return digest, nil
+}
@@ -10,6 +10,7 @@ import (
 	reqContext "context"
 	"time"
 
+	"github.com/hyperledger/fabric-sdk-go/pkg/client/channel/invoke"
 	"github.com/hyperledger/fabric-sdk-go/pkg/common/errors/retry"
 	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/context"
 	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
@@ -20,11 +21,12 @@ import (
 
 // opts allows the user to specify more advanced options
 type requestOptions struct {
-	Targets       []fab.Peer // targets
-	TargetFilter  fab.TargetFilter
-	Retry         retry.Opts
-	Timeouts      map[fab.TimeoutType]time.Duration //timeout options for channel client operations
-	ParentContext reqContext.Context                //parent grpc context for channel client operations (query, execute, invokehandler)
+	Targets          []fab.Peer // targets
+	TargetFilter     fab.TargetFilter
+	Retry            retry.Opts
+	Timeouts         map[fab.TimeoutType]time.Duration //timeout options for channel client operations
+	ParentContext    reqContext.Context                //parent grpc context for channel client operations (query, execute, invokehandler)
+	EndorserSelector invoke.EndorserSelector           //custom endorser selection
 }
 
 // RequestOption func for each Opts argument
@@ -99,7 +101,21 @@ func WithTargetFilter(filter fab.TargetFilter) RequestOption {
 	}
 }
 
-// WithRetry option to configure retries
+// WithEndorserSelector specifies a per-request function which selects (and orders) the endorsers from
+// the candidate peers of the channel, e.g. to prefer peers of the local organization. The selector is
+// passed the endorsement policy of the chaincode. The selection service is used if no selector is provided.
+func WithEndorserSelector(selector invoke.EndorserSelector) RequestOption {
+	return func(ctx context.Client, o *requestOptions) error {
+		if selector == nil {
+			return errors.New("endorser selector is nil")
+		}
+		o.EndorserSelector = selector
+		return nil
+	}
+}
+
+// WithRetry option to configure retries for the request. The retry options override the
+// default retry options of the client for this request only.
 func WithRetry(retryOpt retry.Opts) RequestOption {
 	return func(ctx context.Client, o *requestOptions) error {
 		o.Retry = retryOpt
@@ -125,3 +141,35 @@ func WithParentContext(parentContext reqContext.Context) RequestOption {
 		return nil
 	}
 }
+
+// commitWaitOptions contains the options for WaitForCommit
+type commitWaitOptions struct {
+	Interval time.Duration // interval between ledger queries
+	Timeout  time.Duration // maximum time to wait for the transaction to be committed
+}
+
+// CommitWaitOption func for each commitWaitOptions argument
+type CommitWaitOption func(opts *commitWaitOptions) error
+
+// WithPollInterval sets the interval at which the ledger is queried for the transaction
+func WithPollInterval(interval time.Duration) CommitWaitOption {
+	return func(o *commitWaitOptions) error {
+		if interval <= 0 {
+			return errors.Errorf("invalid poll interval [%s]", interval)
+		}
+		o.Interval = interval
+		return nil
+	}
+}
+
+// WithCommitTimeout sets the maximum time to wait for the transaction to be committed.
+// The default is the Execute timeout from the endpoint config.
+func WithCommitTimeout(timeout time.Duration) CommitWaitOption {
+	return func(o *commitWaitOptions) error {
+		if timeout <= 0 {
+			return errors.Errorf("invalid commit timeout [%s]", timeout)
+		}
+		o.Timeout = timeout
+		return nil
+	}
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
 	fcmocks "github.com/hyperledger/fabric-sdk-go/pkg/fab/mocks"
 	mspmocks "github.com/hyperledger/fabric-sdk-go/pkg/msp/test/mockmsp"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/common"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -99,3 +100,18 @@ func TestTimeoutOptions(t *testing.T) {
 	assert.True(t, opts.Timeouts[fab.Query] == 45*time.Second, "timeout value by type didn't match with one supplied")
 
 }
+
+func TestWithEndorserSelector(t *testing.T) {
+	ctx := setupMockTestContext("test", "Org1MSP")
+
+	opts := requestOptions{}
+	err := WithEndorserSelector(nil)(ctx, &opts)
+	assert.NotNil(t, err, "Should have failed for nil selector")
+
+	selector := func(peers []fab.Peer, policy *common.SignaturePolicyEnvelope) ([]fab.Peer, error) {
+		return peers, nil
+	}
+	err = WithEndorserSelector(selector)(ctx, &opts)
+	assert.Nil(t, err, "Should not have failed for valid selector")
+	assert.NotNil(t, opts.EndorserSelector, "Expecting selector to be set")
+}
@@ -0,0 +1,218 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package channel
+
+import (
+	"bytes"
+	reqContext "context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ChannelRequest is a request which is executed by BatchExecute using the channel client
+// of the channel on which the transaction is to be submitted.
+type ChannelRequest struct {
+	Client  *Client
+	Request Request
+	Options []RequestOption
+}
+
+// BatchResult is the outcome of a single request executed by BatchExecute
+type BatchResult struct {
+	// Index is the index of the request in the batch
+	Index int
+	// ChannelID is the channel on which the request was executed
+	ChannelID string
+	Response  Response
+	Err       error
+}
+
+// BatchResults holds the outcome of each request of a batch, in the order of the requests
+type BatchResults []BatchResult
+
+// Succeeded returns the results of the requests which were executed successfully
+func (r BatchResults) Succeeded() BatchResults {
+	var results BatchResults
+	for _, result := range r {
+		if result.Err == nil {
+			results = append(results, result)
+		}
+	}
+	return results
+}
+
+// Failed returns the results of the requests which failed
+func (r BatchResults) Failed() BatchResults {
+	var results BatchResults
+	for _, result := range r {
+		if result.Err != nil {
+			results = append(results, result)
+		}
+	}
+	return results
+}
+
+// BatchError is returned by BatchExecute if one or more requests of the batch failed.
+//
+// The requests of a batch are independent transactions on (possibly) different channels, so the
+// failure of one request doesn't roll back the requests which succeeded. It is up to the application
+// to compensate for these (e.g. by submitting a reversing transaction) if required.
+type BatchError struct {
+	// Results holds the outcome of all of the requests of the batch
+	Results BatchResults
+}
+
+// Error returns the error message listing the failed requests
+func (e *BatchError) Error() string {
+	failed := e.Results.Failed()
+
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "%d of %d requests failed (requests which succeeded were not rolled back)", len(failed), len(e.Results))
+	for _, result := range failed {
+		fmt.Fprintf(&msg, "; request %d on channel [%s]: %s", result.Index, result.ChannelID, result.Err)
+	}
+	return msg.String()
+}
+
+// batchOptions contains the options for BatchExecute
+type batchOptions struct {
+	ParentContext  reqContext.Context // parent context shared by all of the requests
+	Timeout        time.Duration      // maximum time for the entire batch
+	MaxConcurrency int                // maximum number of requests executed concurrently
+}
+
+// BatchOption func for each batchOptions argument
+type BatchOption func(opts *batchOptions) error
+
+// WithBatchParentContext sets the parent context of all of the requests of the batch. Requests
+// which haven't been started by the time the context is done are not submitted.
+func WithBatchParentContext(parentContext reqContext.Context) BatchOption {
+	return func(o *batchOptions) error {
+		if parentContext == nil {
+			return errors.New("parent context is nil")
+		}
+		o.ParentContext = parentContext
+		return nil
+	}
+}
+
+// WithBatchTimeout sets the deadline for the entire batch. Requests which haven't been started
+// by the time the deadline expires are not submitted.
+func WithBatchTimeout(timeout time.Duration) BatchOption {
+	return func(o *batchOptions) error {
+		if timeout <= 0 {
+			return errors.Errorf("invalid batch timeout [%s]", timeout)
+		}
+		o.Timeout = timeout
+		return nil
+	}
+}
+
+// WithMaxConcurrency limits the number of requests of the batch which are executed concurrently.
+// By default all of the requests are executed concurrently.
+func WithMaxConcurrency(max int) BatchOption {
+	return func(o *batchOptions) error {
+		if max <= 0 {
+			return errors.Errorf("invalid max concurrency [%d]", max)
+		}
+		o.MaxConcurrency = max
+		return nil
+	}
+}
+
+// BatchExecute executes the given requests concurrently, each using the channel client of the request
+// (see Client.Execute), and returns the outcome of each request in the order of the requests.
+//
+// The batch is NOT atomic: each request is an independent transaction on its channel and Fabric
+// doesn't support transactions spanning multiple channels. If one or more requests fail then the
+// returned error is a *BatchError and the requests which succeeded remain committed.
+//
+// All of the requests share the parent context (and timeout) provided in the batch options, which
+// overrides any parent context provided in the request options.
+//  Parameters:
+//  requests holds the requests to execute, along with the channel client of each request
+//  options hold optional batch options
+//
+//  Returns:
+//  the outcome of each request
+func BatchExecute(requests []ChannelRequest, options ...BatchOption) (BatchResults, error) {
+	opts := batchOptions{ParentContext: reqContext.Background()}
+	for _, option := range options {
+		if err := option(&opts); err != nil {
+			return nil, errors.WithMessage(err, "failed to read batch options")
+		}
+	}
+
+	ctx := opts.ParentContext
+	if opts.Timeout > 0 {
+		var cancel reqContext.CancelFunc
+		ctx, cancel = reqContext.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+	}
+
+	maxConcurrency := opts.MaxConcurrency
+	if maxConcurrency == 0 || maxConcurrency > len(requests) {
+		maxConcurrency = len(requests)
+	}
+	semaphore := make(chan struct{}, maxConcurrency)
+
+	results := make(BatchResults, len(requests))
+	var wg sync.WaitGroup
+
+	for i, request := range requests {
+		results[i].Index = i
+		if request.Client == nil {
+			results[i].Err = errors.New("channel client is nil")
+			continue
+		}
+		results[i].ChannelID = request.Client.context.ChannelID()
+
+		if err := acquire(ctx, semaphore); err != nil {
+			results[i].Err = errors.Wrap(err, "request was not submitted")
+			continue
+		}
+
+		wg.Add(1)
+		go func(result *BatchResult, request ChannelRequest) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+
+			reqOptions := make([]RequestOption, 0, len(request.Options)+1)
+			reqOptions = append(reqOptions, request.Options...)
+			reqOptions = append(reqOptions, WithParentContext(ctx))
+
+			result.Response, result.Err = request.Client.Execute(request.Request, reqOptions...)
+		}(&results[i], request)
+	}
+
+	wg.Wait()
+
+	for _, result := range results {
+		if result.Err != nil {
+			return results, &BatchError{Results: results}
+		}
+	}
+	return results, nil
+}
+
+// acquire acquires a slot of the given semaphore, unless the context is done first
+func acquire(ctx reqContext.Context, semaphore chan struct{}) error {
+	// Check the context first since select chooses randomly if both cases are ready
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	select {
+	case semaphore <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
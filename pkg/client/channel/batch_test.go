@@ -0,0 +1,104 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package channel
+
+import (
+	reqContext "context"
+	"testing"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/errors/status"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+	fcmocks "github.com/hyperledger/fabric-sdk-go/pkg/fab/mocks"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBatchExecute(t *testing.T) {
+	okClient := setupBatchTestClient(t)
+	failingClient := setupBatchTestClient(t, status.New(status.OrdererClientStatus, status.ConnectionFailed.ToInt32(), "test error", nil))
+
+	request := Request{ChaincodeID: "test", Fcn: "invoke", Args: [][]byte{[]byte("move"), []byte("a"), []byte("b"), []byte("1")}}
+
+	results, err := BatchExecute([]ChannelRequest{
+		{Client: okClient, Request: request},
+		{Client: failingClient, Request: request},
+		{Client: okClient, Request: request},
+	}, WithMaxConcurrency(2))
+	require.Error(t, err, "expecting batch to fail")
+	require.Len(t, results, 3)
+
+	batchErr, ok := err.(*BatchError)
+	require.True(t, ok, "expecting BatchError but got %T", err)
+	assert.Contains(t, batchErr.Error(), "1 of 3 requests failed")
+	assert.Contains(t, batchErr.Error(), "not rolled back")
+
+	failed := results.Failed()
+	require.Len(t, failed, 1)
+	assert.Equal(t, 1, failed[0].Index)
+	assert.Equal(t, channelID, failed[0].ChannelID)
+	s, ok := status.FromError(failed[0].Err)
+	require.True(t, ok, "expecting status error but got %+v", failed[0].Err)
+	assert.Equal(t, status.OrdererClientStatus, s.Group)
+
+	succeeded := results.Succeeded()
+	require.Len(t, succeeded, 2)
+	assert.Equal(t, 0, succeeded[0].Index)
+	assert.Equal(t, 2, succeeded[1].Index)
+	assert.NotEmpty(t, succeeded[0].Response.TransactionID)
+}
+
+func TestBatchExecuteSuccess(t *testing.T) {
+	client := setupBatchTestClient(t)
+	request := Request{ChaincodeID: "test", Fcn: "invoke", Args: [][]byte{[]byte("move"), []byte("a"), []byte("b"), []byte("1")}}
+
+	results, err := BatchExecute([]ChannelRequest{{Client: client, Request: request}, {Client: client, Request: request}}, WithMaxConcurrency(1))
+	assert.NoError(t, err)
+	assert.Len(t, results.Succeeded(), 2)
+	assert.Empty(t, results.Failed())
+
+	results, err = BatchExecute(nil)
+	assert.NoError(t, err)
+	assert.Empty(t, results)
+}
+
+func TestBatchExecuteContextDone(t *testing.T) {
+	client := setupBatchTestClient(t)
+	request := Request{ChaincodeID: "test", Fcn: "invoke", Args: [][]byte{[]byte("move"), []byte("a"), []byte("b"), []byte("1")}}
+
+	ctx, cancel := reqContext.WithCancel(reqContext.Background())
+	cancel()
+
+	results, err := BatchExecute([]ChannelRequest{{Client: client, Request: request}, {Request: request}}, WithBatchParentContext(ctx))
+	require.Error(t, err)
+	require.Len(t, results.Failed(), 2)
+	assert.Equal(t, reqContext.Canceled, errors.Cause(results[0].Err), "expecting request not to be submitted")
+	assert.Contains(t, results[1].Err.Error(), "channel client is nil")
+}
+
+func TestBatchOptions(t *testing.T) {
+	_, err := BatchExecute(nil, WithMaxConcurrency(0))
+	assert.Error(t, err)
+
+	_, err = BatchExecute(nil, WithBatchTimeout(-1))
+	assert.Error(t, err)
+
+	_, err = BatchExecute(nil, WithBatchParentContext(nil))
+	assert.Error(t, err)
+}
+
+func setupBatchTestClient(t *testing.T, broadcastErrs ...error) *Client {
+	testPeer1 := fcmocks.NewMockPeer("Peer1", "http://peer1.com")
+	testOrderer1 := fcmocks.NewMockOrderer("", nil)
+	for _, err := range broadcastErrs {
+		testOrderer1.EnqueueSendBroadcastError(err)
+	}
+
+	chClient := setupChannelClientWithNodes([]fab.Peer{testPeer1}, []fab.Orderer{testOrderer1}, t)
+	chClient.eventService = fcmocks.NewMockEventService()
+	return chClient
+}
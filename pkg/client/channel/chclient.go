@@ -17,16 +17,24 @@ package channel
 
 import (
 	reqContext "context"
+	"fmt"
+	"sort"
+	"sync"
 	"time"
 
 	"github.com/hyperledger/fabric-sdk-go/pkg/client/channel/invoke"
 	"github.com/hyperledger/fabric-sdk-go/pkg/client/common/discovery/greylist"
 	"github.com/hyperledger/fabric-sdk-go/pkg/client/common/filter"
+	"github.com/hyperledger/fabric-sdk-go/pkg/client/common/verifier"
 	"github.com/hyperledger/fabric-sdk-go/pkg/common/errors/retry"
 	"github.com/hyperledger/fabric-sdk-go/pkg/common/errors/status"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/logging"
 	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/context"
 	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/tracing"
 	contextImpl "github.com/hyperledger/fabric-sdk-go/pkg/context"
+	channelImpl "github.com/hyperledger/fabric-sdk-go/pkg/fab/channel"
+	pb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/peer"
 	"github.com/pkg/errors"
 )
 
@@ -40,11 +48,76 @@ type Client struct {
 	membership   fab.ChannelMembership
 	eventService fab.EventService
 	greylist     *greylist.Filter
+	policyCache  *invoke.CCPolicyCache
+	queryRetry   *retry.Opts
+	executeRetry *retry.Opts
+	// transientSizeLimit is the maximum size of a transient data value (0 for no limit)
+	transientSizeLimit int
+	// closeTimeout is the maximum time for which Close waits for in-flight operations to finish
+	closeTimeout time.Duration
+
+	// rootCtx is cancelled when the client is closed, which cancels the contexts of all in-flight operations
+	rootCtx    reqContext.Context
+	cancelRoot reqContext.CancelFunc
+	closeMutex sync.Mutex
+	closed     bool
+	inFlight   sync.WaitGroup
 }
 
+var logger = logging.NewLogger("fabsdk/client")
+
+// defaultCommitPollInterval is the default interval at which WaitForCommit queries the ledger
+const defaultCommitPollInterval = time.Second
+
+// defaultCloseTimeout is the default maximum time for which Close waits for in-flight operations to finish
+const defaultCloseTimeout = 5 * time.Second
+
 // ClientOption describes a functional parameter for the New constructor
 type ClientOption func(*Client) error
 
+// WithDefaultRetry sets the retry options which are used by Query if no retry options
+// are provided with the request. Queries are not retried by default.
+func WithDefaultRetry(retryOpts retry.Opts) ClientOption {
+	return func(cc *Client) error {
+		cc.queryRetry = &retryOpts
+		return nil
+	}
+}
+
+// WithTransientSizeLimit sets the maximum size (in bytes) of each value in the transient map of a request.
+// Requests with larger transient values fail before the proposal is sent to the endorsers.
+func WithTransientSizeLimit(limit int) ClientOption {
+	return func(cc *Client) error {
+		if limit <= 0 {
+			return errors.Errorf("invalid transient size limit [%d]", limit)
+		}
+		cc.transientSizeLimit = limit
+		return nil
+	}
+}
+
+// WithDefaultExecuteRetry sets the retry options which are used by Execute if no retry options
+// are provided with the request. Since a transaction may not be idempotent, Execute is not retried
+// unless retries are explicitly enabled with this option or with the WithRetry request option.
+func WithDefaultExecuteRetry(retryOpts retry.Opts) ClientOption {
+	return func(cc *Client) error {
+		cc.executeRetry = &retryOpts
+		return nil
+	}
+}
+
+// WithCloseTimeout sets the maximum time for which Close waits for the in-flight operations
+// to finish after they were cancelled (5s by default).
+func WithCloseTimeout(timeout time.Duration) ClientOption {
+	return func(cc *Client) error {
+		if timeout <= 0 {
+			return errors.Errorf("invalid close timeout [%s]", timeout)
+		}
+		cc.closeTimeout = timeout
+		return nil
+	}
+}
+
 // New returns a Client instance. Channel client can query chaincode, execute chaincode and register/unregister for chaincode events on specific channel.
 func New(channelProvider context.ChannelProvider, opts ...ClientOption) (*Client, error) {
 
@@ -73,8 +146,11 @@ func New(channelProvider context.ChannelProvider, opts ...ClientOption) (*Client
 		membership:   membership,
 		eventService: eventService,
 		greylist:     greylistProvider,
+		policyCache:  invoke.NewCCPolicyCache(channelContext.EndpointConfig().Timeout(fab.ChannelConfigRefresh)),
 		context:      channelContext,
+		closeTimeout: defaultCloseTimeout,
 	}
+	channelClient.rootCtx, channelClient.cancelRoot = reqContext.WithCancel(reqContext.Background())
 
 	for _, param := range opts {
 		err := param(&channelClient)
@@ -95,13 +171,62 @@ func New(channelProvider context.ChannelProvider, opts ...ClientOption) (*Client
 //  the proposal responses from peer(s)
 func (cc *Client) Query(request Request, options ...RequestOption) (Response, error) {
 
+	options = prependDefaultRetry(cc.queryRetry, options)
+	options = append(options, addDefaultTimeout(fab.Query))
+	options = append(options, addDefaultTargetFilter(cc.context, filter.ChaincodeQuery))
+
+	return cc.invokeHandler("channel.Query", invoke.NewQueryHandler(), request, options...)
+}
+
+// QueryWithResponses queries chaincode using request and optional request options and returns the
+// responses of all endorsers. Unlike Query, the responses are not required to match so that payloads
+// and statuses can be compared across endorsers (e.g. to diagnose endorsement mismatches).
+//  Parameters:
+//  request holds info about mandatory chaincode ID and function
+//  options holds optional request options
+//
+//  Returns:
+//  the proposal responses from all peer(s)
+func (cc *Client) QueryWithResponses(request Request, options ...RequestOption) ([]*fab.TransactionProposalResponse, error) {
+
+	options = prependDefaultRetry(cc.queryRetry, options)
 	options = append(options, addDefaultTimeout(fab.Query))
 	options = append(options, addDefaultTargetFilter(cc.context, filter.ChaincodeQuery))
 
-	return cc.InvokeHandler(invoke.NewQueryHandler(), request, options...)
+	response, err := cc.InvokeHandler(invoke.NewProposalProcessorHandler(invoke.NewEndorsementHandler()), request, options...)
+	if err != nil {
+		return nil, err
+	}
+	return response.Responses, nil
+}
+
+// QueryAny queries chaincode on all of the given peers and returns the first successful response, cancelling
+// the requests to the other peers. It is intended for read-only queries which don't need to satisfy the
+// endorsement policy, for which waiting for multiple endorsers is wasteful. Note that the responses of the
+// peers aren't compared, so the result may be stale if the answering peer lags behind the other peers.
+//  Parameters:
+//  request holds info about mandatory chaincode ID and function
+//  peers are the peers to query
+//  options holds optional request options (targets are overridden by peers)
+//
+//  Returns:
+//  the proposal response of the peer which answered first (Responses holds that single response, and its
+//  Endorser is the URL of the peer)
+func (cc *Client) QueryAny(request Request, peers []fab.Peer, options ...RequestOption) (Response, error) {
+	if len(peers) == 0 {
+		return Response{}, errors.New("at least one peer is required")
+	}
+
+	options = prependDefaultRetry(cc.queryRetry, options)
+	options = append(options, WithTargets(peers...))
+	options = append(options, addDefaultTimeout(fab.Query))
+
+	return cc.invokeHandler("channel.QueryAny", invoke.NewQueryAnyHandler(), request, options...)
 }
 
-// Execute prepares and executes transaction using request and optional request options
+// Execute prepares and executes transaction using request and optional request options.
+// Since transactions may not be idempotent, Execute isn't retried unless retries are enabled
+// with WithRetry or WithDefaultExecuteRetry.
 //  Parameters:
 //  request holds info about mandatory chaincode ID and function
 //  options holds optional request options
@@ -109,10 +234,20 @@ func (cc *Client) Query(request Request, options ...RequestOption) (Response, er
 //  Returns:
 //  the proposal responses from peer(s)
 func (cc *Client) Execute(request Request, options ...RequestOption) (Response, error) {
+	options = prependDefaultRetry(cc.executeRetry, options)
 	options = append(options, addDefaultTimeout(fab.Execute))
 	options = append(options, addDefaultTargetFilter(cc.context, filter.EndorsingPeer))
 
-	return cc.InvokeHandler(invoke.NewExecuteHandler(), request, options...)
+	return cc.invokeHandler("channel.Execute", invoke.NewExecuteHandler(), request, options...)
+}
+
+// prependDefaultRetry adds the given default retry options (if any) ahead of the request options
+// so that retry options provided with the request take precedence
+func prependDefaultRetry(retryOpts *retry.Opts, options []RequestOption) []RequestOption {
+	if retryOpts == nil {
+		return options
+	}
+	return append([]RequestOption{WithRetry(*retryOpts)}, options...)
 }
 
 // addDefaultTargetFilter adds default target filter if target filter is not specified
@@ -144,15 +279,40 @@ func addDefaultTimeout(tt fab.TimeoutType) RequestOption {
 //  Returns:
 //  the proposal responses from peer(s)
 func (cc *Client) InvokeHandler(handler invoke.Handler, request Request, options ...RequestOption) (Response, error) {
+	return cc.invokeHandler("channel.InvokeHandler", handler, request, options...)
+}
+
+//invokeHandler invokes the handler within a tracing span of the given name
+func (cc *Client) invokeHandler(spanName string, handler invoke.Handler, request Request, options ...RequestOption) (Response, error) {
 	//Read execute tx options
 	txnOpts, err := cc.prepareOptsFromOptions(cc.context, options...)
 	if err != nil {
 		return Response{}, err
 	}
 
+	if !cc.beginOperation() {
+		return Response{}, errClientClosed
+	}
+	defer cc.inFlight.Done()
+
 	reqCtx, cancel := cc.createReqContext(&txnOpts)
 	defer cancel()
 
+	logger.Debugw("Invoking chaincode", contextImpl.LogFields(reqCtx, logging.ChannelID(cc.context.ChannelID()),
+		logging.NewField("chaincodeID", request.ChaincodeID), logging.NewField("fcn", request.Fcn))...)
+
+	reqCtx, span := tracing.StartSpan(reqCtx, spanName)
+	span.SetAttribute(tracing.ChannelKey, cc.context.ChannelID())
+	span.SetAttribute(tracing.ChaincodeKey, request.ChaincodeID)
+	span.SetAttribute(tracing.FunctionKey, request.Fcn)
+
+	response, err := cc.invoke(reqCtx, span, handler, request, txnOpts)
+	tracing.EndSpan(span, err)
+	return response, err
+}
+
+func (cc *Client) invoke(reqCtx reqContext.Context, span tracing.Span, handler invoke.Handler, request Request, txnOpts requestOptions) (Response, error) {
+
 	//Prepare context objects for handler
 	requestContext, clientContext, err := cc.prepareHandlerContexts(reqCtx, request, txnOpts)
 	if err != nil {
@@ -175,9 +335,15 @@ func (cc *Client) InvokeHandler(handler invoke.Handler, request Request, options
 
 	complete := make(chan bool)
 	go func() {
-		_, _ = invoker.Invoke(
+		attempt := 0
+		_, _ = invoker.InvokeWithContext(reqCtx,
 			func() (interface{}, error) {
+				attempt++
 				handler.Handle(requestContext, clientContext)
+				if tracing.IsRecording(span) {
+					span.SetAttribute(tracing.AttemptsKey, attempt)
+					span.SetAttribute(tracing.TargetsKey, tracing.Targets(peerURLs(requestContext.Opts.Targets)))
+				}
 				return nil, requestContext.Error
 			})
 		complete <- true
@@ -186,11 +352,23 @@ func (cc *Client) InvokeHandler(handler invoke.Handler, request Request, options
 	case <-complete:
 		return Response(requestContext.Response), requestContext.Error
 	case <-reqCtx.Done():
+		if cc.rootCtx.Err() != nil {
+			return Response{}, errClientClosed
+		}
 		return Response{}, status.New(status.ClientStatus, status.Timeout.ToInt32(),
 			"request timed out or been cancelled", nil)
 	}
 }
 
+//peerURLs returns the URLs of the given peers
+func peerURLs(peers []fab.Peer) []string {
+	urls := make([]string, len(peers))
+	for i, p := range peers {
+		urls[i] = p.URL()
+	}
+	return urls
+}
+
 //createReqContext creates req context for invoke handler
 func (cc *Client) createReqContext(txnOpts *requestOptions) (reqContext.Context, reqContext.CancelFunc) {
 
@@ -208,7 +386,71 @@ func (cc *Client) createReqContext(txnOpts *requestOptions) (reqContext.Context,
 	//Add timeout overrides here as a value so that it can be used by immediate child contexts (in handlers/transactors)
 	reqCtx = reqContext.WithValue(reqCtx, contextImpl.ReqContextTimeoutOverrides, txnOpts.Timeouts)
 
-	return reqCtx, cancel
+	return cc.withCloseCancel(reqCtx, cancel)
+}
+
+//withCloseCancel returns a copy of the request context which is also cancelled when the client is closed
+func (cc *Client) withCloseCancel(reqCtx reqContext.Context, cancel reqContext.CancelFunc) (reqContext.Context, reqContext.CancelFunc) {
+	reqCtx, cancelReq := reqContext.WithCancel(reqCtx)
+	go func() {
+		select {
+		case <-cc.rootCtx.Done():
+			cancelReq()
+		case <-reqCtx.Done():
+		}
+	}()
+
+	return reqCtx, func() {
+		cancelReq()
+		cancel()
+	}
+}
+
+//beginOperation registers an in-flight operation, returning false if the client is closed.
+//inFlight.Done must be called when the operation completes.
+func (cc *Client) beginOperation() bool {
+	cc.closeMutex.Lock()
+	defer cc.closeMutex.Unlock()
+
+	if cc.closed {
+		return false
+	}
+	cc.inFlight.Add(1)
+	return true
+}
+
+var errClientClosed = status.New(status.ClientStatus, status.ClientClosed.ToInt32(), "channel client is closed", nil)
+
+// Close closes the client: new operations are rejected and the contexts of the in-flight operations
+// (including their connections to peers and orderers) are cancelled. Close then waits for the in-flight
+// operations to return, for at most the close timeout (see WithCloseTimeout). The event service and
+// the other channel services are shared with other clients and are released when the SDK is closed.
+//
+//  Returns:
+//  an error if the in-flight operations did not return before the close timeout
+func (cc *Client) Close() error {
+	cc.closeMutex.Lock()
+	if cc.closed {
+		cc.closeMutex.Unlock()
+		return nil
+	}
+	cc.closed = true
+	cc.closeMutex.Unlock()
+
+	cc.cancelRoot()
+
+	done := make(chan struct{})
+	go func() {
+		cc.inFlight.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-time.After(cc.closeTimeout):
+		return errors.Errorf("timed out after %s waiting for in-flight operations to complete", cc.closeTimeout)
+	}
 }
 
 //prepareHandlerContexts prepares context objects for handlers
@@ -218,6 +460,10 @@ func (cc *Client) prepareHandlerContexts(reqCtx reqContext.Context, request Requ
 		return nil, nil, errors.New("ChaincodeID and Fcn are required")
 	}
 
+	if err := cc.validateTransientSize(request.TransientMap); err != nil {
+		return nil, nil, err
+	}
+
 	chConfig, err := cc.context.ChannelService().ChannelConfig()
 	if err != nil {
 		return nil, nil, errors.WithMessage(err, "failed to retrieve channel config")
@@ -243,6 +489,7 @@ func (cc *Client) prepareHandlerContexts(reqCtx reqContext.Context, request Requ
 		Membership:   cc.membership,
 		Transactor:   transactor,
 		EventService: cc.eventService,
+		PolicyCache:  cc.policyCache,
 	}
 
 	requestContext := &invoke.RequestContext{
@@ -257,6 +504,26 @@ func (cc *Client) prepareHandlerContexts(reqCtx reqContext.Context, request Requ
 	return requestContext, clientContext, nil
 }
 
+// validateTransientSize checks that none of the transient data values exceeds the transient size limit
+func (cc *Client) validateTransientSize(transientMap map[string][]byte) error {
+	if cc.transientSizeLimit == 0 {
+		return nil
+	}
+
+	var keys []string
+	for key := range transientMap {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if size := len(transientMap[key]); size > cc.transientSizeLimit {
+			return errors.Errorf("transient data [%s] of size %d bytes exceeds the transient size limit of %d bytes", key, size, cc.transientSizeLimit)
+		}
+	}
+	return nil
+}
+
 //prepareOptsFromOptions Reads apitxn.Opts from Option array
 func (cc *Client) prepareOptsFromOptions(ctx context.Client, options ...RequestOption) (requestOptions, error) {
 	txnOpts := requestOptions{}
@@ -269,6 +536,72 @@ func (cc *Client) prepareOptsFromOptions(ctx context.Client, options ...RequestO
 	return txnOpts, nil
 }
 
+// WaitForCommit polls the ledger of the channel's peers until the transaction with the given ID has been
+// committed and returns its validation code. This doesn't depend on the event service and may therefore
+// be used to confirm the commit of a transaction if the event service isn't available.
+//  Parameters:
+//  txID is the ID of the transaction
+//  options holds optional commit wait options (poll interval, timeout)
+//
+//  Returns:
+//  the validation code of the committed transaction (INVALID_OTHER_REASON if an error is returned)
+func (cc *Client) WaitForCommit(txID fab.TransactionID, options ...CommitWaitOption) (pb.TxValidationCode, error) {
+	opts := commitWaitOptions{
+		Interval: defaultCommitPollInterval,
+		Timeout:  cc.context.EndpointConfig().Timeout(fab.Execute),
+	}
+	for _, option := range options {
+		if err := option(&opts); err != nil {
+			return pb.TxValidationCode_INVALID_OTHER_REASON, errors.WithMessage(err, "Failed to read opts")
+		}
+	}
+
+	ledger, err := channelImpl.NewLedger(cc.context.ChannelID())
+	if err != nil {
+		return pb.TxValidationCode_INVALID_OTHER_REASON, errors.WithMessage(err, "ledger client creation failed")
+	}
+	ledgerFilter := filter.NewEndpointFilter(cc.context, filter.LedgerQuery)
+	responseVerifier := &verifier.Signature{Membership: cc.membership}
+
+	if !cc.beginOperation() {
+		return pb.TxValidationCode_INVALID_OTHER_REASON, errClientClosed
+	}
+	defer cc.inFlight.Done()
+
+	reqCtx, cancel := cc.withCloseCancel(contextImpl.NewRequest(cc.context, contextImpl.WithTimeout(opts.Timeout)))
+	defer cancel()
+
+	for {
+		peers, err := cc.context.DiscoveryService().GetPeers()
+		if err != nil {
+			return pb.TxValidationCode_INVALID_OTHER_REASON, errors.WithMessage(err, "failed to get peers")
+		}
+
+		var targets []fab.ProposalProcessor
+		for _, p := range peers {
+			if ledgerFilter.Accept(p) {
+				targets = append(targets, p)
+			}
+		}
+		if len(targets) == 0 {
+			return pb.TxValidationCode_INVALID_OTHER_REASON, status.New(status.ClientStatus, status.NoPeersFound.ToInt32(), "no ledger query peers found", nil)
+		}
+
+		responses, err := ledger.QueryTransaction(reqCtx, txID, targets, responseVerifier)
+		if len(responses) > 0 {
+			return pb.TxValidationCode(responses[0].ValidationCode), nil
+		}
+		logger.Debugf("transaction [%s] not found in ledger: %s", txID, err)
+
+		select {
+		case <-reqCtx.Done():
+			return pb.TxValidationCode_INVALID_OTHER_REASON, status.New(status.ClientStatus, status.Timeout.ToInt32(),
+				fmt.Sprintf("timed out waiting for transaction [%s] to be committed", txID), nil)
+		case <-time.After(opts.Interval):
+		}
+	}
+}
+
 // RegisterChaincodeEvent registers for chaincode events. Unregister must be called when the registration is no longer needed.
 //  Parameters:
 //  chaincodeID is the chaincode ID for which events are to be received
@@ -8,10 +8,12 @@ package channel
 
 import (
 	"fmt"
+	"math"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 
@@ -433,6 +435,162 @@ func TestExecuteTxWithRetries(t *testing.T) {
 	assert.Equal(t, testResp, resp.Payload, "expected correct response")
 }
 
+func TestDefaultRetry(t *testing.T) {
+	testStatus := status.New(status.EndorserServerStatus, int32(common.Status_SERVICE_UNAVAILABLE), "test", nil)
+	retryOpts := retry.Opts{
+		Attempts:       2,
+		BackoffFactor:  1,
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     time.Millisecond,
+		RetryableCodes: retry.ChannelClientRetryableCodes,
+	}
+	request := Request{ChaincodeID: "testCC", Fcn: "invoke", Args: [][]byte{[]byte("query"), []byte("b")}}
+
+	testPeer1 := fcmocks.NewMockPeer("Peer1", "http://peer1.com")
+	testPeer1.Error = testStatus
+	chClient := setupChannelClient([]fab.Peer{testPeer1}, t)
+	assert.Nil(t, WithDefaultRetry(retryOpts)(chClient))
+
+	// The default retry options apply to queries
+	_, err := chClient.Query(request)
+	assert.NotNil(t, err, "expected error")
+	assert.Equal(t, retryOpts.Attempts+1, testPeer1.ProcessProposalCalls, "expected query to be retried")
+
+	// Retry options of the request override the defaults
+	testPeer1.ProcessProposalCalls = 0
+	_, err = chClient.Query(request, WithRetry(retry.Opts{}))
+	assert.NotNil(t, err, "expected error")
+	assert.Equal(t, 1, testPeer1.ProcessProposalCalls, "expected query not to be retried")
+
+	// Execute is not retried by default
+	testPeer1.ProcessProposalCalls = 0
+	_, err = chClient.Execute(request)
+	assert.NotNil(t, err, "expected error")
+	assert.Equal(t, 1, testPeer1.ProcessProposalCalls, "expected execute not to be retried")
+
+	// Execute is retried once retries are explicitly enabled
+	testPeer1.ProcessProposalCalls = 0
+	_, err = chClient.Execute(request, WithRetry(retryOpts))
+	assert.NotNil(t, err, "expected error")
+	assert.Equal(t, retryOpts.Attempts+1, testPeer1.ProcessProposalCalls, "expected execute to be retried")
+
+	assert.Nil(t, WithDefaultExecuteRetry(retryOpts)(chClient))
+	testPeer1.ProcessProposalCalls = 0
+	_, err = chClient.Execute(request)
+	assert.NotNil(t, err, "expected error")
+	assert.Equal(t, retryOpts.Attempts+1, testPeer1.ProcessProposalCalls, "expected execute to be retried")
+}
+
+func TestQueryWithResponses(t *testing.T) {
+	testPeer1 := fcmocks.NewMockPeer("Peer1", "http://peer1.com")
+	testPeer1.Payload = []byte("test1")
+	testPeer2 := fcmocks.NewMockPeer("Peer2", "http://peer2.com")
+	testPeer2.Payload = []byte("test2")
+	testPeer2.Status = int32(common.Status_INTERNAL_SERVER_ERROR)
+	chClient := setupChannelClient([]fab.Peer{testPeer1, testPeer2}, t)
+
+	_, err := chClient.QueryWithResponses(Request{})
+	assert.NotNil(t, err, "Should have failed for empty query request")
+
+	// Mismatched responses are returned rather than failing the query
+	responses, err := chClient.QueryWithResponses(Request{ChaincodeID: "testCC", Fcn: "invoke", Args: [][]byte{[]byte("query"), []byte("b")}})
+	assert.Nil(t, err, "expected error to be nil")
+	assert.Len(t, responses, 2, "expected a response from each peer")
+
+	payloads := make(map[string][]byte)
+	statuses := make(map[string]int32)
+	for _, r := range responses {
+		payloads[r.Endorser] = r.ProposalResponse.GetResponse().Payload
+		statuses[r.Endorser] = r.ProposalResponse.GetResponse().Status
+	}
+	assert.Equal(t, []byte("test1"), payloads[testPeer1.URL()])
+	assert.Equal(t, []byte("test2"), payloads[testPeer2.URL()])
+	assert.Equal(t, int32(common.Status_SUCCESS), statuses[testPeer1.URL()])
+	assert.Equal(t, int32(common.Status_INTERNAL_SERVER_ERROR), statuses[testPeer2.URL()])
+}
+
+func TestQueryAny(t *testing.T) {
+	slowPeer := fcmocks.NewMockPeer("Peer1", "http://peer1.com")
+	slowPeer.Payload = []byte("slow")
+	slowPeer.ResponseDelay = 10 * time.Second
+	fastPeer := fcmocks.NewMockPeer("Peer2", "http://peer2.com")
+	fastPeer.Payload = []byte("fast")
+	fastPeer.ResponseDelay = 50 * time.Millisecond
+	failedPeer := fcmocks.NewMockPeer("Peer3", "http://peer3.com")
+	failedPeer.Status = int32(common.Status_INTERNAL_SERVER_ERROR)
+	chClient := setupChannelClient([]fab.Peer{slowPeer, fastPeer, failedPeer}, t)
+
+	request := Request{ChaincodeID: "testCC", Fcn: "invoke", Args: [][]byte{[]byte("query"), []byte("b")}}
+
+	_, err := chClient.QueryAny(request, nil)
+	assert.NotNil(t, err, "Should have failed for no peers")
+
+	start := time.Now()
+	response, err := chClient.QueryAny(request, []fab.Peer{slowPeer, fastPeer, failedPeer})
+	assert.Nil(t, err, "expected error to be nil")
+	assert.True(t, time.Since(start) < slowPeer.ResponseDelay, "expected the slow peer to be cancelled")
+	assert.Equal(t, []byte("fast"), response.Payload)
+	if assert.Len(t, response.Responses, 1) {
+		assert.Equal(t, fastPeer.URL(), response.Responses[0].Endorser, "expected the response of the peer which answered")
+	}
+
+	_, err = chClient.QueryAny(request, []fab.Peer{failedPeer})
+	assert.NotNil(t, err, "Should have failed when no peer returns a successful response")
+}
+
+func TestTransientSizeLimit(t *testing.T) {
+	testPeer1 := fcmocks.NewMockPeer("Peer1", "http://peer1.com")
+	chClient := setupChannelClient([]fab.Peer{testPeer1}, t)
+
+	assert.NotNil(t, WithTransientSizeLimit(0)(chClient), "Should have failed for invalid limit")
+	assert.Nil(t, WithTransientSizeLimit(4)(chClient))
+
+	request := Request{ChaincodeID: "testCC", Fcn: "invoke", Args: [][]byte{[]byte("query"), []byte("b")},
+		TransientMap: map[string][]byte{"small": []byte("1234"), "large": []byte("12345")}}
+	_, err := chClient.Query(request)
+	assert.NotNil(t, err, "Should have failed for transient data exceeding the limit")
+	assert.Contains(t, err.Error(), "transient data [large] of size 5 bytes exceeds the transient size limit of 4 bytes")
+	assert.Equal(t, 0, testPeer1.ProcessProposalCalls, "Proposal should not have been sent")
+
+	delete(request.TransientMap, "large")
+	_, err = chClient.Query(request)
+	assert.Nil(t, err, "Should not have failed for transient data within the limit")
+}
+
+func TestWaitForCommit(t *testing.T) {
+	processedTx, err := proto.Marshal(&pb.ProcessedTransaction{ValidationCode: int32(pb.TxValidationCode_MVCC_READ_CONFLICT)})
+	assert.Nil(t, err, "marshal of processed transaction failed")
+
+	// The transaction isn't found on the first two polls
+	testPeer1 := fcmocks.NewMockPeer("Peer1", "http://peer1.com")
+	testPeer1.Payload = processedTx
+	testPeer1.FailFirstN = 2
+
+	discoveryService, err := setupTestDiscovery(nil, []fab.Peer{testPeer1})
+	assert.Nil(t, err, "Got error %s", err)
+	selectionService, err := setupTestSelection(nil, nil)
+	assert.Nil(t, err, "Got error %s", err)
+	chClient, err := New(createChannelContext(setupCustomTestContext(t, selectionService, discoveryService, nil), channelID))
+	assert.Nil(t, err, "Got error %s", err)
+
+	_, err = chClient.WaitForCommit("txid", WithPollInterval(0))
+	assert.NotNil(t, err, "Should have failed for invalid poll interval")
+
+	code, err := chClient.WaitForCommit("txid", WithPollInterval(10*time.Millisecond), WithCommitTimeout(5*time.Second))
+	assert.Nil(t, err, "Got error %s", err)
+	assert.Equal(t, pb.TxValidationCode_MVCC_READ_CONFLICT, code)
+	assert.Equal(t, 3, testPeer1.ProcessProposalCalls, "Expected ledger to be polled three times")
+
+	// The transaction is never committed
+	testPeer1.ProcessProposalCalls = 0
+	testPeer1.FailFirstN = math.MaxInt32
+	_, err = chClient.WaitForCommit("txid", WithPollInterval(10*time.Millisecond), WithCommitTimeout(100*time.Millisecond))
+	assert.NotNil(t, err, "Should have timed out")
+	s, ok := status.FromError(err)
+	assert.True(t, ok, "Expected status error")
+	assert.EqualValues(t, status.Timeout.ToInt32(), s.Code, "Expected timeout error")
+}
+
 func TestMultiErrorPropogation(t *testing.T) {
 	testErr := fmt.Errorf("Test Error")
 
@@ -585,6 +743,50 @@ func setupTestSelection(discErr error, peers []fab.Peer) (*txnmocks.MockSelectio
 	return mockSelection.CreateSelectionService("mychannel")
 }
 
+func TestClose(t *testing.T) {
+	testPeer := fcmocks.NewMockPeer("Peer1", "http://peer1.com")
+	testPeer.ResponseDelay = 10 * time.Second
+	chClient := setupChannelClient([]fab.Peer{testPeer}, t)
+	request := Request{ChaincodeID: "testCC", Fcn: "invoke", Args: [][]byte{[]byte("query"), []byte("b")}}
+
+	errs := make(chan error, 1)
+	go func() {
+		_, err := chClient.Query(request)
+		errs <- err
+	}()
+
+	// Wait for the query to be in flight
+	time.Sleep(100 * time.Millisecond)
+
+	start := time.Now()
+	if err := chClient.Close(); err != nil {
+		t.Fatalf("Failed to close client: %s", err)
+	}
+	assert.True(t, time.Since(start) < 5*time.Second, "expecting in-flight query to be cancelled")
+
+	select {
+	case err := <-errs:
+		s, ok := status.FromError(err)
+		assert.True(t, ok, "expecting status error but got %v", err)
+		assert.EqualValues(t, status.ClientClosed.ToInt32(), s.Code)
+	case <-time.After(time.Second):
+		t.Fatalf("Expecting in-flight query to return after close")
+	}
+
+	_, err := chClient.Query(request)
+	s, ok := status.FromError(err)
+	assert.True(t, ok, "expecting status error but got %v", err)
+	assert.EqualValues(t, status.ClientClosed.ToInt32(), s.Code, "expecting error for query after close")
+
+	_, err = chClient.WaitForCommit("txid")
+	assert.Error(t, err, "expecting error for wait after close")
+
+	assert.NoError(t, chClient.Close(), "expecting close to be idempotent")
+
+	_, err = New(createChannelContext(setupCustomTestContext(t, nil, nil, nil), channelID), WithCloseTimeout(0))
+	assert.Error(t, err, "expecting error for invalid close timeout")
+}
+
 func setupChannelClient(peers []fab.Peer, t *testing.T) *Client {
 
 	return setupChannelClientWithError(nil, nil, peers, t)
@@ -0,0 +1,185 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package channel
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/common"
+	mb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/msp"
+	"github.com/pkg/errors"
+)
+
+const (
+	// peerOU and clientOU are the default organizational units which identify peers and clients (see NodeOUs)
+	peerOU   = "peer"
+	clientOU = "client"
+)
+
+// VerifyEndorsements evaluates the given signature policy against the identities which endorsed the given
+// proposal responses, in the same way as the validating peers do, so that under-endorsed transactions may
+// be detected before they are sent to the orderer. Only successful responses are taken into account and
+// each endorser counts once. Note that the endorsement signatures are not verified, and that role principals
+// are evaluated without the channel MSPs: members are matched by MSP ID, peers and clients additionally by
+// the default NodeOU identifiers ("peer" and "client") of their certificates, and admins aren't supported.
+//  Parameters:
+//  responses are the proposal responses returned by the endorsers (e.g. Response.Responses)
+//  policy is the endorsement policy of the chaincode
+//
+//  Returns:
+//  whether the policy is satisfied by the endorsements
+func VerifyEndorsements(responses []*fab.TransactionProposalResponse, policy *common.SignaturePolicyEnvelope) (bool, error) {
+	if policy == nil || policy.Rule == nil {
+		return false, errors.New("endorsement policy is required")
+	}
+
+	endorsers, err := endorsingIdentities(responses)
+	if err != nil {
+		return false, err
+	}
+
+	used := make([]bool, len(endorsers))
+	return evaluateSignaturePolicy(policy.Rule, policy.Identities, endorsers, used)
+}
+
+// endorser is the identity of an endorser
+type endorser struct {
+	identity *mb.SerializedIdentity
+	cert     *x509.Certificate
+}
+
+// endorsingIdentities returns the distinct identities which endorsed the successful responses
+func endorsingIdentities(responses []*fab.TransactionProposalResponse) ([]*endorser, error) {
+	endorsers := []*endorser{}
+	seen := make(map[string]bool)
+	for _, response := range responses {
+		if response == nil || response.ProposalResponse == nil || response.Endorsement == nil {
+			continue
+		}
+		if response.ProposalResponse.GetResponse().GetStatus() != int32(common.Status_SUCCESS) {
+			continue
+		}
+
+		serialized := response.Endorsement.Endorser
+		if seen[string(serialized)] {
+			continue
+		}
+		seen[string(serialized)] = true
+
+		identity := &mb.SerializedIdentity{}
+		if err := proto.Unmarshal(serialized, identity); err != nil {
+			return nil, errors.Wrapf(err, "unmarshal identity of endorser [%s] failed", response.Endorser)
+		}
+
+		var cert *x509.Certificate
+		if block, _ := pem.Decode(identity.IdBytes); block != nil {
+			cert, _ = x509.ParseCertificate(block.Bytes)
+		}
+
+		endorsers = append(endorsers, &endorser{identity: identity, cert: cert})
+	}
+	return endorsers, nil
+}
+
+// evaluateSignaturePolicy evaluates the rule against the endorsers which haven't been used yet (by other rules).
+// As in Fabric, an n-out-of rule only consumes the endorsers of its sub-rules which are satisfied.
+func evaluateSignaturePolicy(rule *common.SignaturePolicy, principals []*mb.MSPPrincipal, endorsers []*endorser, used []bool) (bool, error) {
+	switch t := rule.Type.(type) {
+	case *common.SignaturePolicy_SignedBy:
+		if t.SignedBy < 0 || int(t.SignedBy) >= len(principals) {
+			return false, errors.Errorf("invalid principal index %d", t.SignedBy)
+		}
+		for i, e := range endorsers {
+			if used[i] {
+				continue
+			}
+			satisfied, err := satisfiesPrincipal(e, principals[t.SignedBy])
+			if err != nil {
+				return false, err
+			}
+			if satisfied {
+				used[i] = true
+				return true, nil
+			}
+		}
+		return false, nil
+
+	case *common.SignaturePolicy_NOutOf_:
+		verified := int32(0)
+		for _, subRule := range t.NOutOf.Rules {
+			subUsed := append([]bool{}, used...)
+			satisfied, err := evaluateSignaturePolicy(subRule, principals, endorsers, subUsed)
+			if err != nil {
+				return false, err
+			}
+			if satisfied {
+				verified++
+				copy(used, subUsed)
+			}
+		}
+		return verified >= t.NOutOf.N, nil
+
+	default:
+		return false, errors.Errorf("unsupported signature policy type %T", rule.Type)
+	}
+}
+
+// satisfiesPrincipal determines whether the endorser satisfies the principal
+func satisfiesPrincipal(e *endorser, principal *mb.MSPPrincipal) (bool, error) {
+	switch principal.PrincipalClassification {
+	case mb.MSPPrincipal_ROLE:
+		role := &mb.MSPRole{}
+		if err := proto.Unmarshal(principal.Principal, role); err != nil {
+			return false, errors.Wrap(err, "unmarshal role principal failed")
+		}
+		if role.MspIdentifier != e.identity.Mspid {
+			return false, nil
+		}
+		switch role.Role {
+		case mb.MSPRole_MEMBER:
+			return true, nil
+		case mb.MSPRole_PEER:
+			return hasOU(e.cert, peerOU), nil
+		case mb.MSPRole_CLIENT:
+			return hasOU(e.cert, clientOU), nil
+		default:
+			return false, errors.Errorf("role principal %s can't be evaluated without the channel MSPs", role.Role)
+		}
+
+	case mb.MSPPrincipal_ORGANIZATION_UNIT:
+		ou := &mb.OrganizationUnit{}
+		if err := proto.Unmarshal(principal.Principal, ou); err != nil {
+			return false, errors.Wrap(err, "unmarshal organization unit principal failed")
+		}
+		return ou.MspIdentifier == e.identity.Mspid && hasOU(e.cert, ou.OrganizationalUnitIdentifier), nil
+
+	case mb.MSPPrincipal_IDENTITY:
+		identity := &mb.SerializedIdentity{}
+		if err := proto.Unmarshal(principal.Principal, identity); err != nil {
+			return false, errors.Wrap(err, "unmarshal identity principal failed")
+		}
+		return proto.Equal(identity, e.identity), nil
+
+	default:
+		return false, errors.Errorf("unsupported principal classification %s", principal.PrincipalClassification)
+	}
+}
+
+func hasOU(cert *x509.Certificate, ou string) bool {
+	if cert == nil {
+		return false
+	}
+	for _, certOU := range cert.Subject.OrganizationalUnit {
+		if certOU == ou {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,84 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package channel
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+	fcmocks "github.com/hyperledger/fabric-sdk-go/pkg/fab/mocks"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/common/cauthdsl"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyEndorsements(t *testing.T) {
+	org1 := fcmocks.NewProposalResponse().WithEndorserURL("peer0.org1").WithEndorser("Org1MSP", newTestCert(t, "peer")).Build()
+	org1Peer1 := fcmocks.NewProposalResponse().WithEndorserURL("peer1.org1").WithEndorser("Org1MSP", newTestCert(t, "peer")).Build()
+	org2 := fcmocks.NewProposalResponse().WithEndorserURL("peer0.org2").WithEndorser("Org2MSP", newTestCert(t, "client")).Build()
+	org2Failed := fcmocks.NewProposalResponse().WithEndorserURL("peer1.org2").WithEndorser("Org2MSP", newTestCert(t, "peer")).WithStatus(http.StatusInternalServerError).Build()
+
+	tests := []struct {
+		policy    string
+		responses []*fab.TransactionProposalResponse
+		expected  bool
+	}{
+		{"AND('Org1MSP.member','Org2MSP.member')", []*fab.TransactionProposalResponse{org1, org2}, true},
+		{"AND('Org1MSP.member','Org2MSP.member')", []*fab.TransactionProposalResponse{org1, org1Peer1}, false},
+		{"AND('Org1MSP.member','Org2MSP.member')", []*fab.TransactionProposalResponse{org1, org2Failed}, false},
+		{"OR('Org1MSP.member','Org2MSP.member')", []*fab.TransactionProposalResponse{org2}, true},
+		{"OUTOF(2,'Org1MSP.member','Org1MSP.member')", []*fab.TransactionProposalResponse{org1, org1Peer1}, true},
+		{"OUTOF(2,'Org1MSP.member','Org1MSP.member')", []*fab.TransactionProposalResponse{org1, org1}, false},
+		{"AND('Org1MSP.peer','Org2MSP.member')", []*fab.TransactionProposalResponse{org1, org2}, true},
+		{"AND('Org1MSP.member','Org2MSP.peer')", []*fab.TransactionProposalResponse{org1, org2}, false},
+		{"OR('Org2MSP.client')", []*fab.TransactionProposalResponse{org1, org2}, true},
+		{"OR('Org1MSP.member')", nil, false},
+	}
+
+	for _, test := range tests {
+		policy, err := cauthdsl.FromString(test.policy)
+		require.NoError(t, err)
+
+		satisfied, err := VerifyEndorsements(test.responses, policy)
+		require.NoError(t, err, "policy %s", test.policy)
+		assert.Equal(t, test.expected, satisfied, "policy %s", test.policy)
+	}
+
+	_, err := VerifyEndorsements([]*fab.TransactionProposalResponse{org1}, cauthdsl.SignedByMspAdmin("Org1MSP"))
+	assert.Error(t, err, "expecting error for admin principal")
+
+	_, err = VerifyEndorsements([]*fab.TransactionProposalResponse{org1}, nil)
+	assert.Error(t, err, "expecting error for missing policy")
+}
+
+func newTestCert(t *testing.T, ou string) []byte {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	serial, err := rand.Int(rand.Reader, big.NewInt(1<<62))
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: ou, OrganizationalUnit: []string{ou}},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
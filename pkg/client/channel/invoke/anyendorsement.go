@@ -0,0 +1,135 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package invoke
+
+import (
+	reqContext "context"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/errors/multi"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/errors/status"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+	contextImpl "github.com/hyperledger/fabric-sdk-go/pkg/context"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/peer"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/txn"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/common"
+	"github.com/pkg/errors"
+)
+
+//AnyEndorsementHandler for sending a proposal to all targets and keeping the first successful response
+type AnyEndorsementHandler struct {
+	next Handler
+}
+
+//NewAnyEndorsementHandler returns a handler that sends a transaction proposal to all targets and keeps the
+//first successful response with a valid signature, cancelling the requests to the other targets
+func NewAnyEndorsementHandler(next ...Handler) *AnyEndorsementHandler {
+	return &AnyEndorsementHandler{next: getNext(next)}
+}
+
+//NewQueryAnyHandler returns query handler with AnyEndorsementHandler Chained. The signature of the
+//response is validated by the AnyEndorsementHandler so that an invalid response doesn't fail the query.
+func NewQueryAnyHandler(next ...Handler) Handler {
+	return NewProposalProcessorHandler(
+		NewAnyEndorsementHandler(next...),
+	)
+}
+
+//Handle sends the proposal to the targets and keeps the first successful response with a valid signature
+func (h *AnyEndorsementHandler) Handle(requestContext *RequestContext, clientContext *ClientContext) {
+
+	if len(requestContext.Opts.Targets) == 0 {
+		requestContext.Error = status.New(status.ClientStatus, status.NoPeersFound.ToInt32(), "targets were not provided", nil)
+		return
+	}
+
+	proposal, err := createTransactionProposal(clientContext.Transactor, &requestContext.Request)
+	if err != nil {
+		requestContext.Error = err
+		return
+	}
+
+	requestContext.Response.Proposal = proposal
+	requestContext.Response.TransactionID = proposal.TxnID
+
+	verify := func(response *fab.TransactionProposalResponse) error {
+		return verifyProposalResponse(response, clientContext)
+	}
+
+	response, err := sendProposalToAny(requestContext.Ctx, proposal, peer.PeersToTxnProcessors(requestContext.Opts.Targets), verify)
+	if err != nil {
+		requestContext.Error = err
+		return
+	}
+
+	logger.Debugf("Using response of [%s] for transaction [%s]", response.Endorser, proposal.TxnID)
+
+	requestContext.Response.Responses = []*fab.TransactionProposalResponse{response}
+	requestContext.Response.Payload = response.ProposalResponse.GetResponse().GetPayload()
+	requestContext.Response.ChaincodeStatus = response.ChaincodeStatus
+
+	//Delegate to next step if any
+	if h.next != nil {
+		h.next.Handle(requestContext, clientContext)
+	}
+}
+
+// targetResponse holds the proposal response (or error) returned by a single target
+type targetResponse struct {
+	response *fab.TransactionProposalResponse
+	err      error
+}
+
+// sendProposalToAny signs the proposal once, sends it to each target separately and returns the first
+// successful response which passes the given verification. The remaining in-flight requests are cancelled.
+func sendProposalToAny(reqCtx reqContext.Context, proposal *fab.TransactionProposal, targets []fab.ProposalProcessor, verify func(*fab.TransactionProposalResponse) error) (*fab.TransactionProposalResponse, error) {
+	ctx, ok := contextImpl.RequestClientContext(reqCtx)
+	if !ok {
+		return nil, errors.New("failed get client context from reqContext for sendProposalToAny")
+	}
+
+	signedProposal, err := txn.SignProposal(ctx, proposal.Proposal)
+	if err != nil {
+		return nil, errors.WithMessage(err, "sign proposal failed")
+	}
+	request := fab.ProcessProposalRequest{SignedProposal: signedProposal}
+
+	peerCtx, cancel := contextImpl.NewRequest(ctx, contextImpl.WithTimeoutType(fab.PeerResponse), contextImpl.WithParent(reqCtx))
+	defer cancel()
+
+	// The channel is buffered for all targets so that requests which complete
+	// after the first successful response never block
+	responses := make(chan *targetResponse, len(targets))
+
+	for _, target := range targets {
+		go func(target fab.ProposalProcessor) {
+			resp, err := target.ProcessTransactionProposal(peerCtx, request)
+			responses <- &targetResponse{response: resp, err: err}
+		}(target)
+	}
+
+	var errs error
+	for range targets {
+		r := <-responses
+		if r.err != nil {
+			logger.Debugf("Received error response from txn proposal processing: %s", r.err)
+			errs = multi.Append(errs, r.err)
+			continue
+		}
+		if r.response.ProposalResponse.GetResponse().GetStatus() != int32(common.Status_SUCCESS) {
+			errs = multi.Append(errs, status.NewFromProposalResponse(r.response.ProposalResponse, r.response.Endorser))
+			continue
+		}
+		if err := verify(r.response); err != nil {
+			logger.Debugf("Signature validation of the response from [%s] failed: %s", r.response.Endorser, err)
+			errs = multi.Append(errs, errors.WithMessage(err, "signature validation failed"))
+			continue
+		}
+		return r.response, nil
+	}
+
+	return nil, errs
+}
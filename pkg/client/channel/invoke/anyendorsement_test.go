@@ -0,0 +1,119 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package invoke
+
+import (
+	reqContext "context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/context"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/core"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+	contextImpl "github.com/hyperledger/fabric-sdk-go/pkg/context"
+	fcmocks "github.com/hyperledger/fabric-sdk-go/pkg/fab/mocks"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/peer"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/txn"
+	pb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/peer"
+)
+
+func TestSendProposalToAnySignsOnce(t *testing.T) {
+	ctx := &countingSignerContext{Client: setupTestContext()}
+	reqCtx, cancel := contextImpl.NewRequest(ctx, contextImpl.WithTimeout(testTimeOut))
+	defer cancel()
+
+	txh, err := txn.NewHeader(ctx, "testChannel")
+	require.NoError(t, err)
+	proposal, err := txn.CreateChaincodeInvokeProposal(txh, fab.ChaincodeInvokeRequest{ChaincodeID: "testCC", Fcn: "query"})
+	require.NoError(t, err)
+
+	peers := []fab.Peer{fcmocks.NewMockPeer("Peer1", "http://peer1.com"), fcmocks.NewMockPeer("Peer2", "http://peer2.com"), fcmocks.NewMockPeer("Peer3", "http://peer3.com")}
+	response, err := sendProposalToAny(reqCtx, proposal, peer.PeersToTxnProcessors(peers), func(*fab.TransactionProposalResponse) error { return nil })
+	require.NoError(t, err)
+	assert.NotNil(t, response)
+	assert.EqualValues(t, 1, atomic.LoadInt32(&ctx.signs), "expecting the proposal to be signed once for all targets")
+}
+
+func TestSendProposalToAnyInvalidSignature(t *testing.T) {
+	ctx := setupTestContext()
+	reqCtx, cancel := contextImpl.NewRequest(ctx, contextImpl.WithTimeout(testTimeOut))
+	defer cancel()
+
+	txh, err := txn.NewHeader(ctx, "testChannel")
+	require.NoError(t, err)
+	proposal, err := txn.CreateChaincodeInvokeProposal(txh, fab.ChaincodeInvokeRequest{ChaincodeID: "testCC", Fcn: "query"})
+	require.NoError(t, err)
+
+	badPeer := fcmocks.NewMockPeer("Peer1", "http://peer1.com")
+	badPeer.Endorser = []byte("bad")
+	goodPeer := fcmocks.NewMockPeer("Peer2", "http://peer2.com")
+	goodPeer.Endorser = []byte("good")
+	goodPeer.ResponseDelay = 100 * time.Millisecond
+
+	verify := func(response *fab.TransactionProposalResponse) error {
+		if string(response.ProposalResponse.GetEndorsement().GetEndorser()) == "bad" {
+			return errors.New("invalid signature")
+		}
+		return nil
+	}
+
+	response, err := sendProposalToAny(reqCtx, proposal, peer.PeersToTxnProcessors([]fab.Peer{badPeer, goodPeer}), verify)
+	require.NoError(t, err, "expecting the response with a valid signature to be used")
+	assert.Equal(t, "http://peer2.com", response.Endorser)
+
+	_, err = sendProposalToAny(reqCtx, proposal, peer.PeersToTxnProcessors([]fab.Peer{badPeer}), verify)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "signature validation failed")
+}
+
+func TestSendProposalToAnyNilResponse(t *testing.T) {
+	ctx := setupTestContext()
+	reqCtx, cancel := contextImpl.NewRequest(ctx, contextImpl.WithTimeout(testTimeOut))
+	defer cancel()
+
+	txh, err := txn.NewHeader(ctx, "testChannel")
+	require.NoError(t, err)
+	proposal, err := txn.CreateChaincodeInvokeProposal(txh, fab.ChaincodeInvokeRequest{ChaincodeID: "testCC", Fcn: "query"})
+	require.NoError(t, err)
+
+	target := &nilResponseProcessor{}
+	_, err = sendProposalToAny(reqCtx, proposal, []fab.ProposalProcessor{target}, func(*fab.TransactionProposalResponse) error { return nil })
+	assert.Error(t, err, "expecting error for a proposal response without a response")
+}
+
+// nilResponseProcessor returns a proposal response without a response
+type nilResponseProcessor struct{}
+
+func (p *nilResponseProcessor) ProcessTransactionProposal(reqContext.Context, fab.ProcessProposalRequest) (*fab.TransactionProposalResponse, error) {
+	return &fab.TransactionProposalResponse{Endorser: "nil-response", ProposalResponse: &pb.ProposalResponse{}}, nil
+}
+
+// countingSignerContext counts the number of signatures made with its signing manager
+type countingSignerContext struct {
+	context.Client
+	signs int32
+}
+
+func (c *countingSignerContext) SigningManager() core.SigningManager {
+	return &countingSigningManager{SigningManager: c.Client.SigningManager(), signs: &c.signs}
+}
+
+type countingSigningManager struct {
+	core.SigningManager
+	signs *int32
+}
+
+func (m *countingSigningManager) Sign(object []byte, key core.Key) ([]byte, error) {
+	atomic.AddInt32(m.signs, 1)
+	return m.SigningManager.Sign(object, key)
+}
@@ -15,18 +15,25 @@ import (
 	"github.com/hyperledger/fabric-sdk-go/pkg/common/errors/retry"
 	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/core"
 	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/common"
 	pb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/peer"
 )
 
 // Opts allows the user to specify more advanced options
 type Opts struct {
-	Targets       []fab.Peer // targets
-	TargetFilter  fab.TargetFilter
-	Retry         retry.Opts
-	Timeouts      map[fab.TimeoutType]time.Duration
-	ParentContext reqContext.Context //parent grpc context
+	Targets          []fab.Peer // targets
+	TargetFilter     fab.TargetFilter
+	Retry            retry.Opts
+	Timeouts         map[fab.TimeoutType]time.Duration
+	ParentContext    reqContext.Context //parent grpc context
+	EndorserSelector EndorserSelector   //custom endorser selection (selection service is used if nil)
 }
 
+// EndorserSelector selects the endorsers of an invocation from the given candidate peers and
+// returns them in the order in which they should be used. The endorsement policy of the
+// chaincode is nil if it could not be retrieved.
+type EndorserSelector func(candidates []fab.Peer, policy *common.SignaturePolicyEnvelope) ([]fab.Peer, error)
+
 // Request contains the parameters to execute transaction
 type Request struct {
 	ChaincodeID  string
@@ -58,6 +65,7 @@ type ClientContext struct {
 	Membership   fab.ChannelMembership
 	Transactor   fab.Transactor
 	EventService fab.EventService
+	PolicyCache  *CCPolicyCache
 }
 
 //RequestContext contains request, opts, response parameters for handler execution
@@ -0,0 +1,158 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package invoke
+
+import (
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/errors/status"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/logging"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/peer"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/core/common/ccprovider"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/common"
+	"github.com/pkg/errors"
+)
+
+var logger = logging.NewLogger("fabsdk/client")
+
+const (
+	lscc          = "lscc"
+	lsccGetCCData = "getccdata"
+)
+
+// selectEndorsers uses the endorser selector of the request to choose the endorsers from the
+// channel peers which are accepted by the selection filter of the request
+func selectEndorsers(requestContext *RequestContext, clientContext *ClientContext) ([]fab.Peer, error) {
+	peers, err := clientContext.Discovery.GetPeers()
+	if err != nil {
+		return nil, errors.WithMessage(err, "Failed to get candidate endorsing peers")
+	}
+
+	var candidates []fab.Peer
+	for _, p := range peers {
+		if requestContext.SelectionFilter == nil || requestContext.SelectionFilter(p) {
+			candidates = append(candidates, p)
+		}
+	}
+	if len(candidates) == 0 {
+		return nil, status.New(status.ClientStatus, status.NoPeersFound.ToInt32(), "no candidate endorsing peers found", nil)
+	}
+
+	policy, err := chaincodePolicy(clientContext, requestContext.Request.ChaincodeID, candidates)
+	if err != nil {
+		logger.Warnf("Unable to retrieve endorsement policy of chaincode [%s]: %s", requestContext.Request.ChaincodeID, err)
+	}
+
+	endorsers, err := requestContext.Opts.EndorserSelector(candidates, policy)
+	if err != nil {
+		return nil, errors.WithMessage(err, "Failed to select endorsing peers")
+	}
+	if len(endorsers) == 0 {
+		return nil, status.New(status.ClientStatus, status.NoPeersFound.ToInt32(), "no endorsing peers were selected", nil)
+	}
+	return endorsers, nil
+}
+
+// chaincodePolicy returns the endorsement policy of the chaincode from the policy cache of the client
+// context, querying the given peers only if the policy isn't cached
+func chaincodePolicy(clientContext *ClientContext, ccID string, peers []fab.Peer) (*common.SignaturePolicyEnvelope, error) {
+	if clientContext.PolicyCache == nil {
+		return queryChaincodePolicy(clientContext.Transactor, ccID, peers)
+	}
+
+	if policy, ok := clientContext.PolicyCache.get(ccID); ok {
+		return policy, nil
+	}
+
+	policy, err := queryChaincodePolicy(clientContext.Transactor, ccID, peers)
+	if err != nil {
+		return nil, err
+	}
+	clientContext.PolicyCache.put(ccID, policy)
+	return policy, nil
+}
+
+// CCPolicyCache caches the endorsement policies of chaincodes which are passed to the endorser
+// selector (see Opts.EndorserSelector) so that the policy isn't queried on every request
+type CCPolicyCache struct {
+	expiry   time.Duration
+	mutex    sync.RWMutex
+	policies map[string]*cachedPolicy
+}
+
+type cachedPolicy struct {
+	policy  *common.SignaturePolicyEnvelope
+	expires time.Time
+}
+
+// NewCCPolicyCache returns a chaincode policy cache whose entries expire after the given duration
+// (so that policy changes due to chaincode upgrades are picked up)
+func NewCCPolicyCache(expiry time.Duration) *CCPolicyCache {
+	return &CCPolicyCache{
+		expiry:   expiry,
+		policies: make(map[string]*cachedPolicy),
+	}
+}
+
+func (c *CCPolicyCache) get(ccID string) (*common.SignaturePolicyEnvelope, bool) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	cached, ok := c.policies[ccID]
+	if !ok || time.Now().After(cached.expires) {
+		return nil, false
+	}
+	return cached.policy, true
+}
+
+func (c *CCPolicyCache) put(ccID string, policy *common.SignaturePolicyEnvelope) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.policies[ccID] = &cachedPolicy{policy: policy, expires: time.Now().Add(c.expiry)}
+}
+
+// queryChaincodePolicy queries the given peers, one at a time, for the endorsement policy of the chaincode
+func queryChaincodePolicy(transactor fab.ProposalSender, ccID string, peers []fab.Peer) (*common.SignaturePolicyEnvelope, error) {
+	txh, err := transactor.CreateTransactionHeader()
+	if err != nil {
+		return nil, errors.WithMessage(err, "creating transaction header failed")
+	}
+
+	request := Request{
+		ChaincodeID: lscc,
+		Fcn:         lsccGetCCData,
+		Args:        [][]byte{[]byte(txh.ChannelID()), []byte(ccID)},
+	}
+
+	var lastErr error
+	for _, p := range peers {
+		responses, _, err := createAndSendTransactionProposal(transactor, &request, peer.PeersToTxnProcessors([]fab.Peer{p}))
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		if len(responses) == 0 || responses[0].ProposalResponse.GetResponse().GetStatus() != int32(common.Status_SUCCESS) {
+			lastErr = errors.Errorf("querying chaincode data from [%s] failed", p.URL())
+			continue
+		}
+
+		ccData := &ccprovider.ChaincodeData{}
+		if err := proto.Unmarshal(responses[0].ProposalResponse.GetResponse().GetPayload(), ccData); err != nil {
+			return nil, errors.Wrap(err, "unmarshal chaincode data failed")
+		}
+		policy := &common.SignaturePolicyEnvelope{}
+		if err := proto.Unmarshal(ccData.Policy, policy); err != nil {
+			return nil, errors.Wrap(err, "unmarshal endorsement policy failed")
+		}
+		return policy, nil
+	}
+	return nil, lastErr
+}
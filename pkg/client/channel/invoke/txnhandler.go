@@ -65,7 +65,14 @@ type ProposalProcessorHandler struct {
 //Handle selects proposal processors
 func (h *ProposalProcessorHandler) Handle(requestContext *RequestContext, clientContext *ClientContext) {
 	//Get proposal processor, if not supplied then use selection service to get available peers as endorser
-	if len(requestContext.Opts.Targets) == 0 {
+	if len(requestContext.Opts.Targets) == 0 && requestContext.Opts.EndorserSelector != nil {
+		endorsers, err := selectEndorsers(requestContext, clientContext)
+		if err != nil {
+			requestContext.Error = err
+			return
+		}
+		requestContext.Opts.Targets = endorsers
+	} else if len(requestContext.Opts.Targets) == 0 {
 		var selectionOpts []options.Opt
 		if requestContext.SelectionFilter != nil {
 			selectionOpts = append(selectionOpts, selectopts.WithPeerFilter(requestContext.SelectionFilter))
@@ -241,6 +248,17 @@ func createAndSendTransaction(sender fab.Sender, proposal *fab.TransactionPropos
 }
 
 func createAndSendTransactionProposal(transactor fab.ProposalSender, chrequest *Request, targets []fab.ProposalProcessor) ([]*fab.TransactionProposalResponse, *fab.TransactionProposal, error) {
+	proposal, err := createTransactionProposal(transactor, chrequest)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	transactionProposalResponses, err := transactor.SendTransactionProposal(proposal, targets)
+
+	return transactionProposalResponses, proposal, err
+}
+
+func createTransactionProposal(transactor fab.ProposalSender, chrequest *Request) (*fab.TransactionProposal, error) {
 	request := fab.ChaincodeInvokeRequest{
 		ChaincodeID:  chrequest.ChaincodeID,
 		Fcn:          chrequest.Fcn,
@@ -250,15 +268,13 @@ func createAndSendTransactionProposal(transactor fab.ProposalSender, chrequest *
 
 	txh, err := transactor.CreateTransactionHeader()
 	if err != nil {
-		return nil, nil, errors.WithMessage(err, "creating transaction header failed")
+		return nil, errors.WithMessage(err, "creating transaction header failed")
 	}
 
 	proposal, err := txn.CreateChaincodeInvokeProposal(txh, request)
 	if err != nil {
-		return nil, nil, errors.WithMessage(err, "creating transaction proposal failed")
+		return nil, errors.WithMessage(err, "creating transaction proposal failed")
 	}
 
-	transactionProposalResponses, err := transactor.SendTransactionProposal(proposal, targets)
-
-	return transactionProposalResponses, proposal, err
+	return proposal, nil
 }
@@ -13,6 +13,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 
@@ -22,6 +23,9 @@ import (
 	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
 	fcmocks "github.com/hyperledger/fabric-sdk-go/pkg/fab/mocks"
 	mspmocks "github.com/hyperledger/fabric-sdk-go/pkg/msp/test/mockmsp"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/common/cauthdsl"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/core/common/ccprovider"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/common"
 	pb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/peer"
 )
 
@@ -195,6 +199,29 @@ func TestResponseValidation(t *testing.T) {
 	assert.EqualValues(t, int32(status.EndorsementMismatch), s.Code, "expected endorsement mismatch")
 }
 
+func TestResponseValidationWithBuiltResponses(t *testing.T) {
+	p1 := fcmocks.NewProposalResponse().WithEndorserURL("peer1:7051").WithEndorser("Org1MSP", []byte("cert1")).WithPayload([]byte("value")).Build()
+	p2 := fcmocks.NewProposalResponse().WithEndorserURL("peer2:7051").WithEndorser("Org2MSP", []byte("cert2")).WithPayload([]byte("value")).Build()
+
+	h := EndorsementValidationHandler{}
+	assert.NoError(t, h.validate([]*fab.TransactionProposalResponse{p1, p2}), "expected matching responses of different endorsers to be valid")
+	assert.EqualValues(t, http.StatusOK, p1.ChaincodeStatus)
+
+	p3 := fcmocks.NewProposalResponse().WithEndorserURL("peer3:7051").WithPayload([]byte("other value")).Build()
+	err := h.validate([]*fab.TransactionProposalResponse{p1, p3})
+	s, ok := status.FromError(err)
+	assert.True(t, ok, "expected status error")
+	assert.EqualValues(t, int32(status.EndorsementMismatch), s.Code, "expected endorsement mismatch")
+
+	p4 := fcmocks.NewProposalResponse().WithStatus(http.StatusInternalServerError).WithMessage("chaincode error").Build()
+	err = h.validate([]*fab.TransactionProposalResponse{p1, p4})
+	s, ok = status.FromError(err)
+	assert.True(t, ok, "expected status error")
+	assert.Equal(t, status.EndorserServerStatus, s.Group, "expected endorser server status")
+	assert.EqualValues(t, http.StatusInternalServerError, s.Code)
+	assert.Equal(t, "chaincode error", s.Message)
+}
+
 func TestProposalProcessorHandlerError(t *testing.T) {
 	peer1 := fcmocks.NewMockPeer("p1", "peer1:7051")
 	peer2 := fcmocks.NewMockPeer("p2", "peer2:7051")
@@ -268,6 +295,104 @@ func TestProposalProcessorHandler(t *testing.T) {
 	}
 }
 
+func TestProposalProcessorHandlerWithEndorserSelector(t *testing.T) {
+	policy := cauthdsl.SignedByAnyMember([]string{"Org1MSP", "Org2MSP"})
+	policyBytes, err := proto.Marshal(policy)
+	if err != nil {
+		t.Fatalf("Failed to marshal policy: %s", err)
+	}
+	ccData, err := proto.Marshal(&ccprovider.ChaincodeData{Name: "testCC", Policy: policyBytes})
+	if err != nil {
+		t.Fatalf("Failed to marshal chaincode data: %s", err)
+	}
+
+	peer1 := fcmocks.NewMockPeer("p1", "peer1:7051")
+	peer1.Payload = ccData
+	peer2 := fcmocks.NewMockPeer("p2", "peer2:7051")
+	peer2.SetMSPID("Org2MSP")
+	peer2.Payload = ccData
+	peer3 := fcmocks.NewMockPeer("p3", "peer3:7051")
+	peer3.Payload = ccData
+	discoveryPeers := []fab.Peer{peer1, peer2, peer3}
+
+	clientContext := setupChannelClientContext(nil, nil, nil, t)
+	discoveryService, err := setupTestDiscovery(nil, discoveryPeers)
+	if err != nil {
+		t.Fatalf("Failed to setup discovery service: %s", err)
+	}
+	clientContext.Discovery = discoveryService
+
+	var candidates []fab.Peer
+	var receivedPolicy *common.SignaturePolicyEnvelope
+	// Prefer peers of Org2MSP
+	selector := func(peers []fab.Peer, policy *common.SignaturePolicyEnvelope) ([]fab.Peer, error) {
+		candidates = peers
+		receivedPolicy = policy
+		var local, other []fab.Peer
+		for _, p := range peers {
+			if p.MSPID() == "Org2MSP" {
+				local = append(local, p)
+			} else {
+				other = append(other, p)
+			}
+		}
+		return append(local, other...), nil
+	}
+
+	handler := NewProposalProcessorHandler()
+	request := Request{ChaincodeID: "testCC", Fcn: "invoke", Args: [][]byte{[]byte("query"), []byte("b")}}
+	requestContext := prepareRequestContext(request, Opts{EndorserSelector: selector, TargetFilter: &excludeFilter{peer: peer3}}, t)
+	handler.Handle(requestContext, clientContext)
+	if requestContext.Error != nil {
+		t.Fatalf("Got error: %s", requestContext.Error)
+	}
+	assert.Equal(t, []fab.Peer{peer1, peer2}, candidates, "expecting filtered discovery peers as candidates")
+	assert.True(t, proto.Equal(policy, receivedPolicy), "expecting chaincode policy to be passed to selector")
+	assert.Equal(t, []fab.Peer{peer2, peer1}, requestContext.Opts.Targets)
+
+	// The policy is queried once per chaincode when a policy cache is provided
+	clientContext.PolicyCache = NewCCPolicyCache(time.Minute)
+	for i := 0; i < 2; i++ {
+		receivedPolicy = nil
+		calls := peer1.ProcessProposalCalls
+		requestContext = prepareRequestContext(request, Opts{EndorserSelector: selector, TargetFilter: &excludeFilter{peer: peer3}}, t)
+		handler.Handle(requestContext, clientContext)
+		if requestContext.Error != nil {
+			t.Fatalf("Got error: %s", requestContext.Error)
+		}
+		assert.True(t, proto.Equal(policy, receivedPolicy), "expecting chaincode policy to be passed to selector")
+		if i == 0 {
+			assert.True(t, peer1.ProcessProposalCalls > calls, "expecting the policy to be queried")
+		} else {
+			assert.Equal(t, calls, peer1.ProcessProposalCalls, "expecting the cached policy to be used")
+		}
+	}
+
+	// The selection service must not be used when a selector is provided
+	requestContext = prepareRequestContext(request, Opts{EndorserSelector: selector}, t)
+	handler.Handle(requestContext, setupChannelClientContext(nil, errors.New(selectionServiceError), nil, t))
+	if requestContext.Error != nil && strings.Contains(requestContext.Error.Error(), selectionServiceError) {
+		t.Fatalf("Selection service should not have been used: %s", requestContext.Error)
+	}
+
+	selectorErr := errors.New("selector error")
+	requestContext = prepareRequestContext(request, Opts{EndorserSelector: func(peers []fab.Peer, policy *common.SignaturePolicyEnvelope) ([]fab.Peer, error) {
+		return nil, selectorErr
+	}}, t)
+	handler.Handle(requestContext, clientContext)
+	if requestContext.Error == nil || !strings.Contains(requestContext.Error.Error(), selectorErr.Error()) {
+		t.Fatalf("Expected error: %s, Received error: %v", selectorErr, requestContext.Error)
+	}
+}
+
+type excludeFilter struct {
+	peer fab.Peer
+}
+
+func (f *excludeFilter) Accept(p fab.Peer) bool {
+	return p.URL() != f.peer.URL()
+}
+
 //prepareHandlerContexts prepares context objects for handlers
 func prepareRequestContext(request Request, opts Opts, t *testing.T) *RequestContext {
 	requestContext := &RequestContext{Request: request,
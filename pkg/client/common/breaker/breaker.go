@@ -0,0 +1,254 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package breaker provides a circuit breaker for peers (proposal processors). A circuit breaker
+// opens after a number of consecutive failures, after which calls to the peer are short-circuited
+// for a cool-down period. The breaker then half-opens and lets a single call through to probe the peer.
+package breaker
+
+import (
+	"sync"
+	"time"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/errors/status"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/logging"
+	"github.com/pkg/errors"
+)
+
+var logger = logging.NewLogger("fabsdk/client")
+
+const (
+	defaultFailureThreshold = 5
+	defaultFailureWindow    = time.Minute
+	defaultCooldown         = 30 * time.Second
+)
+
+// ErrOpen is returned (wrapped) when a call is short-circuited because the circuit breaker is open
+var ErrOpen = errors.New("circuit breaker is open")
+
+// State is the state of a circuit breaker
+type State int32
+
+const (
+	// Closed means that calls are allowed through
+	Closed State = iota
+	// Open means that calls are short-circuited
+	Open
+	// HalfOpen means that the cool-down has elapsed and a single probe call is allowed through
+	HalfOpen
+)
+
+func (s State) String() string {
+	switch s {
+	case Closed:
+		return "CLOSED"
+	case Open:
+		return "OPEN"
+	case HalfOpen:
+		return "HALF_OPEN"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// StateObserver is notified when the circuit breaker of a target changes state.
+// The observer is invoked synchronously and must not call back into the breaker.
+type StateObserver func(target string, from, to State)
+
+// Opts contains the circuit breaker options
+type Opts struct {
+	// FailureThreshold is the number of consecutive failures after which the breaker opens
+	FailureThreshold int
+	// FailureWindow is the period within which the consecutive failures must occur
+	FailureWindow time.Duration
+	// Cooldown is the period for which calls are short-circuited before the breaker half-opens
+	Cooldown time.Duration
+	// Observer, if set, is notified of state changes
+	Observer StateObserver
+}
+
+// Opt is a circuit breaker option
+type Opt func(opts *Opts)
+
+// WithFailureThreshold sets the number of consecutive failures after which the breaker opens
+func WithFailureThreshold(value int) Opt {
+	return func(opts *Opts) {
+		opts.FailureThreshold = value
+	}
+}
+
+// WithFailureWindow sets the period within which the consecutive failures must occur for the breaker to open
+func WithFailureWindow(value time.Duration) Opt {
+	return func(opts *Opts) {
+		opts.FailureWindow = value
+	}
+}
+
+// WithCooldown sets the period for which calls are short-circuited before the breaker half-opens
+func WithCooldown(value time.Duration) Opt {
+	return func(opts *Opts) {
+		opts.Cooldown = value
+	}
+}
+
+// WithStateObserver sets an observer which is notified of state changes
+func WithStateObserver(value StateObserver) Opt {
+	return func(opts *Opts) {
+		opts.Observer = value
+	}
+}
+
+func newOpts(opts ...Opt) Opts {
+	o := Opts{
+		FailureThreshold: defaultFailureThreshold,
+		FailureWindow:    defaultFailureWindow,
+		Cooldown:         defaultCooldown,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// Breaker is the circuit breaker of a single target
+type Breaker struct {
+	target       string
+	opts         Opts
+	mutex        sync.Mutex
+	state        State
+	failures     int
+	firstFailure time.Time
+	openedAt     time.Time
+	probing      bool
+}
+
+// New returns a new circuit breaker for the given target
+func New(target string, opts ...Opt) *Breaker {
+	return &Breaker{target: target, opts: newOpts(opts...)}
+}
+
+// Target returns the target of the circuit breaker
+func (b *Breaker) Target() string {
+	return b.target
+}
+
+// State returns the current state of the circuit breaker. An open breaker whose
+// cool-down has elapsed is reported as half-open.
+func (b *Breaker) State() State {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if b.state == Open && b.cooledDown() {
+		return HalfOpen
+	}
+	return b.state
+}
+
+// Allow returns true if a call may be made to the target. If true is returned then the outcome
+// of the call must be reported with Success or Failure. While half-open, only a single probe
+// call is allowed at a time.
+func (b *Breaker) Allow() bool {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	switch b.state {
+	case Closed:
+		return true
+	case Open:
+		if !b.cooledDown() {
+			return false
+		}
+		b.setState(HalfOpen)
+		b.probing = true
+		return true
+	default:
+		if b.probing {
+			return false
+		}
+		b.probing = true
+		return true
+	}
+}
+
+// Success reports a successful call. A half-open breaker is closed.
+func (b *Breaker) Success() {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.failures = 0
+	b.probing = false
+	if b.state != Closed {
+		b.setState(Closed)
+	}
+}
+
+// Failure reports a failed call. The breaker opens if the failure threshold is reached
+// within the failure window, or if the failed call was a half-open probe.
+func (b *Breaker) Failure() {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	now := time.Now()
+	b.probing = false
+
+	switch b.state {
+	case HalfOpen:
+		b.open(now)
+	case Closed:
+		if b.failures == 0 || now.Sub(b.firstFailure) > b.opts.FailureWindow {
+			b.failures = 0
+			b.firstFailure = now
+		}
+		b.failures++
+		if b.failures >= b.opts.FailureThreshold {
+			b.open(now)
+		}
+	}
+}
+
+// Record reports the outcome of a call based on the error returned by the call. Errors which
+// were returned by the peer itself (e.g. a chaincode error) mean that the peer is reachable
+// and are therefore not counted as failures.
+func (b *Breaker) Record(err error) {
+	if IsFailure(err) {
+		b.Failure()
+	} else {
+		b.Success()
+	}
+}
+
+// IsFailure returns true if the given error indicates that the target is not functioning,
+// as opposed to an error response from a functioning target
+func IsFailure(err error) bool {
+	if err == nil {
+		return false
+	}
+	s, ok := status.FromError(err)
+	if !ok {
+		return true
+	}
+	return s.Group != status.EndorserServerStatus && s.Group != status.ChaincodeStatus
+}
+
+func (b *Breaker) open(now time.Time) {
+	b.failures = 0
+	b.openedAt = now
+	b.setState(Open)
+}
+
+func (b *Breaker) cooledDown() bool {
+	return time.Since(b.openedAt) >= b.opts.Cooldown
+}
+
+func (b *Breaker) setState(state State) {
+	from := b.state
+	b.state = state
+
+	logger.Debugf("Circuit breaker for [%s] changed state from %s to %s", b.target, from, state)
+	if b.opts.Observer != nil {
+		b.opts.Observer(b.target, from, state)
+	}
+}
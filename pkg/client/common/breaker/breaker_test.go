@@ -0,0 +1,74 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package breaker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/errors/status"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBreaker(t *testing.T) {
+	var transitions []State
+	b := New("peer1", WithFailureThreshold(3), WithCooldown(50*time.Millisecond),
+		WithStateObserver(func(target string, from, to State) {
+			assert.Equal(t, "peer1", target)
+			transitions = append(transitions, to)
+		}))
+
+	assert.Equal(t, Closed, b.State())
+	assert.True(t, b.Allow())
+
+	b.Failure()
+	b.Failure()
+	b.Success()
+	b.Failure()
+	b.Failure()
+	assert.Equal(t, Closed, b.State(), "consecutive failures were interrupted by a success")
+
+	b.Failure()
+	assert.Equal(t, Open, b.State())
+	assert.False(t, b.Allow(), "calls should be short-circuited while open")
+
+	time.Sleep(60 * time.Millisecond)
+	assert.Equal(t, HalfOpen, b.State())
+	assert.True(t, b.Allow(), "a probe should be allowed after the cool-down")
+	assert.False(t, b.Allow(), "only a single probe should be allowed")
+
+	b.Failure()
+	assert.Equal(t, Open, b.State(), "a failed probe should re-open the breaker")
+
+	time.Sleep(60 * time.Millisecond)
+	assert.True(t, b.Allow())
+	b.Success()
+	assert.Equal(t, Closed, b.State(), "a successful probe should close the breaker")
+
+	assert.Equal(t, []State{Open, HalfOpen, Open, HalfOpen, Closed}, transitions)
+}
+
+func TestBreakerFailureWindow(t *testing.T) {
+	b := New("peer1", WithFailureThreshold(2), WithFailureWindow(20*time.Millisecond))
+
+	b.Failure()
+	time.Sleep(30 * time.Millisecond)
+	b.Failure()
+	assert.Equal(t, Closed, b.State(), "failures outside of the window should not open the breaker")
+
+	b.Failure()
+	assert.Equal(t, Open, b.State())
+}
+
+func TestIsFailure(t *testing.T) {
+	assert.False(t, IsFailure(nil))
+	assert.True(t, IsFailure(errors.New("connection refused")))
+	assert.True(t, IsFailure(status.New(status.EndorserClientStatus, status.ConnectionFailed.ToInt32(), "connection failed", nil)))
+	assert.False(t, IsFailure(errors.Wrap(status.New(status.EndorserServerStatus, 500, "chaincode error", nil), "endorsement failed")))
+	assert.False(t, IsFailure(status.New(status.ChaincodeStatus, 400, "bad request", nil)))
+}
@@ -0,0 +1,132 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package breaker
+
+import (
+	reqContext "context"
+	"sync"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+	"github.com/pkg/errors"
+)
+
+// Registry maintains a circuit breaker per target (peer URL). The registry is also a
+// fab.TargetFilter which rejects peers whose circuit breaker is open, so it may be used with
+// channel client (channel.WithTargetFilter) and channel config (chconfig.WithTargetFilter)
+// target selection to avoid known-bad peers.
+type Registry struct {
+	opts     []Opt
+	mutex    sync.RWMutex
+	breakers map[string]*Breaker
+}
+
+// NewRegistry returns a new circuit breaker registry. The given options apply to each of the circuit breakers.
+func NewRegistry(opts ...Opt) *Registry {
+	return &Registry{
+		opts:     opts,
+		breakers: make(map[string]*Breaker),
+	}
+}
+
+// Breaker returns the circuit breaker for the given target, creating it if necessary
+func (r *Registry) Breaker(target string) *Breaker {
+	r.mutex.RLock()
+	b, ok := r.breakers[target]
+	r.mutex.RUnlock()
+	if ok {
+		return b
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	b, ok = r.breakers[target]
+	if !ok {
+		b = New(target, r.opts...)
+		r.breakers[target] = b
+	}
+	return b
+}
+
+// States returns the state of each of the circuit breakers, keyed by target
+func (r *Registry) States() map[string]State {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	states := make(map[string]State, len(r.breakers))
+	for target, b := range r.breakers {
+		states[target] = b.State()
+	}
+	return states
+}
+
+// Accept returns false if the circuit breaker of the given peer is open
+func (r *Registry) Accept(peer fab.Peer) bool {
+	r.mutex.RLock()
+	b, ok := r.breakers[peer.URL()]
+	r.mutex.RUnlock()
+
+	return !ok || b.State() != Open
+}
+
+// WrapProcessor returns a proposal processor which is guarded by the circuit breaker of the given target
+func (r *Registry) WrapProcessor(target string, processor fab.ProposalProcessor) fab.ProposalProcessor {
+	return &processorWrapper{ProposalProcessor: processor, breaker: r.Breaker(target)}
+}
+
+// Wrap returns a peer whose proposal processing is guarded by the circuit breaker of the peer
+func (r *Registry) Wrap(peer fab.Peer) fab.Peer {
+	return &peerWrapper{
+		Peer:    peer,
+		breaker: r.Breaker(peer.URL()),
+	}
+}
+
+// WrapPeers wraps each of the given peers (see Wrap)
+func (r *Registry) WrapPeers(peers []fab.Peer) []fab.Peer {
+	wrapped := make([]fab.Peer, len(peers))
+	for i, p := range peers {
+		wrapped[i] = r.Wrap(p)
+	}
+	return wrapped
+}
+
+type processorWrapper struct {
+	fab.ProposalProcessor
+	breaker *Breaker
+}
+
+func (p *processorWrapper) ProcessTransactionProposal(ctx reqContext.Context, request fab.ProcessProposalRequest) (*fab.TransactionProposalResponse, error) {
+	return process(ctx, p.breaker, p.ProposalProcessor, request)
+}
+
+type peerWrapper struct {
+	fab.Peer
+	breaker *Breaker
+}
+
+func (p *peerWrapper) ProcessTransactionProposal(ctx reqContext.Context, request fab.ProcessProposalRequest) (*fab.TransactionProposalResponse, error) {
+	return process(ctx, p.breaker, p.Peer, request)
+}
+
+// Health delegates to the wrapped peer. Peers which don't support health checks are assumed to be healthy.
+func (p *peerWrapper) Health(ctx reqContext.Context) error {
+	if hc, ok := p.Peer.(fab.HealthChecker); ok {
+		return hc.Health(ctx)
+	}
+	return nil
+}
+
+func process(ctx reqContext.Context, b *Breaker, processor fab.ProposalProcessor, request fab.ProcessProposalRequest) (*fab.TransactionProposalResponse, error) {
+	if !b.Allow() {
+		return nil, errors.Wrapf(ErrOpen, "proposal to [%s] short-circuited", b.Target())
+	}
+
+	resp, err := processor.ProcessTransactionProposal(ctx, request)
+	b.Record(err)
+	return resp, err
+}
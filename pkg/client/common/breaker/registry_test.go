@@ -0,0 +1,60 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package breaker
+
+import (
+	reqContext "context"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/mocks"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegistry(t *testing.T) {
+	registry := NewRegistry(WithFailureThreshold(2), WithCooldown(time.Minute))
+
+	good := mocks.NewMockPeer("good", "http://peer1.com")
+	bad := mocks.NewMockPeer("bad", "http://peer2.com")
+	bad.Error = errors.New("connection refused")
+
+	peers := registry.WrapPeers([]fab.Peer{good, bad})
+	for i := 0; i < 2; i++ {
+		for _, p := range peers {
+			_, _ = p.ProcessTransactionProposal(reqContext.Background(), fab.ProcessProposalRequest{})
+		}
+	}
+
+	assert.Equal(t, map[string]State{"http://peer1.com": Closed, "http://peer2.com": Open}, registry.States())
+	assert.True(t, registry.Accept(good))
+	assert.False(t, registry.Accept(bad), "expecting peer with open breaker to be rejected")
+	assert.True(t, registry.Accept(mocks.NewMockPeer("unknown", "http://peer3.com")))
+
+	_, err := peers[1].ProcessTransactionProposal(reqContext.Background(), fab.ProcessProposalRequest{})
+	assert.Equal(t, ErrOpen, errors.Cause(err))
+	assert.Equal(t, 2, bad.ProcessProposalCalls, "short-circuited proposals should not reach the peer")
+
+	var hc fab.HealthChecker = peers[0].(fab.HealthChecker)
+	assert.Nil(t, hc.Health(reqContext.Background()))
+}
+
+func TestRegistryWrapProcessor(t *testing.T) {
+	registry := NewRegistry(WithFailureThreshold(1))
+
+	peer := mocks.NewMockPeer("bad", "http://peer1.com")
+	peer.Error = errors.New("connection refused")
+
+	processor := registry.WrapProcessor(peer.URL(), peer)
+	_, err := processor.ProcessTransactionProposal(reqContext.Background(), fab.ProcessProposalRequest{})
+	assert.Equal(t, peer.Error, err)
+
+	_, err = processor.ProcessTransactionProposal(reqContext.Background(), fab.ProcessProposalRequest{})
+	assert.Equal(t, ErrOpen, errors.Cause(err))
+	assert.Equal(t, Open, registry.Breaker(peer.URL()).State())
+}
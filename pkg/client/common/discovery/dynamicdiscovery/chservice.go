@@ -10,6 +10,7 @@ import (
 	discclient "github.com/hyperledger/fabric-sdk-go/internal/github.com/hyperledger/fabric/discovery/client"
 	contextAPI "github.com/hyperledger/fabric-sdk-go/pkg/common/providers/context"
 	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/tracing"
 	reqContext "github.com/hyperledger/fabric-sdk-go/pkg/context"
 	fabdiscovery "github.com/hyperledger/fabric-sdk-go/pkg/fab/discovery"
 	"github.com/pkg/errors"
@@ -59,8 +60,15 @@ func (s *channelService) queryPeers() ([]fab.Peer, error) {
 	reqCtx, cancel := reqContext.NewRequest(channelContext, reqContext.WithTimeout(s.responseTimeout))
 	defer cancel()
 
+	reqCtx, span := tracing.StartSpan(reqCtx, "discovery.QueryPeers")
+	span.SetAttribute(tracing.ChannelKey, channelContext.ChannelID())
+	if tracing.IsRecording(span) {
+		span.SetAttribute(tracing.TargetsKey, tracing.Targets(peerConfigURLs(targets)))
+	}
+
 	req := discclient.NewRequest().OfChannel(channelContext.ChannelID()).AddPeersQuery()
 	responses, err := s.discoveryClient().Send(reqCtx, req, targets...)
+	tracing.EndSpan(span, err)
 	if err != nil {
 		if len(responses) == 0 {
 			return nil, errors.Wrapf(err, "error calling discover service send")
@@ -84,6 +92,14 @@ func (s *channelService) getTargets(ctx contextAPI.Channel) ([]fab.PeerConfig, e
 	return targets, nil
 }
 
+func peerConfigURLs(peers []fab.PeerConfig) []string {
+	urls := make([]string, len(peers))
+	for i, p := range peers {
+		urls[i] = p.URL
+	}
+	return urls
+}
+
 // evaluate validates the responses and returns the peers
 func (s *channelService) evaluate(ctx contextAPI.Channel, responses []fabdiscovery.Response) ([]fab.Peer, error) {
 	if len(responses) == 0 {
@@ -10,6 +10,7 @@ import (
 	discclient "github.com/hyperledger/fabric-sdk-go/internal/github.com/hyperledger/fabric/discovery/client"
 	contextAPI "github.com/hyperledger/fabric-sdk-go/pkg/common/providers/context"
 	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/tracing"
 	reqContext "github.com/hyperledger/fabric-sdk-go/pkg/context"
 	"github.com/pkg/errors"
 )
@@ -54,8 +55,12 @@ func (s *LocalService) queryPeers() ([]fab.Peer, error) {
 	reqCtx, cancel := reqContext.NewRequest(ctx, reqContext.WithTimeout(s.responseTimeout))
 	defer cancel()
 
+	reqCtx, span := tracing.StartSpan(reqCtx, "discovery.QueryLocalPeers")
+	span.SetAttribute(tracing.TargetsKey, target.URL)
+
 	req := discclient.NewRequest().AddLocalPeersQuery()
 	responses, err := s.discoveryClient().Send(reqCtx, req, *target)
+	tracing.EndSpan(span, err)
 	if err != nil {
 		return nil, errors.Wrapf(err, "error calling discover service send")
 	}
@@ -125,7 +125,7 @@ func asPeers(ctx contextAPI.Client, endpoints []*discclient.Peer) []fab.Peer {
 			logger.Warnf("Unable to create peer config for [%s]: %s", url, err)
 			continue
 		}
-		peers = append(peers, peer)
+		peers = append(peers, fabdiscovery.NewPeerState(peer, endpoint))
 	}
 
 	return peers
@@ -63,7 +63,7 @@ func (b *Filter) Greylist(err error) {
 // required decides whether the given status error warrants a greylist
 // on the peer causing the error
 func required(s *status.Status) (bool, string) {
-	if s.Group == status.EndorserClientStatus && s.Code == status.ConnectionFailed.ToInt32() {
+	if s.Group == status.EndorserClientStatus && (s.Code == status.ConnectionFailed.ToInt32() || s.Code == status.DialTimeout.ToInt32()) {
 		return true, peerURLFromConnectionFailedStatus(s.Details)
 	}
 	return false, ""
@@ -192,11 +192,24 @@ func (s *selectionService) GetEndorsersForChaincode(chaincodeIDs []string, opts
 		peers = filteredPeers
 	}
 
+	if params.MaxBlockHeightLag != nil {
+		peers = options.FilterByBlockHeight(peers, *params.MaxBlockHeightLag)
+	}
+
+	if len(params.RequiredOrgs) > 0 {
+		peers = options.FilterByOrgs(peers, params.RequiredOrgs)
+	}
+
 	peerGroup, err := resolver.Resolve(peers)
 	if err != nil {
 		return nil, err
 	}
-	return peerGroup.Peers(), nil
+
+	endorsers := peerGroup.Peers()
+	if len(endorsers) == 0 && len(params.RequiredOrgs) > 0 {
+		return nil, errors.Errorf("endorsement policy of chaincodes %v on channel [%s] cannot be satisfied by peers of the required orgs %v", chaincodeIDs, s.channelID, params.RequiredOrgs)
+	}
+	return endorsers, nil
 }
 
 func (s *selectionService) Close() {
@@ -13,6 +13,7 @@ import (
 
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric-sdk-go/pkg/client/common/selection/dynamicselection/pgresolver"
+	"github.com/hyperledger/fabric-sdk-go/pkg/client/common/selection/options"
 	"github.com/hyperledger/fabric-sdk-go/pkg/common/logging"
 	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
 	"github.com/hyperledger/fabric-sdk-go/pkg/core/config"
@@ -83,6 +84,72 @@ func TestGetEndorsersForChaincodeOneCC(t *testing.T) {
 	verify(t, service, expected, channel1, cc1)
 }
 
+func TestGetEndorsersForChaincodeLeastRecentlyUsed(t *testing.T) {
+	channelPeers := []fab.Peer{p1, p2, p3, p4, p5, p6, p7, p8}
+
+	service, err := newMockSelectionService(
+		newMockCCDataProvider(channel1).
+			add(cc1, getPolicy1()),
+		pgresolver.NewLeastRecentlyUsedLBP(),
+		newMockDiscoveryService(channelPeers...),
+	)
+	if err != nil {
+		t.Fatalf("got error creating selection service: %s", err)
+	}
+
+	// Channel1(Policy(cc1)) = Org1 - the policy should alternate between peer1 and peer2
+	var previous []fab.Peer
+	for i := 0; i < 4; i++ {
+		peers, err := service.GetEndorsersForChaincode([]string{cc1})
+		if err != nil {
+			t.Fatalf("error getting endorsers: %s", err)
+		}
+		if !containsPeerGroup([]pgresolver.PeerGroup{pg(p1), pg(p2)}, peers) {
+			t.Fatalf("peer group %s does not satisfy the policy", toString(peers))
+		}
+		if previous != nil && previous[0].URL() == peers[0].URL() {
+			t.Fatalf("expecting a different peer to be chosen than the previous request but got %s", toString(peers))
+		}
+		previous = peers
+	}
+}
+
+func TestGetEndorsersForChaincodeWithRequiredOrgs(t *testing.T) {
+	channelPeers := []fab.Peer{p1, p2, p3, p4, p5, p6, p7, p8}
+
+	service, err := newMockSelectionService(
+		newMockCCDataProvider(channel1).
+			add(cc1, getPolicy1()).
+			add(cc2, getPolicy2()),
+		pgresolver.NewRoundRobinLBP(),
+		newMockDiscoveryService(channelPeers...),
+	)
+	if err != nil {
+		t.Fatalf("got error creating selection service: %s", err)
+	}
+
+	// Channel1(Policy(cc1) and Policy(cc2)) = Org1 and (1 of [(2 of [Org1,Org2]),(2 of [Org1,Org3,Org4])])
+	// Restricted to Org1 and Org3
+	expected := []pgresolver.PeerGroup{
+		pg(p1, p5), pg(p1, p6), pg(p1, p7), pg(p2, p5), pg(p2, p6), pg(p2, p7),
+	}
+	for i := 0; i < len(expected); i++ {
+		peers, err := service.GetEndorsersForChaincode([]string{cc1, cc2}, options.WithRequiredOrgs([]string{org1, org3}))
+		if err != nil {
+			t.Fatalf("error getting endorsers: %s", err)
+		}
+		if !containsPeerGroup(expected, peers) {
+			t.Fatalf("peer group %s is not one of the expected peer groups: %v", toString(peers), expected)
+		}
+	}
+
+	// The policy cannot be satisfied without Org1
+	_, err = service.GetEndorsersForChaincode([]string{cc1, cc2}, options.WithRequiredOrgs([]string{org2, org3}))
+	if err == nil {
+		t.Fatalf("expecting error since the policy cannot be satisfied by the required orgs")
+	}
+}
+
 func TestGetEndorsersForChaincodeTwoCCs(t *testing.T) {
 	channelPeers := []fab.Peer{p1, p2, p3, p4, p5, p6, p7, p8}
 
@@ -8,6 +8,9 @@ package pgresolver
 
 import (
 	"math/rand"
+	"sync"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
 )
 
 type randomLBP struct {
@@ -34,6 +37,7 @@ func (lbp *randomLBP) Choose(peerGroups []PeerGroup) PeerGroup {
 }
 
 type roundRobinLBP struct {
+	lock  sync.Mutex
 	index int
 }
 
@@ -49,6 +53,9 @@ func (lbp *roundRobinLBP) Choose(peerGroups []PeerGroup) PeerGroup {
 		return NewPeerGroup()
 	}
 
+	lbp.lock.Lock()
+	defer lbp.lock.Unlock()
+
 	if lbp.index == -1 {
 		lbp.index = rand.Intn(len(peerGroups))
 	} else {
@@ -63,3 +70,57 @@ func (lbp *roundRobinLBP) Choose(peerGroups []PeerGroup) PeerGroup {
 
 	return peerGroups[lbp.index]
 }
+
+type lruLBP struct {
+	lock     sync.Mutex
+	seq      uint64
+	lastUsed map[string]uint64
+}
+
+// NewLeastRecentlyUsedLBP returns a load-balance policy which chooses the peer group whose
+// most recently used peer was used the longest time ago. Peer groups whose peers have never
+// been chosen are preferred over all others.
+func NewLeastRecentlyUsedLBP() LoadBalancePolicy {
+	return &lruLBP{lastUsed: make(map[string]uint64)}
+}
+
+func (lbp *lruLBP) Choose(peerGroups []PeerGroup) PeerGroup {
+	if len(peerGroups) == 0 {
+		logger.Warn("No available peer groups\n")
+		// Return an empty PeerGroup
+		return NewPeerGroup()
+	}
+
+	lbp.lock.Lock()
+	defer lbp.lock.Unlock()
+
+	index := 0
+	var minLastUsed uint64
+	for i, peerGroup := range peerGroups {
+		lastUsed := lbp.latest(peerGroup.Peers())
+		if i == 0 || lastUsed < minLastUsed {
+			index = i
+			minLastUsed = lastUsed
+		}
+	}
+
+	lbp.seq++
+	for _, peer := range peerGroups[index].Peers() {
+		lbp.lastUsed[peer.URL()] = lbp.seq
+	}
+
+	logger.Debugf("lruLBP - Choosing index %d\n", index)
+
+	return peerGroups[index]
+}
+
+// latest returns the sequence number of the most recent use of any of the given peers
+func (lbp *lruLBP) latest(peers []fab.Peer) uint64 {
+	var latest uint64
+	for _, peer := range peers {
+		if lastUsed := lbp.lastUsed[peer.URL()]; lastUsed > latest {
+			latest = lastUsed
+		}
+	}
+	return latest
+}
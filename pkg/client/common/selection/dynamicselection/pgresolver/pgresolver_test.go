@@ -7,6 +7,7 @@ package pgresolver
 
 import (
 	"math/rand"
+	"sync"
 	"testing"
 	"time"
 
@@ -326,6 +327,61 @@ func TestPeerGroupResolverRandomPeers(t *testing.T) {
 	}
 }
 
+func TestPeerGroupResolverLeastRecentlyUsed(t *testing.T) {
+	signedBy, identities, err := GetPolicies(org1)
+	if err != nil {
+		panic(err)
+	}
+
+	sigPolicyEnv := &common.SignaturePolicyEnvelope{
+		Version: 0, Rule: signedBy[o1], Identities: identities,
+	}
+
+	pgResolver, err := NewLeastRecentlyUsedPeerGroupResolver(sigPolicyEnv)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The policy is satisfied by either of the Org1 peers so the peers should be chosen alternately
+	peers := []fab.Peer{p1, p2, p3}
+	expected := []PeerGroup{pg(p1), pg(p2)}
+
+	var previous PeerGroup
+	for i := 0; i < 4; i++ {
+		peerGroup, err := pgResolver.Resolve(peers)
+		if err != nil {
+			t.Fatalf("got error resolving peer groups: %s", err)
+		}
+		if !containsPeerGroup(expected, peerGroup) {
+			t.Fatalf("peer group %s is not one of the expected peer groups: %v", peerGroup, expected)
+		}
+		if previous != nil && containsAllPeers(previous, peerGroup) {
+			t.Fatalf("expecting a different peer group to be chosen than the previous one but got %s", peerGroup)
+		}
+		previous = peerGroup
+	}
+}
+
+func TestLoadBalancePoliciesConcurrent(t *testing.T) {
+	peerGroups := []PeerGroup{pg(p1, p3), pg(p1, p4), pg(p2, p3), pg(p2, p4)}
+
+	for _, lbp := range []LoadBalancePolicy{NewRoundRobinLBP(), NewLeastRecentlyUsedLBP()} {
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for j := 0; j < 100; j++ {
+					if peerGroup := lbp.Choose(peerGroups); !containsPeerGroup(peerGroups, peerGroup) {
+						t.Errorf("peer group %s is not one of the given peer groups", peerGroup)
+					}
+				}
+			}()
+		}
+		wg.Wait()
+	}
+}
+
 func testPeerGroupResolver(t *testing.T, sigPolicyEnv *common.SignaturePolicyEnvelope, peers []fab.Peer, expected []PeerGroup, expectedErr error) {
 	pgResolver, err := NewRoundRobinPeerGroupResolver(sigPolicyEnv)
 	if err != nil {
@@ -48,6 +48,15 @@ func NewRandomPeerGroupResolver(sigPolicyEnv *common.SignaturePolicyEnvelope) (P
 	return NewPeerGroupResolver(groupRetriever, NewRandomLBP())
 }
 
+// NewLeastRecentlyUsedPeerGroupResolver returns a PeerGroupResolver that chooses the least recently used peers
+func NewLeastRecentlyUsedPeerGroupResolver(sigPolicyEnv *common.SignaturePolicyEnvelope) (PeerGroupResolver, error) {
+	groupRetriever, err := CompileSignaturePolicy(sigPolicyEnv)
+	if err != nil {
+		return nil, errors.WithMessage(err, "error evaluating signature policy")
+	}
+	return NewPeerGroupResolver(groupRetriever, NewLeastRecentlyUsedLBP())
+}
+
 // NewPeerGroupResolver returns a new PeerGroupResolver
 func NewPeerGroupResolver(groupRetriever GroupRetriever, lbp LoadBalancePolicy) (PeerGroupResolver, error) {
 	return &peerGroupResolver{
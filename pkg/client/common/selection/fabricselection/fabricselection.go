@@ -0,0 +1,193 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fabricselection
+
+import (
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/client/common/selection/options"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/logging"
+	copts "github.com/hyperledger/fabric-sdk-go/pkg/common/options"
+	contextAPI "github.com/hyperledger/fabric-sdk-go/pkg/common/providers/context"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/discovery"
+	"github.com/hyperledger/fabric-sdk-go/pkg/util/concurrent/lazycache"
+	"github.com/pkg/errors"
+)
+
+const loggerModule = "fabsdk/client"
+
+var logger = logging.NewLogger(loggerModule)
+
+type endorsementService interface {
+	GetEndorsers(ccID string) ([]fab.Peer, error)
+	Invalidate(ccIDs ...string)
+	Close()
+}
+
+// endorsementServiceProvider is overridden by unit tests
+var endorsementServiceProvider = func(ctx contextAPI.Channel, opts ...discovery.Opt) (endorsementService, error) {
+	return discovery.NewEndorsementService(ctx, opts...)
+}
+
+// SelectionProvider implements a selection provider which uses Fabric's Discovery service
+// to select the endorsers of a chaincode. The endorsers of each chaincode are cached (see
+// discovery.WithCacheTTL and discovery.WithRefreshInterval) and the cache is invalidated
+// whenever the membership of the channel changes.
+type SelectionProvider struct {
+	cache *lazycache.Cache
+}
+
+// New returns a Fabric selection provider. The given options (such as discovery.WithCacheTTL)
+// are applied to the endorsement service of each channel.
+func New(config fab.EndpointConfig, opts ...discovery.Opt) (*SelectionProvider, error) {
+	return &SelectionProvider{
+		cache: lazycache.New("Fabric_Selection_Service_Cache", func(key lazycache.Key) (interface{}, error) {
+			return &selectionService{channelID: key.String(), opts: opts}, nil
+		}),
+	}, nil
+}
+
+// CreateSelectionService returns the Fabric selection service of the given channel. The service
+// (and therefore the cached endorsers) is shared by all clients of the channel.
+func (p *SelectionProvider) CreateSelectionService(channelID string) (fab.SelectionService, error) {
+	if channelID == "" {
+		return nil, errors.New("Must provide channel ID")
+	}
+
+	ref, err := p.cache.Get(lazycache.NewStringKey(channelID))
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to get selection service from cache")
+	}
+	return ref.(fab.SelectionService), nil
+}
+
+// Close the selection services created by this provider
+func (p *SelectionProvider) Close() {
+	p.cache.Close()
+}
+
+type selectionService struct {
+	channelID          string
+	opts               []discovery.Opt
+	discoveryService   fab.DiscoveryService
+	endorsementService endorsementService
+	lock               sync.Mutex
+	membership         *string
+}
+
+// Initialize initializes the service with the channel context. Since the service is shared
+// by all clients of the channel, only the first context is used.
+func (s *selectionService) Initialize(context contextAPI.Channel) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.endorsementService != nil {
+		logger.Debugf("Already initialized selection service for channel [%s]", s.channelID)
+		return nil
+	}
+
+	endorsementService, err := endorsementServiceProvider(context, s.opts...)
+	if err != nil {
+		return errors.WithMessage(err, "error creating endorsement service")
+	}
+
+	s.discoveryService = context.DiscoveryService()
+	s.endorsementService = endorsementService
+	return nil
+}
+
+func (s *selectionService) GetEndorsersForChaincode(chaincodeIDs []string, opts ...copts.Opt) ([]fab.Peer, error) {
+	if len(chaincodeIDs) == 0 {
+		return nil, errors.New("no chaincode IDs provided")
+	}
+	if len(chaincodeIDs) > 1 {
+		return nil, errors.Errorf("selection of endorsers for multiple chaincodes %v is not supported", chaincodeIDs)
+	}
+
+	params := options.NewParams(opts)
+
+	endorsementService, err := s.checkMembership()
+	if err != nil {
+		return nil, err
+	}
+
+	endorsers, err := endorsementService.GetEndorsers(chaincodeIDs[0])
+	if err != nil {
+		return nil, errors.WithMessage(err, "error getting endorsers from discovery service")
+	}
+
+	if params.PeerFilter != nil {
+		var filteredEndorsers []fab.Peer
+		for _, endorser := range endorsers {
+			if params.PeerFilter(endorser) {
+				filteredEndorsers = append(filteredEndorsers, endorser)
+			} else {
+				logger.Debugf("Peer [%s] is not accepted by the filter and therefore will be excluded.", endorser.URL())
+			}
+		}
+		endorsers = filteredEndorsers
+	}
+
+	if params.MaxBlockHeightLag != nil {
+		endorsers = options.FilterByBlockHeight(endorsers, *params.MaxBlockHeightLag)
+	}
+
+	if len(params.RequiredOrgs) > 0 {
+		endorsers = options.FilterByOrgs(endorsers, params.RequiredOrgs)
+		if len(endorsers) == 0 {
+			return nil, errors.Errorf("no endorsers of chaincode [%s] on channel [%s] belong to the required orgs %v", chaincodeIDs[0], s.channelID, params.RequiredOrgs)
+		}
+	}
+
+	return endorsers, nil
+}
+
+func (s *selectionService) Close() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.endorsementService != nil {
+		s.endorsementService.Close()
+	}
+}
+
+// checkMembership invalidates the cached endorsers if the peers of the channel have
+// changed since the previous request
+func (s *selectionService) checkMembership() (endorsementService, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.endorsementService == nil {
+		return nil, errors.New("the selection service has not been initialized")
+	}
+
+	peers, err := s.discoveryService.GetPeers()
+	if err != nil {
+		return nil, errors.WithMessage(err, "error getting channel peers")
+	}
+
+	membership := membershipKey(peers)
+	if s.membership != nil && *s.membership != membership {
+		logger.Debugf("Membership of channel [%s] has changed - invalidating cached endorsers", s.channelID)
+		s.endorsementService.Invalidate()
+	}
+	s.membership = &membership
+
+	return s.endorsementService, nil
+}
+
+func membershipKey(peers []fab.Peer) string {
+	urls := make([]string, len(peers))
+	for i, peer := range peers {
+		urls[i] = peer.URL()
+	}
+	sort.Strings(urls)
+	return strings.Join(urls, ",")
+}
@@ -0,0 +1,158 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fabricselection
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/client/common/selection/options"
+	contextAPI "github.com/hyperledger/fabric-sdk-go/pkg/common/providers/context"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/discovery"
+	fabmocks "github.com/hyperledger/fabric-sdk-go/pkg/fab/mocks"
+	mspmocks "github.com/hyperledger/fabric-sdk-go/pkg/msp/test/mockmsp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	channelID = "testchannel"
+	ccID      = "testcc"
+)
+
+var (
+	peer1 = fabmocks.NewMockPeer("p1", "peer1.org1.com:7051")
+	peer2 = fabmocks.NewMockPeer("p2", "peer1.org2.com:7051")
+	peer3 = fabmocks.NewMockPeer("p3", "peer1.org3.com:7051")
+)
+
+type serviceInit interface {
+	Initialize(context contextAPI.Channel) error
+}
+
+func TestFabricSelection(t *testing.T) {
+	endorsers := &mockEndorsementService{endorsers: []fab.Peer{peer1, peer2}}
+	numOpts := setEndorsementService(endorsers)
+
+	provider, err := New(fabmocks.NewMockEndpointConfig(), discovery.WithCacheTTL(time.Minute))
+	require.NoError(t, err)
+	defer provider.Close()
+
+	_, err = provider.CreateSelectionService("")
+	assert.Error(t, err, "expecting error for empty channel ID")
+
+	service, err := provider.CreateSelectionService(channelID)
+	require.NoError(t, err)
+
+	_, err = service.GetEndorsersForChaincode([]string{ccID})
+	assert.Error(t, err, "expecting error since the service is not initialized")
+
+	discoveryService := fabmocks.NewMockDiscoveryService(nil, []fab.Peer{peer1, peer2, peer3})
+	require.NoError(t, service.(serviceInit).Initialize(newChannelContext(discoveryService)))
+	assert.Equal(t, 1, *numOpts, "expecting the options to be passed to the endorsement service")
+
+	other, err := provider.CreateSelectionService(channelID)
+	require.NoError(t, err)
+	assert.Equal(t, service, other, "expecting the selection service to be shared by the clients of the channel")
+	require.NoError(t, other.(serviceInit).Initialize(newChannelContext(discoveryService)))
+
+	_, err = service.GetEndorsersForChaincode(nil)
+	assert.Error(t, err, "expecting error for no chaincode IDs")
+
+	_, err = service.GetEndorsersForChaincode([]string{ccID, "othercc"})
+	assert.Error(t, err, "expecting error for multiple chaincode IDs")
+
+	peers, err := service.GetEndorsersForChaincode([]string{ccID})
+	require.NoError(t, err)
+	assert.Equal(t, []fab.Peer{peer1, peer2}, peers)
+
+	peers, err = service.GetEndorsersForChaincode([]string{ccID}, options.WithPeerFilter(func(peer fab.Peer) bool {
+		return peer.URL() == peer2.URL()
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, []fab.Peer{peer2}, peers)
+
+	_, err = service.GetEndorsersForChaincode([]string{ccID}, options.WithRequiredOrgs([]string{"Org3MSP"}))
+	assert.Error(t, err, "expecting error since no endorser belongs to the required orgs")
+
+	assert.Equal(t, 0, endorsers.NumInvalidations())
+}
+
+func TestFabricSelectionMembershipChange(t *testing.T) {
+	endorsers := &mockEndorsementService{endorsers: []fab.Peer{peer1, peer2}}
+	setEndorsementService(endorsers)
+
+	provider, err := New(fabmocks.NewMockEndpointConfig())
+	require.NoError(t, err)
+	defer provider.Close()
+
+	service, err := provider.CreateSelectionService(channelID)
+	require.NoError(t, err)
+
+	discoveryService := fabmocks.NewMockDiscoveryService(nil, []fab.Peer{peer1, peer2, peer3})
+	require.NoError(t, service.(serviceInit).Initialize(newChannelContext(discoveryService)))
+
+	_, err = service.GetEndorsersForChaincode([]string{ccID})
+	require.NoError(t, err)
+
+	discoveryService.Peers = []fab.Peer{peer3, peer2, peer1}
+	_, err = service.GetEndorsersForChaincode([]string{ccID})
+	require.NoError(t, err)
+	assert.Equal(t, 0, endorsers.NumInvalidations(), "expecting no invalidation if the membership is unchanged")
+
+	discoveryService.Peers = []fab.Peer{peer2, peer3}
+	_, err = service.GetEndorsersForChaincode([]string{ccID})
+	require.NoError(t, err)
+	assert.Equal(t, 1, endorsers.NumInvalidations(), "expecting the cached endorsers to be invalidated when a peer leaves")
+
+	discoveryService.Peers = []fab.Peer{peer1, peer2, peer3}
+	_, err = service.GetEndorsersForChaincode([]string{ccID})
+	require.NoError(t, err)
+	assert.Equal(t, 2, endorsers.NumInvalidations(), "expecting the cached endorsers to be invalidated when a peer joins")
+}
+
+func setEndorsementService(service *mockEndorsementService) *int {
+	numOpts := 0
+	endorsementServiceProvider = func(ctx contextAPI.Channel, opts ...discovery.Opt) (endorsementService, error) {
+		numOpts = len(opts)
+		return service, nil
+	}
+	return &numOpts
+}
+
+func newChannelContext(discoveryService fab.DiscoveryService) contextAPI.Channel {
+	ctx := fabmocks.NewMockChannelContext(fabmocks.NewMockContext(mspmocks.NewMockSigningIdentity("User1", "Org1MSP")), channelID)
+	ctx.Discovery = discoveryService
+	return ctx
+}
+
+type mockEndorsementService struct {
+	lock          sync.Mutex
+	endorsers     []fab.Peer
+	invalidations int
+}
+
+func (s *mockEndorsementService) GetEndorsers(ccID string) ([]fab.Peer, error) {
+	return s.endorsers, nil
+}
+
+func (s *mockEndorsementService) Invalidate(ccIDs ...string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.invalidations++
+}
+
+func (s *mockEndorsementService) Close() {
+}
+
+func (s *mockEndorsementService) NumInvalidations() int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.invalidations
+}
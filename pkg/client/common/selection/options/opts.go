@@ -19,7 +19,9 @@ type PeerFilter func(peer fab.Peer) bool
 
 // Params defines the parameters of a selection service request
 type Params struct {
-	PeerFilter PeerFilter
+	PeerFilter        PeerFilter
+	MaxBlockHeightLag *uint64
+	RequiredOrgs      []string
 }
 
 // NewParams creates new parameters based on the provided options
@@ -38,6 +40,27 @@ func WithPeerFilter(value PeerFilter) copts.Opt {
 	}
 }
 
+// WithMaxBlockHeightLag excludes peers whose ledger height is more than the given number of
+// blocks behind the highest ledger height of the candidate peers. Only peers which report their
+// ledger height (see fab.PeerState) are excluded.
+func WithMaxBlockHeightLag(value uint64) copts.Opt {
+	return func(p copts.Params) {
+		if setter, ok := p.(maxBlockHeightLagSetter); ok {
+			setter.SetMaxBlockHeightLag(value)
+		}
+	}
+}
+
+// WithRequiredOrgs restricts selection to peers of the given orgs (MSP IDs). An error is
+// returned if the endorsement policy cannot be satisfied by peers of those orgs alone.
+func WithRequiredOrgs(mspIDs []string) copts.Opt {
+	return func(p copts.Params) {
+		if setter, ok := p.(requiredOrgsSetter); ok {
+			setter.SetRequiredOrgs(mspIDs)
+		}
+	}
+}
+
 type peerFilterSetter interface {
 	SetPeerFilter(value PeerFilter)
 }
@@ -47,3 +70,67 @@ func (p *Params) SetPeerFilter(value PeerFilter) {
 	logger.Debugf("PeerFilter: %#v", value)
 	p.PeerFilter = value
 }
+
+type maxBlockHeightLagSetter interface {
+	SetMaxBlockHeightLag(value uint64)
+}
+
+// SetMaxBlockHeightLag sets the maximum number of blocks that a peer may lag behind
+func (p *Params) SetMaxBlockHeightLag(value uint64) {
+	logger.Debugf("MaxBlockHeightLag: %d", value)
+	p.MaxBlockHeightLag = &value
+}
+
+type requiredOrgsSetter interface {
+	SetRequiredOrgs(mspIDs []string)
+}
+
+// SetRequiredOrgs sets the orgs (MSP IDs) to which selection is restricted
+func (p *Params) SetRequiredOrgs(mspIDs []string) {
+	logger.Debugf("RequiredOrgs: %v", mspIDs)
+	p.RequiredOrgs = mspIDs
+}
+
+// FilterByOrgs returns the peers which belong to one of the given orgs (MSP IDs)
+func FilterByOrgs(peers []fab.Peer, mspIDs []string) []fab.Peer {
+	var filtered []fab.Peer
+	for _, peer := range peers {
+		if containsString(mspIDs, peer.MSPID()) {
+			filtered = append(filtered, peer)
+		} else {
+			logger.Debugf("Excluding peer [%s] since its org [%s] is not one of the required orgs %v", peer.URL(), peer.MSPID(), mspIDs)
+		}
+	}
+	return filtered
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// FilterByBlockHeight returns the peers whose ledger height is no more than maxLag blocks
+// behind the highest ledger height of the given peers. Peers which don't report their
+// ledger height are not excluded.
+func FilterByBlockHeight(peers []fab.Peer, maxLag uint64) []fab.Peer {
+	var maxHeight uint64
+	for _, peer := range peers {
+		if state, ok := peer.(fab.PeerState); ok && state.BlockHeight() > maxHeight {
+			maxHeight = state.BlockHeight()
+		}
+	}
+
+	var filtered []fab.Peer
+	for _, peer := range peers {
+		if state, ok := peer.(fab.PeerState); ok && state.BlockHeight()+maxLag < maxHeight {
+			logger.Debugf("Excluding peer [%s] since its block height [%d] lags behind the max block height [%d] by more than %d blocks", peer.URL(), state.BlockHeight(), maxHeight, maxLag)
+			continue
+		}
+		filtered = append(filtered, peer)
+	}
+	return filtered
+}
@@ -0,0 +1,65 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package options
+
+import (
+	"testing"
+
+	copts "github.com/hyperledger/fabric-sdk-go/pkg/common/options"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMaxBlockHeightLag(t *testing.T) {
+	params := NewParams(nil)
+	assert.Nil(t, params.MaxBlockHeightLag)
+
+	params = NewParams([]copts.Opt{WithMaxBlockHeightLag(2)})
+	require.NotNil(t, params.MaxBlockHeightLag)
+	assert.Equal(t, uint64(2), *params.MaxBlockHeightLag)
+}
+
+func TestFilterByBlockHeight(t *testing.T) {
+	peer1 := &peerState{MockPeer: mocks.NewMockPeer("p1", "peer1:7051"), blockHeight: 1000}
+	peer2 := &peerState{MockPeer: mocks.NewMockPeer("p2", "peer2:7051"), blockHeight: 998}
+	peer3 := &peerState{MockPeer: mocks.NewMockPeer("p3", "peer3:7051"), blockHeight: 990}
+	peer4 := mocks.NewMockPeer("p4", "peer4:7051")
+
+	peers := []fab.Peer{peer1, peer2, peer3, peer4}
+
+	assert.Equal(t, []fab.Peer{peer1, peer2, peer4}, FilterByBlockHeight(peers, 2))
+	assert.Equal(t, []fab.Peer{peer1, peer4}, FilterByBlockHeight(peers, 0))
+	assert.Equal(t, peers, FilterByBlockHeight(peers, 10))
+}
+
+func TestFilterByOrgs(t *testing.T) {
+	peer1 := mocks.NewMockPeer("p1", "peer1:7051")
+	peer1.SetMSPID("Org1MSP")
+	peer2 := mocks.NewMockPeer("p2", "peer2:7051")
+	peer2.SetMSPID("Org2MSP")
+	peer3 := mocks.NewMockPeer("p3", "peer3:7051")
+	peer3.SetMSPID("Org3MSP")
+
+	peers := []fab.Peer{peer1, peer2, peer3}
+
+	assert.Equal(t, []fab.Peer{peer1, peer3}, FilterByOrgs(peers, []string{"Org1MSP", "Org3MSP"}))
+	assert.Empty(t, FilterByOrgs(peers, []string{"Org4MSP"}))
+
+	params := NewParams([]copts.Opt{WithRequiredOrgs([]string{"Org1MSP"})})
+	assert.Equal(t, []string{"Org1MSP"}, params.RequiredOrgs)
+}
+
+type peerState struct {
+	*mocks.MockPeer
+	blockHeight uint64
+}
+
+func (p *peerState) BlockHeight() uint64 {
+	return p.blockHeight
+}
@@ -12,6 +12,7 @@ import (
 	copts "github.com/hyperledger/fabric-sdk-go/pkg/common/options"
 	contextAPI "github.com/hyperledger/fabric-sdk-go/pkg/common/providers/context"
 	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+	"github.com/pkg/errors"
 )
 
 const loggerModule = "fabsdk/client"
@@ -63,6 +64,17 @@ func (s *selectionService) GetEndorsersForChaincode(chaincodeIDs []string, opts
 		channelPeers = peers
 	}
 
+	if params.MaxBlockHeightLag != nil {
+		channelPeers = options.FilterByBlockHeight(channelPeers, *params.MaxBlockHeightLag)
+	}
+
+	if len(params.RequiredOrgs) > 0 {
+		channelPeers = options.FilterByOrgs(channelPeers, params.RequiredOrgs)
+		if len(channelPeers) == 0 {
+			return nil, errors.Errorf("no peers of the required orgs %v found on channel", params.RequiredOrgs)
+		}
+	}
+
 	if logging.IsEnabledFor(loggerModule, logging.DEBUG) {
 		str := ""
 		for i, peer := range channelPeers {
@@ -82,4 +82,18 @@ func TestStaticSelection(t *testing.T) {
 	if peers[0].URL() != peer2.URL() {
 		t.Fatalf("Expecting peer %s but got %s", peer2.URL(), peers[0].URL())
 	}
+
+	peer2.SetMSPID("Org2MSP")
+	peers, err = selectionService.GetEndorsersForChaincode(nil, options.WithRequiredOrgs([]string{"Org2MSP"}))
+	if err != nil {
+		t.Fatalf("Failed to get endorsers: %s", err)
+	}
+	if len(peers) != 1 || peers[0].URL() != peer2.URL() {
+		t.Fatalf("Expecting only peer %s of the required org but got %d peers", peer2.URL(), len(peers))
+	}
+
+	_, err = selectionService.GetEndorsersForChaincode(nil, options.WithRequiredOrgs([]string{"Org3MSP"}))
+	if err == nil {
+		t.Fatalf("Expecting error since there are no peers of the required org")
+	}
 }
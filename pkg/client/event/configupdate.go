@@ -0,0 +1,106 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package event
+
+import (
+	"sync"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/logging"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/events/service/blockfilter/headertypefilter"
+	cb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+)
+
+var logger = logging.NewLogger("fabsdk/client")
+
+// RegisterConfigUpdateEvent registers for channel config update events. An event is sent whenever a block
+// containing a CONFIG or CONFIG_UPDATE transaction is committed on the channel. Since the config is only
+// contained in full blocks, the client must be created with the WithBlockEvents option.
+// Unregister must be called when the registration is no longer needed.
+//  Returns:
+//  the registration and a channel that is used to receive events. The channel is closed when Unregister is called.
+func (c *Client) RegisterConfigUpdateEvent() (fab.Registration, <-chan *fab.ConfigUpdateEvent, error) {
+	reg, blockch, err := c.eventService.RegisterBlockEvent(headertypefilter.New(cb.HeaderType_CONFIG, cb.HeaderType_CONFIG_UPDATE))
+	if err != nil {
+		return nil, nil, errors.WithMessage(err, "error registering for config update events")
+	}
+
+	configReg := &configUpdateRegistration{Registration: reg, done: make(chan struct{})}
+	eventch := make(chan *fab.ConfigUpdateEvent, cap(blockch))
+	go configReg.forward(blockch, eventch)
+
+	return configReg, eventch, nil
+}
+
+// configUpdateRegistration wraps the block event registration of a config update registration.
+// The done channel is closed by Unregister so that the forwarding goroutine doesn't block
+// on a consumer which no longer reads from the event channel.
+type configUpdateRegistration struct {
+	fab.Registration
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+func (r *configUpdateRegistration) close() {
+	r.closeOnce.Do(func() {
+		close(r.done)
+	})
+}
+
+// forward sends a config update event for each of the given block events until the block
+// event channel is closed or the registration is unregistered
+func (r *configUpdateRegistration) forward(blockch <-chan *fab.BlockEvent, eventch chan<- *fab.ConfigUpdateEvent) {
+	defer close(eventch)
+	for event := range blockch {
+		sequence, err := configSequence(event.Block)
+		if err != nil {
+			logger.Warnf("Unable to extract config sequence from block [%d]: %s", event.Block.Header.Number, err)
+			continue
+		}
+		select {
+		case eventch <- &fab.ConfigUpdateEvent{
+			BlockNumber: event.Block.Header.Number,
+			Sequence:    sequence,
+			SourceURL:   event.SourceURL,
+		}:
+		case <-r.done:
+			logger.Debugf("Config update registration was unregistered - dropping config update event for block [%d]", event.Block.Header.Number)
+			return
+		}
+	}
+}
+
+// configSequence returns the sequence of the config contained in the given block
+// or zero if the block doesn't contain a CONFIG transaction
+func configSequence(block *cb.Block) (uint64, error) {
+	for i := range block.Data.Data {
+		env, err := utils.ExtractEnvelope(block, i)
+		if err != nil {
+			return 0, err
+		}
+		payload, err := utils.ExtractPayload(env)
+		if err != nil {
+			return 0, err
+		}
+		chdr, err := utils.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+		if err != nil {
+			return 0, err
+		}
+		if cb.HeaderType(chdr.Type) != cb.HeaderType_CONFIG {
+			continue
+		}
+		configEnv := &cb.ConfigEnvelope{}
+		if err := proto.Unmarshal(payload.Data, configEnv); err != nil {
+			return 0, errors.Wrap(err, "unmarshal config envelope failed")
+		}
+		return configEnv.GetConfig().GetSequence(), nil
+	}
+	return 0, nil
+}
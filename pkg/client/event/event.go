@@ -15,9 +15,16 @@ SPDX-License-Identifier: Apache-2.0
 package event
 
 import (
+	"regexp"
+	"time"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/options"
 	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/context"
 	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
 	"github.com/hyperledger/fabric-sdk-go/pkg/fab/events/client"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/events/deliverclient"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/events/deliverclient/seek"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/events/service/dispatcher"
 	"github.com/pkg/errors"
 )
 
@@ -25,6 +32,15 @@ import (
 type Client struct {
 	eventService      fab.EventService
 	permitBlockEvents bool
+	seekType          seek.Type
+	fromBlock         uint64
+	reconnect         bool
+	maxReconnAttempts uint
+	reconnBackoff     time.Duration
+	replayBufferSize  uint
+	connStateHandler  client.ConnectionStateHandler
+	dispatchWorkers   int
+	dispatchPolicy    dispatcher.DispatchPolicy
 }
 
 // New returns a Client instance. Client receives events such as block, filtered block,
@@ -41,7 +57,7 @@ func New(channelProvider context.ChannelProvider, opts ...ClientOption) (*Client
 	for _, param := range opts {
 		err1 := param(&eventClient)
 		if err1 != nil {
-			return nil, errors.WithMessage(err, "option failed")
+			return nil, errors.WithMessage(err1, "option failed")
 		}
 	}
 
@@ -49,13 +65,7 @@ func New(channelProvider context.ChannelProvider, opts ...ClientOption) (*Client
 		return nil, errors.New("channel service not initialized")
 	}
 
-	var es fab.EventService
-	if eventClient.permitBlockEvents {
-		es, err = channelContext.ChannelService().EventService(client.WithBlockEvents())
-	} else {
-		es, err = channelContext.ChannelService().EventService()
-	}
-
+	es, err := channelContext.ChannelService().EventService(eventClient.eventServiceOpts()...)
 	if err != nil {
 		return nil, errors.WithMessage(err, "event service creation failed")
 	}
@@ -65,6 +75,45 @@ func New(channelProvider context.ChannelProvider, opts ...ClientOption) (*Client
 	return &eventClient, nil
 }
 
+// eventServiceOpts returns the event service options corresponding to the client options
+func (c *Client) eventServiceOpts() []options.Opt {
+	var opts []options.Opt
+	if c.permitBlockEvents {
+		opts = append(opts, client.WithBlockEvents())
+	}
+	switch c.seekType {
+	case seek.FromBlock:
+		opts = append(opts, deliverclient.WithSeekFrom(c.fromBlock))
+	case seek.Oldest:
+		opts = append(opts, deliverclient.WithSeekType(seek.Oldest))
+	}
+	if c.replayBufferSize > 0 {
+		// Block the dispatcher while the consumer's buffer is full so that the
+		// replayed blocks are throttled rather than dropped
+		opts = append(opts,
+			dispatcher.WithEventConsumerBufferSize(c.replayBufferSize),
+			dispatcher.WithEventConsumerTimeout(0),
+		)
+	}
+	if c.reconnect {
+		opts = append(opts,
+			client.WithReconnect(true),
+			client.WithMaxReconnectAttempts(c.maxReconnAttempts),
+			client.WithTimeBetweenConnectAttempts(c.reconnBackoff),
+		)
+	}
+	if c.dispatchWorkers > 0 {
+		opts = append(opts,
+			dispatcher.WithDispatchWorkers(c.dispatchWorkers),
+			dispatcher.WithDispatchPolicy(c.dispatchPolicy),
+		)
+	}
+	if c.connStateHandler != nil {
+		opts = append(opts, client.WithConnectionStateHandler(c.connStateHandler))
+	}
+	return opts
+}
+
 // RegisterBlockEvent registers for block events. If the caller does not have permission
 // to register for block events then an error is returned. Unregister must be called when the registration is no longer needed.
 //  Parameters:
@@ -94,6 +143,24 @@ func (c *Client) RegisterChaincodeEvent(ccID, eventFilter string) (fab.Registrat
 	return c.eventService.RegisterChaincodeEvent(ccID, eventFilter)
 }
 
+// RegisterChaincodeEventByPattern registers for chaincode events whose name matches the given compiled
+// regular expression. Unregister must be called when the registration is no longer needed.
+//  Parameters:
+//  ccID is the chaincode ID for which events are to be received
+//  pattern is the regular expression which is matched against the chaincode event name
+//
+//  Returns:
+//  the registration and a channel that is used to receive events. The channel is closed when Unregister is called.
+func (c *Client) RegisterChaincodeEventByPattern(ccID string, pattern *regexp.Regexp) (fab.Registration, <-chan *fab.CCEvent, error) {
+	if pattern == nil {
+		return nil, nil, errors.New("event pattern is required")
+	}
+	if registrar, ok := c.eventService.(fab.CCEventPatternRegistrar); ok {
+		return registrar.RegisterChaincodeEventByPattern(ccID, pattern)
+	}
+	return c.eventService.RegisterChaincodeEvent(ccID, pattern.String())
+}
+
 // RegisterTxStatusEvent registers for transaction status events. Unregister must be called when the registration is no longer needed.
 //  Parameters:
 //  txID is the transaction ID for which events are to be received
@@ -104,9 +171,26 @@ func (c *Client) RegisterTxStatusEvent(txID string) (fab.Registration, <-chan *f
 	return c.eventService.RegisterTxStatusEvent(txID)
 }
 
+// StartBlockNum returns the number of the first block for which an event was received. When seeking from the
+// oldest block (see WithSeekFromOldest), a start block greater than zero indicates that the peer no longer has the
+// earlier blocks and that only a suffix of the channel history is received.
+//  Returns:
+//  the block number and true, or false if no block has been received yet or the event service doesn't report it
+func (c *Client) StartBlockNum() (uint64, bool) {
+	reporter, ok := c.eventService.(fab.StartBlockReporter)
+	if !ok {
+		return 0, false
+	}
+	return reporter.StartBlockNum()
+}
+
 // Unregister removes the given registration and closes the event channel.
 //  Parameters:
 //  reg is the registration handle that was returned from one of the Register functions
 func (c *Client) Unregister(reg fab.Registration) {
+	if configReg, ok := reg.(*configUpdateRegistration); ok {
+		configReg.close()
+		reg = configReg.Registration
+	}
 	c.eventService.Unregister(reg)
 }
@@ -14,14 +14,17 @@ import (
 	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/context"
 	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
 	contextImpl "github.com/hyperledger/fabric-sdk-go/pkg/context"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/events/client"
 	fcmocks "github.com/hyperledger/fabric-sdk-go/pkg/fab/mocks"
 	mspmocks "github.com/hyperledger/fabric-sdk-go/pkg/msp/test/mockmsp"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/events/deliverclient/seek"
 	"github.com/hyperledger/fabric-sdk-go/pkg/fab/events/service"
 	"github.com/hyperledger/fabric-sdk-go/pkg/fab/events/service/dispatcher"
 	servicemocks "github.com/hyperledger/fabric-sdk-go/pkg/fab/events/service/mocks"
+	cb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/common"
 	pb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/peer"
 )
 
@@ -53,6 +56,146 @@ func TestNewEventClient(t *testing.T) {
 	}
 }
 
+type eventServiceParams struct {
+	seekType                seek.Type
+	fromBlock               uint64
+	reconnect               bool
+	maxReconnectAttempts    uint
+	timeBetweenConnAttempts time.Duration
+	consumerBufferSize      uint
+	consumerTimeout         *time.Duration
+	connStateHandler        client.ConnectionStateHandler
+	dispatchWorkers         int
+	dispatchPolicy          dispatcher.DispatchPolicy
+}
+
+func (p *eventServiceParams) SetSeekType(value seek.Type) {
+	p.seekType = value
+}
+
+func (p *eventServiceParams) SetFromBlock(value uint64) {
+	p.fromBlock = value
+}
+
+func (p *eventServiceParams) SetReconnect(value bool) {
+	p.reconnect = value
+}
+
+func (p *eventServiceParams) SetMaxReconnectAttempts(value uint) {
+	p.maxReconnectAttempts = value
+}
+
+func (p *eventServiceParams) SetTimeBetweenConnectAttempts(value time.Duration) {
+	p.timeBetweenConnAttempts = value
+}
+
+func (p *eventServiceParams) SetEventConsumerBufferSize(value uint) {
+	p.consumerBufferSize = value
+}
+
+func (p *eventServiceParams) SetEventConsumerTimeout(value time.Duration) {
+	p.consumerTimeout = &value
+}
+
+func TestReconnectAndSeekFromOpts(t *testing.T) {
+	fabCtx := setupCustomTestContext(t, nil)
+	ctx := createChannelContext(fabCtx, channelID)
+
+	_, err := New(ctx, WithReconnect(3, -time.Second))
+	assert.Error(t, err, "Expected error for negative backoff")
+
+	eventClient, err := New(ctx, WithSeekFrom(10), WithReconnect(3, 2*time.Second))
+	if err != nil {
+		t.Fatalf("Failed to create new event client: %s", err)
+	}
+
+	params := &eventServiceParams{}
+	options.Apply(params, eventClient.eventServiceOpts())
+
+	assert.Equal(t, seek.Type(seek.FromBlock), params.seekType)
+	assert.Equal(t, uint64(10), params.fromBlock)
+	assert.True(t, params.reconnect)
+	assert.Equal(t, uint(3), params.maxReconnectAttempts)
+	assert.Equal(t, 2*time.Second, params.timeBetweenConnAttempts)
+}
+
+func (p *eventServiceParams) SetConnectionStateHandler(value client.ConnectionStateHandler) {
+	p.connStateHandler = value
+}
+
+func (p *eventServiceParams) SetDispatchWorkers(value int) {
+	p.dispatchWorkers = value
+}
+
+func (p *eventServiceParams) SetDispatchPolicy(value dispatcher.DispatchPolicy) {
+	p.dispatchPolicy = value
+}
+
+func TestDispatchWorkersOpts(t *testing.T) {
+	fabCtx := setupCustomTestContext(t, nil)
+	ctx := createChannelContext(fabCtx, channelID)
+
+	_, err := New(ctx, WithDispatchWorkers(0))
+	assert.Error(t, err, "Expected error for invalid number of workers")
+
+	eventClient, err := New(ctx, WithDispatchWorkers(4), WithDispatchPolicy(dispatcher.DropWhenFull))
+	if err != nil {
+		t.Fatalf("Failed to create new event client: %s", err)
+	}
+
+	params := &eventServiceParams{}
+	options.Apply(params, eventClient.eventServiceOpts())
+	assert.Equal(t, 4, params.dispatchWorkers)
+	assert.Equal(t, dispatcher.DropWhenFull, params.dispatchPolicy)
+}
+
+func TestConnectionStateHandlerOpt(t *testing.T) {
+	fabCtx := setupCustomTestContext(t, nil)
+	ctx := createChannelContext(fabCtx, channelID)
+
+	_, err := New(ctx, WithConnectionStateHandler(nil))
+	assert.Error(t, err, "Expected error for nil handler")
+
+	var transitions int
+	eventClient, err := New(ctx, WithConnectionStateHandler(func(from, to client.ConnectionState, err error) {
+		transitions++
+	}))
+	if err != nil {
+		t.Fatalf("Failed to create new event client: %s", err)
+	}
+
+	params := &eventServiceParams{}
+	options.Apply(params, eventClient.eventServiceOpts())
+	if params.connStateHandler == nil {
+		t.Fatalf("Expected connection state handler to be set")
+	}
+	params.connStateHandler(client.Disconnected, client.Connecting, nil)
+	assert.Equal(t, 1, transitions)
+}
+
+func TestReplayOpts(t *testing.T) {
+	fabCtx := setupCustomTestContext(t, nil)
+	ctx := createChannelContext(fabCtx, channelID)
+
+	_, err := New(ctx, WithReplay(0, 0))
+	assert.Error(t, err, "Expected error for invalid buffer size")
+
+	eventClient, err := New(ctx, WithBlockEvents(), WithReplay(0, 50))
+	if err != nil {
+		t.Fatalf("Failed to create new event client: %s", err)
+	}
+
+	params := &eventServiceParams{}
+	options.Apply(params, eventClient.eventServiceOpts())
+
+	assert.Equal(t, seek.Type(seek.FromBlock), params.seekType)
+	assert.Equal(t, uint64(0), params.fromBlock)
+	assert.Equal(t, uint(50), params.consumerBufferSize)
+	if params.consumerTimeout == nil || *params.consumerTimeout != 0 {
+		t.Fatalf("Expected event consumer timeout of 0 (block until sent)")
+	}
+}
+
 func TestBlockEvents(t *testing.T) {
 
 	eventService, eventProducer, err := newServiceWithMockProducer(defaultOpts, withBlockLedger(sourceURL))
@@ -90,6 +233,135 @@ func TestBlockEvents(t *testing.T) {
 	}
 }
 
+func TestConfigUpdateEvents(t *testing.T) {
+
+	eventService, eventProducer, err := newServiceWithMockProducer(defaultOpts, withBlockLedger(sourceURL))
+	if err != nil {
+		t.Fatalf("error creating channel event client: %s", err)
+	}
+	defer eventProducer.Close()
+	defer eventService.Stop()
+
+	fabCtx := setupCustomTestContext(t, nil)
+	ctx := createChannelContext(fabCtx, channelID)
+
+	client, err := New(ctx, WithBlockEvents())
+	if err != nil {
+		t.Fatalf("Failed to create new event client: %s", err)
+	}
+
+	client.eventService = eventService
+
+	registration, eventch, err := client.RegisterConfigUpdateEvent()
+	if err != nil {
+		t.Fatalf("error registering for config update events: %s", err)
+	}
+
+	eventProducer.Ledger().NewBlock(channelID, servicemocks.NewTransaction("txid1", pb.TxValidationCode_VALID, cb.HeaderType_ENDORSER_TRANSACTION))
+	eventProducer.Ledger().NewBlock(channelID, servicemocks.NewConfigTransaction("txid2", 3))
+
+	select {
+	case event, ok := <-eventch:
+		if !ok {
+			t.Fatalf("unexpected closed channel")
+		}
+		assert.Equal(t, uint64(1), event.BlockNumber)
+		assert.Equal(t, uint64(3), event.Sequence)
+		assert.Equal(t, sourceURL, event.SourceURL)
+	case <-time.After(5 * time.Second):
+		t.Fatalf("timed out waiting for config update event")
+	}
+
+	client.Unregister(registration)
+
+	select {
+	case _, ok := <-eventch:
+		if ok {
+			t.Fatalf("expecting closed channel after unregister")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("timed out waiting for channel to be closed")
+	}
+}
+
+func TestConfigUpdateEventsUnregisterWithoutConsumer(t *testing.T) {
+	blockch := make(chan *fab.BlockEvent, 2)
+	for i := 0; i < 2; i++ {
+		block := servicemocks.NewBlock(channelID, servicemocks.NewConfigTransaction("txid", uint64(i)))
+		blockch <- &fab.BlockEvent{Block: block, SourceURL: sourceURL}
+	}
+
+	reg := &configUpdateRegistration{done: make(chan struct{})}
+	eventch := make(chan *fab.ConfigUpdateEvent)
+
+	stopped := make(chan struct{})
+	go func() {
+		reg.forward(blockch, eventch)
+		close(stopped)
+	}()
+
+	// Nobody reads from the event channel so the goroutine is blocked sending the first event
+	reg.close()
+	reg.close()
+
+	select {
+	case <-stopped:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("timed out waiting for the config update events to stop being forwarded")
+	}
+
+	_, ok := <-eventch
+	assert.False(t, ok, "expecting closed channel after unregister")
+}
+
+func TestSeekFromOldest(t *testing.T) {
+
+	eventService, eventProducer, err := newServiceWithMockProducer(defaultOpts, withBlockLedger(sourceURL))
+	if err != nil {
+		t.Fatalf("error creating channel event client: %s", err)
+	}
+	defer eventProducer.Close()
+	defer eventService.Stop()
+
+	fabCtx := setupCustomTestContext(t, nil)
+	ctx := createChannelContext(fabCtx, channelID)
+
+	client, err := New(ctx, WithSeekFromOldest())
+	if err != nil {
+		t.Fatalf("Failed to create new event client: %s", err)
+	}
+
+	params := &eventServiceParams{}
+	options.Apply(params, client.eventServiceOpts())
+	assert.Equal(t, seek.Type(seek.Oldest), params.seekType)
+
+	client.eventService = eventService
+
+	_, ok := client.StartBlockNum()
+	assert.False(t, ok, "Expected no start block before any block is received")
+
+	registration, eventch, err := client.RegisterFilteredBlockEvent()
+	if err != nil {
+		t.Fatalf("error registering for filtered block events: %s", err)
+	}
+	defer client.Unregister(registration)
+
+	eventProducer.Ledger().NewBlock(channelID)
+
+	select {
+	case _, ok := <-eventch:
+		if !ok {
+			t.Fatalf("unexpected closed channel")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("timed out waiting for filtered block event")
+	}
+
+	startBlock, ok := client.StartBlockNum()
+	assert.True(t, ok, "Expected start block to be reported")
+	assert.Equal(t, uint64(0), startBlock)
+}
+
 func TestFilteredBlockEvents(t *testing.T) {
 
 	eventService, eventProducer, err := newServiceWithMockProducer(defaultOpts, withFilteredBlockLedger(sourceURL))
@@ -6,6 +6,15 @@ SPDX-License-Identifier: Apache-2.0
 
 package event
 
+import (
+	"time"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/events/client"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/events/deliverclient/seek"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/events/service/dispatcher"
+	"github.com/pkg/errors"
+)
+
 // ClientOption describes a functional parameter for the New constructor
 type ClientOption func(*Client) error
 
@@ -17,3 +26,93 @@ func WithBlockEvents() ClientOption {
 		return nil
 	}
 }
+
+// WithSeekFrom indicates that events are to be received starting from the given block number.
+// Note that this option is only supported by the deliver event service.
+func WithSeekFrom(blockNum uint64) ClientOption {
+	return func(c *Client) error {
+		c.seekType = seek.FromBlock
+		c.fromBlock = blockNum
+		return nil
+	}
+}
+
+// WithSeekFromOldest indicates that events are to be received starting from the oldest block which is
+// still available on the peer. This may not be the genesis block if the peer has pruned its ledger;
+// the actual start block is reported by StartBlockNum.
+// Note that this option is only supported by the deliver event service.
+func WithSeekFromOldest() ClientOption {
+	return func(c *Client) error {
+		c.seekType = seek.Oldest
+		return nil
+	}
+}
+
+// WithReplay indicates that historical blocks are to be replayed starting from the given block number,
+// after which live events are received on the same stream so that there is no gap or duplicate between
+// the replayed and the live events. The given buffer size is used for the consumer's event channel;
+// when the buffer is full, delivery is blocked until the consumer catches up.
+// Note that this option is only supported by the deliver event service.
+func WithReplay(startBlock uint64, bufferSize int) ClientOption {
+	return func(c *Client) error {
+		if bufferSize <= 0 {
+			return errors.Errorf("invalid replay buffer size [%d]", bufferSize)
+		}
+		c.seekType = seek.FromBlock
+		c.fromBlock = startBlock
+		c.replayBufferSize = uint(bufferSize)
+		return nil
+	}
+}
+
+// WithReconnect indicates that the event service should reconnect after the connection is lost,
+// making at most maxAttempts attempts (0 for unlimited) with the given backoff between attempts.
+// On reconnect, events are resumed from the block following the last block received so that
+// no events are missed or delivered twice.
+func WithReconnect(maxAttempts uint, backoff time.Duration) ClientOption {
+	return func(c *Client) error {
+		if backoff < 0 {
+			return errors.Errorf("invalid reconnect backoff [%s]", backoff)
+		}
+		c.reconnect = true
+		c.maxReconnAttempts = maxAttempts
+		c.reconnBackoff = backoff
+		return nil
+	}
+}
+
+// WithConnectionStateHandler sets a handler which is invoked whenever the connection to the event server changes
+// state, i.e. when it connects, disconnects or reconnects. On a transition to Disconnected the handler is passed the
+// error which caused the disconnect (if any). The handler is invoked synchronously and must therefore not block.
+// Note that an event client with a connection state handler does not share its connection with other event clients.
+func WithConnectionStateHandler(handler client.ConnectionStateHandler) ClientOption {
+	return func(c *Client) error {
+		if handler == nil {
+			return errors.New("connection state handler is nil")
+		}
+		c.connStateHandler = handler
+		return nil
+	}
+}
+
+// WithDispatchWorkers delivers events to the registered consumers on a pool of n workers so that a slow
+// consumer doesn't stall the delivery of events to other consumers. Events for a given consumer remain in order.
+func WithDispatchWorkers(n int) ClientOption {
+	return func(c *Client) error {
+		if n <= 0 {
+			return errors.Errorf("invalid number of dispatch workers [%d]", n)
+		}
+		c.dispatchWorkers = n
+		return nil
+	}
+}
+
+// WithDispatchPolicy sets the policy which is applied when the queue of a dispatch worker is full,
+// i.e. block until there is room in the queue (default) or drop the event.
+// Note that this option only applies if dispatch workers are configured (see WithDispatchWorkers).
+func WithDispatchPolicy(policy dispatcher.DispatchPolicy) ClientOption {
+	return func(c *Client) error {
+		c.dispatchPolicy = policy
+		return nil
+	}
+}
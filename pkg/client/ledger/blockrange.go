@@ -0,0 +1,211 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ledger
+
+import (
+	ledgerutil "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/core/ledger/util"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+)
+
+// Transaction contains the details of a transaction which was committed to the ledger
+type Transaction struct {
+	// BlockNumber is the number of the block which contains the transaction
+	BlockNumber uint64
+	// TxIndex is the index of the transaction within the block
+	TxIndex int
+	// TxID is the transaction ID
+	TxID string
+	// Type is the header type of the transaction (e.g. ENDORSER_TRANSACTION or CONFIG)
+	Type common.HeaderType
+	// ValidationCode is the validation code which was assigned to the transaction at commit time
+	ValidationCode pb.TxValidationCode
+	// ChaincodeAction is the chaincode action (results, events and response) of an endorser transaction.
+	// It is nil for other types of transaction.
+	ChaincodeAction *pb.ChaincodeAction
+}
+
+// TransactionHandler is invoked for each transaction by ForEachTransaction.
+// If the handler returns an error then the scan is stopped and the error is returned.
+type TransactionHandler func(tx *Transaction) error
+
+// QueryBlockRange queries the ledger for the blocks within the given (inclusive) range of block numbers.
+// The scan stops if the parent context (see WithParentContext) is cancelled.
+//  Parameters:
+//  start is the number of the first block
+//  end is the number of the last block
+//  options hold optional request options
+//
+//  Returns:
+//  the blocks, in order of block number
+func (c *Client) QueryBlockRange(start, end uint64, options ...RequestOption) ([]*common.Block, error) {
+	var blocks []*common.Block
+	err := c.scanBlocks(start, end, options, func(block *common.Block) error {
+		blocks = append(blocks, block)
+		return nil
+	})
+	if err != nil {
+		return nil, errors.WithMessage(err, "QueryBlockRange failed")
+	}
+	return blocks, nil
+}
+
+// ForEachTransaction invokes the given handler for each of the transactions in the blocks within
+// the given (inclusive) range of block numbers. Both valid and invalid transactions are included.
+// The scan stops if the handler returns an error or if the parent context (see WithParentContext)
+// is cancelled.
+//  Parameters:
+//  start is the number of the first block
+//  end is the number of the last block
+//  handler is invoked for each transaction
+//  options hold optional request options
+func (c *Client) ForEachTransaction(start, end uint64, handler TransactionHandler, options ...RequestOption) error {
+	if handler == nil {
+		return errors.New("ForEachTransaction failed: transaction handler is required")
+	}
+
+	err := c.scanBlocks(start, end, options, func(block *common.Block) error {
+		txs, err := BlockTransactions(block)
+		if err != nil {
+			return err
+		}
+		for _, tx := range txs {
+			if err := handler(tx); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return errors.WithMessage(err, "ForEachTransaction failed")
+	}
+	return nil
+}
+
+// scanBlocks queries each of the blocks in the given range in turn and invokes the given function
+func (c *Client) scanBlocks(start, end uint64, options []RequestOption, fn func(block *common.Block) error) error {
+	if start > end {
+		return errors.Errorf("start block [%d] is greater than end block [%d]", start, end)
+	}
+
+	opts, err := c.prepareRequestOpts(options...)
+	if err != nil {
+		return err
+	}
+
+	for blockNumber := start; ; blockNumber++ {
+		if opts.ParentContext != nil {
+			select {
+			case <-opts.ParentContext.Done():
+				return errors.Wrapf(opts.ParentContext.Err(), "scan cancelled before block [%d]", blockNumber)
+			default:
+			}
+		}
+
+		block, err := c.QueryBlock(blockNumber, options...)
+		if err != nil {
+			return errors.WithMessage(err, "failed to query block")
+		}
+
+		if err := fn(block); err != nil {
+			return err
+		}
+
+		if blockNumber == end {
+			return nil
+		}
+	}
+}
+
+// BlockTransactions decodes the transactions in the given block
+func BlockTransactions(block *common.Block) ([]*Transaction, error) {
+	if block == nil || block.Header == nil || block.Data == nil {
+		return nil, errors.New("block is incomplete")
+	}
+
+	var txFilter ledgerutil.TxValidationFlags
+	if block.Metadata != nil && len(block.Metadata.Metadata) > int(common.BlockMetadataIndex_TRANSACTIONS_FILTER) {
+		txFilter = ledgerutil.TxValidationFlags(block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER])
+	}
+
+	var txs []*Transaction
+	for i, data := range block.Data.Data {
+		tx, err := decodeTransaction(data)
+		if err != nil {
+			return nil, errors.WithMessage(err, "failed to decode transaction")
+		}
+
+		tx.BlockNumber = block.Header.Number
+		tx.TxIndex = i
+		if i < len(txFilter) {
+			tx.ValidationCode = txFilter.Flag(i)
+		}
+		txs = append(txs, tx)
+	}
+	return txs, nil
+}
+
+func decodeTransaction(data []byte) (*Transaction, error) {
+	env, err := utils.GetEnvelopeFromBlock(data)
+	if err != nil {
+		return nil, err
+	}
+
+	payload, err := utils.GetPayload(env)
+	if err != nil {
+		return nil, err
+	}
+	if payload.Header == nil {
+		return nil, errors.New("payload header is missing")
+	}
+
+	chdr, err := utils.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+	if err != nil {
+		return nil, err
+	}
+
+	tx := &Transaction{
+		TxID: chdr.TxId,
+		Type: common.HeaderType(chdr.Type),
+	}
+
+	if tx.Type == common.HeaderType_ENDORSER_TRANSACTION {
+		tx.ChaincodeAction, err = chaincodeAction(payload.Data)
+		if err != nil {
+			return nil, errors.WithMessage(err, "failed to decode chaincode action")
+		}
+	}
+
+	return tx, nil
+}
+
+func chaincodeAction(data []byte) (*pb.ChaincodeAction, error) {
+	tx, err := utils.GetTransaction(data)
+	if err != nil {
+		return nil, err
+	}
+	if len(tx.Actions) == 0 {
+		return nil, errors.New("transaction has no actions")
+	}
+
+	ccActionPayload, err := utils.GetChaincodeActionPayload(tx.Actions[0].Payload)
+	if err != nil {
+		return nil, err
+	}
+	if ccActionPayload.Action == nil {
+		return nil, errors.New("chaincode endorsed action is missing")
+	}
+
+	prp, err := utils.GetProposalResponsePayload(ccActionPayload.Action.ProposalResponsePayload)
+	if err != nil {
+		return nil, err
+	}
+
+	return utils.GetChaincodeAction(prp.Extension)
+}
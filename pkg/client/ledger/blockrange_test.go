@@ -0,0 +1,110 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+package ledger
+
+import (
+	reqContext "context"
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/mocks"
+	pb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/peer"
+	"github.com/pkg/errors"
+)
+
+func TestQueryBlockRange(t *testing.T) {
+	peer := newMockPeerWithBlock(t, "txid1", pb.TxValidationCode_VALID)
+	lc := setupLedgerClient([]fab.Peer{peer}, t)
+
+	blocks, err := lc.QueryBlockRange(2, 4)
+	if err != nil {
+		t.Fatalf("Test ledger query block range failed: %s", err)
+	}
+	if len(blocks) != 3 {
+		t.Fatalf("Expecting 3 blocks but got %d", len(blocks))
+	}
+	if peer.ProcessProposalCalls != 3 {
+		t.Fatalf("Expecting one query per block but got %d", peer.ProcessProposalCalls)
+	}
+
+	_, err = lc.QueryBlockRange(4, 2)
+	expected := "is greater than end block"
+	if err == nil || !strings.Contains(err.Error(), expected) {
+		t.Fatalf("Test ledger query block range should have failed with '%s'", expected)
+	}
+
+	ctx, cancel := reqContext.WithCancel(reqContext.Background())
+	cancel()
+	_, err = lc.QueryBlockRange(2, 4, WithParentContext(ctx))
+	expected = "scan cancelled"
+	if err == nil || !strings.Contains(err.Error(), expected) {
+		t.Fatalf("Test ledger query block range should have failed with '%s'", expected)
+	}
+}
+
+func TestForEachTransaction(t *testing.T) {
+	peer := newMockPeerWithBlock(t, "txid1", pb.TxValidationCode_MVCC_READ_CONFLICT)
+	lc := setupLedgerClient([]fab.Peer{peer}, t)
+
+	var txs []*Transaction
+	err := lc.ForEachTransaction(0, 1, func(tx *Transaction) error {
+		txs = append(txs, tx)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Test ledger for each transaction failed: %s", err)
+	}
+	if len(txs) != 2 {
+		t.Fatalf("Expecting 2 transactions but got %d", len(txs))
+	}
+
+	tx := txs[0]
+	if tx.TxID != "txid1" {
+		t.Fatalf("Expecting transaction ID [txid1] but got [%s]", tx.TxID)
+	}
+	if tx.ValidationCode != pb.TxValidationCode_MVCC_READ_CONFLICT {
+		t.Fatalf("Expecting validation code MVCC_READ_CONFLICT but got %s", tx.ValidationCode)
+	}
+	if tx.ChaincodeAction == nil || tx.ChaincodeAction.Response.Status != 200 {
+		t.Fatalf("Expecting chaincode action with the chaincode response")
+	}
+
+	expected := "handler error"
+	err = lc.ForEachTransaction(0, 1, func(tx *Transaction) error {
+		return errors.New(expected)
+	})
+	if err == nil || !strings.Contains(err.Error(), expected) {
+		t.Fatalf("Test ledger for each transaction should have failed with '%s'", expected)
+	}
+
+	err = lc.ForEachTransaction(0, 1, nil)
+	if err == nil {
+		t.Fatalf("Test ledger for each transaction should have failed due to nil handler")
+	}
+}
+
+func TestBlockTransactionsIncomplete(t *testing.T) {
+	_, err := BlockTransactions(nil)
+	if err == nil {
+		t.Fatalf("Expecting error for nil block")
+	}
+}
+
+func newMockPeerWithBlock(t *testing.T, txID string, code pb.TxValidationCode) *mocks.MockPeer {
+	block, err := mocks.CreateBlockWithCCEventAndTxStatus(&pb.ChaincodeEvent{}, txID, channelID, code)
+	if err != nil {
+		t.Fatalf("Failed to create block: %s", err)
+	}
+
+	payload, err := proto.Marshal(block)
+	if err != nil {
+		t.Fatalf("Failed to marshal block: %s", err)
+	}
+
+	return &mocks.MockPeer{MockName: "Peer1", MockURL: "http://peer1.com", Status: 200, MockMSP: "test", Payload: payload}
+}
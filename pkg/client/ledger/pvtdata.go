@@ -0,0 +1,105 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ledger
+
+import (
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/ledger/rwset"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/ledger/rwset/kvrwset"
+	"github.com/pkg/errors"
+)
+
+// CollectionHashes contains the hashes which a transaction recorded on the ledger for a private data collection.
+// The private data itself is never included in a block; only these hashes are.
+type CollectionHashes struct {
+	// Namespace is the chaincode which owns the collection
+	Namespace string
+	// Collection is the name of the private data collection
+	Collection string
+	// PvtRwsetHash is the hash of the private read-write set of the collection
+	PvtRwsetHash []byte
+	// HashedReads contains the hashes of the keys which were read from the collection
+	HashedReads []*kvrwset.KVReadHash
+	// HashedWrites contains the hashes of the keys and values which were written to the collection
+	HashedWrites []*kvrwset.KVWriteHash
+}
+
+// PrivateDataTransaction is a transaction which touched one or more private data collections
+type PrivateDataTransaction struct {
+	*Transaction
+	// Collections contains the hashes for each (namespace, collection) touched by the transaction
+	Collections []*CollectionHashes
+}
+
+// CollectionNames returns the names of the collections touched by the transaction, keyed by namespace
+func (t *PrivateDataTransaction) CollectionNames() map[string][]string {
+	names := make(map[string][]string)
+	for _, c := range t.Collections {
+		names[c.Namespace] = append(names[c.Namespace], c.Collection)
+	}
+	return names
+}
+
+// QueryPrivateDataHashes scans the blocks within the given (inclusive) range of block numbers and
+// returns the private data hashes of each transaction which touched a private data collection.
+// This allows the collections written by a transaction to be verified without access to the private data.
+//  Parameters:
+//  start is the number of the first block
+//  end is the number of the last block
+//  options hold optional request options
+//
+//  Returns:
+//  the transactions which touched private data, in ledger order
+func (c *Client) QueryPrivateDataHashes(start, end uint64, options ...RequestOption) ([]*PrivateDataTransaction, error) {
+	var txs []*PrivateDataTransaction
+	err := c.ForEachTransaction(start, end, func(tx *Transaction) error {
+		collections, err := PrivateDataHashes(tx)
+		if err != nil {
+			return errors.WithMessage(err, "failed to extract private data hashes from transaction "+tx.TxID)
+		}
+		if len(collections) > 0 {
+			txs = append(txs, &PrivateDataTransaction{Transaction: tx, Collections: collections})
+		}
+		return nil
+	}, options...)
+	if err != nil {
+		return nil, errors.WithMessage(err, "QueryPrivateDataHashes failed")
+	}
+	return txs, nil
+}
+
+// PrivateDataHashes extracts the private data hashes from the read-write set of the given transaction.
+// Nil is returned if the transaction did not touch any private data collections.
+func PrivateDataHashes(tx *Transaction) ([]*CollectionHashes, error) {
+	if tx == nil || tx.ChaincodeAction == nil || len(tx.ChaincodeAction.Results) == 0 {
+		return nil, nil
+	}
+
+	txRWSet := &rwset.TxReadWriteSet{}
+	if err := proto.Unmarshal(tx.ChaincodeAction.Results, txRWSet); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal read-write set")
+	}
+
+	var collections []*CollectionHashes
+	for _, nsRWSet := range txRWSet.NsRwset {
+		for _, collRWSet := range nsRWSet.CollectionHashedRwset {
+			hashedRWSet := &kvrwset.HashedRWSet{}
+			if err := proto.Unmarshal(collRWSet.HashedRwset, hashedRWSet); err != nil {
+				return nil, errors.Wrapf(err, "failed to unmarshal hashed read-write set of collection [%s:%s]", nsRWSet.Namespace, collRWSet.CollectionName)
+			}
+
+			collections = append(collections, &CollectionHashes{
+				Namespace:    nsRWSet.Namespace,
+				Collection:   collRWSet.CollectionName,
+				PvtRwsetHash: collRWSet.PvtRwsetHash,
+				HashedReads:  hashedRWSet.HashedReads,
+				HashedWrites: hashedRWSet.HashedWrites,
+			})
+		}
+	}
+	return collections, nil
+}
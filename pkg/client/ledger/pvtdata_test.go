@@ -0,0 +1,155 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+package ledger
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/mocks"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/ledger/rwset"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/ledger/rwset/kvrwset"
+	pb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/utils"
+)
+
+func TestQueryPrivateDataHashes(t *testing.T) {
+	txRWSet := &rwset.TxReadWriteSet{
+		DataModel: rwset.TxReadWriteSet_KV,
+		NsRwset: []*rwset.NsReadWriteSet{
+			{
+				Namespace: "examplecc",
+				CollectionHashedRwset: []*rwset.CollectionHashedReadWriteSet{
+					newCollectionHashedRWSet(t, "coll1", []byte("key1hash"), []byte("value1hash")),
+					newCollectionHashedRWSet(t, "coll2", []byte("key2hash"), []byte("value2hash")),
+				},
+			},
+			{Namespace: "lscc"},
+		},
+	}
+	peer := newMockPeerWithResults(t, "txid1", txRWSet)
+	lc := setupLedgerClient([]fab.Peer{peer}, t)
+
+	txs, err := lc.QueryPrivateDataHashes(1, 1)
+	if err != nil {
+		t.Fatalf("Test ledger query private data hashes failed: %s", err)
+	}
+	if len(txs) != 1 {
+		t.Fatalf("Expecting 1 private data transaction but got %d", len(txs))
+	}
+
+	tx := txs[0]
+	if tx.TxID != "txid1" || tx.BlockNumber != 1 {
+		t.Fatalf("Unexpected transaction [%s] in block %d", tx.TxID, tx.BlockNumber)
+	}
+	if !reflect.DeepEqual(tx.CollectionNames(), map[string][]string{"examplecc": {"coll1", "coll2"}}) {
+		t.Fatalf("Unexpected collection names: %v", tx.CollectionNames())
+	}
+
+	coll := tx.Collections[1]
+	if !bytes.Equal(coll.PvtRwsetHash, []byte("coll2-pvthash")) {
+		t.Fatalf("Unexpected private read-write set hash: %s", coll.PvtRwsetHash)
+	}
+	if len(coll.HashedWrites) != 1 || !bytes.Equal(coll.HashedWrites[0].KeyHash, []byte("key2hash")) ||
+		!bytes.Equal(coll.HashedWrites[0].ValueHash, []byte("value2hash")) {
+		t.Fatalf("Unexpected hashed writes: %v", coll.HashedWrites)
+	}
+	if len(coll.HashedReads) != 1 || !bytes.Equal(coll.HashedReads[0].KeyHash, []byte("key2hash")) {
+		t.Fatalf("Unexpected hashed reads: %v", coll.HashedReads)
+	}
+}
+
+func TestQueryPrivateDataHashesNoPrivateData(t *testing.T) {
+	txRWSet := &rwset.TxReadWriteSet{
+		DataModel: rwset.TxReadWriteSet_KV,
+		NsRwset:   []*rwset.NsReadWriteSet{{Namespace: "examplecc"}},
+	}
+	peer := newMockPeerWithResults(t, "txid1", txRWSet)
+	lc := setupLedgerClient([]fab.Peer{peer}, t)
+
+	txs, err := lc.QueryPrivateDataHashes(1, 1)
+	if err != nil {
+		t.Fatalf("Test ledger query private data hashes failed: %s", err)
+	}
+	if len(txs) != 0 {
+		t.Fatalf("Expecting no private data transactions but got %d", len(txs))
+	}
+
+	collections, err := PrivateDataHashes(&Transaction{Type: common.HeaderType_CONFIG})
+	if err != nil || collections != nil {
+		t.Fatalf("Expecting no private data hashes for a config transaction")
+	}
+
+	_, err = PrivateDataHashes(&Transaction{ChaincodeAction: &pb.ChaincodeAction{Results: []byte("invalid")}})
+	if err == nil {
+		t.Fatalf("Expecting error for invalid read-write set")
+	}
+}
+
+func newCollectionHashedRWSet(t *testing.T, name string, keyHash, valueHash []byte) *rwset.CollectionHashedReadWriteSet {
+	hashedRWSet, err := proto.Marshal(&kvrwset.HashedRWSet{
+		HashedReads:  []*kvrwset.KVReadHash{{KeyHash: keyHash}},
+		HashedWrites: []*kvrwset.KVWriteHash{{KeyHash: keyHash, ValueHash: valueHash}},
+	})
+	if err != nil {
+		t.Fatalf("Failed to marshal hashed read-write set: %s", err)
+	}
+
+	return &rwset.CollectionHashedReadWriteSet{
+		CollectionName: name,
+		HashedRwset:    hashedRWSet,
+		PvtRwsetHash:   []byte(name + "-pvthash"),
+	}
+}
+
+// newMockPeerWithResults returns a mock peer which serves a block containing a single
+// endorser transaction with the given read-write set
+func newMockPeerWithResults(t *testing.T, txID string, txRWSet *rwset.TxReadWriteSet) *mocks.MockPeer {
+	block, err := mocks.CreateBlockWithCCEventAndTxStatus(&pb.ChaincodeEvent{}, txID, channelID, pb.TxValidationCode_VALID)
+	if err != nil {
+		t.Fatalf("Failed to create block: %s", err)
+	}
+
+	results, err := proto.Marshal(txRWSet)
+	if err != nil {
+		t.Fatalf("Failed to marshal read-write set: %s", err)
+	}
+
+	env, err := utils.GetEnvelopeFromBlock(block.Data.Data[0])
+	if err != nil {
+		t.Fatalf("Failed to get envelope: %s", err)
+	}
+	payload, err := utils.GetPayload(env)
+	if err != nil {
+		t.Fatalf("Failed to get payload: %s", err)
+	}
+
+	prp, err := utils.GetBytesProposalResponsePayload([]byte("proposal_hash"), &pb.Response{Status: 200}, results, nil, nil)
+	if err != nil {
+		t.Fatalf("Failed to create proposal response payload: %s", err)
+	}
+	ccaPayload, err := utils.GetBytesChaincodeActionPayload(&pb.ChaincodeActionPayload{Action: &pb.ChaincodeEndorsedAction{ProposalResponsePayload: prp}})
+	if err != nil {
+		t.Fatalf("Failed to marshal chaincode action payload: %s", err)
+	}
+	payload.Data, err = utils.GetBytesTransaction(&pb.Transaction{Actions: []*pb.TransactionAction{{Payload: ccaPayload}}})
+	if err != nil {
+		t.Fatalf("Failed to marshal transaction: %s", err)
+	}
+	env.Payload = utils.MarshalOrPanic(payload)
+	block.Data.Data[0] = utils.MarshalOrPanic(env)
+
+	blockBytes, err := proto.Marshal(block)
+	if err != nil {
+		t.Fatalf("Failed to marshal block: %s", err)
+	}
+
+	return &mocks.MockPeer{MockName: "Peer1", MockURL: "http://peer1.com", Status: 200, MockMSP: "test", Payload: blockBytes}
+}
@@ -0,0 +1,154 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ledger
+
+import (
+	reqContext "context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/errors/multi"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+	pb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+)
+
+// TransactionStatus contains the commit status of a transaction
+type TransactionStatus struct {
+	// BlockNumber is the number of the block in which the transaction was committed
+	BlockNumber uint64
+	// ValidationCode is the validation code which was assigned to the transaction at commit time
+	ValidationCode pb.TxValidationCode
+	// Transaction is the processed transaction
+	Transaction *pb.ProcessedTransaction
+}
+
+// ErrTransactionStatusMismatch is returned by QueryTransactionWithQuorum when the
+// target peers do not agree on the block number or validation code of the transaction
+type ErrTransactionStatusMismatch struct {
+	TxID fab.TransactionID
+	// Statuses contains the status reported by each of the peers, keyed by peer URL
+	Statuses map[string]*TransactionStatus
+}
+
+func (e *ErrTransactionStatusMismatch) Error() string {
+	var peers []string
+	for peer := range e.Statuses {
+		peers = append(peers, peer)
+	}
+	sort.Strings(peers)
+
+	var reported []string
+	for _, peer := range peers {
+		s := e.Statuses[peer]
+		reported = append(reported, fmt.Sprintf("%s: block %d, %s", peer, s.BlockNumber, s.ValidationCode))
+	}
+	return fmt.Sprintf("peers do not agree on the status of transaction [%s] (%s)", e.TxID, strings.Join(reported, "; "))
+}
+
+// QueryTransactionWithQuorum queries multiple peers for the commit status of a transaction and
+// confirms that they agree on the block number and validation code of the transaction. At least
+// MinTargets peers (see WithMinTargets) must respond and all of the responses must agree, otherwise
+// an error is returned. If the peers disagree then the error is an ErrTransactionStatusMismatch
+// which contains the status reported by each peer.
+//  Parameters:
+//  txID is required transaction ID
+//  options hold optional request options
+//
+//  Returns:
+//  the commit status which all of the responding peers agree on
+func (c *Client) QueryTransactionWithQuorum(txID fab.TransactionID, options ...RequestOption) (*TransactionStatus, error) {
+	targets, opts, err := c.prepareRequestParams(options...)
+	if err != nil {
+		return nil, errors.WithMessage(err, "QueryTransactionWithQuorum failed to prepare request parameters")
+	}
+	reqCtx, cancel := c.createRequestContext(opts)
+	defer cancel()
+
+	var mutex sync.Mutex
+	var wg sync.WaitGroup
+	var errs error
+	statuses := make(map[string]*TransactionStatus)
+
+	for _, target := range targets {
+		wg.Add(1)
+		go func(target fab.Peer) {
+			defer wg.Done()
+
+			status, err := c.queryTransactionStatus(reqCtx, txID, target)
+
+			mutex.Lock()
+			defer mutex.Unlock()
+			if err != nil {
+				errs = multi.Append(errs, errors.WithMessage(err, "From target: "+target.URL()))
+				return
+			}
+			statuses[target.URL()] = status
+		}(target)
+	}
+	wg.Wait()
+
+	if len(statuses) < opts.MinTargets {
+		return nil, errors.Errorf("QueryTransactionWithQuorum: Number of responses %d is less than MinTargets %d. Error: %v", len(statuses), opts.MinTargets, errs)
+	}
+
+	var agreed *TransactionStatus
+	for _, status := range statuses {
+		if agreed == nil {
+			agreed = status
+			continue
+		}
+		if status.BlockNumber != agreed.BlockNumber || status.ValidationCode != agreed.ValidationCode {
+			return nil, &ErrTransactionStatusMismatch{TxID: txID, Statuses: statuses}
+		}
+	}
+
+	return agreed, nil
+}
+
+// queryTransactionStatus queries the given peer for the block which contains the transaction
+// and extracts the status of the transaction from the block
+func (c *Client) queryTransactionStatus(reqCtx reqContext.Context, txID fab.TransactionID, target fab.Peer) (*TransactionStatus, error) {
+	blocks, err := c.ledger.QueryBlockByTxID(reqCtx, txID, peersToTxnProcessors([]fab.Peer{target}), c.verifier)
+	if len(blocks) == 0 {
+		if err == nil {
+			err = errors.New("no response")
+		}
+		return nil, err
+	}
+
+	block := blocks[0]
+	txs, err := BlockTransactions(block)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, tx := range txs {
+		if tx.TxID != string(txID) {
+			continue
+		}
+
+		env, err := utils.GetEnvelopeFromBlock(block.Data.Data[tx.TxIndex])
+		if err != nil {
+			return nil, err
+		}
+
+		return &TransactionStatus{
+			BlockNumber:    tx.BlockNumber,
+			ValidationCode: tx.ValidationCode,
+			Transaction: &pb.ProcessedTransaction{
+				TransactionEnvelope: env,
+				ValidationCode:      int32(tx.ValidationCode),
+			},
+		}, nil
+	}
+
+	return nil, errors.Errorf("transaction [%s] not found in block [%d]", txID, block.Header.Number)
+}
@@ -0,0 +1,87 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+package ledger
+
+import (
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/mocks"
+	pb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/peer"
+	"github.com/pkg/errors"
+)
+
+func TestQueryTransactionWithQuorum(t *testing.T) {
+	peer1 := newQuorumPeer(t, "http://peer1.com", "txid1", pb.TxValidationCode_VALID)
+	peer2 := newQuorumPeer(t, "http://peer2.com", "txid1", pb.TxValidationCode_VALID)
+	lc := setupLedgerClient([]fab.Peer{peer1, peer2}, t)
+
+	status, err := lc.QueryTransactionWithQuorum("txid1", WithMinTargets(2))
+	if err != nil {
+		t.Fatalf("Test ledger query transaction with quorum failed: %s", err)
+	}
+	if status.ValidationCode != pb.TxValidationCode_VALID || status.BlockNumber != 1 {
+		t.Fatalf("Unexpected transaction status: block %d, %s", status.BlockNumber, status.ValidationCode)
+	}
+	if status.Transaction == nil || status.Transaction.TransactionEnvelope == nil {
+		t.Fatalf("Expecting the processed transaction to be returned")
+	}
+	if peer1.ProcessProposalCalls != 1 || peer2.ProcessProposalCalls != 1 {
+		t.Fatalf("Expecting both peers to be queried")
+	}
+
+	_, err = lc.QueryTransactionWithQuorum("txid2", WithMinTargets(2))
+	expected := "not found in block"
+	if err == nil || !strings.Contains(err.Error(), expected) {
+		t.Fatalf("Test ledger query transaction with quorum should have failed with '%s'", expected)
+	}
+}
+
+func TestQueryTransactionWithQuorumMismatch(t *testing.T) {
+	peer1 := newQuorumPeer(t, "http://peer1.com", "txid1", pb.TxValidationCode_VALID)
+	peer2 := newQuorumPeer(t, "http://peer2.com", "txid1", pb.TxValidationCode_MVCC_READ_CONFLICT)
+	lc := setupLedgerClient([]fab.Peer{peer1, peer2}, t)
+
+	_, err := lc.QueryTransactionWithQuorum("txid1", WithMinTargets(2))
+	mismatch, ok := errors.Cause(err).(*ErrTransactionStatusMismatch)
+	if !ok {
+		t.Fatalf("Expecting ErrTransactionStatusMismatch but got: %v", err)
+	}
+	if len(mismatch.Statuses) != 2 {
+		t.Fatalf("Expecting the status reported by each peer but got %d", len(mismatch.Statuses))
+	}
+	if mismatch.Statuses[peer2.MockURL].ValidationCode != pb.TxValidationCode_MVCC_READ_CONFLICT {
+		t.Fatalf("Expecting the divergent status of peer2 to be reported")
+	}
+	if !strings.Contains(err.Error(), "MVCC_READ_CONFLICT") {
+		t.Fatalf("Expecting the divergence to be described in the error: %s", err)
+	}
+}
+
+func TestQueryTransactionWithQuorumNotEnoughResponses(t *testing.T) {
+	peer1 := newQuorumPeer(t, "http://peer1.com", "txid1", pb.TxValidationCode_VALID)
+	peer2 := &mocks.MockPeer{MockName: "Peer2", MockURL: "http://peer2.com", Status: 500, MockMSP: "test", RWLock: &sync.RWMutex{}}
+	lc := setupLedgerClient([]fab.Peer{peer1, peer2}, t)
+
+	_, err := lc.QueryTransactionWithQuorum("txid1", WithMinTargets(2))
+	expected := "is less than MinTargets"
+	if err == nil || !strings.Contains(err.Error(), expected) {
+		t.Fatalf("Test ledger query transaction with quorum should have failed with '%s'", expected)
+	}
+
+	if _, err := lc.QueryTransactionWithQuorum("txid1", WithTargets(peer1)); err != nil {
+		t.Fatalf("Test ledger query transaction with quorum failed: %s", err)
+	}
+}
+
+func newQuorumPeer(t *testing.T, url, txID string, code pb.TxValidationCode) *mocks.MockPeer {
+	peer := newMockPeerWithBlock(t, txID, code)
+	peer.MockURL = url
+	peer.RWLock = &sync.RWMutex{}
+	return peer
+}
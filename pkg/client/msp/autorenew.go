@@ -0,0 +1,186 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package msp
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/logging"
+	mspctx "github.com/hyperledger/fabric-sdk-go/pkg/common/providers/msp"
+	"github.com/pkg/errors"
+)
+
+var logger = logging.NewLogger("fabsdk/client")
+
+const defaultRenewCheckInterval = time.Minute
+
+// autoRenewOptions contains the options for StartAutoRenew
+type autoRenewOptions struct {
+	checkInterval time.Duration
+	onRenewed     func(identity mspctx.SigningIdentity)
+	onFailure     func(err error)
+}
+
+// AutoRenewOption describes a functional parameter for StartAutoRenew
+type AutoRenewOption func(*autoRenewOptions) error
+
+// WithRenewCheckInterval sets the interval at which the expiry of the enrollment certificate is checked
+func WithRenewCheckInterval(interval time.Duration) AutoRenewOption {
+	return func(o *autoRenewOptions) error {
+		if interval <= 0 {
+			return errors.Errorf("invalid check interval [%s]", interval)
+		}
+		o.checkInterval = interval
+		return nil
+	}
+}
+
+// WithRenewedCallback sets the function which is called with the new identity after each successful renewal
+func WithRenewedCallback(callback func(identity mspctx.SigningIdentity)) AutoRenewOption {
+	return func(o *autoRenewOptions) error {
+		o.onRenewed = callback
+		return nil
+	}
+}
+
+// WithRenewFailedCallback sets the function which is called when a renewal fails. The renewal is
+// retried at the next check.
+func WithRenewFailedCallback(callback func(err error)) AutoRenewOption {
+	return func(o *autoRenewOptions) error {
+		o.onFailure = callback
+		return nil
+	}
+}
+
+// AutoRenewer re-enrolls an identity before its enrollment certificate expires
+type AutoRenewer struct {
+	client    *Client
+	threshold time.Duration
+	opts      autoRenewOptions
+	identity  atomic.Value
+	done      chan struct{}
+	stopOnce  sync.Once
+}
+
+// StartAutoRenew starts re-enrolling the given identity whenever its enrollment certificate is within
+// the given threshold of its expiry (NotAfter). The renewed identity replaces the active identity,
+// which is returned by AutoRenewer.Identity, atomically.
+//  Parameters:
+//  identity is the enrolled identity to renew
+//  threshold is the time before the certificate's expiry from which the identity is renewed
+//  options holds optional parameters (check interval and callbacks)
+//
+//  Returns:
+//  the auto renewer which must be stopped when no longer needed
+func (c *Client) StartAutoRenew(identity mspctx.SigningIdentity, threshold time.Duration, options ...AutoRenewOption) (*AutoRenewer, error) {
+	if identity == nil {
+		return nil, errors.New("identity is required")
+	}
+	if threshold <= 0 {
+		return nil, errors.Errorf("invalid threshold [%s]", threshold)
+	}
+
+	opts := autoRenewOptions{checkInterval: defaultRenewCheckInterval}
+	for _, option := range options {
+		if err := option(&opts); err != nil {
+			return nil, errors.WithMessage(err, "failed to start auto renew")
+		}
+	}
+
+	r := &AutoRenewer{
+		client:    c,
+		threshold: threshold,
+		opts:      opts,
+		done:      make(chan struct{}),
+	}
+	r.identity.Store(identity)
+
+	go r.run()
+
+	return r, nil
+}
+
+// Identity returns the active (most recently renewed) identity
+func (r *AutoRenewer) Identity() mspctx.SigningIdentity {
+	return r.identity.Load().(mspctx.SigningIdentity)
+}
+
+// Stop stops renewing the identity
+func (r *AutoRenewer) Stop() {
+	r.stopOnce.Do(func() {
+		close(r.done)
+	})
+}
+
+func (r *AutoRenewer) run() {
+	ticker := time.NewTicker(r.opts.checkInterval)
+	defer ticker.Stop()
+
+	for {
+		r.check()
+
+		select {
+		case <-r.done:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (r *AutoRenewer) check() {
+	identity := r.Identity()
+
+	notAfter, err := certExpiry(identity.EnrollmentCertificate())
+	if err != nil {
+		r.failed(errors.WithMessage(err, "failed to determine expiry of enrollment certificate"))
+		return
+	}
+	if time.Until(notAfter) > r.threshold {
+		return
+	}
+
+	id := identity.Identifier().ID
+	logger.Debugf("Enrollment certificate of [%s] expires at %s - re-enrolling", id, notAfter)
+
+	if err := r.client.Reenroll(id); err != nil {
+		r.failed(errors.WithMessage(err, "re-enroll failed"))
+		return
+	}
+	renewed, err := r.client.GetSigningIdentity(id)
+	if err != nil {
+		r.failed(errors.WithMessage(err, "failed to get renewed identity"))
+		return
+	}
+
+	r.identity.Store(renewed)
+	if r.opts.onRenewed != nil {
+		r.opts.onRenewed(renewed)
+	}
+}
+
+func (r *AutoRenewer) failed(err error) {
+	logger.Warnf("Auto renew of identity failed: %s", err)
+	if r.opts.onFailure != nil {
+		r.opts.onFailure(err)
+	}
+}
+
+func certExpiry(certPEM []byte) (time.Time, error) {
+	block, _ := pem.Decode(certPEM)
+	if block == nil {
+		return time.Time{}, errors.New("PEM encoded certificate is required")
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return time.Time{}, errors.Wrap(err, "certificate parsing failed")
+	}
+	return cert.NotAfter, nil
+}
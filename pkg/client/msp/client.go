@@ -8,6 +8,7 @@ package msp
 
 import (
 	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/context"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/core"
 	mspctx "github.com/hyperledger/fabric-sdk-go/pkg/common/providers/msp"
 	"github.com/hyperledger/fabric-sdk-go/pkg/msp"
 	mspapi "github.com/hyperledger/fabric-sdk-go/pkg/msp/api"
@@ -74,6 +75,8 @@ func newCAClient(ctx context.Client, orgName string) (mspapi.CAClient, error) {
 // enrollmentOptions represent enrollment options
 type enrollmentOptions struct {
 	secret string
+	csr    []byte
+	csrKey core.Key
 }
 
 // EnrollmentOption describes a functional parameter for Enroll
@@ -87,9 +90,35 @@ func WithSecret(secret string) EnrollmentOption {
 	}
 }
 
+// WithCSR enrollment option which submits the given (PEM encoded) certificate request to the CA
+// instead of generating a new key pair. The private key of the request is never held by the SDK
+// (e.g. it is held in an HSM), only the enrollment certificate issued by the CA is stored.
+func WithCSR(pemBytes []byte) EnrollmentOption {
+	return func(o *enrollmentOptions) error {
+		if len(pemBytes) == 0 {
+			return errors.New("CSR is required")
+		}
+		o.csr = pemBytes
+		return nil
+	}
+}
+
+// WithCSRKey enrollment option which sets the handle of the key (e.g. held in an HSM) which
+// is expected to have signed the certificate request provided with WithCSR. Enrollment fails
+// if the public key of the certificate request doesn't match the key.
+func WithCSRKey(key core.Key) EnrollmentOption {
+	return func(o *enrollmentOptions) error {
+		if key == nil {
+			return errors.New("CSR key is required")
+		}
+		o.csrKey = key
+		return nil
+	}
+}
+
 // Enroll enrolls a registered user in order to receive a signed X509 certificate.
-// A new key pair is generated for the user. The private key and the
-// enrollment certificate issued by the CA are stored in SDK stores.
+// A new key pair is generated for the user, unless a certificate request is provided with WithCSR.
+// The private key and the enrollment certificate issued by the CA are stored in SDK stores.
 // They can be retrieved by calling IdentityManager.GetSigningIdentity().
 //
 // enrollmentID enrollment ID of a registered user
@@ -104,10 +133,17 @@ func (c *Client) Enroll(enrollmentID string, opts ...EnrollmentOption) error {
 		}
 	}
 
+	if eo.csrKey != nil && eo.csr == nil {
+		return errors.New("failed to enroll: CSR key requires a CSR")
+	}
+
 	ca, err := newCAClient(c.ctx, c.orgName)
 	if err != nil {
 		return err
 	}
+	if eo.csr != nil {
+		return ca.EnrollWithCSR(enrollmentID, eo.secret, eo.csr, eo.csrKey)
+	}
 	return ca.Enroll(enrollmentID, eo.secret)
 }
 
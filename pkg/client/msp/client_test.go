@@ -7,11 +7,18 @@ SPDX-License-Identifier: Apache-2.0
 package msp
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	cryptorand "crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
 	"math/rand"
 	"net"
 	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"fmt"
 	"os"
@@ -114,6 +121,129 @@ func testWithOrg2(t *testing.T, ctxProvider contextApi.ClientProvider) {
 	}
 }
 
+// TestEnrollWithCSR tests enrollment with a pre-built certificate request
+func TestEnrollWithCSR(t *testing.T) {
+
+	f := textFixture{}
+	sdk := f.setup()
+	defer f.close()
+
+	msp, err := New(sdk.Context())
+	if err != nil {
+		t.Fatalf("failed to create CA client: %v", err)
+	}
+
+	err = msp.Enroll("csrUser", WithSecret("enrollmentSecret"), WithCSR(nil))
+	if err == nil {
+		t.Fatalf("Enroll should return error for empty CSR")
+	}
+
+	key, err := cryptosuite.GetDefault().KeyGen(cryptosuite.GetECDSAP256KeyGenOpts(true))
+	if err != nil {
+		t.Fatalf("Failed to generate key: %s", err)
+	}
+	err = msp.Enroll("csrUser", WithSecret("enrollmentSecret"), WithCSRKey(key))
+	if err == nil {
+		t.Fatalf("Enroll should return error for CSR key without CSR")
+	}
+
+	privKey, err := ecdsa.GenerateKey(elliptic.P256(), cryptorand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %s", err)
+	}
+	csr, err := x509.CreateCertificateRequest(cryptorand.Reader, &x509.CertificateRequest{Subject: pkix.Name{CommonName: "csrUser"}}, privKey)
+	if err != nil {
+		t.Fatalf("Failed to create certificate request: %s", err)
+	}
+
+	enrollUsername := randomUsername()
+	err = msp.Enroll(enrollUsername, WithSecret("enrollmentSecret"), WithCSR(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: csr})))
+	if err != nil {
+		t.Fatalf("Enroll return error %v", err)
+	}
+
+	enrolledUser, err := msp.GetSigningIdentity(enrollUsername)
+	if err != nil {
+		t.Fatalf("Expected to find user")
+	}
+	if enrolledUser.Identifier().ID != enrollUsername {
+		t.Fatalf("Enrolled user name doesn't match")
+	}
+}
+
+// TestAutoRenew tests re-enrollment of an identity whose certificate is about to expire
+func TestAutoRenew(t *testing.T) {
+
+	f := textFixture{}
+	sdk := f.setup()
+	defer f.close()
+
+	msp, err := New(sdk.Context())
+	if err != nil {
+		t.Fatalf("failed to create CA client: %v", err)
+	}
+
+	enrolledUser := getEnrolledUser(t, msp)
+
+	_, err = msp.StartAutoRenew(enrolledUser, 0)
+	if err == nil {
+		t.Fatalf("StartAutoRenew should return error for invalid threshold")
+	}
+	_, err = msp.StartAutoRenew(enrolledUser, time.Hour, WithRenewCheckInterval(0))
+	if err == nil {
+		t.Fatalf("StartAutoRenew should return error for invalid check interval")
+	}
+
+	// The certificate issued by the mock CA expires well within the threshold
+	renewed := make(chan mspctx.SigningIdentity, 1)
+	renewer, err := msp.StartAutoRenew(enrolledUser, 24*365*10*time.Hour, WithRenewCheckInterval(50*time.Millisecond),
+		WithRenewedCallback(func(identity mspctx.SigningIdentity) {
+			select {
+			case renewed <- identity:
+			default:
+			}
+		}))
+	if err != nil {
+		t.Fatalf("StartAutoRenew return error %v", err)
+	}
+	defer renewer.Stop()
+
+	select {
+	case identity := <-renewed:
+		if identity.Identifier().ID != enrolledUser.Identifier().ID {
+			t.Fatalf("Renewed identity doesn't match")
+		}
+		if renewer.Identity() == enrolledUser {
+			t.Fatalf("Expected active identity to be replaced")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Timed out waiting for renewal")
+	}
+
+	// The certificate of the identity can't be parsed
+	failed := make(chan error, 1)
+	failingRenewer, err := msp.StartAutoRenew(mockmsp.NewMockSigningIdentity("test", "Org1MSP"), time.Hour,
+		WithRenewFailedCallback(func(err error) {
+			select {
+			case failed <- err:
+			default:
+			}
+		}))
+	if err != nil {
+		t.Fatalf("StartAutoRenew return error %v", err)
+	}
+	defer failingRenewer.Stop()
+
+	select {
+	case err := <-failed:
+		if !strings.Contains(err.Error(), "failed to determine expiry") {
+			t.Fatalf("Unexpected renewal error: %s", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Timed out waiting for renewal failure")
+	}
+}
+
 func getEnrolledUser(t *testing.T, msp *Client) mspctx.SigningIdentity {
 	// Successful enrollment scenario
 
@@ -0,0 +1,71 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package resmgmt
+
+import (
+	reqContext "context"
+	"crypto/sha256"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/resource/api"
+)
+
+// ComputeChaincodeID computes the unique ID of a chaincode package in the same way as the peer
+// does when the package is installed, i.e. H(H(CodePackage) || H(name || version)). The ID is
+// returned by the peer in the installed chaincodes (see QueryInstalledChaincodes), so it may be
+// used to verify that the installed package is the expected one.
+//  Parameters:
+//  name is the chaincode name
+//  version is the chaincode version
+//  pkg is the chaincode package
+//
+//  Returns:
+//  the chaincode ID
+func ComputeChaincodeID(name, version string, pkg *api.CCPackage) []byte {
+	var code []byte
+	if pkg != nil {
+		code = pkg.Code
+	}
+
+	codeHash := sha256.Sum256(code)
+	metadataHash := sha256.Sum256([]byte(name + version))
+
+	id := sha256.Sum256(append(codeHash[:], metadataHash[:]...))
+	return id[:]
+}
+
+// progressProcessor reports the progress of sending a proposal to the wrapped processor. The peer
+// accepts the install proposal as a single message, so progress is reported once the proposal is
+// about to be sent and once it has been accepted by the peer.
+type progressProcessor struct {
+	fab.Peer
+	handler InstallProgressHandler
+}
+
+func withInstallProgress(peers []fab.Peer, handler InstallProgressHandler) []fab.ProposalProcessor {
+	processors := make([]fab.ProposalProcessor, len(peers))
+	for i, peer := range peers {
+		processors[i] = &progressProcessor{Peer: peer, handler: handler}
+	}
+	return processors
+}
+
+func (p *progressProcessor) ProcessTransactionProposal(reqCtx reqContext.Context, request fab.ProcessProposalRequest) (*fab.TransactionProposalResponse, error) {
+	progress := InstallProgress{Target: p.Peer.URL(), TotalBytes: int64(proto.Size(request.SignedProposal))}
+	p.handler(progress)
+
+	response, err := p.Peer.ProcessTransactionProposal(reqCtx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	progress.BytesSent = progress.TotalBytes
+	p.handler(progress)
+
+	return response, nil
+}
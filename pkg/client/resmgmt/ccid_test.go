@@ -0,0 +1,101 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package resmgmt
+
+import (
+	"crypto/sha256"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	fcmocks "github.com/hyperledger/fabric-sdk-go/pkg/fab/mocks"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/resource/api"
+	pb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComputeChaincodeID(t *testing.T) {
+	pkg := &api.CCPackage{Type: 1, Code: []byte("code")}
+
+	// Computed by the peer as in ccprovider.CDSPackage
+	hash := sha256.New()
+	hash.Write(pkg.Code)
+	codeHash := hash.Sum(nil)
+	hash.Reset()
+	hash.Write([]byte("name"))
+	hash.Write([]byte("v1"))
+	metadataHash := hash.Sum(nil)
+	hash.Reset()
+	hash.Write(codeHash)
+	hash.Write(metadataHash)
+
+	id := ComputeChaincodeID("name", "v1", pkg)
+	assert.Equal(t, hash.Sum(nil), id)
+	assert.Equal(t, id, ComputeChaincodeID("name", "v1", &api.CCPackage{Type: 1, Code: []byte("code")}), "expecting the same ID for the same package")
+	assert.NotEqual(t, id, ComputeChaincodeID("name", "v2", pkg), "expecting a different ID for a different version")
+	assert.NotEqual(t, id, ComputeChaincodeID("name", "v1", &api.CCPackage{Type: 1, Code: []byte("other code")}), "expecting a different ID for a different package")
+}
+
+func TestInstallCCWithProgress(t *testing.T) {
+	rc := setupDefaultResMgmtClient(t)
+
+	peer1 := &fcmocks.MockPeer{MockName: "Peer1", MockURL: "http://peer1.com", Status: http.StatusOK, MockMSP: "Org1MSP"}
+	peer2 := &fcmocks.MockPeer{MockName: "Peer2", MockURL: "http://peer2.com", Status: http.StatusOK, MockMSP: "Org1MSP"}
+
+	var mutex sync.Mutex
+	progress := make(map[string][]InstallProgress)
+	handler := func(p InstallProgress) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		progress[p.Target] = append(progress[p.Target], p)
+	}
+
+	req := InstallCCRequest{Name: "ID", Version: "v0", Path: "path", Package: &api.CCPackage{Type: 1, Code: []byte("code")}}
+	responses, err := rc.InstallCC(req, WithTargets(peer1, peer2), WithInstallProgress(handler))
+	require.NoError(t, err)
+	require.Equal(t, 2, len(responses))
+	for _, response := range responses {
+		assert.Equal(t, ComputeChaincodeID(req.Name, req.Version, req.Package), response.ChaincodeID)
+	}
+
+	for _, peer := range []*fcmocks.MockPeer{peer1, peer2} {
+		peerProgress := progress[peer.URL()]
+		require.Equal(t, 2, len(peerProgress), "expecting progress before and after sending the package")
+		assert.True(t, peerProgress[0].TotalBytes > int64(len(req.Package.Code)))
+		assert.EqualValues(t, 0, peerProgress[0].BytesSent)
+		assert.Equal(t, peerProgress[0].TotalBytes, peerProgress[1].BytesSent)
+	}
+}
+
+func TestInstallCCAlreadyInstalledWithDifferentPackage(t *testing.T) {
+	rc := setupDefaultResMgmtClient(t)
+
+	req := InstallCCRequest{Name: "name", Version: "version", Path: "path", Package: &api.CCPackage{Type: 1, Code: []byte("code")}}
+	id := ComputeChaincodeID(req.Name, req.Version, req.Package)
+
+	installedPeer := func(id []byte) *fcmocks.MockPeer {
+		response := &pb.ChaincodeQueryResponse{Chaincodes: []*pb.ChaincodeInfo{{Name: "name", Path: "path", Version: "version", Id: id}}}
+		responseBytes, err := proto.Marshal(response)
+		require.NoError(t, err)
+		return &fcmocks.MockPeer{MockName: "Peer1", MockURL: "http://peer1.com", Status: http.StatusOK, MockMSP: "Org1MSP", Payload: responseBytes}
+	}
+
+	responses, err := rc.InstallCC(req, WithTargets(installedPeer(id)))
+	require.NoError(t, err)
+	require.Equal(t, 1, len(responses))
+	assert.Equal(t, "already installed", responses[0].Info)
+	assert.Equal(t, id, responses[0].ChaincodeID)
+
+	otherID := ComputeChaincodeID(req.Name, req.Version, &api.CCPackage{Type: 1, Code: []byte("other code")})
+	responses, err = rc.InstallCC(req, WithTargets(installedPeer(otherID)))
+	require.NoError(t, err)
+	require.Equal(t, 1, len(responses))
+	assert.Equal(t, "already installed with a different package", responses[0].Info)
+	assert.Equal(t, otherID, responses[0].ChaincodeID, "expecting the ID reported by the peer")
+}
@@ -0,0 +1,98 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package resmgmt
+
+import (
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/errors/multi"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/context"
+	"github.com/hyperledger/fabric-sdk-go/pkg/core/config/cryptoutil"
+	"github.com/hyperledger/fabric-sdk-go/pkg/core/cryptosuite"
+	"github.com/pkg/errors"
+)
+
+// LifecycleSignPackage creates a detached signature over the given chaincode package bytes
+// (e.g. the code package produced by the Go packager) with the private key of the context's identity.
+//  Parameters:
+//  pkgBytes is the chaincode package
+//  ctx is the client context of the identity which signs the package
+//
+//  Returns:
+//  the signature
+func LifecycleSignPackage(pkgBytes []byte, ctx context.Client) ([]byte, error) {
+	if len(pkgBytes) == 0 {
+		return nil, errors.New("chaincode package is required")
+	}
+	if ctx == nil {
+		return nil, errors.New("client context is required")
+	}
+
+	signingMgr := ctx.SigningManager()
+	if signingMgr == nil {
+		return nil, errors.New("signing manager is nil")
+	}
+
+	signature, err := signingMgr.Sign(pkgBytes, ctx.PrivateKey())
+	if err != nil {
+		return nil, errors.WithMessage(err, "signing chaincode package failed")
+	}
+	return signature, nil
+}
+
+// LifecycleVerifyPackage verifies a detached signature, created with LifecycleSignPackage, over the given
+// chaincode package bytes. The signature is verified with the default crypto suite and must have been
+// created by the holder of one of the given (PEM encoded) certificates.
+//  Parameters:
+//  pkgBytes is the chaincode package
+//  sig is the signature over the package
+//  certs are the certificates of the trusted signers
+//
+//  Returns:
+//  nil if the signature is valid for one of the certificates, otherwise an error
+func LifecycleVerifyPackage(pkgBytes, sig []byte, certs [][]byte) error {
+	if len(pkgBytes) == 0 {
+		return errors.New("chaincode package is required")
+	}
+	if len(sig) == 0 {
+		return errors.New("signature is required")
+	}
+	if len(certs) == 0 {
+		return errors.New("at least one certificate is required")
+	}
+
+	cs := cryptosuite.GetDefault()
+	digest, err := cs.Hash(pkgBytes, cryptosuite.GetSHAOpts())
+	if err != nil {
+		return errors.WithMessage(err, "hashing chaincode package failed")
+	}
+
+	errs := multi.Errors{}
+	for _, cert := range certs {
+		key, err := cryptoutil.GetPublicKeyFromCert(cert, cs)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		msg := digest
+		if cryptosuite.IsEd25519Key(key) {
+			// Ed25519 signs the package itself rather than a digest of it
+			msg = pkgBytes
+		}
+		valid, err := cs.Verify(key, sig, msg, nil)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if valid {
+			return nil
+		}
+	}
+
+	if len(errs) > 0 {
+		return errors.WithMessage(errs, "chaincode package signature is not valid for any of the certificates")
+	}
+	return errors.New("chaincode package signature is not valid for any of the certificates")
+}
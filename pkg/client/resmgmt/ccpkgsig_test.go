@@ -0,0 +1,126 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package resmgmt
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/context"
+	configImpl "github.com/hyperledger/fabric-sdk-go/pkg/core/config"
+	"github.com/hyperledger/fabric-sdk-go/pkg/core/cryptosuite"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/signingmgr"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fabsdk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestPackageSigner(t *testing.T, sdk *fabsdk.FabricSDK, user, org string) context.Client {
+	ctx, err := sdk.Context(fabsdk.WithUser(user), fabsdk.WithOrg(org))()
+	require.NoError(t, err, "Failed to create client context for %s@%s", user, org)
+	return ctx
+}
+
+func TestLifecycleSignAndVerifyPackage(t *testing.T) {
+	sdk, err := fabsdk.New(configImpl.FromFile(configPath))
+	require.NoError(t, err)
+	defer sdk.Close()
+
+	pkgBytes := []byte("chaincode package")
+
+	signer := newTestPackageSigner(t, sdk, "User1", "Org1")
+	otherSigner := newTestPackageSigner(t, sdk, "Admin", "Org1")
+	cert := signer.EnrollmentCertificate()
+	otherCert := otherSigner.EnrollmentCertificate()
+
+	sig, err := LifecycleSignPackage(pkgBytes, signer)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, sig)
+
+	assert.NoError(t, LifecycleVerifyPackage(pkgBytes, sig, [][]byte{cert}))
+	assert.NoError(t, LifecycleVerifyPackage(pkgBytes, sig, [][]byte{otherCert, cert}), "Expecting signature to be valid for one of the certificates")
+
+	err = LifecycleVerifyPackage([]byte("tampered package"), sig, [][]byte{cert})
+	assert.Error(t, err, "Expecting verification of tampered package to fail")
+
+	err = LifecycleVerifyPackage(pkgBytes, sig, [][]byte{otherCert})
+	assert.Error(t, err, "Expecting verification with wrong certificate to fail")
+
+	otherSig, err := LifecycleSignPackage(pkgBytes, otherSigner)
+	assert.NoError(t, err)
+	err = LifecycleVerifyPackage(pkgBytes, otherSig, [][]byte{cert})
+	assert.Error(t, err, "Expecting verification of signature from another signer to fail")
+
+	err = LifecycleVerifyPackage(pkgBytes, sig, [][]byte{[]byte("invalid cert")})
+	assert.Error(t, err, "Expecting verification with invalid certificate to fail")
+}
+
+func TestLifecycleSignPackageInvalidArgs(t *testing.T) {
+	sdk, err := fabsdk.New(configImpl.FromFile(configPath))
+	require.NoError(t, err)
+	defer sdk.Close()
+
+	signer := newTestPackageSigner(t, sdk, "User1", "Org1")
+	cert := signer.EnrollmentCertificate()
+
+	_, err = LifecycleSignPackage(nil, signer)
+	assert.Error(t, err, "Expecting error for empty package")
+
+	_, err = LifecycleSignPackage([]byte("chaincode package"), nil)
+	assert.Error(t, err, "Expecting error for nil client context")
+
+	err = LifecycleVerifyPackage(nil, []byte("sig"), [][]byte{cert})
+	assert.Error(t, err, "Expecting error for empty package")
+
+	err = LifecycleVerifyPackage([]byte("chaincode package"), nil, [][]byte{cert})
+	assert.Error(t, err, "Expecting error for empty signature")
+
+	err = LifecycleVerifyPackage([]byte("chaincode package"), []byte("sig"), nil)
+	assert.Error(t, err, "Expecting error for no certificates")
+}
+
+func TestLifecycleVerifyPackageEd25519(t *testing.T) {
+	cs := cryptosuite.GetDefault()
+
+	key, err := cs.KeyGen(cryptosuite.GetED25519KeyGenOpts(true))
+	require.NoError(t, err)
+	pubKey, err := key.PublicKey()
+	require.NoError(t, err)
+	pubKeyRaw, err := pubKey.Bytes()
+	require.NoError(t, err)
+	pub, err := x509.ParsePKIXPublicKey(pubKeyRaw)
+	require.NoError(t, err)
+
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "signer"},
+		NotBefore:    time.Now().Add(-1 * time.Hour),
+		NotAfter:     time.Now().Add(1 * time.Hour),
+	}
+	certRaw, err := x509.CreateCertificate(rand.Reader, &template, &template, pub, caKey)
+	require.NoError(t, err)
+	cert := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certRaw})
+
+	signingMgr, err := signingmgr.New(cs)
+	require.NoError(t, err)
+
+	pkgBytes := []byte("chaincode package")
+	sig, err := signingMgr.Sign(pkgBytes, key)
+	require.NoError(t, err)
+
+	assert.NoError(t, LifecycleVerifyPackage(pkgBytes, sig, [][]byte{cert}))
+	assert.Error(t, LifecycleVerifyPackage([]byte("tampered package"), sig, [][]byte{cert}), "Expecting verification of tampered package to fail")
+}
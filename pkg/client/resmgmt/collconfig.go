@@ -0,0 +1,330 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package resmgmt
+
+import (
+	reqContext "context"
+	"fmt"
+	"net/http"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/errors/retry"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/errors/status"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/context"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+	contextImpl "github.com/hyperledger/fabric-sdk-go/pkg/context"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/txn"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/common"
+	"github.com/pkg/errors"
+)
+
+// ErrCollectionUpdateNotSupported is returned by UpdateCollectionConfig if the channel doesn't support
+// updating the collection config of a chaincode without upgrading the chaincode
+var ErrCollectionUpdateNotSupported = errors.New("standalone collection config update is not supported by the target network")
+
+const (
+	applicationGroupKey = "Application"
+	// v20Capability enables the new chaincode lifecycle (_lifecycle) which allows the chaincode
+	// definition (including the collection config) to be updated without a new chaincode package
+	v20Capability = "V2_0"
+)
+
+// UpdateCollectionConfig updates the private data collection config of a chaincode without
+// redeploying the chaincode. The collection config is validated before the update is submitted.
+//
+// The update is submitted through the new chaincode lifecycle: the current chaincode definition is
+// approved by the client's org with the new collection config and an incremented sequence and is then
+// committed to the channel. The other orgs must approve the same definition (the commit is only
+// successful once the channel's LifecycleEndorsement policy is satisfied).
+// ErrCollectionUpdateNotSupported is returned if the channel doesn't have the V2_0 application capability
+// (in which case the collection config may only be changed by upgrading the chaincode - see UpgradeCC).
+//  Parameters:
+//  channelID is mandatory channel name
+//  ccName is mandatory chaincode name
+//  collections is the new collection config of the chaincode
+//  options holds optional request options
+//
+//  Returns:
+//  an error if the collection config is invalid or the update fails
+func (rc *Client) UpdateCollectionConfig(channelID, ccName string, collections []*common.CollectionConfig, options ...RequestOption) error {
+	if channelID == "" {
+		return errors.New("must provide channel ID")
+	}
+	if ccName == "" {
+		return errors.New("must provide chaincode name")
+	}
+
+	opts, err := rc.prepareRequestOpts(options...)
+	if err != nil {
+		return err
+	}
+
+	if err := validateCollectionConfigs(collections); err != nil {
+		return errors.WithMessage(err, "collection config validation failed")
+	}
+
+	chCtx, err := contextImpl.NewChannel(
+		func() (context.Client, error) {
+			return rc.ctx, nil
+		},
+		channelID,
+	)
+	if err != nil {
+		return errors.WithMessage(err, "failed to create channel context")
+	}
+
+	chConfig, err := chCtx.ChannelService().ChannelConfig()
+	if err != nil {
+		return errors.WithMessage(err, "get channel config failed")
+	}
+	if !hasCapability(chConfig.Capabilities()[applicationGroupKey], v20Capability) {
+		return errors.WithMessage(ErrCollectionUpdateNotSupported, fmt.Sprintf("unable to update collection config of chaincode [%s] on channel [%s] - upgrade the chaincode with the new collection config instead", ccName, channelID))
+	}
+
+	targets, orgTargets, err := rc.getLifecycleTargets(chCtx, opts)
+	if err != nil {
+		return err
+	}
+
+	reqCtx, cancel := rc.createRequestContext(opts, fab.ResMgmt)
+	defer cancel()
+
+	definition := &queryChaincodeDefinitionResult{}
+	if err := rc.queryLifecycle(reqCtx, channelID, lifecycleQueryDefinition, &queryChaincodeDefinitionArgs{Name: ccName}, orgTargets[0], opts.Retry, definition); err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("failed to query definition of chaincode [%s]", ccName))
+	}
+
+	source, err := rc.chaincodeSource(reqCtx, channelID, ccName, definition.Version, orgTargets[0], opts.Retry)
+	if err != nil {
+		return err
+	}
+
+	collectionConfig := &common.CollectionConfigPackage{Config: collections}
+
+	approveArgs := &approveChaincodeDefinitionArgs{
+		Sequence:            definition.Sequence + 1,
+		Name:                ccName,
+		Version:             definition.Version,
+		EndorsementPlugin:   definition.EndorsementPlugin,
+		ValidationPlugin:    definition.ValidationPlugin,
+		ValidationParameter: definition.ValidationParameter,
+		Collections:         collectionConfig,
+		InitRequired:        definition.InitRequired,
+		Source:              source,
+	}
+	if _, err := rc.sendLifecycleTransaction(reqCtx, chCtx, lifecycleApproveDefinitionForOrg, approveArgs, orgTargets); err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("failed to approve definition of chaincode [%s]", ccName))
+	}
+
+	commitArgs := &commitChaincodeDefinitionArgs{
+		Sequence:            approveArgs.Sequence,
+		Name:                ccName,
+		Version:             definition.Version,
+		EndorsementPlugin:   definition.EndorsementPlugin,
+		ValidationPlugin:    definition.ValidationPlugin,
+		ValidationParameter: definition.ValidationParameter,
+		Collections:         collectionConfig,
+		InitRequired:        definition.InitRequired,
+	}
+	if _, err := rc.sendLifecycleTransaction(reqCtx, chCtx, lifecycleCommitDefinition, commitArgs, targets); err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("failed to commit definition of chaincode [%s]", ccName))
+	}
+
+	return nil
+}
+
+func hasCapability(capabilities []string, capability string) bool {
+	for _, c := range capabilities {
+		if c == capability {
+			return true
+		}
+	}
+	return false
+}
+
+// getLifecycleTargets returns the targets of the commit and the targets of the client's org (which
+// approve the definition). The channel peers are used if no targets are provided.
+func (rc *Client) getLifecycleTargets(chCtx context.Channel, opts requestOptions) ([]fab.Peer, []fab.Peer, error) {
+	if len(opts.Targets) > 0 && opts.TargetFilter != nil {
+		return nil, nil, errors.New("If targets are provided, filter cannot be provided")
+	}
+
+	targets := opts.Targets
+	if len(targets) == 0 {
+		peers, err := chCtx.DiscoveryService().GetPeers()
+		if err != nil {
+			return nil, nil, errors.WithMessage(err, "failed to discover peers")
+		}
+
+		filter := opts.TargetFilter
+		if filter == nil {
+			filter = rc.filter
+		}
+		targets = filterTargets(peers, filter)
+	}
+
+	mspID := chCtx.Identifier().MSPID
+	orgTargets := filterTargets(targets, &mspFilter{mspID: mspID})
+	if len(orgTargets) == 0 {
+		return nil, nil, errors.WithStack(status.New(status.ClientStatus, status.NoPeersFound.ToInt32(), fmt.Sprintf("no targets in MSP [%s]", mspID), nil))
+	}
+
+	return targets, orgTargets, nil
+}
+
+// chaincodeSource returns the installed package which is referenced by the current definition of the chaincode.
+// If the package isn't installed on the target then the org approves the definition without a package.
+func (rc *Client) chaincodeSource(reqCtx reqContext.Context, channelID, ccName, ccVersion string, target fab.ProposalProcessor, retryOpts retry.Opts) (*chaincodeSource, error) {
+	installed := &queryInstalledChaincodesResult{}
+	if err := rc.queryLifecycle(reqCtx, fab.SystemChannel, lifecycleQueryInstalled, &queryInstalledChaincodesArgs{}, target, retryOpts, installed); err != nil {
+		return nil, errors.WithMessage(err, "failed to query installed chaincodes")
+	}
+
+	for _, cc := range installed.InstalledChaincodes {
+		refs, ok := cc.References[channelID]
+		if !ok {
+			continue
+		}
+		for _, ref := range refs.Chaincodes {
+			if ref.Name == ccName && ref.Version == ccVersion {
+				return &chaincodeSource{LocalPackage: &chaincodeSourceLocal{PackageID: cc.PackageID}}, nil
+			}
+		}
+	}
+
+	logger.Debugf("No installed package is referenced by chaincode [%s:%s] on channel [%s]", ccName, ccVersion, channelID)
+	return &chaincodeSource{Unavailable: &chaincodeSourceUnavailable{}}, nil
+}
+
+// queryLifecycle sends a query to the _lifecycle system chaincode of the target and unmarshals the result
+func (rc *Client) queryLifecycle(reqCtx reqContext.Context, channelID, fcn string, args proto.Message, target fab.ProposalProcessor, retryOpts retry.Opts, result proto.Message) error {
+	tp, err := rc.createLifecycleTP(channelID, fcn, args)
+	if err != nil {
+		return err
+	}
+
+	resp, err := retry.NewInvoker(retry.New(retryOpts)).InvokeWithContext(reqCtx,
+		func() (interface{}, error) {
+			return txn.SendProposal(reqCtx, tp, []fab.ProposalProcessor{target})
+		},
+	)
+	if err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("%s failed", fcn))
+	}
+
+	tpr := resp.([]*fab.TransactionProposalResponse)[0]
+	if err := checkLifecycleResponse(tpr); err != nil {
+		return err
+	}
+
+	return proto.Unmarshal(tpr.ProposalResponse.GetResponse().GetPayload(), result)
+}
+
+// sendLifecycleTransaction endorses the given _lifecycle transaction on the targets and submits it to the orderer
+func (rc *Client) sendLifecycleTransaction(reqCtx reqContext.Context, chCtx context.Channel, fcn string, args proto.Message, targets []fab.Peer) (fab.TransactionID, error) {
+	channelService := chCtx.ChannelService()
+
+	chConfig, err := channelService.ChannelConfig()
+	if err != nil {
+		return fab.EmptyTransactionID, errors.WithMessage(err, "get channel config failed")
+	}
+	transactor, err := rc.ctx.InfraProvider().CreateChannelTransactor(reqCtx, chConfig)
+	if err != nil {
+		return fab.EmptyTransactionID, errors.WithMessage(err, "get channel transactor failed")
+	}
+
+	tp, err := rc.createLifecycleTP(chCtx.ChannelID(), fcn, args)
+	if err != nil {
+		return fab.EmptyTransactionID, err
+	}
+
+	txProposalResponse, err := transactor.SendTransactionProposal(tp, peersToTxnProcessors(targets))
+	if err != nil {
+		return tp.TxnID, errors.WithMessage(err, fmt.Sprintf("sending %s transaction proposal failed", fcn))
+	}
+	for _, tpr := range txProposalResponse {
+		if err := checkLifecycleResponse(tpr); err != nil {
+			return tp.TxnID, err
+		}
+	}
+
+	err = rc.verifyTPSignature(channelService, txProposalResponse)
+	if err != nil {
+		return tp.TxnID, errors.WithMessage(err, fmt.Sprintf("sending %s transaction proposal failed to verify signature", fcn))
+	}
+
+	eventService, err := channelService.EventService()
+	if err != nil {
+		return tp.TxnID, errors.WithMessage(err, "unable to get event service")
+	}
+
+	return rc.sendTransactionAndCheckEvent(eventService, tp, txProposalResponse, transactor, reqCtx)
+}
+
+// createLifecycleTP creates a proposal for the given _lifecycle function
+func (rc *Client) createLifecycleTP(channelID, fcn string, args proto.Message) (*fab.TransactionProposal, error) {
+	argsBytes, err := proto.Marshal(args)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to marshal %s args", fcn)
+	}
+
+	txh, err := txn.NewHeader(rc.ctx, channelID)
+	if err != nil {
+		return nil, errors.WithMessage(err, "create transaction ID failed")
+	}
+
+	request := fab.ChaincodeInvokeRequest{
+		ChaincodeID: lifecycleCC,
+		Fcn:         fcn,
+		Args:        [][]byte{argsBytes},
+	}
+	tp, err := txn.CreateChaincodeInvokeProposal(txh, request)
+	if err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("creating %s proposal failed", fcn))
+	}
+	return tp, nil
+}
+
+func checkLifecycleResponse(tpr *fab.TransactionProposalResponse) error {
+	if tpr.Status != http.StatusOK {
+		return errors.Errorf("bad status from %s (%d): %s", tpr.Endorser, tpr.Status, tpr.ProposalResponse.GetResponse().GetMessage())
+	}
+	return nil
+}
+
+// validateCollectionConfigs checks that the given collection configs are well formed
+func validateCollectionConfigs(collections []*common.CollectionConfig) error {
+	if len(collections) == 0 {
+		return errors.New("at least one collection config is required")
+	}
+
+	names := make(map[string]bool)
+	for _, collection := range collections {
+		config := collection.GetStaticCollectionConfig()
+		if config == nil {
+			return errors.New("only static collection configs are supported")
+		}
+		if config.Name == "" {
+			return errors.New("collection name is required")
+		}
+		if names[config.Name] {
+			return errors.Errorf("duplicate collection [%s]", config.Name)
+		}
+		names[config.Name] = true
+
+		if config.MemberOrgsPolicy.GetSignaturePolicy() == nil {
+			return errors.Errorf("member orgs signature policy is required for collection [%s]", config.Name)
+		}
+		if config.RequiredPeerCount < 0 {
+			return errors.Errorf("invalid required peer count [%d] for collection [%s]", config.RequiredPeerCount, config.Name)
+		}
+		if config.MaximumPeerCount < config.RequiredPeerCount {
+			return errors.Errorf("maximum peer count [%d] is less than required peer count [%d] for collection [%s]", config.MaximumPeerCount, config.RequiredPeerCount, config.Name)
+		}
+	}
+	return nil
+}
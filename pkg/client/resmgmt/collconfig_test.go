@@ -0,0 +1,235 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package resmgmt
+
+import (
+	reqContext "context"
+	"sync"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+	fcmocks "github.com/hyperledger/fabric-sdk-go/pkg/fab/mocks"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/common/cauthdsl"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/peer"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newCollectionConfig(name string, requiredPeerCount, maxPeerCount int32, policy *common.SignaturePolicyEnvelope) *common.CollectionConfig {
+	config := &common.StaticCollectionConfig{
+		Name:              name,
+		RequiredPeerCount: requiredPeerCount,
+		MaximumPeerCount:  maxPeerCount,
+	}
+	if policy != nil {
+		config.MemberOrgsPolicy = &common.CollectionPolicyConfig{
+			Payload: &common.CollectionPolicyConfig_SignaturePolicy{SignaturePolicy: policy},
+		}
+	}
+	return &common.CollectionConfig{Payload: &common.CollectionConfig_StaticCollectionConfig{StaticCollectionConfig: config}}
+}
+
+func TestUpdateCollectionConfig(t *testing.T) {
+	rc := setupDefaultResMgmtClient(t)
+	policy := cauthdsl.SignedByAnyMember([]string{"Org1MSP", "Org2MSP"})
+
+	err := rc.UpdateCollectionConfig("", "mycc", []*common.CollectionConfig{newCollectionConfig("coll1", 0, 1, policy)})
+	assert.NotNil(t, err, "Should have failed for empty channel ID")
+
+	err = rc.UpdateCollectionConfig("mychannel", "", []*common.CollectionConfig{newCollectionConfig("coll1", 0, 1, policy)})
+	assert.NotNil(t, err, "Should have failed for empty chaincode name")
+
+	invalidConfigs := [][]*common.CollectionConfig{
+		nil,
+		{{}},
+		{newCollectionConfig("", 0, 1, policy)},
+		{newCollectionConfig("coll1", 0, 1, policy), newCollectionConfig("coll1", 0, 1, policy)},
+		{newCollectionConfig("coll1", 0, 1, nil)},
+		{newCollectionConfig("coll1", -1, 1, policy)},
+		{newCollectionConfig("coll1", 2, 1, policy)},
+	}
+	for _, collections := range invalidConfigs {
+		err = rc.UpdateCollectionConfig("mychannel", "mycc", collections)
+		assert.NotNil(t, err, "Should have failed for invalid collection config")
+		assert.Contains(t, err.Error(), "collection config validation failed")
+	}
+
+	err = rc.UpdateCollectionConfig("mychannel", "mycc", []*common.CollectionConfig{newCollectionConfig("coll1", 0, 1, policy), newCollectionConfig("coll2", 1, 2, policy)})
+	assert.NotNil(t, err, "Should have failed since standalone collection updates are not supported")
+	assert.Equal(t, ErrCollectionUpdateNotSupported, errors.Cause(err))
+}
+
+func TestUpdateCollectionConfigV20(t *testing.T) {
+	ctx := setupTestContext("test", "Org1MSP")
+	ctx.SetEndpointConfig(getNetworkConfig(t))
+	ctx.ChannelProvider().(*fcmocks.MockChannelProvider).SetCapabilities(map[string][]string{applicationGroupKey: {v20Capability}})
+	infraProvider := &fcmocks.MockInfraProvider{}
+	infraProvider.SetCustomTransactor(&mockLifecycleTransactor{})
+	ctx.SetCustomInfraProvider(infraProvider)
+	rc := setupResMgmtClient(t, ctx)
+
+	policy := cauthdsl.SignedByAnyMember([]string{"Org1MSP", "Org2MSP"})
+	collections := []*common.CollectionConfig{newCollectionConfig("coll1", 0, 1, policy)}
+
+	peer1 := newMockLifecyclePeer("peer1", "Org1MSP", "mychannel")
+	peer2 := newMockLifecyclePeer("peer2", "Org2MSP", "mychannel")
+
+	err := rc.UpdateCollectionConfig("mychannel", "mycc", collections, WithTargets(peer2))
+	assert.NotNil(t, err, "Should have failed since none of the targets belong to the client's org")
+
+	err = rc.UpdateCollectionConfig("mychannel", "mycc", collections, WithTargets(peer1, peer2))
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{lifecycleQueryDefinition, lifecycleQueryInstalled, lifecycleApproveDefinitionForOrg, lifecycleCommitDefinition}, peer1.Calls())
+	assert.Equal(t, []string{lifecycleCommitDefinition}, peer2.Calls(), "only the client's org should approve the definition")
+
+	approveArgs := &approveChaincodeDefinitionArgs{}
+	require.NoError(t, proto.Unmarshal(peer1.Args(lifecycleApproveDefinitionForOrg), approveArgs))
+	assert.Equal(t, int64(4), approveArgs.Sequence)
+	assert.Equal(t, "v1", approveArgs.Version)
+	require.NotNil(t, approveArgs.Source.LocalPackage)
+	assert.Equal(t, "pkg1", approveArgs.Source.LocalPackage.PackageID)
+	assert.True(t, proto.Equal(&common.CollectionConfigPackage{Config: collections}, approveArgs.Collections))
+
+	commitArgs := &commitChaincodeDefinitionArgs{}
+	require.NoError(t, proto.Unmarshal(peer2.Args(lifecycleCommitDefinition), commitArgs))
+	assert.Equal(t, int64(4), commitArgs.Sequence)
+	assert.True(t, proto.Equal(&common.CollectionConfigPackage{Config: collections}, commitArgs.Collections))
+
+	peer1.installed = &queryInstalledChaincodesResult{}
+	err = rc.UpdateCollectionConfig("mychannel", "mycc", collections, WithTargets(peer1, peer2))
+	require.NoError(t, err)
+
+	require.NoError(t, proto.Unmarshal(peer1.Args(lifecycleApproveDefinitionForOrg), approveArgs))
+	assert.NotNil(t, approveArgs.Source.Unavailable, "expecting approval without a package if the package isn't installed")
+}
+
+// mockLifecycleTransactor sends the transaction proposals to the targets
+type mockLifecycleTransactor struct {
+	fcmocks.MockTransactor
+}
+
+func (t *mockLifecycleTransactor) SendTransactionProposal(proposal *fab.TransactionProposal, targets []fab.ProposalProcessor) ([]*fab.TransactionProposalResponse, error) {
+	proposalBytes, err := proto.Marshal(proposal.Proposal)
+	if err != nil {
+		return nil, err
+	}
+
+	var responses []*fab.TransactionProposalResponse
+	for _, target := range targets {
+		response, err := target.ProcessTransactionProposal(reqContext.Background(), fab.ProcessProposalRequest{SignedProposal: &pb.SignedProposal{ProposalBytes: proposalBytes}})
+		if err != nil {
+			return nil, err
+		}
+		responses = append(responses, response)
+	}
+	return responses, nil
+}
+
+// mockLifecyclePeer responds to the _lifecycle functions which are invoked by UpdateCollectionConfig
+type mockLifecyclePeer struct {
+	*fcmocks.MockPeer
+	lock      sync.Mutex
+	installed *queryInstalledChaincodesResult
+	calls     []string
+	args      map[string][]byte
+}
+
+func newMockLifecyclePeer(name, mspID, channelID string) *mockLifecyclePeer {
+	peer := fcmocks.NewMockPeer(name, name+".example.com:7051")
+	peer.MockMSP = mspID
+	return &mockLifecyclePeer{
+		MockPeer: peer,
+		installed: &queryInstalledChaincodesResult{
+			InstalledChaincodes: []*installedChaincode{{
+				PackageID: "pkg1",
+				Label:     "mycc_v1",
+				References: map[string]*installedChaincodeRefs{
+					channelID: {Chaincodes: []*installedChaincodeRef{{Name: "mycc", Version: "v1"}}},
+				},
+			}},
+		},
+		args: make(map[string][]byte),
+	}
+}
+
+func (p *mockLifecyclePeer) ProcessTransactionProposal(ctx reqContext.Context, request fab.ProcessProposalRequest) (*fab.TransactionProposalResponse, error) {
+	fcn, args, err := lifecycleInvocation(request.SignedProposal)
+	if err != nil {
+		return nil, err
+	}
+
+	p.lock.Lock()
+	p.calls = append(p.calls, fcn)
+	p.args[fcn] = args
+	p.lock.Unlock()
+
+	var result proto.Message
+	switch fcn {
+	case lifecycleQueryDefinition:
+		result = &queryChaincodeDefinitionResult{Sequence: 3, Version: "v1", ValidationParameter: []byte("policy")}
+	case lifecycleQueryInstalled:
+		result = p.installed
+	}
+
+	var payload []byte
+	if result != nil {
+		if payload, err = proto.Marshal(result); err != nil {
+			return nil, err
+		}
+	}
+
+	return &fab.TransactionProposalResponse{
+		Endorser: p.MockURL,
+		Status:   200,
+		ProposalResponse: &pb.ProposalResponse{
+			Response:    &pb.Response{Status: 200, Payload: payload},
+			Endorsement: &pb.Endorsement{Endorser: p.Endorser, Signature: []byte("signature")},
+		},
+	}, nil
+}
+
+func (p *mockLifecyclePeer) Calls() []string {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	calls := p.calls
+	p.calls = nil
+	return calls
+}
+
+func (p *mockLifecyclePeer) Args(fcn string) []byte {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return p.args[fcn]
+}
+
+// lifecycleInvocation returns the function and the args of a _lifecycle proposal
+func lifecycleInvocation(signedProposal *pb.SignedProposal) (string, []byte, error) {
+	prop := &pb.Proposal{}
+	if err := proto.Unmarshal(signedProposal.ProposalBytes, prop); err != nil {
+		return "", nil, err
+	}
+	payload := &pb.ChaincodeProposalPayload{}
+	if err := proto.Unmarshal(prop.Payload, payload); err != nil {
+		return "", nil, err
+	}
+	spec := &pb.ChaincodeInvocationSpec{}
+	if err := proto.Unmarshal(payload.Input, spec); err != nil {
+		return "", nil, err
+	}
+	if spec.ChaincodeSpec.ChaincodeId.Name != lifecycleCC {
+		return "", nil, errors.Errorf("unexpected chaincode [%s]", spec.ChaincodeSpec.ChaincodeId.Name)
+	}
+	args := spec.ChaincodeSpec.Input.Args
+	if len(args) != 2 {
+		return "", nil, errors.Errorf("unexpected number of args [%d]", len(args))
+	}
+	return string(args[0]), args[1], nil
+}
@@ -0,0 +1,118 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package resmgmt
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/common"
+	"github.com/pkg/errors"
+)
+
+const rootConfigGroup = "Channel"
+
+// configUpdateDiff returns a human readable summary of the changes in the given (serialized) config update.
+// Each line describes an element of the write set which is added (+), modified (~) or removed (-) with
+// respect to the read set.
+func configUpdateDiff(configUpdateBytes []byte) (string, error) {
+	configUpdate := &common.ConfigUpdate{}
+	if err := proto.Unmarshal(configUpdateBytes, configUpdate); err != nil {
+		return "", errors.Wrap(err, "unmarshal config update failed")
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "Config update for channel [%s]\n", configUpdate.ChannelId)
+	diffGroup(&buf, rootConfigGroup, configUpdate.ReadSet, configUpdate.WriteSet)
+	return buf.String(), nil
+}
+
+func diffGroup(buf *bytes.Buffer, path string, read, write *common.ConfigGroup) {
+	if write == nil {
+		return
+	}
+
+	if read == nil {
+		fmt.Fprintf(buf, "+ [Group] %s\n", path)
+		read = &common.ConfigGroup{}
+	} else if write.Version > read.Version {
+		fmt.Fprintf(buf, "~ [Group] %s (version %d -> %d)\n", path, read.Version, write.Version)
+		// The write set of a modified group contains all of its elements so elements which
+		// are only in the read set are removed
+		for _, name := range sortedKeys(read.Groups) {
+			if _, ok := write.Groups[name]; !ok {
+				fmt.Fprintf(buf, "- [Group] %s/%s\n", path, name)
+			}
+		}
+		for _, name := range sortedKeys(read.Values) {
+			if _, ok := write.Values[name]; !ok {
+				fmt.Fprintf(buf, "- [Value] %s/%s\n", path, name)
+			}
+		}
+		for _, name := range sortedKeys(read.Policies) {
+			if _, ok := write.Policies[name]; !ok {
+				fmt.Fprintf(buf, "- [Policy] %s/%s\n", path, name)
+			}
+		}
+	}
+
+	for _, name := range sortedKeys(write.Values) {
+		diffElement(buf, "Value", path+"/"+name, write.Values[name].Version, read.Values[name] != nil, versionOfValue(read.Values[name]))
+	}
+	for _, name := range sortedKeys(write.Policies) {
+		diffElement(buf, "Policy", path+"/"+name, write.Policies[name].Version, read.Policies[name] != nil, versionOfPolicy(read.Policies[name]))
+	}
+	for _, name := range sortedKeys(write.Groups) {
+		diffGroup(buf, path+"/"+name, read.Groups[name], write.Groups[name])
+	}
+}
+
+func diffElement(buf *bytes.Buffer, kind, path string, writeVersion uint64, inReadSet bool, readVersion uint64) {
+	if !inReadSet {
+		fmt.Fprintf(buf, "+ [%s] %s\n", kind, path)
+		return
+	}
+	if writeVersion > readVersion {
+		fmt.Fprintf(buf, "~ [%s] %s (version %d -> %d)\n", kind, path, readVersion, writeVersion)
+	}
+}
+
+func versionOfValue(v *common.ConfigValue) uint64 {
+	if v == nil {
+		return 0
+	}
+	return v.Version
+}
+
+func versionOfPolicy(p *common.ConfigPolicy) uint64 {
+	if p == nil {
+		return 0
+	}
+	return p.Version
+}
+
+func sortedKeys(m interface{}) []string {
+	var keys []string
+	switch elements := m.(type) {
+	case map[string]*common.ConfigGroup:
+		for k := range elements {
+			keys = append(keys, k)
+		}
+	case map[string]*common.ConfigValue:
+		for k := range elements {
+			keys = append(keys, k)
+		}
+	case map[string]*common.ConfigPolicy:
+		for k := range elements {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,69 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package resmgmt
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfigUpdateDiff(t *testing.T) {
+	configUpdate := &common.ConfigUpdate{
+		ChannelId: "mychannel",
+		ReadSet: &common.ConfigGroup{
+			Groups: map[string]*common.ConfigGroup{
+				"Application": {
+					Version: 1,
+					Groups: map[string]*common.ConfigGroup{
+						"Org1MSP": {},
+						"Org2MSP": {},
+					},
+					Values: map[string]*common.ConfigValue{
+						"Capabilities": {Version: 2},
+					},
+				},
+			},
+		},
+		WriteSet: &common.ConfigGroup{
+			Groups: map[string]*common.ConfigGroup{
+				"Application": {
+					Version: 2,
+					Groups: map[string]*common.ConfigGroup{
+						"Org1MSP": {},
+						"Org3MSP": {
+							Policies: map[string]*common.ConfigPolicy{
+								"Admins": {},
+							},
+						},
+					},
+					Values: map[string]*common.ConfigValue{
+						"Capabilities": {Version: 3},
+					},
+				},
+			},
+		},
+	}
+	configUpdateBytes, err := proto.Marshal(configUpdate)
+	assert.Nil(t, err, "marshal config update failed")
+
+	diff, err := configUpdateDiff(configUpdateBytes)
+	assert.Nil(t, err, "config update diff failed")
+
+	expected := "Config update for channel [mychannel]\n" +
+		"~ [Group] Channel/Application (version 1 -> 2)\n" +
+		"- [Group] Channel/Application/Org2MSP\n" +
+		"~ [Value] Channel/Application/Capabilities (version 2 -> 3)\n" +
+		"+ [Group] Channel/Application/Org3MSP\n" +
+		"+ [Policy] Channel/Application/Org3MSP/Admins\n"
+	assert.Equal(t, expected, diff)
+
+	_, err = configUpdateDiff([]byte("invalid"))
+	assert.NotNil(t, err, "expecting error for invalid config update")
+}
@@ -0,0 +1,155 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package resmgmt
+
+import (
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/common"
+)
+
+// The pinned Fabric protos predate the new chaincode lifecycle (enabled by the V2_0 application
+// capability) so the messages of the _lifecycle system chaincode which are needed to update a
+// chaincode definition are declared here. They are wire compatible with peer/lifecycle/lifecycle.proto.
+
+const (
+	lifecycleCC                      = "_lifecycle"
+	lifecycleQueryDefinition         = "QueryChaincodeDefinition"
+	lifecycleQueryInstalled          = "QueryInstalledChaincodes"
+	lifecycleApproveDefinitionForOrg = "ApproveChaincodeDefinitionForMyOrg"
+	lifecycleCommitDefinition        = "CommitChaincodeDefinition"
+)
+
+// queryChaincodeDefinitionArgs is the argument of QueryChaincodeDefinition
+type queryChaincodeDefinitionArgs struct {
+	Name string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+}
+
+func (m *queryChaincodeDefinitionArgs) Reset()         { *m = queryChaincodeDefinitionArgs{} }
+func (m *queryChaincodeDefinitionArgs) String() string { return proto.CompactTextString(m) }
+func (*queryChaincodeDefinitionArgs) ProtoMessage()    {}
+
+// queryChaincodeDefinitionResult is the committed definition of a chaincode
+type queryChaincodeDefinitionResult struct {
+	Sequence            int64                           `protobuf:"varint,1,opt,name=sequence" json:"sequence,omitempty"`
+	Version             string                          `protobuf:"bytes,2,opt,name=version" json:"version,omitempty"`
+	EndorsementPlugin   string                          `protobuf:"bytes,3,opt,name=endorsement_plugin,json=endorsementPlugin" json:"endorsement_plugin,omitempty"`
+	ValidationPlugin    string                          `protobuf:"bytes,4,opt,name=validation_plugin,json=validationPlugin" json:"validation_plugin,omitempty"`
+	ValidationParameter []byte                          `protobuf:"bytes,5,opt,name=validation_parameter,json=validationParameter,proto3" json:"validation_parameter,omitempty"`
+	Collections         *common.CollectionConfigPackage `protobuf:"bytes,6,opt,name=collections" json:"collections,omitempty"`
+	InitRequired        bool                            `protobuf:"varint,7,opt,name=init_required,json=initRequired" json:"init_required,omitempty"`
+}
+
+func (m *queryChaincodeDefinitionResult) Reset()         { *m = queryChaincodeDefinitionResult{} }
+func (m *queryChaincodeDefinitionResult) String() string { return proto.CompactTextString(m) }
+func (*queryChaincodeDefinitionResult) ProtoMessage()    {}
+
+// queryInstalledChaincodesArgs is the (empty) argument of QueryInstalledChaincodes
+type queryInstalledChaincodesArgs struct{}
+
+func (m *queryInstalledChaincodesArgs) Reset()         { *m = queryInstalledChaincodesArgs{} }
+func (m *queryInstalledChaincodesArgs) String() string { return proto.CompactTextString(m) }
+func (*queryInstalledChaincodesArgs) ProtoMessage()    {}
+
+// queryInstalledChaincodesResult lists the chaincode packages installed on a peer
+type queryInstalledChaincodesResult struct {
+	InstalledChaincodes []*installedChaincode `protobuf:"bytes,1,rep,name=installed_chaincodes,json=installedChaincodes" json:"installed_chaincodes,omitempty"`
+}
+
+func (m *queryInstalledChaincodesResult) Reset()         { *m = queryInstalledChaincodesResult{} }
+func (m *queryInstalledChaincodesResult) String() string { return proto.CompactTextString(m) }
+func (*queryInstalledChaincodesResult) ProtoMessage()    {}
+
+// installedChaincode is an installed chaincode package along with the chaincode
+// definitions (keyed by channel) which reference the package
+type installedChaincode struct {
+	PackageID  string                             `protobuf:"bytes,1,opt,name=package_id,json=packageId" json:"package_id,omitempty"`
+	Label      string                             `protobuf:"bytes,2,opt,name=label" json:"label,omitempty"`
+	References map[string]*installedChaincodeRefs `protobuf:"bytes,3,rep,name=references" json:"references,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+}
+
+func (m *installedChaincode) Reset()         { *m = installedChaincode{} }
+func (m *installedChaincode) String() string { return proto.CompactTextString(m) }
+func (*installedChaincode) ProtoMessage()    {}
+
+// installedChaincodeRefs are the chaincode definitions of a channel which reference an installed package
+type installedChaincodeRefs struct {
+	Chaincodes []*installedChaincodeRef `protobuf:"bytes,1,rep,name=chaincodes" json:"chaincodes,omitempty"`
+}
+
+func (m *installedChaincodeRefs) Reset()         { *m = installedChaincodeRefs{} }
+func (m *installedChaincodeRefs) String() string { return proto.CompactTextString(m) }
+func (*installedChaincodeRefs) ProtoMessage()    {}
+
+// installedChaincodeRef is a chaincode definition which references an installed package
+type installedChaincodeRef struct {
+	Name    string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	Version string `protobuf:"bytes,2,opt,name=version" json:"version,omitempty"`
+}
+
+func (m *installedChaincodeRef) Reset()         { *m = installedChaincodeRef{} }
+func (m *installedChaincodeRef) String() string { return proto.CompactTextString(m) }
+func (*installedChaincodeRef) ProtoMessage()    {}
+
+// chaincodeSource is the package which the org uses for an approved chaincode definition. Exactly one
+// of the fields is set (the fields make up the "Type" oneof of lifecycle.ChaincodeSource).
+type chaincodeSource struct {
+	Unavailable  *chaincodeSourceUnavailable `protobuf:"bytes,1,opt,name=unavailable" json:"unavailable,omitempty"`
+	LocalPackage *chaincodeSourceLocal       `protobuf:"bytes,2,opt,name=local_package,json=localPackage" json:"local_package,omitempty"`
+}
+
+func (m *chaincodeSource) Reset()         { *m = chaincodeSource{} }
+func (m *chaincodeSource) String() string { return proto.CompactTextString(m) }
+func (*chaincodeSource) ProtoMessage()    {}
+
+// chaincodeSourceUnavailable indicates that the org has no package for the chaincode definition
+type chaincodeSourceUnavailable struct{}
+
+func (m *chaincodeSourceUnavailable) Reset()         { *m = chaincodeSourceUnavailable{} }
+func (m *chaincodeSourceUnavailable) String() string { return proto.CompactTextString(m) }
+func (*chaincodeSourceUnavailable) ProtoMessage()    {}
+
+// chaincodeSourceLocal is an installed package
+type chaincodeSourceLocal struct {
+	PackageID string `protobuf:"bytes,1,opt,name=package_id,json=packageId" json:"package_id,omitempty"`
+}
+
+func (m *chaincodeSourceLocal) Reset()         { *m = chaincodeSourceLocal{} }
+func (m *chaincodeSourceLocal) String() string { return proto.CompactTextString(m) }
+func (*chaincodeSourceLocal) ProtoMessage()    {}
+
+// approveChaincodeDefinitionArgs is the argument of ApproveChaincodeDefinitionForMyOrg
+type approveChaincodeDefinitionArgs struct {
+	Sequence            int64                           `protobuf:"varint,1,opt,name=sequence" json:"sequence,omitempty"`
+	Name                string                          `protobuf:"bytes,2,opt,name=name" json:"name,omitempty"`
+	Version             string                          `protobuf:"bytes,3,opt,name=version" json:"version,omitempty"`
+	EndorsementPlugin   string                          `protobuf:"bytes,4,opt,name=endorsement_plugin,json=endorsementPlugin" json:"endorsement_plugin,omitempty"`
+	ValidationPlugin    string                          `protobuf:"bytes,5,opt,name=validation_plugin,json=validationPlugin" json:"validation_plugin,omitempty"`
+	ValidationParameter []byte                          `protobuf:"bytes,6,opt,name=validation_parameter,json=validationParameter,proto3" json:"validation_parameter,omitempty"`
+	Collections         *common.CollectionConfigPackage `protobuf:"bytes,7,opt,name=collections" json:"collections,omitempty"`
+	InitRequired        bool                            `protobuf:"varint,8,opt,name=init_required,json=initRequired" json:"init_required,omitempty"`
+	Source              *chaincodeSource                `protobuf:"bytes,9,opt,name=source" json:"source,omitempty"`
+}
+
+func (m *approveChaincodeDefinitionArgs) Reset()         { *m = approveChaincodeDefinitionArgs{} }
+func (m *approveChaincodeDefinitionArgs) String() string { return proto.CompactTextString(m) }
+func (*approveChaincodeDefinitionArgs) ProtoMessage()    {}
+
+// commitChaincodeDefinitionArgs is the argument of CommitChaincodeDefinition
+type commitChaincodeDefinitionArgs struct {
+	Sequence            int64                           `protobuf:"varint,1,opt,name=sequence" json:"sequence,omitempty"`
+	Name                string                          `protobuf:"bytes,2,opt,name=name" json:"name,omitempty"`
+	Version             string                          `protobuf:"bytes,3,opt,name=version" json:"version,omitempty"`
+	EndorsementPlugin   string                          `protobuf:"bytes,4,opt,name=endorsement_plugin,json=endorsementPlugin" json:"endorsement_plugin,omitempty"`
+	ValidationPlugin    string                          `protobuf:"bytes,5,opt,name=validation_plugin,json=validationPlugin" json:"validation_plugin,omitempty"`
+	ValidationParameter []byte                          `protobuf:"bytes,6,opt,name=validation_parameter,json=validationParameter,proto3" json:"validation_parameter,omitempty"`
+	Collections         *common.CollectionConfigPackage `protobuf:"bytes,7,opt,name=collections" json:"collections,omitempty"`
+	InitRequired        bool                            `protobuf:"varint,8,opt,name=init_required,json=initRequired" json:"init_required,omitempty"`
+}
+
+func (m *commitChaincodeDefinitionArgs) Reset()         { *m = commitChaincodeDefinitionArgs{} }
+func (m *commitChaincodeDefinitionArgs) String() string { return proto.CompactTextString(m) }
+func (*commitChaincodeDefinitionArgs) ProtoMessage()    {}
@@ -116,6 +116,49 @@ func WithParentContext(parentContext reqContext.Context) RequestOption {
 	}
 }
 
+// WithInstallProgress specifies a callback which is invoked as the chaincode package is sent to each
+// peer by InstallCC. Note that the peer accepts the package in a single install proposal (it doesn't
+// support chunked streaming), so progress is reported per peer: once before the proposal is sent and
+// once the peer has accepted it.
+func WithInstallProgress(handler InstallProgressHandler) RequestOption {
+	return func(ctx context.Client, o *requestOptions) error {
+		o.InstallProgress = handler
+		return nil
+	}
+}
+
+// WithMaxConcurrency limits the number of requests which are sent to peers concurrently.
+func WithMaxConcurrency(maxConcurrency int) RequestOption {
+	return func(ctx context.Client, o *requestOptions) error {
+		if maxConcurrency <= 0 {
+			return errors.Errorf("invalid max concurrency [%d]", maxConcurrency)
+		}
+		o.MaxConcurrency = maxConcurrency
+		return nil
+	}
+}
+
+// WithDryRun builds and signs the config update of a SaveChannel request without sending it to the orderer.
+// The serialized envelope and a human readable diff of the update are returned in the response.
+func WithDryRun() RequestOption {
+	return func(ctx context.Client, o *requestOptions) error {
+		o.DryRun = true
+		return nil
+	}
+}
+
+// WithCommitWait makes SaveChannel wait, up to the given timeout, until the config update has been
+// committed by the orderer. The number of the config block is returned in the response.
+func WithCommitWait(timeout time.Duration) RequestOption {
+	return func(ctx context.Client, o *requestOptions) error {
+		if timeout <= 0 {
+			return errors.Errorf("invalid commit wait timeout [%s]", timeout)
+		}
+		o.CommitWaitTimeout = timeout
+		return nil
+	}
+}
+
 // WithRetry sets retry options.
 func WithRetry(retryOpt retry.Opts) RequestOption {
 	return func(ctx context.Client, o *requestOptions) error {
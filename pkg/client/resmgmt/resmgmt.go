@@ -20,13 +20,17 @@ SPDX-License-Identifier: Apache-2.0
 package resmgmt
 
 import (
+	"bytes"
 	reqContext "context"
+	"fmt"
 	"io"
 	"io/ioutil"
 	"math/rand"
 	"os"
+	"sync"
 	"time"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric-sdk-go/pkg/common/errors/retry"
 
 	"github.com/hyperledger/fabric-sdk-go/pkg/client/common/verifier"
@@ -59,11 +63,22 @@ type InstallCCRequest struct {
 
 // InstallCCResponse contains install chaincode response status
 type InstallCCResponse struct {
-	Target string
-	Status int32
-	Info   string
+	Target      string
+	Status      int32
+	Info        string
+	ChaincodeID []byte // ID of the chaincode package, as reported by the peer if already installed (see ComputeChaincodeID)
 }
 
+// InstallProgress reports the progress of sending a chaincode package to a peer
+type InstallProgress struct {
+	Target     string // URL of the peer
+	BytesSent  int64  // number of bytes of the install proposal sent to the peer
+	TotalBytes int64  // total number of bytes of the install proposal
+}
+
+// InstallProgressHandler is invoked as a chaincode package is sent to each peer
+type InstallProgressHandler func(progress InstallProgress)
+
 // InstantiateCCRequest contains instantiate chaincode request parameters
 type InstantiateCCRequest struct {
 	Name       string
@@ -96,12 +111,16 @@ type UpgradeCCResponse struct {
 
 //requestOptions contains options for operations performed by ResourceMgmtClient
 type requestOptions struct {
-	Targets       []fab.Peer                        // target peers
-	TargetFilter  fab.TargetFilter                  // target filter
-	Orderer       fab.Orderer                       // use specific orderer
-	Timeouts      map[fab.TimeoutType]time.Duration //timeout options for resmgmt operations
-	ParentContext reqContext.Context                //parent grpc context for resmgmt operations
-	Retry         retry.Opts
+	Targets           []fab.Peer                        // target peers
+	TargetFilter      fab.TargetFilter                  // target filter
+	Orderer           fab.Orderer                       // use specific orderer
+	Timeouts          map[fab.TimeoutType]time.Duration //timeout options for resmgmt operations
+	ParentContext     reqContext.Context                //parent grpc context for resmgmt operations
+	Retry             retry.Opts
+	MaxConcurrency    int                    // maximum number of concurrent requests to peers (0 for unlimited)
+	DryRun            bool                   // build the request without sending it to the orderer
+	CommitWaitTimeout time.Duration          // wait for config updates to be committed (0 for no wait)
+	InstallProgress   InstallProgressHandler // progress callback for chaincode installation
 }
 
 //SaveChannelRequest holds parameters for save channel request
@@ -116,6 +135,10 @@ type SaveChannelRequest struct {
 // SaveChannelResponse contains response parameters for save channel
 type SaveChannelResponse struct {
 	TransactionID fab.TransactionID
+	Orderer       string // URL of the orderer which accepted the config update
+	BlockNumber   uint64 // number of the config block which committed the update (commit wait only)
+	Envelope      []byte // serialized, signed config update envelope (dry run only)
+	Diff          string // human readable summary of the config update (dry run only)
 }
 
 //RequestOption func for each Opts argument
@@ -123,6 +146,9 @@ type RequestOption func(ctx context.Client, opts *requestOptions) error
 
 var logger = logging.NewLogger("fabsdk/client")
 
+// commitPollInterval is the interval at which the orderer is polled while waiting for a config update to be committed
+const commitPollInterval = 500 * time.Millisecond
+
 // Client enables managing resources in Fabric network.
 type Client struct {
 	ctx              context.Client
@@ -333,25 +359,38 @@ func (rc *Client) calculateTargets(targets []fab.Peer, filter fab.TargetFilter)
 
 // isChaincodeInstalled verify if chaincode is installed on peer
 func (rc *Client) isChaincodeInstalled(reqCtx reqContext.Context, req InstallCCRequest, peer fab.ProposalProcessor, retryOpts retry.Opts) (bool, error) {
+	chaincode, err := rc.installedChaincode(reqCtx, req, peer, retryOpts)
+	if err != nil {
+		return false, err
+	}
+	return chaincode != nil, nil
+}
+
+// installedChaincode returns the info of the chaincode installed on peer (nil if not installed)
+func (rc *Client) installedChaincode(reqCtx reqContext.Context, req InstallCCRequest, peer fab.ProposalProcessor, retryOpts retry.Opts) (*pb.ChaincodeInfo, error) {
 
 	chaincodeQueryResponse, err := resource.QueryInstalledChaincodes(reqCtx, peer, resource.WithRetry(retryOpts))
 	if err != nil {
-		return false, err
+		return nil, err
 	}
 
 	logger.Debugf("isChaincodeInstalled: %v", chaincodeQueryResponse)
 
 	for _, chaincode := range chaincodeQueryResponse.Chaincodes {
 		if chaincode.Name == req.Name && chaincode.Version == req.Version && chaincode.Path == req.Path {
-			return true, nil
+			return chaincode, nil
 		}
 	}
 
-	return false, nil
+	return nil, nil
 }
 
 // InstallCC allows administrators to install chaincode onto the filesystem of a peer.
 // If peer(s) are not specified in options it will default to all peers that belong to admin's MSP.
+// The progress of sending the package to each peer may be observed using the WithInstallProgress
+// request option. Each response holds the ID of the package (see ComputeChaincodeID). For peers on which
+// the chaincode is already installed, the response holds the ID reported by the peer and its Info notes if
+// the installed package differs from the requested one.
 //  Parameters:
 //  req holds info about mandatory chaincode name, path, version and policy
 //  options holds optional request options
@@ -395,7 +434,9 @@ func (rc *Client) InstallCC(req InstallCCRequest, options ...RequestOption) ([]I
 		return nil, errors.WithStack(status.New(status.ClientStatus, status.NoPeersFound.ToInt32(), "no targets available", nil))
 	}
 
-	responses, newTargets, errs := rc.adjustTargets(targets, req, opts.Retry, parentReqCtx)
+	chaincodeID := ComputeChaincodeID(req.Name, req.Version, req.Package)
+
+	responses, newTargets, errs := rc.adjustTargets(targets, req, chaincodeID, opts.Retry, parentReqCtx)
 
 	if len(newTargets) == 0 {
 		// CC is already installed on all targets and/or
@@ -406,7 +447,7 @@ func (rc *Client) InstallCC(req InstallCCRequest, options ...RequestOption) ([]I
 	reqCtx, cancel := contextImpl.NewRequest(rc.ctx, contextImpl.WithTimeoutType(fab.ResMgmt), contextImpl.WithParent(parentReqCtx))
 	defer cancel()
 
-	responses = rc.sendIntallCCRequest(req, reqCtx, newTargets, responses)
+	responses = rc.sendIntallCCRequest(req, chaincodeID, opts.InstallProgress, reqCtx, newTargets, responses)
 
 	if err != nil {
 		installErrs, ok := err.(multi.Errors)
@@ -420,19 +461,23 @@ func (rc *Client) InstallCC(req InstallCCRequest, options ...RequestOption) ([]I
 	return responses, errs.ToError()
 }
 
-func (rc *Client) sendIntallCCRequest(req InstallCCRequest, reqCtx reqContext.Context, newTargets []fab.Peer, responses []InstallCCResponse) []InstallCCResponse {
+func (rc *Client) sendIntallCCRequest(req InstallCCRequest, chaincodeID []byte, progress InstallProgressHandler, reqCtx reqContext.Context, newTargets []fab.Peer, responses []InstallCCResponse) []InstallCCResponse {
 	icr := api.InstallChaincodeRequest{Name: req.Name, Path: req.Path, Version: req.Version, Package: req.Package}
-	transactionProposalResponse, _, _ := resource.InstallChaincode(reqCtx, icr, peer.PeersToTxnProcessors(newTargets))
+	targets := peer.PeersToTxnProcessors(newTargets)
+	if progress != nil {
+		targets = withInstallProgress(newTargets, progress)
+	}
+	transactionProposalResponse, _, _ := resource.InstallChaincode(reqCtx, icr, targets)
 	for _, v := range transactionProposalResponse {
 		logger.Debugf("Install chaincode '%s' endorser '%s' returned ProposalResponse status:%v", req.Name, v.Endorser, v.Status)
 
-		response := InstallCCResponse{Target: v.Endorser, Status: v.Status}
+		response := InstallCCResponse{Target: v.Endorser, Status: v.Status, ChaincodeID: chaincodeID}
 		responses = append(responses, response)
 	}
 	return responses
 }
 
-func (rc *Client) adjustTargets(targets []fab.Peer, req InstallCCRequest, retry retry.Opts, parentReqCtx reqContext.Context) ([]InstallCCResponse, []fab.Peer, multi.Errors) {
+func (rc *Client) adjustTargets(targets []fab.Peer, req InstallCCRequest, chaincodeID []byte, retry retry.Opts, parentReqCtx reqContext.Context) ([]InstallCCResponse, []fab.Peer, multi.Errors) {
 	errs := multi.Errors{}
 
 	responses := make([]InstallCCResponse, 0)
@@ -443,16 +488,15 @@ func (rc *Client) adjustTargets(targets []fab.Peer, req InstallCCRequest, retry
 		reqCtx, cancel := contextImpl.NewRequest(rc.ctx, contextImpl.WithTimeoutType(fab.PeerResponse), contextImpl.WithParent(parentReqCtx))
 		defer cancel()
 
-		installed, err1 := rc.isChaincodeInstalled(reqCtx, req, target, retry)
+		installed, err1 := rc.installedChaincode(reqCtx, req, target, retry)
 		if err1 != nil {
 			// Add to errors with unable to verify error message
 			errs = append(errs, errors.Errorf("unable to verify if cc is installed on %s. Got error: %s", target.URL(), err1.Error()))
 			continue
 		}
-		if installed {
+		if installed != nil {
 			// Nothing to do - add info message to response
-			response := InstallCCResponse{Target: target.URL(), Info: "already installed"}
-			responses = append(responses, response)
+			responses = append(responses, installedCCResponse(target.URL(), installed, chaincodeID))
 		} else {
 			// Not installed - add for processing
 			newTargets = append(newTargets, target)
@@ -463,6 +507,20 @@ func (rc *Client) adjustTargets(targets []fab.Peer, req InstallCCRequest, retry
 
 }
 
+// installedCCResponse returns the response for a target on which the chaincode is already installed. The response
+// holds the package ID reported by the peer and notes if the installed package differs from the requested one.
+func installedCCResponse(target string, installed *pb.ChaincodeInfo, chaincodeID []byte) InstallCCResponse {
+	if len(installed.Id) == 0 {
+		return InstallCCResponse{Target: target, Info: "already installed", ChaincodeID: chaincodeID}
+	}
+	response := InstallCCResponse{Target: target, Info: "already installed", ChaincodeID: installed.Id}
+	if !bytes.Equal(installed.Id, chaincodeID) {
+		logger.Warnf("cc is already installed on %s with a different package (ID %x instead of %x)", target, installed.Id, chaincodeID)
+		response.Info = "already installed with a different package"
+	}
+	return response
+}
+
 func checkRequiredInstallCCParams(req InstallCCRequest) error {
 	if req.Name == "" || req.Version == "" || req.Path == "" || req.Package == nil {
 		return errors.New("Chaincode name, version, path and chaincode package are required")
@@ -540,6 +598,42 @@ func (rc *Client) QueryInstalledChaincodes(options ...RequestOption) (*pb.Chainc
 	return resource.QueryInstalledChaincodes(reqCtx, opts.Targets[0], resource.WithRetry(opts.Retry))
 }
 
+// QueryInstalledChaincodesOnPeers concurrently queries the installed chaincodes on the given peers.
+// The number of concurrent queries may be limited using the WithMaxConcurrency request option and
+// all queries are subject to the deadline of the request context.
+//  Parameters:
+//  peers are the peers to query
+//  options hold optional request options
+//
+//  Returns:
+//  the installed chaincodes keyed by peer URL and, if any of the queries failed, an error
+//  which aggregates the errors of the failed peers (the results of the successful peers are still returned)
+func (rc *Client) QueryInstalledChaincodesOnPeers(peers []fab.Peer, options ...RequestOption) (map[string]*pb.ChaincodeQueryResponse, error) {
+	if len(peers) == 0 {
+		return nil, errors.New("at least one peer is required")
+	}
+
+	opts, err := rc.prepareRequestOpts(options...)
+	if err != nil {
+		return nil, err
+	}
+
+	reqCtx, cancel := rc.createRequestContext(opts, fab.PeerResponse)
+	defer cancel()
+
+	results, err := queryOnPeers(reqCtx, peers, opts.MaxConcurrency, "query installed chaincodes",
+		func(peer fab.Peer) (interface{}, error) {
+			return resource.QueryInstalledChaincodes(reqCtx, peer, resource.WithRetry(opts.Retry))
+		},
+	)
+
+	responses := make(map[string]*pb.ChaincodeQueryResponse)
+	for url, result := range results {
+		responses[url] = result.(*pb.ChaincodeQueryResponse)
+	}
+	return responses, err
+}
+
 // QueryInstantiatedChaincodes queries the instantiated chaincodes on a peer for specific channel. If peer is not specified in options it will query random peer on this channel.
 //  Parameters:
 //  channel is manadatory channel name
@@ -637,6 +731,90 @@ func (rc *Client) QueryChannels(options ...RequestOption) (*pb.ChannelQueryRespo
 
 }
 
+// QueryChannelsOnPeers concurrently queries the names of the channels that each of the given peers has joined.
+// The number of concurrent queries may be limited using the WithMaxConcurrency request option and
+// all queries are subject to the deadline of the request context.
+//  Parameters:
+//  peers are the peers to query
+//  options hold optional request options
+//
+//  Returns:
+//  the joined channels keyed by peer URL and, if any of the queries failed (or didn't complete before
+//  the deadline), an error which aggregates the errors of the failed peers (the results of the successful
+//  peers are still returned)
+func (rc *Client) QueryChannelsOnPeers(peers []fab.Peer, options ...RequestOption) (map[string][]string, error) {
+	if len(peers) == 0 {
+		return nil, errors.New("at least one peer is required")
+	}
+
+	opts, err := rc.prepareRequestOpts(options...)
+	if err != nil {
+		return nil, err
+	}
+
+	reqCtx, cancel := rc.createRequestContext(opts, fab.PeerResponse)
+	defer cancel()
+
+	results, err := queryOnPeers(reqCtx, peers, opts.MaxConcurrency, "query channels",
+		func(peer fab.Peer) (interface{}, error) {
+			return resource.QueryChannels(reqCtx, peer, resource.WithRetry(opts.Retry))
+		},
+	)
+
+	channels := make(map[string][]string)
+	for url, result := range results {
+		channelIDs := []string{}
+		for _, channel := range result.(*pb.ChannelQueryResponse).Channels {
+			channelIDs = append(channelIDs, channel.ChannelId)
+		}
+		channels[url] = channelIDs
+	}
+	return channels, err
+}
+
+// queryOnPeers concurrently invokes the given query on each of the given peers, at most maxConcurrency
+// (or all if zero) at a time. The results of the successful queries are returned keyed by peer URL along
+// with an error which aggregates the errors of the failed queries.
+func queryOnPeers(reqCtx reqContext.Context, peers []fab.Peer, maxConcurrency int, description string, query func(peer fab.Peer) (interface{}, error)) (map[string]interface{}, error) {
+	if maxConcurrency <= 0 || maxConcurrency > len(peers) {
+		maxConcurrency = len(peers)
+	}
+	semaphore := make(chan struct{}, maxConcurrency)
+
+	var mutex sync.Mutex
+	var wg sync.WaitGroup
+	results := make(map[string]interface{})
+	errs := multi.Errors{}
+
+	for _, peer := range peers {
+		wg.Add(1)
+		go func(peer fab.Peer) {
+			defer wg.Done()
+
+			var result interface{}
+			var err error
+			select {
+			case semaphore <- struct{}{}:
+				result, err = query(peer)
+				<-semaphore
+			case <-reqCtx.Done():
+				err = reqCtx.Err()
+			}
+
+			mutex.Lock()
+			defer mutex.Unlock()
+			if err != nil {
+				errs = append(errs, errors.WithMessage(err, fmt.Sprintf("%s failed on peer [%s]", description, peer.URL())))
+				return
+			}
+			results[peer.URL()] = result
+		}(peer)
+	}
+	wg.Wait()
+
+	return results, errs.ToError()
+}
+
 // validateSendCCProposal
 func (rc *Client) getCCProposalTargets(channelID string, req InstantiateCCRequest, opts requestOptions) ([]fab.Peer, error) {
 
@@ -863,6 +1041,10 @@ func (rc *Client) SaveChannel(req SaveChannelRequest, options ...RequestOption)
 		return SaveChannelResponse{}, errors.WithMessage(err, "extracting channel config failed")
 	}
 
+	if opts.DryRun {
+		return rc.saveChannelDryRun(req, chConfig)
+	}
+
 	orderer, err := rc.requestOrderer(&opts, req.ChannelID)
 	if err != nil {
 		return SaveChannelResponse{}, errors.WithMessage(err, "failed to find orderer for request")
@@ -888,7 +1070,98 @@ func (rc *Client) SaveChannel(req SaveChannelRequest, options ...RequestOption)
 		return SaveChannelResponse{}, errors.WithMessage(err, "create channel failed")
 	}
 
-	return SaveChannelResponse{TransactionID: txID}, nil
+	resp := SaveChannelResponse{TransactionID: txID, Orderer: orderer.URL()}
+
+	if opts.CommitWaitTimeout > 0 {
+		resp.BlockNumber, err = rc.waitForConfigCommit(opts, req.ChannelID, orderer, txID)
+		if err != nil {
+			return resp, err
+		}
+	}
+
+	return resp, nil
+}
+
+// waitForConfigCommit polls the orderer until the last config block of the channel contains
+// the config update with the given transaction ID and returns the number of that block
+func (rc *Client) waitForConfigCommit(opts requestOptions, channelID string, orderer fab.Orderer, txID fab.TransactionID) (uint64, error) {
+
+	reqCtx, cancel := contextImpl.NewRequest(rc.ctx, contextImpl.WithTimeout(opts.CommitWaitTimeout), contextImpl.WithParent(opts.ParentContext))
+	defer cancel()
+
+	for {
+		block, err := resource.LastConfigFromOrderer(reqCtx, channelID, orderer)
+		if err != nil {
+			logger.Debugf("retrieving last config block of channel [%s] failed: %s", channelID, err)
+		} else if configUpdateTxID(block) == txID {
+			logger.Debugf("config update [%s] committed in block [%d] of channel [%s]", txID, block.Header.Number, channelID)
+			return block.Header.Number, nil
+		}
+
+		select {
+		case <-reqCtx.Done():
+			return 0, errors.Errorf("timed out waiting for config update [%s] to be committed on channel [%s]", txID, channelID)
+		case <-time.After(commitPollInterval):
+		}
+	}
+}
+
+// configUpdateTxID returns the transaction ID of the config update which produced the given config block
+func configUpdateTxID(block *common.Block) fab.TransactionID {
+	if block.Data == nil || len(block.Data.Data) == 0 {
+		return fab.EmptyTransactionID
+	}
+
+	configEnvelope, err := resource.CreateConfigEnvelope(block.Data.Data[0])
+	if err != nil || configEnvelope.LastUpdate == nil {
+		return fab.EmptyTransactionID
+	}
+
+	payload := &common.Payload{}
+	if err := proto.Unmarshal(configEnvelope.LastUpdate.Payload, payload); err != nil || payload.Header == nil {
+		return fab.EmptyTransactionID
+	}
+
+	channelHeader := &common.ChannelHeader{}
+	if err := proto.Unmarshal(payload.Header.ChannelHeader, channelHeader); err != nil {
+		return fab.EmptyTransactionID
+	}
+	return fab.TransactionID(channelHeader.TxId)
+}
+
+// saveChannelDryRun collects the config signatures and builds the signed config update envelope
+// but doesn't send it to the orderer
+func (rc *Client) saveChannelDryRun(req SaveChannelRequest, chConfig []byte) (SaveChannelResponse, error) {
+
+	diff, err := configUpdateDiff(chConfig)
+	if err != nil {
+		return SaveChannelResponse{}, err
+	}
+
+	configSignatures, err := rc.getConfigSignatures(req, chConfig)
+	if err != nil {
+		return SaveChannelResponse{}, err
+	}
+
+	request := api.CreateChannelRequest{
+		Name:       req.ChannelID,
+		Config:     chConfig,
+		Signatures: configSignatures,
+	}
+
+	envelope, txID, err := resource.CreateChannelEnvelope(rc.ctx, request)
+	if err != nil {
+		return SaveChannelResponse{}, errors.WithMessage(err, "creating config update envelope failed")
+	}
+
+	envelopeBytes, err := proto.Marshal(&common.Envelope{Payload: envelope.Payload, Signature: envelope.Signature})
+	if err != nil {
+		return SaveChannelResponse{}, errors.Wrap(err, "marshal config update envelope failed")
+	}
+
+	logger.Debugf("dry run - not sending config update for channel [%s] to orderer", req.ChannelID)
+
+	return SaveChannelResponse{TransactionID: txID, Envelope: envelopeBytes, Diff: diff}, nil
 }
 
 func (rc *Client) validateSaveChannelRequest(req SaveChannelRequest) error {
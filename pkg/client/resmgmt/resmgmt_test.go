@@ -7,11 +7,13 @@ SPDX-License-Identifier: Apache-2.0
 package resmgmt
 
 import (
+	reqContext "context"
 	"fmt"
 	"net"
 	"net/http"
 	"os"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -447,6 +449,49 @@ func TestQueryInstalledChaincodes(t *testing.T) {
 
 }
 
+func TestQueryInstalledChaincodesOnPeers(t *testing.T) {
+
+	rc := setupDefaultResMgmtClient(t)
+
+	_, err := rc.QueryInstalledChaincodesOnPeers(nil)
+	if err == nil {
+		t.Fatalf("QueryInstalledChaincodesOnPeers: expecting error for no peers")
+	}
+
+	peer1 := &fcmocks.MockPeer{MockName: "Peer1", MockURL: "http://peer1.com", MockMSP: "Org1MSP", Status: http.StatusOK, ResponseDelay: 50 * time.Millisecond}
+	peer2 := &fcmocks.MockPeer{MockName: "Peer2", MockURL: "http://peer2.com", MockMSP: "Org1MSP", Status: http.StatusOK, ResponseDelay: 50 * time.Millisecond}
+	peer3 := &fcmocks.MockPeer{MockName: "Peer3", MockURL: "http://peer3.com", MockMSP: "Org1MSP", Status: http.StatusOK, FailFirstN: 100}
+
+	_, err = rc.QueryInstalledChaincodesOnPeers([]fab.Peer{peer1}, WithMaxConcurrency(0))
+	if err == nil {
+		t.Fatalf("QueryInstalledChaincodesOnPeers: expecting error for invalid max concurrency")
+	}
+
+	responses, err := rc.QueryInstalledChaincodesOnPeers([]fab.Peer{peer1, peer2}, WithMaxConcurrency(2))
+	if err != nil {
+		t.Fatalf("QueryInstalledChaincodesOnPeers failed: %s", err)
+	}
+	if len(responses) != 2 || responses[peer1.URL()] == nil || responses[peer2.URL()] == nil {
+		t.Fatalf("expecting responses from both peers but got %v", responses)
+	}
+
+	responses, err = rc.QueryInstalledChaincodesOnPeers([]fab.Peer{peer1, peer2, peer3}, WithMaxConcurrency(1))
+	if err == nil {
+		t.Fatalf("QueryInstalledChaincodesOnPeers: expecting error from peer3")
+	}
+	if !strings.Contains(err.Error(), peer3.URL()) {
+		t.Fatalf("expecting error to reference peer3 but got: %s", err)
+	}
+	if len(responses) != 2 {
+		t.Fatalf("expecting responses from the two successful peers but got %v", responses)
+	}
+
+	_, err = rc.QueryInstalledChaincodesOnPeers([]fab.Peer{peer1, peer2}, WithTimeout(fab.PeerResponse, 10*time.Millisecond))
+	if err == nil {
+		t.Fatalf("QueryInstalledChaincodesOnPeers: expecting error when the deadline is exceeded")
+	}
+}
+
 func TestQueryInstantiatedChaincodes(t *testing.T) {
 	rc := setupDefaultResMgmtClient(t)
 
@@ -506,6 +551,52 @@ func TestQueryChannels(t *testing.T) {
 
 }
 
+func TestQueryChannelsOnPeers(t *testing.T) {
+
+	rc := setupDefaultResMgmtClient(t)
+
+	_, err := rc.QueryChannelsOnPeers(nil)
+	if err == nil {
+		t.Fatalf("QueryChannelsOnPeers: expecting error for no peers")
+	}
+
+	response := &pb.ChannelQueryResponse{Channels: []*pb.ChannelInfo{{ChannelId: "ch1"}, {ChannelId: "ch2"}}}
+	responseBytes, err := proto.Marshal(response)
+	if err != nil {
+		t.Fatal("failed to marshal sample response")
+	}
+
+	peer1 := &fcmocks.MockPeer{MockName: "Peer1", MockURL: "http://peer1.com", MockMSP: "Org1MSP", Status: http.StatusOK, Payload: responseBytes}
+	peer2 := &fcmocks.MockPeer{MockName: "Peer2", MockURL: "http://peer2.com", MockMSP: "Org2MSP", Status: http.StatusOK, Payload: responseBytes, ResponseDelay: 50 * time.Millisecond}
+	peer3 := &fcmocks.MockPeer{MockName: "Peer3", MockURL: "http://peer3.com", MockMSP: "Org1MSP", Status: http.StatusOK, FailFirstN: 100}
+
+	channels, err := rc.QueryChannelsOnPeers([]fab.Peer{peer1, peer2}, WithMaxConcurrency(1))
+	if err != nil {
+		t.Fatalf("QueryChannelsOnPeers failed: %s", err)
+	}
+	assert.Equal(t, map[string][]string{peer1.URL(): {"ch1", "ch2"}, peer2.URL(): {"ch1", "ch2"}}, channels)
+
+	channels, err = rc.QueryChannelsOnPeers([]fab.Peer{peer1, peer3})
+	if err == nil {
+		t.Fatalf("QueryChannelsOnPeers: expecting error from peer3")
+	}
+	if !strings.Contains(err.Error(), peer3.URL()) {
+		t.Fatalf("expecting error to reference peer3 but got: %s", err)
+	}
+	assert.Equal(t, map[string][]string{peer1.URL(): {"ch1", "ch2"}}, channels, "expecting results of the successful peer")
+
+	// Partial results are returned when the deadline is exceeded
+	peer2.ResponseDelay = 5 * time.Second
+	channels, err = rc.QueryChannelsOnPeers([]fab.Peer{peer1, peer2}, WithTimeout(fab.PeerResponse, 500*time.Millisecond))
+	if err == nil {
+		t.Fatalf("QueryChannelsOnPeers: expecting error when the deadline is exceeded")
+	}
+	if !strings.Contains(err.Error(), peer2.URL()) {
+		t.Fatalf("expecting error to reference peer2 but got: %s", err)
+	}
+	assert.Equal(t, map[string][]string{peer1.URL(): {"ch1", "ch2"}}, channels, "expecting results of the peer which responded in time")
+}
+
 func TestInstallCCWithOpts(t *testing.T) {
 
 	rc := setupDefaultResMgmtClient(t)
@@ -1441,3 +1532,98 @@ EDAKBggqhkjOPQQDAgNHADBEAiAHp5Rbp9Em1G/UmKn8WsCbqDfWecVbZPQj3RK4
 oG5kQQIgQAe4OOKYhJdh3f7URaKfGTf492/nmRmtK+ySKjpHSrU=
 -----END CERTIFICATE-----
 `
+
+func TestSaveChannelDryRun(t *testing.T) {
+	// No orderer is configured so the request would fail if it was sent
+	fabCtx := setupTestContext("user", "Org1MSP")
+	fabCtx.SetEndpointConfig(getNetworkConfig(t))
+
+	cc, err := New(createClientContext(fabCtx))
+	if err != nil {
+		t.Fatalf("Failed to create new channel management client: %s", err)
+	}
+
+	r, err := os.Open(channelConfig)
+	assert.Nil(t, err, "opening channel config file failed")
+	defer r.Close()
+
+	resp, err := cc.SaveChannel(SaveChannelRequest{ChannelID: "Invalid", ChannelConfig: r}, WithDryRun())
+	assert.Nil(t, err, "dry run should not require an orderer")
+	assert.NotEmpty(t, resp.TransactionID, "transaction ID should be populated")
+	assert.Contains(t, resp.Diff, "Config update for channel [mychannel]")
+	assert.Contains(t, resp.Diff, "[Group] Channel/Application")
+
+	envelope := &common.Envelope{}
+	err = proto.Unmarshal(resp.Envelope, envelope)
+	assert.Nil(t, err, "unmarshal envelope failed")
+	assert.NotEmpty(t, envelope.Signature, "envelope should be signed")
+	payload := &common.Payload{}
+	err = proto.Unmarshal(envelope.Payload, payload)
+	assert.Nil(t, err, "unmarshal payload failed")
+	configUpdateEnvelope := &common.ConfigUpdateEnvelope{}
+	err = proto.Unmarshal(payload.Data, configUpdateEnvelope)
+	assert.Nil(t, err, "unmarshal config update envelope failed")
+	assert.Len(t, configUpdateEnvelope.Signatures, 1, "config update should be signed by the client")
+}
+
+// configCommitOrderer commits broadcast config updates in a config block which is returned by deliver
+type configCommitOrderer struct {
+	*fcmocks.MockOrderer
+	mutex      sync.Mutex
+	commit     bool
+	lastUpdate *common.Envelope
+}
+
+func (o *configCommitOrderer) SendBroadcast(ctx reqContext.Context, envelope *fab.SignedEnvelope) (*common.Status, error) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	if o.commit {
+		o.lastUpdate = &common.Envelope{Payload: envelope.Payload, Signature: envelope.Signature}
+	}
+	return o.MockOrderer.SendBroadcast(ctx, envelope)
+}
+
+func (o *configCommitOrderer) SendDeliver(ctx reqContext.Context, envelope *fab.SignedEnvelope) (chan *common.Block, chan error) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	blocks := make(chan *common.Block, 1)
+	builder := &fcmocks.MockConfigBlockBuilder{Index: 3, LastConfigIndex: 3, LastUpdate: o.lastUpdate}
+	blocks <- builder.Build()
+	close(blocks)
+	return blocks, make(chan error)
+}
+
+func TestSaveChannelWithCommitWait(t *testing.T) {
+	ctx := setupTestContext("test", "Org1MSP")
+	ctx.SetEndpointConfig(&fcmocks.MockConfig{})
+	cc := setupResMgmtClient(t, ctx)
+
+	_, err := cc.SaveChannel(SaveChannelRequest{}, WithCommitWait(0))
+	assert.NotNil(t, err, "Should have failed for invalid commit wait timeout")
+
+	orderer := &configCommitOrderer{MockOrderer: fcmocks.NewMockOrderer("orderer.example.com:7050", nil), commit: true}
+
+	r1, err := os.Open(channelConfig)
+	assert.Nil(t, err, "opening channel config file failed")
+	defer r1.Close()
+
+	resp, err := cc.SaveChannel(SaveChannelRequest{ChannelID: "mychannel", ChannelConfig: r1}, WithOrderer(orderer), WithCommitWait(5*time.Second))
+	assert.Nil(t, err, "error should be nil")
+	assert.NotEmpty(t, resp.TransactionID, "transaction ID should be populated")
+	assert.Equal(t, "orderer.example.com:7050", resp.Orderer)
+	assert.Equal(t, uint64(3), resp.BlockNumber)
+
+	// The orderer never commits the config update
+	orderer = &configCommitOrderer{MockOrderer: fcmocks.NewMockOrderer("orderer.example.com:7050", nil)}
+
+	r2, err := os.Open(channelConfig)
+	assert.Nil(t, err, "opening channel config file failed")
+	defer r2.Close()
+
+	resp, err = cc.SaveChannel(SaveChannelRequest{ChannelID: "mychannel", ChannelConfig: r2}, WithOrderer(orderer), WithCommitWait(time.Second))
+	assert.NotNil(t, err, "Should have timed out waiting for commit")
+	assert.Contains(t, err.Error(), "timed out waiting for config update")
+	assert.NotEmpty(t, resp.TransactionID, "transaction ID should be populated")
+	assert.Equal(t, "orderer.example.com:7050", resp.Orderer)
+}
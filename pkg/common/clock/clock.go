@@ -0,0 +1,100 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package clock provides the source of time used by time-dependent SDK logic (such as retry
+// backoffs) so that it can be replaced, for example by a Mock clock in tests.
+//
+// A clock is either passed as an option (e.g. retry.WithClock) or attached to the request
+// context (see WithClock). If no clock is configured then the real clock is used.
+package clock
+
+import (
+	reqContext "context"
+	"sync"
+	"time"
+)
+
+// Clock is a source of time
+type Clock interface {
+	// Now returns the current time
+	Now() time.Time
+	// After waits for the given duration to elapse and then sends the current time on the returned channel
+	After(d time.Duration) <-chan time.Time
+}
+
+type clockContextKey struct{}
+
+// Real is the clock which uses the system time
+var Real Clock = realClock{}
+
+// WithClock returns a copy of the parent context to which the given clock is attached. The
+// returned context should be passed as the parent of the request context (e.g. using
+// channel.WithParentContext), in which case the clock is used instead of the real clock.
+func WithClock(parent reqContext.Context, clock Clock) reqContext.Context {
+	return reqContext.WithValue(parent, clockContextKey{}, clock)
+}
+
+// FromContext returns the clock attached to the given context or, if none, the real clock
+func FromContext(ctx reqContext.Context) Clock {
+	if ctx != nil {
+		if clock, ok := ctx.Value(clockContextKey{}).(Clock); ok && clock != nil {
+			return clock
+		}
+	}
+	return Real
+}
+
+// Since returns the time elapsed on the given clock since t
+func Since(clock Clock, t time.Time) time.Duration {
+	return clock.Now().Sub(t)
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+func (realClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+// Mock is a Clock whose time only changes when it is advanced, either explicitly (see Add)
+// or by waiting on it: After advances the clock by the given duration and returns immediately,
+// so that backoffs elapse instantly and deterministically.
+type Mock struct {
+	mutex sync.RWMutex
+	now   time.Time
+}
+
+// NewMock returns a Mock clock set to the given time
+func NewMock(now time.Time) *Mock {
+	return &Mock{now: now}
+}
+
+// Now returns the current time of the clock
+func (m *Mock) Now() time.Time {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	return m.now
+}
+
+// After advances the clock by the given duration and sends the new time on the returned channel
+func (m *Mock) After(d time.Duration) <-chan time.Time {
+	ch := make(chan time.Time, 1)
+	ch <- m.Add(d)
+	return ch
+}
+
+// Add advances the clock by the given duration and returns the new time
+func (m *Mock) Add(d time.Duration) time.Time {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if d > 0 {
+		m.now = m.now.Add(d)
+	}
+	return m.now
+}
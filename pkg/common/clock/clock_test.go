@@ -0,0 +1,41 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package clock
+
+import (
+	reqContext "context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromContext(t *testing.T) {
+	assert.Equal(t, Real, FromContext(nil))
+	assert.Equal(t, Real, FromContext(reqContext.Background()))
+
+	clock := NewMock(time.Unix(1000, 0))
+	assert.Equal(t, clock, FromContext(WithClock(reqContext.Background(), clock)))
+	assert.Equal(t, Real, FromContext(WithClock(reqContext.Background(), nil)))
+}
+
+func TestMock(t *testing.T) {
+	start := time.Unix(1000, 0)
+	clock := NewMock(start)
+	assert.Equal(t, start, clock.Now())
+
+	assert.Equal(t, start.Add(time.Second), clock.Add(time.Second))
+	assert.Equal(t, time.Second, Since(clock, start))
+
+	select {
+	case now := <-clock.After(time.Hour):
+		assert.Equal(t, start.Add(time.Hour+time.Second), now)
+	default:
+		t.Fatal("Expecting After to return immediately")
+	}
+	assert.Equal(t, time.Hour+time.Second, Since(clock, start))
+}
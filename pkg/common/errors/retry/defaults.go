@@ -127,7 +127,7 @@ var ResMgmtDefaultRetryableCodes = map[status.Group][]status.Code{
 // transient by fabric-sdk-go/pkg/client/channel.Client
 var ChannelClientRetryableCodes = map[status.Group][]status.Code{
 	status.EndorserClientStatus: {
-		status.ConnectionFailed, status.EndorsementMismatch,
+		status.ConnectionFailed, status.DialTimeout, status.EndorsementMismatch,
 		status.PrematureChaincodeExecution,
 	},
 	status.EndorserServerStatus: {
@@ -135,7 +135,7 @@ var ChannelClientRetryableCodes = map[status.Group][]status.Code{
 		status.Code(common.Status_INTERNAL_SERVER_ERROR),
 	},
 	status.OrdererClientStatus: {
-		status.ConnectionFailed,
+		status.ConnectionFailed, status.DialTimeout,
 	},
 	status.OrdererServerStatus: {
 		status.Code(common.Status_SERVICE_UNAVAILABLE),
@@ -7,6 +7,8 @@ SPDX-License-Identifier: Apache-2.0
 package retry
 
 import (
+	reqContext "context"
+
 	"github.com/hyperledger/fabric-sdk-go/pkg/common/errors/multi"
 	"github.com/hyperledger/fabric-sdk-go/pkg/common/logging"
 )
@@ -51,6 +53,12 @@ func NewInvoker(handler Handler, opts ...InvokerOpt) *RetryableInvoker {
 // Invoke invokes the given function and performs retries according
 // to the retry options.
 func (ri *RetryableInvoker) Invoke(invocation Invocation) (interface{}, error) {
+	return ri.InvokeWithContext(reqContext.Background(), invocation)
+}
+
+// InvokeWithContext invokes the given function and performs retries according
+// to the retry options. No further retries are attempted once the given context is done.
+func (ri *RetryableInvoker) InvokeWithContext(ctx reqContext.Context, invocation Invocation) (interface{}, error) {
 	attemptNum := 0
 	var lastErr error
 
@@ -69,7 +77,7 @@ func (ri *RetryableInvoker) Invoke(invocation Invocation) (interface{}, error) {
 		}
 
 		logger.Debugf("Failed with err [%s] on attempt #%d. Checking if retry is warranted...", err, attemptNum)
-		if !ri.resolveRetry(err) {
+		if !ri.resolveRetry(ctx, err) {
 			if lastErr != nil && lastErr.Error() != err.Error() {
 				logger.Debugf("... retry for err [%s] is NOT warranted after %d attempt(s). Previous error [%s]", err, lastErr)
 			} else {
@@ -82,13 +90,13 @@ func (ri *RetryableInvoker) Invoke(invocation Invocation) (interface{}, error) {
 	}
 }
 
-func (ri *RetryableInvoker) resolveRetry(err error) bool {
+func (ri *RetryableInvoker) resolveRetry(ctx reqContext.Context, err error) bool {
 	errs, ok := err.(multi.Errors)
 	if !ok {
 		errs = append(errs, err)
 	}
 	for _, e := range errs {
-		if ri.handler.Required(e) {
+		if required(ctx, ri.handler, e) {
 			logger.Debugf("Retrying on error %s", e)
 			if ri.beforeRetry != nil {
 				ri.beforeRetry(err)
@@ -98,3 +106,14 @@ func (ri *RetryableInvoker) resolveRetry(err error) bool {
 	}
 	return false
 }
+
+func required(ctx reqContext.Context, handler Handler, err error) bool {
+	if h, ok := handler.(HandlerWithContext); ok {
+		return h.RequiredWithContext(ctx, err)
+	}
+	if ctx.Err() != nil {
+		logger.Debugf("Not retrying on error %s since context is done: %s", err, ctx.Err())
+		return false
+	}
+	return handler.Required(err)
+}
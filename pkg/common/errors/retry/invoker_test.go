@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package retry
 
 import (
+	reqContext "context"
 	"testing"
 	"time"
 
@@ -102,3 +103,50 @@ func TestInvokeWithBeforeRetry(t *testing.T) {
 	assert.Equal(t, 2, attempt)
 	assert.Equal(t, 1, beforeRetryHandlerCalled)
 }
+
+func TestInvokeWithContextCancelled(t *testing.T) {
+	r := New(Opts{
+		Attempts:       3,
+		BackoffFactor:  2,
+		InitialBackoff: 10 * time.Second,
+		MaxBackoff:     10 * time.Second,
+	})
+
+	ctx, cancel := reqContext.WithCancel(reqContext.Background())
+
+	attempt := 0
+	transientErr := status.New(status.EndorserClientStatus, status.EndorsementMismatch.ToInt32(), "", nil)
+	invoker := NewInvoker(r)
+
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	start := time.Now()
+	_, err := invoker.InvokeWithContext(ctx,
+		func() (interface{}, error) {
+			attempt++
+			return nil, transientErr
+		},
+	)
+
+	assert.Equal(t, transientErr, err)
+	assert.Equal(t, 1, attempt, "Expected no retries once context is cancelled")
+	assert.True(t, time.Since(start) < 5*time.Second, "Expected backoff to be interrupted by cancelled context")
+
+	// handler without context support should not be invoked once context is done
+	attempt = 0
+	_, err = NewInvoker(&simpleHandler{}).InvokeWithContext(ctx,
+		func() (interface{}, error) {
+			attempt++
+			return nil, transientErr
+		},
+	)
+	assert.Equal(t, transientErr, err)
+	assert.Equal(t, 1, attempt, "Expected no retries once context is done")
+}
+
+// simpleHandler always requires a retry
+type simpleHandler struct{}
+
+func (h *simpleHandler) Required(err error) bool {
+	return true
+}
@@ -0,0 +1,81 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package retry
+
+import (
+	reqContext "context"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/clock"
+)
+
+// Attempt holds the details of a failed attempt as seen by a RecordingHandler
+type Attempt struct {
+	// Err the error returned by the attempt
+	Err error
+	// Time the time at which the error was evaluated (on the clock of the handler, see WithClock)
+	Time time.Time
+	// Backoff the backoff computed by the handler before the next attempt
+	Backoff time.Duration
+	// Retried true if the error warranted a retry
+	Retried bool
+}
+
+// RecordingHandler is a retry Handler which wraps another Handler and records
+// the history of attempts for diagnostic purposes
+type RecordingHandler struct {
+	handler Handler
+	mutex   sync.RWMutex
+	history []Attempt
+}
+
+// NewRecordingHandler returns a new RecordingHandler which wraps the given handler
+func NewRecordingHandler(handler Handler) *RecordingHandler {
+	return &RecordingHandler{handler: handler}
+}
+
+// Required determines if retry is required for the given error and records the attempt
+func (h *RecordingHandler) Required(err error) bool {
+	return h.RequiredWithContext(reqContext.Background(), err)
+}
+
+// RequiredWithContext determines if retry is required for the given error and records the attempt
+func (h *RecordingHandler) RequiredWithContext(ctx reqContext.Context, err error) bool {
+	var attempt Attempt
+	if handler, ok := h.handler.(backoffHandler); ok {
+		attempt.Time = handler.clockFor(ctx).Now()
+		attempt.Retried, attempt.Backoff = handler.requiredWithBackoff(ctx, err)
+	} else {
+		attempt.Time = clock.FromContext(ctx).Now()
+		attempt.Retried = required(ctx, h.handler, err)
+	}
+	attempt.Err = err
+
+	h.mutex.Lock()
+	h.history = append(h.history, attempt)
+	h.mutex.Unlock()
+
+	return attempt.Retried
+}
+
+// backoffHandler is implemented by the retry Handlers of this package, which report the
+// backoff that they computed and the clock on which it elapsed
+type backoffHandler interface {
+	clockFor(ctx reqContext.Context) clock.Clock
+	requiredWithBackoff(ctx reqContext.Context, err error) (bool, time.Duration)
+}
+
+// History returns the attempts recorded so far
+func (h *RecordingHandler) History() []Attempt {
+	h.mutex.RLock()
+	defer h.mutex.RUnlock()
+
+	history := make([]Attempt, len(h.history))
+	copy(history, h.history)
+	return history
+}
@@ -0,0 +1,62 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package retry
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/clock"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/errors/status"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecordingHandler(t *testing.T) {
+	start := time.Unix(1000, 0)
+	r := NewRecordingHandler(New(Opts{
+		Attempts:       2,
+		BackoffFactor:  2,
+		InitialBackoff: time.Second,
+		MaxBackoff:     time.Minute,
+	}, WithClock(clock.NewMock(start))))
+
+	mismatchErr := status.New(status.EndorserClientStatus, status.EndorsementMismatch.ToInt32(), "", nil)
+	prematureErr := status.New(status.EndorserClientStatus, status.PrematureChaincodeExecution.ToInt32(), "", nil)
+	errs := []error{mismatchErr, prematureErr, mismatchErr}
+
+	attempt := 0
+	_, err := NewInvoker(r).Invoke(
+		func() (interface{}, error) {
+			e := errs[attempt]
+			attempt++
+			return nil, e
+		},
+	)
+	assert.Equal(t, mismatchErr, err)
+
+	history := r.History()
+	if len(history) != len(errs) {
+		t.Fatalf("Expected %d recorded attempts, got %d", len(errs), len(history))
+	}
+	expectedBackoffs := []time.Duration{time.Second, 2 * time.Second}
+	expectedTime := start
+	for i, a := range history {
+		assert.Equal(t, errs[i], a.Err)
+		assert.Equal(t, expectedTime, a.Time, "Expected attempt time to be taken from the handler's clock")
+		if i < len(history)-1 {
+			assert.True(t, a.Retried, "Expected attempt %d to be retried", i)
+			assert.Equal(t, expectedBackoffs[i], a.Backoff, "Expected computed backoff to be recorded for attempt %d", i)
+			expectedTime = expectedTime.Add(a.Backoff)
+		} else {
+			assert.False(t, a.Retried, "Expected last attempt to not be retried")
+			assert.Equal(t, time.Duration(0), a.Backoff)
+		}
+	}
+
+	history[0].Err = nil
+	assert.Equal(t, errs[0], r.History()[0].Err, "Expected History to return a copy")
+}
@@ -14,9 +14,13 @@ SPDX-License-Identifier: Apache-2.0
 package retry
 
 import (
+	reqContext "context"
+	"math/rand"
 	"time"
 
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/clock"
 	"github.com/hyperledger/fabric-sdk-go/pkg/common/errors/status"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/metrics"
 )
 
 // Opts defines the retry parameters
@@ -35,6 +39,11 @@ type Opts struct {
 	// RetryableCodes defines the status codes, mapped by group, returned by fabric-sdk-go
 	// that warrant a retry. This will default to retry.DefaultRetryableCodes.
 	RetryableCodes map[status.Group][]status.Code
+	// MaxElapsedTime the maximum total time, including backoffs, measured from the
+	// creation of the Handler (on the clock of the Handler, see WithClock) after which
+	// no more retries are attempted regardless of the remaining attempts. Zero means
+	// unbounded (only Attempts applies).
+	MaxElapsedTime time.Duration
 }
 
 // Handler retry handler interface decides whether a retry is required for the given
@@ -43,47 +52,159 @@ type Handler interface {
 	Required(err error) bool
 }
 
+// HandlerWithContext is a retry Handler which also takes the request context into account.
+// A retry is never required once the context is done and backoffs are interrupted when
+// the context is cancelled.
+type HandlerWithContext interface {
+	Handler
+	RequiredWithContext(ctx reqContext.Context, err error) bool
+}
+
 // impl retry Handler implementation
 type impl struct {
-	opts    Opts
-	retries int
+	opts              Opts
+	retries           int
+	start             time.Time
+	jitter            float64
+	rand              *rand.Rand
+	clock             clock.Clock
+	additionalCodes   map[status.Group][]status.Code
+	nonRetryableCodes map[status.Group][]status.Code
+}
+
+// HandlerOpt is a retry Handler option
+type HandlerOpt func(handler *impl)
+
+// WithJitter randomizes each computed backoff by up to +/- the given factor
+// (for example, 0.2 for +/- 20%). The resulting backoff never exceeds MaxBackoff.
+func WithJitter(factor float64) HandlerOpt {
+	return func(handler *impl) {
+		handler.jitter = factor
+	}
+}
+
+// WithRandSource specifies the source of randomness used for jitter. This
+// allows backoff periods to be reproduced, for example in tests.
+func WithRandSource(source rand.Source) HandlerOpt {
+	return func(handler *impl) {
+		handler.rand = rand.New(source)
+	}
+}
+
+// WithClock specifies the clock on which backoffs elapse and MaxElapsedTime is measured. If
+// not specified, backoffs elapse on the clock attached to the request context (see
+// clock.WithClock) and MaxElapsedTime is measured on the real clock.
+func WithClock(c clock.Clock) HandlerOpt {
+	return func(handler *impl) {
+		handler.clock = c
+	}
+}
+
+// WithAdditionalRetryableCodes adds the given codes of the given group to the
+// retryable codes defined in Opts (or the defaults if none are defined).
+func WithAdditionalRetryableCodes(group status.Group, codes ...status.Code) HandlerOpt {
+	return func(handler *impl) {
+		if handler.additionalCodes == nil {
+			handler.additionalCodes = make(map[status.Group][]status.Code)
+		}
+		handler.additionalCodes[group] = append(handler.additionalCodes[group], codes...)
+	}
+}
+
+// WithNonRetryableCodes excludes the given codes of the given group from being retried.
+// Non-retryable codes are evaluated first, so a code which is non-retryable is never
+// retried even if it is also defined in Opts.RetryableCodes or added with
+// WithAdditionalRetryableCodes.
+func WithNonRetryableCodes(group status.Group, codes ...status.Code) HandlerOpt {
+	return func(handler *impl) {
+		if handler.nonRetryableCodes == nil {
+			handler.nonRetryableCodes = make(map[status.Group][]status.Code)
+		}
+		handler.nonRetryableCodes[group] = append(handler.nonRetryableCodes[group], codes...)
+	}
 }
 
 // New retry Handler with the given opts
-func New(opts Opts) Handler {
+func New(opts Opts, handlerOpts ...HandlerOpt) Handler {
 	if len(opts.RetryableCodes) == 0 {
 		opts.RetryableCodes = DefaultRetryableCodes
 	}
-	return &impl{opts: opts}
+	handler := &impl{opts: opts}
+	for _, opt := range handlerOpts {
+		opt(handler)
+	}
+	handler.start = handler.now()
+	if handler.jitter > 0 && handler.rand == nil {
+		handler.rand = rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+	return handler
 }
 
 // WithDefaults new retry Handler with default opts
 func WithDefaults() Handler {
-	return &impl{opts: DefaultOpts}
+	return &impl{opts: DefaultOpts, start: time.Now()}
 }
 
 // WithAttempts new retry Handler with given attempts. Other opts are set to default.
 func WithAttempts(attempts int) Handler {
 	opts := DefaultOpts
 	opts.Attempts = attempts
-	return &impl{opts: opts}
+	return &impl{opts: opts, start: time.Now()}
 }
 
 // Required determines if retry is required for the given error
 // Note: backoffs are implemented behind this interface
 func (i *impl) Required(err error) bool {
-	if i.retries == i.opts.Attempts {
-		return false
+	return i.RequiredWithContext(reqContext.Background(), err)
+}
+
+// RequiredWithContext determines if retry is required for the given error.
+// Retry is not required if the given context is done (or is cancelled during the backoff).
+func (i *impl) RequiredWithContext(ctx reqContext.Context, err error) bool {
+	retry, _ := i.requiredWithBackoff(ctx, err)
+	return retry
+}
+
+// requiredWithBackoff determines if retry is required for the given error and, if so,
+// returns the backoff which elapsed before returning
+func (i *impl) requiredWithBackoff(ctx reqContext.Context, err error) (bool, time.Duration) {
+	if i.retries == i.opts.Attempts || ctx.Err() != nil {
+		return false, 0
 	}
 
 	s, ok := status.FromError(err)
 	if ok && i.isRetryable(s.Group, s.Code) {
-		time.Sleep(i.backoffPeriod())
+		backoff := i.backoffPeriod()
+		if i.opts.MaxElapsedTime > 0 && i.now().Sub(i.start)+backoff >= i.opts.MaxElapsedTime {
+			return false, 0
+		}
+		select {
+		case <-i.clockFor(ctx).After(backoff):
+		case <-ctx.Done():
+			return false, 0
+		}
 		i.retries++
-		return true
+		metrics.Get().IncRetries(err)
+		return true, backoff
 	}
 
-	return false
+	return false, 0
+}
+
+// now returns the current time on the clock of the handler
+func (i *impl) now() time.Time {
+	if i.clock != nil {
+		return i.clock.Now()
+	}
+	return clock.Real.Now()
+}
+
+// clockFor returns the clock of the handler or, if none, the clock attached to the given context
+func (i *impl) clockFor(ctx reqContext.Context) clock.Clock {
+	if i.clock != nil {
+		return i.clock
+	}
+	return clock.FromContext(ctx)
 }
 
 // backoffPeriod calculates the backoff duration based on the provided opts
@@ -92,6 +213,12 @@ func (i *impl) backoffPeriod() time.Duration {
 	for j := 0; j < i.retries && backoff < max; j++ {
 		backoff *= i.opts.BackoffFactor
 	}
+	if i.jitter > 0 {
+		backoff += backoff * i.jitter * (2*i.rand.Float64() - 1)
+		if backoff < 0 {
+			backoff = 0
+		}
+	}
 	if backoff > max {
 		backoff = max
 	}
@@ -101,11 +228,19 @@ func (i *impl) backoffPeriod() time.Duration {
 
 // isRetryable determines if the given status is configured to be retryable
 func (i *impl) isRetryable(g status.Group, c int32) bool {
-	for group, codes := range i.opts.RetryableCodes {
+	if containsCode(i.nonRetryableCodes, g, c) {
+		return false
+	}
+	return containsCode(i.opts.RetryableCodes, g, c) || containsCode(i.additionalCodes, g, c)
+}
+
+// containsCode determines if the given status is contained in the given codes
+func containsCode(codes map[status.Group][]status.Code, g status.Group, c int32) bool {
+	for group, groupCodes := range codes {
 		if g != group {
 			continue
 		}
-		for _, code := range codes {
+		for _, code := range groupCodes {
 			if status.Code(c) == code {
 				return true
 			}
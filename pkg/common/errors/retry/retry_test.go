@@ -7,13 +7,18 @@ SPDX-License-Identifier: Apache-2.0
 package retry
 
 import (
+	reqContext "context"
 	"fmt"
+	"math/rand"
 	"testing"
 	"time"
 
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/clock"
 	"github.com/hyperledger/fabric-sdk-go/pkg/common/errors/status"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/metrics"
 	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/common"
 	"github.com/stretchr/testify/assert"
+	grpcCodes "google.golang.org/grpc/codes"
 )
 
 func TestRetryRequired(t *testing.T) {
@@ -40,6 +45,38 @@ func TestRetryRequired(t *testing.T) {
 	assert.False(t, r.Required(unknownErr), "Expected retry to not be required on unknown error")
 }
 
+func TestRetryMetrics(t *testing.T) {
+	collector := &retryCollector{}
+	metrics.SetCollector(collector)
+	defer metrics.SetCollector(nil)
+
+	transientErr := status.New(status.EndorserClientStatus, status.EndorsementMismatch.ToInt32(), "", nil)
+	r := New(Opts{
+		Attempts:       2,
+		BackoffFactor:  2,
+		InitialBackoff: 1 * time.Millisecond,
+		MaxBackoff:     1 * time.Second,
+	})
+	assert.True(t, r.Required(transientErr), "Expected retry to be required on transient error")
+	assert.True(t, r.Required(transientErr), "Expected retry to be required on transient error")
+	assert.False(t, r.Required(transientErr), "Expected retry to not be required after exhausting attempts")
+	assert.Equal(t, 2, collector.retries, "expecting each retry to be counted")
+	assert.Equal(t, transientErr, collector.lastErr)
+}
+
+type retryCollector struct {
+	retries int
+	lastErr error
+}
+
+func (c *retryCollector) ObserveProposalLatency(target string, latency time.Duration, err error) {}
+func (c *retryCollector) AddActiveConnections(delta int)                                         {}
+
+func (c *retryCollector) IncRetries(err error) {
+	c.retries++
+	c.lastErr = err
+}
+
 func TestBackoffPeriod(t *testing.T) {
 	testAttempts := 10
 	testBackoffFactor := 3.34
@@ -63,3 +100,138 @@ func TestBackoffPeriod(t *testing.T) {
 	i.retries = 3
 	assert.Equal(t, testMaxBackoff, i.backoffPeriod(), "Expected max backoff")
 }
+
+func TestBackoffPeriodWithJitter(t *testing.T) {
+	testInitialBackoff := 2 * time.Second
+	testMaxBackoff := 3 * time.Second
+	opts := Opts{
+		Attempts:       10,
+		BackoffFactor:  2,
+		InitialBackoff: testInitialBackoff,
+		MaxBackoff:     testMaxBackoff,
+	}
+
+	r := New(opts, WithJitter(0.5), WithRandSource(rand.NewSource(1)))
+	i := r.(*impl)
+	var periods []time.Duration
+	for j := 0; j < 5; j++ {
+		i.retries = j
+		backoff := i.backoffPeriod()
+		assert.True(t, backoff <= testMaxBackoff, "Expected backoff to be bounded by max backoff")
+		if j == 0 {
+			assert.True(t, backoff >= testInitialBackoff/2 && backoff <= testInitialBackoff*3/2, "Expected jitter within factor")
+		}
+		periods = append(periods, backoff)
+	}
+
+	// same source must reproduce the same backoff periods
+	i = New(opts, WithJitter(0.5), WithRandSource(rand.NewSource(1))).(*impl)
+	for j := 0; j < 5; j++ {
+		i.retries = j
+		assert.Equal(t, periods[j], i.backoffPeriod(), "Expected reproducible backoff with same rand source")
+	}
+}
+
+func TestAdditionalAndNonRetryableCodes(t *testing.T) {
+	opts := Opts{
+		Attempts:       3,
+		BackoffFactor:  2,
+		InitialBackoff: 1 * time.Millisecond,
+		MaxBackoff:     1 * time.Second,
+	}
+	exhaustedErr := status.New(status.GRPCTransportStatus, int32(grpcCodes.ResourceExhausted), "", nil)
+	unavailableErr := status.New(status.GRPCTransportStatus, int32(grpcCodes.Unavailable), "", nil)
+	mismatchErr := status.New(status.EndorserClientStatus, status.EndorsementMismatch.ToInt32(), "", nil)
+
+	r := New(opts)
+	assert.False(t, r.Required(exhaustedErr), "Expected ResourceExhausted to not be retryable by default")
+
+	r = New(opts, WithAdditionalRetryableCodes(status.GRPCTransportStatus, status.Code(grpcCodes.ResourceExhausted)))
+	assert.True(t, r.Required(exhaustedErr), "Expected additional code to be retryable")
+	assert.True(t, r.Required(unavailableErr), "Expected default codes to remain retryable")
+
+	r = New(opts, WithNonRetryableCodes(status.GRPCTransportStatus, status.Code(grpcCodes.Unavailable)))
+	assert.False(t, r.Required(unavailableErr), "Expected non-retryable code to not be retried")
+	assert.True(t, r.Required(mismatchErr), "Expected other default codes to remain retryable")
+
+	r = New(opts,
+		WithAdditionalRetryableCodes(status.GRPCTransportStatus, status.Code(grpcCodes.ResourceExhausted)),
+		WithNonRetryableCodes(status.GRPCTransportStatus, status.Code(grpcCodes.ResourceExhausted)))
+	assert.False(t, r.Required(exhaustedErr), "Expected non-retryable codes to take precedence")
+
+	// default codes must not be modified
+	for _, code := range DefaultRetryableCodes[status.GRPCTransportStatus] {
+		assert.NotEqual(t, status.Code(grpcCodes.ResourceExhausted), code, "default retryable codes modified")
+	}
+}
+
+func TestMaxElapsedTime(t *testing.T) {
+	opts := Opts{
+		Attempts:       100,
+		BackoffFactor:  1,
+		InitialBackoff: 10 * time.Millisecond,
+		MaxBackoff:     10 * time.Millisecond,
+		MaxElapsedTime: 100 * time.Millisecond,
+	}
+	r := New(opts)
+	testErr := status.New(status.EndorserClientStatus, status.EndorsementMismatch.ToInt32(), "", nil)
+
+	start := time.Now()
+	retries := 0
+	for r.Required(testErr) {
+		retries++
+	}
+	elapsed := time.Since(start)
+
+	if retries == 0 || retries >= 10 {
+		t.Fatalf("Expected fewer than 10 retries within max elapsed time, got %d", retries)
+	}
+	if elapsed >= 2*opts.MaxElapsedTime {
+		t.Fatalf("Expected retries to stop at max elapsed time [%s], elapsed [%s]", opts.MaxElapsedTime, elapsed)
+	}
+
+	opts.MaxElapsedTime = 0
+	opts.Attempts = 3
+	opts.InitialBackoff = time.Millisecond
+	opts.MaxBackoff = time.Millisecond
+	r = New(opts)
+	retries = 0
+	for r.Required(testErr) {
+		retries++
+	}
+	assert.Equal(t, opts.Attempts, retries, "Expected zero max elapsed time to be unbounded")
+}
+
+func TestRetryWithClock(t *testing.T) {
+	opts := Opts{
+		Attempts:       100,
+		BackoffFactor:  1,
+		InitialBackoff: time.Minute,
+		MaxBackoff:     time.Minute,
+		MaxElapsedTime: 10 * time.Minute,
+	}
+	testErr := status.New(status.EndorserClientStatus, status.EndorsementMismatch.ToInt32(), "", nil)
+
+	start := time.Unix(1000, 0)
+	clk := clock.NewMock(start)
+	r := New(opts, WithClock(clk))
+	retries := 0
+	for r.Required(testErr) {
+		retries++
+	}
+	assert.Equal(t, 9, retries, "Expected retries to stop at max elapsed time")
+	assert.Equal(t, 9*time.Minute, clk.Now().Sub(start), "Expected backoffs to elapse on the mock clock")
+
+	// Backoffs elapse on the clock attached to the context
+	clk = clock.NewMock(start)
+	ctx := clock.WithClock(reqContext.Background(), clk)
+	opts.MaxElapsedTime = 0
+	opts.Attempts = 3
+	r = New(opts)
+	retries = 0
+	for r.(HandlerWithContext).RequiredWithContext(ctx, testErr) {
+		retries++
+	}
+	assert.Equal(t, opts.Attempts, retries)
+	assert.Equal(t, 3*time.Minute, clk.Now().Sub(start), "Expected backoffs to elapse on the context clock")
+}
@@ -0,0 +1,88 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package status
+
+import (
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/peer"
+)
+
+// Category is the category of the status of a proposal response
+type Category int32
+
+const (
+	// UnknownCategory is the category of a missing or unrecognized response
+	UnknownCategory Category = iota
+	// Success is the category of a successful response
+	Success
+	// TransientFailure is the category of a failure which may succeed if the proposal is sent again
+	// (e.g. the endorser is unavailable or failed with an internal error)
+	TransientFailure
+	// PolicyViolation is the category of a proposal which was rejected because the creator
+	// is not authorized (e.g. by a channel or chaincode access policy)
+	PolicyViolation
+	// ValidationFailure is the category of a malformed or invalid proposal (e.g. an unknown
+	// chaincode or a request which is too large)
+	ValidationFailure
+	// ChaincodeFailure is the category of any other error returned by the chaincode or the endorser
+	ChaincodeFailure
+)
+
+// CategoryName maps the categories in this package to human-readable strings
+var CategoryName = map[int32]string{
+	0: "UNKNOWN",
+	1: "SUCCESS",
+	2: "TRANSIENT_FAILURE",
+	3: "POLICY_VIOLATION",
+	4: "VALIDATION_FAILURE",
+	5: "CHAINCODE_FAILURE",
+}
+
+func (c Category) String() string {
+	if s, ok := CategoryName[int32(c)]; ok {
+		return s
+	}
+	return UnknownCategory.String()
+}
+
+// Classify returns the category of the status of the given proposal response and whether the
+// proposal may be retried, as decided by the SDK: only the SUCCESS (200) status is successful,
+// and the SERVICE_UNAVAILABLE and INTERNAL_SERVER_ERROR statuses are retried by default by the
+// channel and resource management clients (see retry.DefaultRetryableCodes).
+//  Parameters:
+//  response is the proposal response returned by an endorser
+//
+//  Returns:
+//  the category of the response status
+//  whether the proposal may be retried
+func Classify(response *pb.ProposalResponse) (Category, bool) {
+	if response == nil || response.Response == nil {
+		return UnknownCategory, false
+	}
+
+	return ClassifyCode(response.Response.Status)
+}
+
+// ClassifyCode returns the category of the given proposal response status code and whether
+// the proposal may be retried (see Classify).
+func ClassifyCode(code int32) (Category, bool) {
+	switch common.Status(code) {
+	case common.Status_SUCCESS:
+		return Success, false
+	case common.Status_SERVICE_UNAVAILABLE, common.Status_INTERNAL_SERVER_ERROR:
+		return TransientFailure, true
+	case common.Status_FORBIDDEN:
+		return PolicyViolation, false
+	case common.Status_BAD_REQUEST, common.Status_NOT_FOUND, common.Status_REQUEST_ENTITY_TOO_LARGE:
+		return ValidationFailure, false
+	}
+
+	if code <= 0 {
+		return UnknownCategory, false
+	}
+	return ChaincodeFailure, false
+}
@@ -0,0 +1,50 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package status
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/peer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		code      int32
+		category  Category
+		retryable bool
+	}{
+		{int32(common.Status_SUCCESS), Success, false},
+		{int32(common.Status_SERVICE_UNAVAILABLE), TransientFailure, true},
+		{int32(common.Status_INTERNAL_SERVER_ERROR), TransientFailure, true},
+		{int32(common.Status_FORBIDDEN), PolicyViolation, false},
+		{int32(common.Status_BAD_REQUEST), ValidationFailure, false},
+		{int32(common.Status_NOT_FOUND), ValidationFailure, false},
+		{int32(common.Status_REQUEST_ENTITY_TOO_LARGE), ValidationFailure, false},
+		{int32(common.Status_NOT_IMPLEMENTED), ChaincodeFailure, false},
+		{600, ChaincodeFailure, false},
+		{int32(common.Status_UNKNOWN), UnknownCategory, false},
+	}
+
+	for _, test := range tests {
+		category, retryable := Classify(&pb.ProposalResponse{Response: &pb.Response{Status: test.code}})
+		assert.Equal(t, test.category, category, "unexpected category for status %d", test.code)
+		assert.Equal(t, test.retryable, retryable, "unexpected retryable for status %d", test.code)
+	}
+
+	category, retryable := Classify(nil)
+	assert.Equal(t, UnknownCategory, category)
+	assert.False(t, retryable)
+
+	category, _ = Classify(&pb.ProposalResponse{})
+	assert.Equal(t, UnknownCategory, category)
+
+	assert.Equal(t, "POLICY_VIOLATION", PolicyViolation.String())
+	assert.Equal(t, "UNKNOWN", Category(100).String())
+}
@@ -63,6 +63,12 @@ const (
 
 	// NoMatchingChannelEntity is if entityMatchers are unable to find any matchingChannel
 	NoMatchingChannelEntity Code = 25
+
+	// DialTimeout is returned when a network connection could not be established within the dial timeout
+	DialTimeout Code = 26
+
+	// ClientClosed is returned when an operation is invoked on (or cancelled by) a client which was closed
+	ClientClosed Code = 27
 )
 
 // CodeName maps the codes in this packages to human-readable strings
@@ -83,6 +89,8 @@ var CodeName = map[int32]string{
 	23: "NO_MATCHING_ORDERER_ENTITY",
 	24: "PREMATURE_CHAINCODE_EXECUTION",
 	25: "NO_MATCHING_CHANNEL_ENTITY",
+	26: "DIAL_TIMEOUT",
+	27: "CLIENT_CLOSED",
 }
 
 // ToInt32 cast to int32
@@ -151,9 +151,9 @@ func NewFromProposalResponse(res *pb.ProposalResponse, endorser string) *Status
 	if res == nil {
 		return nil
 	}
-	details := []interface{}{endorser, res.Response.Payload}
+	details := []interface{}{endorser, res.GetResponse().GetPayload()}
 
-	return New(EndorserServerStatus, res.Response.Status, res.Response.Message, details)
+	return New(EndorserServerStatus, res.GetResponse().GetStatus(), res.GetResponse().GetMessage(), details)
 }
 
 // NewFromGRPCStatus new Status from gRPC status response
@@ -46,6 +46,43 @@ const (
 	loggerModule            = "fabsdk/common"
 )
 
+// Standard field keys
+const (
+	// EndpointURLKey is the key of the peer or orderer URL field
+	EndpointURLKey = "endpointURL"
+	// ChannelIDKey is the key of the channel ID field
+	ChannelIDKey = "channelID"
+	// TxIDKey is the key of the transaction ID field
+	TxIDKey = "txID"
+	// RequestIDKey is the key of the request (correlation) ID field
+	RequestIDKey = "requestID"
+)
+
+// NewField returns a log field with the given key and value
+func NewField(key string, value interface{}) api.Field {
+	return api.Field{Key: key, Value: value}
+}
+
+// EndpointURL returns a field containing the given peer or orderer URL
+func EndpointURL(url string) api.Field {
+	return NewField(EndpointURLKey, url)
+}
+
+// ChannelID returns a field containing the given channel ID
+func ChannelID(channelID string) api.Field {
+	return NewField(ChannelIDKey, channelID)
+}
+
+// TxID returns a field containing the given transaction ID
+func TxID(txID string) api.Field {
+	return NewField(TxIDKey, txID)
+}
+
+// RequestID returns a field containing the given request (correlation) ID
+func RequestID(requestID string) api.Field {
+	return NewField(RequestIDKey, requestID)
+}
+
 // NewLogger creates and returns a Logger object based on the module name.
 func NewLogger(module string) *Logger {
 	// note: the underlying logger instance is lazy initialized on first use
@@ -205,6 +242,47 @@ func (l *Logger) Errorln(args ...interface{}) {
 	l.logger().Errorln(args...)
 }
 
+//Debugw logs the message at DEBUG level along with the given key-value fields
+func (l *Logger) Debugw(msg string, fields ...api.Field) {
+	l.logw(api.DEBUG, msg, fields)
+}
+
+//Infow logs the message at INFO level along with the given key-value fields
+func (l *Logger) Infow(msg string, fields ...api.Field) {
+	l.logw(api.INFO, msg, fields)
+}
+
+//Warnw logs the message at WARNING level along with the given key-value fields
+func (l *Logger) Warnw(msg string, fields ...api.Field) {
+	l.logw(api.WARNING, msg, fields)
+}
+
+//Errorw logs the message at ERROR level along with the given key-value fields
+func (l *Logger) Errorw(msg string, fields ...api.Field) {
+	l.logw(api.ERROR, msg, fields)
+}
+
+// logw passes the fields to the underlying logger if it is a structured logger;
+// otherwise the fields are appended to the message
+func (l *Logger) logw(level api.Level, msg string, fields []api.Field) {
+	logger := l.logger()
+	if sl, ok := logger.(api.StructuredLogger); ok {
+		sl.Logw(level, msg, fields...)
+		return
+	}
+	// the message is only formatted if it is logged
+	modlog.LogMessage(logger, level, &message{msg: msg, fields: fields})
+}
+
+type message struct {
+	msg    string
+	fields []api.Field
+}
+
+func (m *message) String() string {
+	return modlog.FormatMessage(m.msg, m.fields)
+}
+
 func (l *Logger) logger() api.Logger {
 	l.once.Do(func() {
 		l.instance = loggerProvider().GetLogger(l.module)
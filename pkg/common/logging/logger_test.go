@@ -7,6 +7,7 @@ package logging
 
 import (
 	"bytes"
+	"fmt"
 	"sync"
 	"testing"
 
@@ -194,3 +195,73 @@ func TestDefaultCustomModuledLoggingBehavior(t *testing.T) {
 	modlog.VerifyBasicLogging(t, api.DEBUG, nil, dlogger.Debugf, &buf, true, moduleName2)
 
 }
+
+func TestStructuredLogging(t *testing.T) {
+	module := "module-xyz-structured"
+
+	//Fields should be appended to the message for unstructured loggers
+	ulogger := &unstructuredLogger{}
+	resetLoggerInstance()
+	Initialize(&structuredLoggerProvider{logger: ulogger})
+	logger := NewLogger(module)
+
+	logger.Infow("brown fox jumps over the lazy dog", EndpointURL("peer1"), ChannelID("mychannel"), NewField("attempt", 2))
+	assert.Equal(t, "INFO brown fox jumps over the lazy dog endpointURL=peer1 channelID=mychannel attempt=2", ulogger.output)
+	logger.Errorw("brown fox jumps over the lazy dog")
+	assert.Equal(t, "ERROR brown fox jumps over the lazy dog", ulogger.output)
+
+	//Structured loggers should receive the fields
+	slogger := &structuredLogger{}
+	resetLoggerInstance()
+	Initialize(&structuredLoggerProvider{logger: slogger})
+	logger = NewLogger(module)
+
+	logger.Warnw("brown fox jumps over the lazy dog", RequestID("req1"), TxID("txid"))
+	assert.Equal(t, api.WARNING, slogger.level)
+	assert.Equal(t, "brown fox jumps over the lazy dog", slogger.msg)
+	assert.Equal(t, []api.Field{{Key: RequestIDKey, Value: "req1"}, {Key: TxIDKey, Value: "txid"}}, slogger.fields)
+
+	resetLoggerInstance()
+}
+
+type structuredLoggerProvider struct {
+	logger api.Logger
+}
+
+func (p *structuredLoggerProvider) GetLogger(module string) api.Logger {
+	return p.logger
+}
+
+type unstructuredLogger struct {
+	api.Logger
+	output string
+}
+
+func (l *unstructuredLogger) Debug(args ...interface{}) {
+	l.output = "DEBUG " + fmt.Sprint(args...)
+}
+
+func (l *unstructuredLogger) Info(args ...interface{}) {
+	l.output = "INFO " + fmt.Sprint(args...)
+}
+
+func (l *unstructuredLogger) Warn(args ...interface{}) {
+	l.output = "WARN " + fmt.Sprint(args...)
+}
+
+func (l *unstructuredLogger) Error(args ...interface{}) {
+	l.output = "ERROR " + fmt.Sprint(args...)
+}
+
+type structuredLogger struct {
+	unstructuredLogger
+	level  api.Level
+	msg    string
+	fields []api.Field
+}
+
+func (l *structuredLogger) Logw(level api.Level, msg string, fields ...api.Field) {
+	l.level = level
+	l.msg = msg
+	l.fields = fields
+}
@@ -0,0 +1,84 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package metrics provides a hook through which the SDK reports metrics such as the latency of
+// proposals, the number of retries and the number of active gRPC connections.
+//
+// The SDK doesn't depend on a particular metrics library. Applications set a Collector (see
+// SetCollector) which records the metrics in their metrics backend, e.g. a Prometheus collector
+// which observes proposal latencies in a histogram, counts retries in a counter and tracks
+// active connections in a gauge:
+//
+//	func (c *promCollector) ObserveProposalLatency(target string, latency time.Duration, err error) {
+//		group, code := metrics.StatusLabels(err)
+//		c.latency.WithLabelValues(target, group, code).Observe(latency.Seconds())
+//	}
+//
+// By default metrics are discarded.
+package metrics
+
+import (
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/errors/status"
+)
+
+// Collector records SDK metrics. Implementations must be safe for concurrent use and
+// should not block since they are invoked inline.
+type Collector interface {
+	// ObserveProposalLatency records the time taken by the given peer (target) to process a
+	// proposal. The error is nil if the proposal was processed successfully.
+	ObserveProposalLatency(target string, latency time.Duration, err error)
+	// IncRetries is invoked each time an operation is retried due to the given error
+	IncRetries(err error)
+	// AddActiveConnections adds the given delta (which may be negative) to the number of active gRPC connections
+	AddActiveConnections(delta int)
+}
+
+type collectorHolder struct {
+	collector Collector
+}
+
+var current atomic.Value
+
+func init() {
+	current.Store(collectorHolder{collector: noopCollector{}})
+}
+
+// SetCollector sets the collector to which the SDK reports metrics. A nil collector discards the metrics.
+func SetCollector(collector Collector) {
+	if collector == nil {
+		collector = noopCollector{}
+	}
+	current.Store(collectorHolder{collector: collector})
+}
+
+// Get returns the current collector
+func Get() Collector {
+	return current.Load().(collectorHolder).collector
+}
+
+// StatusLabels returns the status group and code of the given error as label values. Both
+// label values are empty if the error is nil, and the group is "Unknown" if the error is not
+// a status error.
+func StatusLabels(err error) (group string, code string) {
+	if err == nil {
+		return "", ""
+	}
+	s, ok := status.FromError(err)
+	if !ok {
+		return status.UnknownStatus.String(), ""
+	}
+	return s.Group.String(), strconv.Itoa(int(s.Code))
+}
+
+type noopCollector struct{}
+
+func (noopCollector) ObserveProposalLatency(target string, latency time.Duration, err error) {}
+func (noopCollector) IncRetries(err error)                                                   {}
+func (noopCollector) AddActiveConnections(delta int)                                         {}
@@ -0,0 +1,68 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/errors/status"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetCollector(t *testing.T) {
+	_, ok := Get().(noopCollector)
+	assert.True(t, ok, "expecting no-op collector by default")
+
+	collector := &mockCollector{}
+	SetCollector(collector)
+	assert.Equal(t, collector, Get())
+
+	Get().ObserveProposalLatency("peer1", time.Second, nil)
+	Get().IncRetries(errors.New("error"))
+	Get().AddActiveConnections(2)
+	assert.Equal(t, 1, collector.proposals)
+	assert.Equal(t, 1, collector.retries)
+	assert.Equal(t, 2, collector.connections)
+
+	SetCollector(nil)
+	_, ok = Get().(noopCollector)
+	assert.True(t, ok, "expecting no-op collector after setting nil collector")
+}
+
+func TestStatusLabels(t *testing.T) {
+	group, code := StatusLabels(nil)
+	assert.Empty(t, group)
+	assert.Empty(t, code)
+
+	group, code = StatusLabels(errors.Wrap(status.New(status.EndorserClientStatus, status.DialTimeout.ToInt32(), "dial timed out", nil), "proposal failed"))
+	assert.Equal(t, "Endorser Client Status", group)
+	assert.Equal(t, "26", code)
+
+	group, code = StatusLabels(errors.New("error"))
+	assert.Equal(t, "Unknown", group)
+	assert.Empty(t, code)
+}
+
+type mockCollector struct {
+	proposals   int
+	retries     int
+	connections int
+}
+
+func (c *mockCollector) ObserveProposalLatency(target string, latency time.Duration, err error) {
+	c.proposals++
+}
+
+func (c *mockCollector) IncRetries(err error) {
+	c.retries++
+}
+
+func (c *mockCollector) AddActiveConnections(delta int) {
+	c.connections += delta
+}
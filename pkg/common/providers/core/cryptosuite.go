@@ -63,6 +63,14 @@ type CryptoSuite interface {
 	Verify(k Key, signature, digest []byte, opts SignerOpts) (valid bool, err error)
 }
 
+// NonceGenerator may be implemented by a CryptoSuite to supply the nonces used in
+// transaction and signature headers. If it isn't implemented then random nonces are used.
+type NonceGenerator interface {
+
+	// GetNonce returns a new nonce.
+	GetNonce() ([]byte, error)
+}
+
 // Key represents a cryptographic key
 type Key interface {
 
@@ -20,6 +20,34 @@ type OrgAnchorPeer struct {
 	Port int32
 }
 
+// AnchorPeer is the endpoint of an anchor peer
+type AnchorPeer struct {
+	Host string
+	Port int32
+}
+
+// ConsensusType contains the consensus type of the ordering service along with its metadata
+type ConsensusType struct {
+	// Type is the consensus type (e.g. solo, kafka or etcdraft)
+	Type string
+	// State is the consensus state (STATE_NORMAL or STATE_MAINTENANCE)
+	State string
+	// Metadata is the undecoded consensus type specific metadata
+	Metadata []byte
+	// Consenters is the consenter set of the raft cluster (etcdraft only)
+	Consenters []*Consenter
+	// KafkaBrokers are the Kafka brokers (kafka only)
+	KafkaBrokers []string
+}
+
+// Consenter is a member of a raft ordering service cluster
+type Consenter struct {
+	Host          string
+	Port          uint32
+	ClientTLSCert []byte
+	ServerTLSCert []byte
+}
+
 // ChannelConfig allows for interaction with peer regarding channel configuration
 type ChannelConfig interface {
 
@@ -32,8 +60,18 @@ type ChannelCfg interface {
 	ID() string
 	BlockNumber() uint64
 	MSPs() []*mspCfg.MSPConfig
+	// MSPConfigs returns the MSP configuration of each org (application and orderer), keyed by MSP ID
+	MSPConfigs() map[string]*mspCfg.FabricMSPConfig
 	AnchorPeers() []*OrgAnchorPeer
+	// AnchorPeersByMSP returns the anchor peers of each application org, keyed by MSP ID
+	AnchorPeersByMSP() map[string][]AnchorPeer
 	Orderers() []string
+	// OrdererEndpoints returns the endpoints defined in each orderer org, keyed by MSP ID
+	OrdererEndpoints() map[string][]string
+	// ConsensusType returns the consensus type of the ordering service (nil if not defined)
+	ConsensusType() *ConsensusType
+	// Capabilities returns the enabled capabilities of the Channel, Orderer and Application groups, keyed by group
+	Capabilities() map[string][]string
 	Versions() *Versions
 }
 
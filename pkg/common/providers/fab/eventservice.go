@@ -7,6 +7,8 @@ SPDX-License-Identifier: Apache-2.0
 package fab
 
 import (
+	"regexp"
+
 	cb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/common"
 	pb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/peer"
 )
@@ -58,6 +60,18 @@ type CCEvent struct {
 	SourceURL string
 }
 
+// ConfigUpdateEvent contains the data for a channel config update event
+type ConfigUpdateEvent struct {
+	// BlockNumber contains the number of the block in which
+	// the config update was committed
+	BlockNumber uint64
+	// Sequence is the sequence number of the new channel config.
+	// NOTE: Sequence will be zero if the block only contains CONFIG_UPDATE transactions
+	Sequence uint64
+	// SourceURL specifies the URL of the peer that produced the event
+	SourceURL string
+}
+
 // Registration is a handle that is returned from a successful RegisterXXXEvent.
 // This handle should be used in Unregister in order to unregister the event.
 type Registration interface{}
@@ -103,6 +117,26 @@ type EventService interface {
 	Unregister(reg Registration)
 }
 
+// CCEventPatternRegistrar is implemented by event services which support registering for
+// chaincode events with a compiled regular expression
+type CCEventPatternRegistrar interface {
+	// RegisterChaincodeEventByPattern registers for chaincode events whose name matches the given pattern.
+	// Note that Unregister must be called when the registration is no longer needed.
+	// - ccID is the chaincode ID for which events are to be received
+	// - pattern is the compiled regular expression which is matched against the event name
+	// - Returns the registration and a channel that is used to receive events. The channel
+	//   is closed when Unregister is called.
+	RegisterChaincodeEventByPattern(ccID string, pattern *regexp.Regexp) (Registration, <-chan *CCEvent, error)
+}
+
+// StartBlockReporter is implemented by event services which report the number of the
+// first block for which an event was received
+type StartBlockReporter interface {
+	// StartBlockNum returns the number of the first block for which an event was received.
+	// False is returned if no block has been received yet.
+	StartBlockNum() (uint64, bool)
+}
+
 // ConnectionEvent is sent when the client disconnects from or
 // reconnects to the event server. Connected == true means that the
 // client has connected, whereas Connected == false means that the
@@ -75,10 +75,22 @@ type OrganizationConfig struct {
 	MSPID                  string
 	CryptoPath             string
 	Users                  map[string]endpoint.TLSKeyPair
+	HSMKeys                map[string]HSMKeyConfig
 	Peers                  []string
 	CertificateAuthorities []string
 }
 
+// HSMKeyConfig identifies the private key of a user which is held in a PKCS#11 token (HSM).
+// The user's certificate is still loaded from the embedded users or the crypto path.
+type HSMKeyConfig struct {
+	// Label is the label of the PKCS#11 token which holds the key. It must be the token
+	// of the SDK's PKCS#11 crypto suite (client.BCCSP.security.label).
+	Label string
+	// ObjectID is the hex encoded ID (CKA_ID) of the key object. If empty then the
+	// subject key identifier of the user's certificate is used.
+	ObjectID string
+}
+
 // OrdererConfig defines an orderer configuration
 type OrdererConfig struct {
 	URL         string
@@ -6,6 +6,10 @@ SPDX-License-Identifier: Apache-2.0
 
 package fab
 
+import (
+	reqContext "context"
+)
+
 // The Peer class represents a peer in the target blockchain network to which
 // HFC sends endorsement proposals or query requests.
 type Peer interface {
@@ -18,3 +22,17 @@ type Peer interface {
 
 	// TODO: Roles, Name, EnrollmentCertificate (if needed)
 }
+
+// HealthChecker is optionally implemented by peers which support checking whether
+// the peer is reachable, e.g. before selecting it as a target
+type HealthChecker interface {
+	// Health returns an error if the peer is not reachable
+	Health(ctx reqContext.Context) error
+}
+
+// PeerState is optionally implemented by peers whose state is known, e.g. peers
+// returned by the Discovery service
+type PeerState interface {
+	// BlockHeight returns the height of the peer's ledger
+	BlockHeight() uint64
+}
@@ -35,6 +35,11 @@ type Identity interface {
 
 	// EnrollmentCertificate Returns the underlying ECert representing this user’s identity.
 	EnrollmentCertificate() []byte
+
+	// Attributes returns the attributes (used for attribute-based access control) which the
+	// Fabric CA embedded in the enrollment certificate. An empty map is returned if the
+	// certificate has no attributes.
+	Attributes() (map[string]string, error)
 }
 
 // SigningIdentity is an extension of Identity to cover signing capabilities.
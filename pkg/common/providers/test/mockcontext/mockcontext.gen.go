@@ -192,6 +192,19 @@ func (m *MockClient) EXPECT() *MockClientMockRecorder {
 	return m.recorder
 }
 
+// Attributes mocks base method
+func (m *MockClient) Attributes() (map[string]string, error) {
+	ret := m.ctrl.Call(m, "Attributes")
+	ret0, _ := ret[0].(map[string]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Attributes indicates an expected call of Attributes
+func (mr *MockClientMockRecorder) Attributes() *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Attributes", reflect.TypeOf((*MockClient)(nil).Attributes))
+}
+
 // ChannelProvider mocks base method
 func (m *MockClient) ChannelProvider() fab.ChannelProvider {
 	ret := m.ctrl.Call(m, "ChannelProvider")
@@ -0,0 +1,150 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package tracing provides tracing spans around the major SDK operations (channel config
+// queries, channel client execute/query, orderer deliver and discovery).
+//
+// The SDK doesn't depend on a particular tracing library. Instead, spans are created by a
+// Tracer which is either set globally (see SetTracer) or attached to the request context
+// (see WithTracer). The interfaces follow the OpenTelemetry API so that an OpenTelemetry
+// tracer may be plugged in with a thin adapter, for example:
+//
+//	type otelTracer struct{ trace.Tracer }
+//
+//	func (t otelTracer) Start(ctx context.Context, name string) (context.Context, tracing.Span) {
+//		ctx, span := t.Tracer.Start(ctx, name)
+//		return ctx, otelSpan{span}
+//	}
+//
+// If no tracer is configured then tracing is a no-op.
+package tracing
+
+import (
+	reqContext "context"
+	"strings"
+	"sync"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/errors/status"
+)
+
+// Span attribute keys
+const (
+	// TargetsKey is the attribute holding the target endpoint(s) of the operation
+	TargetsKey = "fabric.targets"
+	// AttemptsKey is the attribute holding the number of attempts made (including retries)
+	AttemptsKey = "fabric.attempts"
+	// ChannelKey is the attribute holding the channel ID
+	ChannelKey = "fabric.channel"
+	// ChaincodeKey is the attribute holding the chaincode ID
+	ChaincodeKey = "fabric.chaincode"
+	// FunctionKey is the attribute holding the chaincode function
+	FunctionKey = "fabric.function"
+	// StatusGroupKey is the attribute holding the status group of a failed operation (see status.Group)
+	StatusGroupKey = "fabric.status.group"
+	// StatusCodeKey is the attribute holding the status code of a failed operation
+	StatusCodeKey = "fabric.status.code"
+)
+
+// Tracer starts spans
+type Tracer interface {
+	// Start starts a span with the given name. The returned context carries the span
+	// so that spans started from it are children of the span.
+	Start(ctx reqContext.Context, name string) (reqContext.Context, Span)
+}
+
+// Span is a single traced operation
+type Span interface {
+	// SetAttribute sets an attribute of the span
+	SetAttribute(key string, value interface{})
+	// RecordError records the given error and marks the span as failed
+	RecordError(err error)
+	// End ends the span
+	End()
+}
+
+type tracerContextKey struct{}
+type spanContextKey struct{}
+
+var mutex sync.RWMutex
+var globalTracer Tracer
+
+// SetTracer sets the global tracer which is used if no tracer is attached to the request context.
+// A nil tracer disables tracing.
+func SetTracer(tracer Tracer) {
+	mutex.Lock()
+	defer mutex.Unlock()
+	globalTracer = tracer
+}
+
+// WithTracer returns a copy of the parent context to which the given tracer is attached. The
+// returned context should be passed as the parent of the request context (e.g. using
+// channel.WithParentContext), in which case the tracer overrides the global tracer.
+func WithTracer(parent reqContext.Context, tracer Tracer) reqContext.Context {
+	return reqContext.WithValue(parent, tracerContextKey{}, tracer)
+}
+
+// StartSpan starts a span with the given name using the tracer attached to the context or, if none,
+// the global tracer. The span may be retrieved from the returned context using SpanFromContext. If no
+// tracer is configured then the context is returned as is, along with a no-op span.
+func StartSpan(ctx reqContext.Context, name string) (reqContext.Context, Span) {
+	tracer := tracerFromContext(ctx)
+	if tracer == nil {
+		return ctx, noopSpan{}
+	}
+	ctx, span := tracer.Start(ctx, name)
+	return reqContext.WithValue(ctx, spanContextKey{}, span), span
+}
+
+// SpanFromContext returns the current span of the given context, or a no-op span if there is none
+func SpanFromContext(ctx reqContext.Context) Span {
+	if span, ok := ctx.Value(spanContextKey{}).(Span); ok {
+		return span
+	}
+	return noopSpan{}
+}
+
+// EndSpan records the outcome of the operation on the given span and ends the span. If the error is
+// a status error then its group and code are recorded as attributes.
+func EndSpan(span Span, err error) {
+	if err != nil {
+		if s, ok := status.FromError(err); ok {
+			span.SetAttribute(StatusGroupKey, s.Group.String())
+			span.SetAttribute(StatusCodeKey, s.Code)
+		}
+		span.RecordError(err)
+	}
+	span.End()
+}
+
+// IsRecording returns true if the given span is not a no-op span. It may be used to avoid
+// computing expensive attributes when tracing is disabled.
+func IsRecording(span Span) bool {
+	_, ok := span.(noopSpan)
+	return !ok
+}
+
+// Targets converts the given endpoints into a span attribute value
+func Targets(targets []string) string {
+	return strings.Join(targets, ",")
+}
+
+func tracerFromContext(ctx reqContext.Context) Tracer {
+	if ctx != nil {
+		if tracer, ok := ctx.Value(tracerContextKey{}).(Tracer); ok && tracer != nil {
+			return tracer
+		}
+	}
+
+	mutex.RLock()
+	defer mutex.RUnlock()
+	return globalTracer
+}
+
+type noopSpan struct{}
+
+func (noopSpan) SetAttribute(key string, value interface{}) {}
+func (noopSpan) RecordError(err error)                      {}
+func (noopSpan) End()                                       {}
@@ -0,0 +1,128 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package tracing
+
+import (
+	reqContext "context"
+	"sync"
+	"testing"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/errors/status"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNoTracer(t *testing.T) {
+	ctx := reqContext.Background()
+
+	spanCtx, span := StartSpan(ctx, "test")
+	assert.Equal(t, ctx, spanCtx, "expecting context to be returned as is")
+	assert.False(t, IsRecording(span))
+	assert.False(t, IsRecording(SpanFromContext(spanCtx)))
+
+	// Should be a no-op
+	span.SetAttribute(TargetsKey, "peer1")
+	EndSpan(span, errors.New("error"))
+}
+
+func TestGlobalTracer(t *testing.T) {
+	tracer := &mockTracer{}
+	SetTracer(tracer)
+	defer SetTracer(nil)
+
+	ctx, span := StartSpan(reqContext.Background(), "parent")
+	assert.True(t, IsRecording(span))
+	assert.Equal(t, span, SpanFromContext(ctx))
+
+	_, child := StartSpan(ctx, "child")
+	child.SetAttribute(AttemptsKey, 2)
+	EndSpan(child, status.New(status.EndorserClientStatus, status.DialTimeout.ToInt32(), "dial timed out", nil))
+	EndSpan(span, nil)
+
+	spans := tracer.Spans()
+	assert.Len(t, spans, 2)
+
+	assert.Equal(t, "parent", spans[0].name)
+	assert.True(t, spans[0].ended)
+	assert.Nil(t, spans[0].err)
+
+	assert.Equal(t, "child", spans[1].name)
+	assert.Equal(t, spans[0], spans[1].parent)
+	assert.True(t, spans[1].ended)
+	assert.Error(t, spans[1].err)
+	assert.Equal(t, 2, spans[1].Attribute(AttemptsKey))
+	assert.Equal(t, "Endorser Client Status", spans[1].Attribute(StatusGroupKey))
+	assert.Equal(t, status.DialTimeout.ToInt32(), spans[1].Attribute(StatusCodeKey))
+}
+
+func TestContextTracer(t *testing.T) {
+	globalTracer := &mockTracer{}
+	SetTracer(globalTracer)
+	defer SetTracer(nil)
+
+	tracer := &mockTracer{}
+	_, span := StartSpan(WithTracer(reqContext.Background(), tracer), "test")
+	span.SetAttribute(TargetsKey, Targets([]string{"peer1", "peer2"}))
+	EndSpan(span, nil)
+
+	assert.Empty(t, globalTracer.Spans(), "expecting context tracer to override global tracer")
+	spans := tracer.Spans()
+	assert.Len(t, spans, 1)
+	assert.Equal(t, "peer1,peer2", spans[0].Attribute(TargetsKey))
+}
+
+type mockTracer struct {
+	mutex sync.Mutex
+	spans []*mockSpan
+}
+
+func (m *mockTracer) Start(ctx reqContext.Context, name string) (reqContext.Context, Span) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	span := &mockSpan{name: name, attributes: make(map[string]interface{})}
+	if parent, ok := SpanFromContext(ctx).(*mockSpan); ok {
+		span.parent = parent
+	}
+	m.spans = append(m.spans, span)
+	return ctx, span
+}
+
+func (m *mockTracer) Spans() []*mockSpan {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return m.spans
+}
+
+type mockSpan struct {
+	mutex      sync.Mutex
+	name       string
+	parent     *mockSpan
+	attributes map[string]interface{}
+	err        error
+	ended      bool
+}
+
+func (s *mockSpan) SetAttribute(key string, value interface{}) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.attributes[key] = value
+}
+
+func (s *mockSpan) Attribute(key string) interface{} {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.attributes[key]
+}
+
+func (s *mockSpan) RecordError(err error) {
+	s.err = err
+}
+
+func (s *mockSpan) End() {
+	s.ended = true
+}
@@ -13,10 +13,13 @@ import (
 
 	"time"
 
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/logging"
 	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/context"
 	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/core"
 	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
 	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/msp"
+	"github.com/hyperledger/fabric-sdk-go/pkg/core/logging/api"
+	"google.golang.org/grpc/metadata"
 )
 
 // Client supplies the configuration and signing identity to client objects.
@@ -344,6 +347,11 @@ type reqContextKey string
 var ReqContextTimeoutOverrides = reqContextKey("timeout-overrides")
 var reqContextCommManager = reqContextKey("commManager")
 var reqContextClient = reqContextKey("clientContext")
+var reqContextRequestID = reqContextKey("requestID")
+var reqContextSigningIdentity = reqContextKey("signingIdentity")
+
+// RequestIDMetadataKey is the gRPC metadata key under which the request ID is sent to peers and orderers
+const RequestIDMetadataKey = "x-request-id"
 
 //WithTimeoutType sets timeout by type defined in config to request context
 func WithTimeoutType(timeoutType fab.TimeoutType) ReqContextOptions {
@@ -399,6 +407,10 @@ func NewRequest(client context.Client, options ...ReqContextOptions) (reqContext
 		timeout = client.EndpointConfig().Timeout(reqCtxOpts.timeoutType)
 	}
 
+	if identity, ok := RequestSigningIdentity(parentContext); ok {
+		client = &Client{Providers: client, SigningIdentity: identity}
+	}
+
 	ctx := reqContext.WithValue(parentContext, reqContextCommManager, client.InfraProvider().CommManager())
 	ctx = reqContext.WithValue(ctx, reqContextClient, client)
 	ctx, cancel := reqContext.WithTimeout(ctx, timeout)
@@ -418,6 +430,73 @@ func RequestClientContext(ctx reqContext.Context) (context.Client, bool) {
 	return clientContext, ok
 }
 
+//RequestIDOptions parameter for attaching a request ID to a context
+type RequestIDOptions func(opts *requestIDOpts)
+
+type requestIDOpts struct {
+	metadata bool
+}
+
+//WithRequestIDMetadata also sends the request ID to peers and orderers as gRPC metadata (see RequestIDMetadataKey)
+func WithRequestIDMetadata() RequestIDOptions {
+	return func(opts *requestIDOpts) {
+		opts.metadata = true
+	}
+}
+
+// WithRequestID returns a copy of the parent context which carries the given client-generated
+// request (correlation) ID. The request ID is logged by the SDK at each stage of a request
+// (channel config query, proposal processing, broadcast and deliver) so that the SDK logs for
+// a single logical operation may be correlated with each other and, if WithRequestIDMetadata is
+// specified, with the peer and orderer logs. The returned context should be passed as the
+// parent of the request context, e.g. using channel.WithParentContext.
+func WithRequestID(parent reqContext.Context, id string, options ...RequestIDOptions) reqContext.Context {
+	opts := requestIDOpts{}
+	for _, option := range options {
+		option(&opts)
+	}
+
+	ctx := reqContext.WithValue(parent, reqContextRequestID, id)
+	if opts.metadata {
+		ctx = metadata.AppendToOutgoingContext(ctx, RequestIDMetadataKey, id)
+	}
+	return ctx
+}
+
+// RequestID extracts the request ID from the request-scoped context.
+func RequestID(ctx reqContext.Context) (string, bool) {
+	id, ok := ctx.Value(reqContextRequestID).(string)
+	return id, ok && id != ""
+}
+
+// WithSigningIdentity returns a copy of the parent context which overrides the signing identity of
+// the client context for the requests created from it. Proposals and transactions of a request
+// whose parent is the returned context (e.g. set using channel.WithParentContext) are created and
+// signed with the given identity instead of the client's identity, which allows for a single
+// operation to be performed as a different user (e.g. an admin) without creating a new client.
+// Requests created from other contexts, including the given parent, are not affected.
+func WithSigningIdentity(parent reqContext.Context, identity msp.SigningIdentity) reqContext.Context {
+	if identity == nil {
+		return parent
+	}
+	return reqContext.WithValue(parent, reqContextSigningIdentity, identity)
+}
+
+// RequestSigningIdentity extracts the overriding signing identity (see WithSigningIdentity) from the request-scoped context.
+func RequestSigningIdentity(ctx reqContext.Context) (msp.SigningIdentity, bool) {
+	identity, ok := ctx.Value(reqContextSigningIdentity).(msp.SigningIdentity)
+	return identity, ok
+}
+
+// LogFields returns the given log fields along with the request ID field if the
+// request-scoped context carries a request ID.
+func LogFields(ctx reqContext.Context, fields ...api.Field) []api.Field {
+	if id, ok := RequestID(ctx); ok {
+		return append(fields, logging.RequestID(id))
+	}
+	return fields
+}
+
 // requestTimeoutOverrides extracts the timeout from timeout override map from the request-scoped context.
 func requestTimeoutOverride(ctx reqContext.Context, timeoutType fab.TimeoutType) time.Duration {
 	timeoutOverrides, ok := ctx.Value(ReqContextTimeoutOverrides).(map[fab.TimeoutType]time.Duration)
@@ -0,0 +1,55 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package context
+
+import (
+	reqContext "context"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/mocks"
+	mspmocks "github.com/hyperledger/fabric-sdk-go/pkg/msp/test/mockmsp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithSigningIdentity(t *testing.T) {
+	user := mspmocks.NewMockSigningIdentity("user1", "Org1MSP")
+	admin := mspmocks.NewMockSigningIdentity("admin", "Org1MSP")
+	ctx := mocks.NewMockContext(user)
+
+	parent := reqContext.Background()
+	adminParent := WithSigningIdentity(parent, admin)
+
+	reqCtx, cancel := NewRequest(ctx, WithTimeout(time.Second), WithParent(adminParent))
+	defer cancel()
+
+	client, ok := RequestClientContext(reqCtx)
+	require.True(t, ok)
+	assert.Equal(t, "admin", client.Identifier().ID, "expecting overridden identity")
+	assert.Equal(t, ctx.EndpointConfig(), client.EndpointConfig(), "expecting providers of the client context")
+
+	// Child requests (e.g. created by transactors) inherit the override
+	childCtx, childCancel := NewRequest(client, WithTimeout(time.Second), WithParent(reqCtx))
+	defer childCancel()
+	child, ok := RequestClientContext(childCtx)
+	require.True(t, ok)
+	assert.Equal(t, "admin", child.Identifier().ID)
+
+	// Requests created from the parent (or without a parent) are not affected
+	for _, p := range []reqContext.Context{parent, nil} {
+		reqCtx, cancel := NewRequest(ctx, WithTimeout(time.Second), WithParent(p))
+		defer cancel()
+		client, ok := RequestClientContext(reqCtx)
+		require.True(t, ok)
+		assert.Equal(t, "user1", client.Identifier().ID, "expecting default identity")
+	}
+
+	_, ok = RequestSigningIdentity(parent)
+	assert.False(t, ok)
+	assert.True(t, WithSigningIdentity(parent, nil) == parent, "expecting parent for nil identity")
+}
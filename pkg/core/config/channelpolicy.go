@@ -0,0 +1,88 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package config
+
+import (
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/errors/multi"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/errors/retry"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+	"github.com/pkg/errors"
+)
+
+// ChannelPolicyBuilder assembles a QueryChannelConfigPolicy. Values which are not set are
+// left as zero so that the defaults are applied when the channel config is queried.
+type ChannelPolicyBuilder struct {
+	policy fab.QueryChannelConfigPolicy
+}
+
+// NewChannelPolicyBuilder returns a new channel policy builder
+func NewChannelPolicyBuilder() *ChannelPolicyBuilder {
+	return &ChannelPolicyBuilder{}
+}
+
+// MinResponses sets the minimum number of matching responses required from the targets
+func (b *ChannelPolicyBuilder) MinResponses(min int) *ChannelPolicyBuilder {
+	b.policy.MinResponses = min
+	return b
+}
+
+// MaxTargets sets the maximum number of targets which are queried
+func (b *ChannelPolicyBuilder) MaxTargets(max int) *ChannelPolicyBuilder {
+	b.policy.MaxTargets = max
+	return b
+}
+
+// RetryOpts sets the options of the query retry handler
+func (b *ChannelPolicyBuilder) RetryOpts(opts retry.Opts) *ChannelPolicyBuilder {
+	b.policy.RetryOpts = opts
+	return b
+}
+
+// Build validates and returns the policy. All of the problems found are returned in the error.
+func (b *ChannelPolicyBuilder) Build() (fab.QueryChannelConfigPolicy, error) {
+	var errs multi.Errors
+
+	p := b.policy
+	if p.MinResponses < 0 {
+		errs = append(errs, errors.Errorf("min responses [%d] must not be negative", p.MinResponses))
+	}
+	if p.MaxTargets < 0 {
+		errs = append(errs, errors.Errorf("max targets [%d] must not be negative", p.MaxTargets))
+	}
+	if p.MaxTargets > 0 && p.MaxTargets < p.MinResponses {
+		errs = append(errs, errors.Errorf("max targets [%d] must not be less than min responses [%d]", p.MaxTargets, p.MinResponses))
+	}
+	errs = append(errs, validateRetryOpts(p.RetryOpts)...)
+
+	if err := errs.ToError(); err != nil {
+		return fab.QueryChannelConfigPolicy{}, errors.WithMessage(err, "invalid query channel config policy")
+	}
+	return p, nil
+}
+
+func validateRetryOpts(opts retry.Opts) multi.Errors {
+	var errs multi.Errors
+	if opts.Attempts < 0 {
+		errs = append(errs, errors.Errorf("retry attempts [%d] must not be negative", opts.Attempts))
+	}
+	if opts.InitialBackoff < 0 {
+		errs = append(errs, errors.Errorf("retry initial backoff [%s] must not be negative", opts.InitialBackoff))
+	}
+	if opts.MaxBackoff < 0 {
+		errs = append(errs, errors.Errorf("retry max backoff [%s] must not be negative", opts.MaxBackoff))
+	}
+	if opts.MaxBackoff > 0 && opts.MaxBackoff < opts.InitialBackoff {
+		errs = append(errs, errors.Errorf("retry max backoff [%s] must not be less than initial backoff [%s]", opts.MaxBackoff, opts.InitialBackoff))
+	}
+	if opts.BackoffFactor != 0 && opts.BackoffFactor < 1 {
+		errs = append(errs, errors.Errorf("retry backoff factor [%v] must not be less than 1", opts.BackoffFactor))
+	}
+	if opts.MaxElapsedTime < 0 {
+		errs = append(errs, errors.Errorf("retry max elapsed time [%s] must not be negative", opts.MaxElapsedTime))
+	}
+	return errs
+}
@@ -0,0 +1,53 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/errors/multi"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/errors/retry"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChannelPolicyBuilder(t *testing.T) {
+	retryOpts := retry.Opts{Attempts: 3, InitialBackoff: time.Second, MaxBackoff: 10 * time.Second, BackoffFactor: 2}
+
+	policy, err := NewChannelPolicyBuilder().MinResponses(2).MaxTargets(3).RetryOpts(retryOpts).Build()
+	require.NoError(t, err)
+	assert.Equal(t, 2, policy.MinResponses)
+	assert.Equal(t, 3, policy.MaxTargets)
+	assert.Equal(t, retryOpts, policy.RetryOpts)
+
+	policy, err = NewChannelPolicyBuilder().Build()
+	require.NoError(t, err, "expecting unset values to be left for the defaults")
+	assert.Zero(t, policy.MinResponses)
+	assert.Zero(t, policy.MaxTargets)
+}
+
+func TestChannelPolicyBuilderInvalid(t *testing.T) {
+	_, err := NewChannelPolicyBuilder().MinResponses(2).MaxTargets(1).Build()
+	assert.Error(t, err, "expecting error since max targets is less than min responses")
+
+	_, err = NewChannelPolicyBuilder().MinResponses(-1).Build()
+	assert.Error(t, err)
+
+	_, err = NewChannelPolicyBuilder().RetryOpts(retry.Opts{BackoffFactor: 0.5}).Build()
+	assert.Error(t, err)
+
+	_, err = NewChannelPolicyBuilder().RetryOpts(retry.Opts{InitialBackoff: time.Second, MaxBackoff: time.Millisecond}).Build()
+	assert.Error(t, err)
+
+	_, err = NewChannelPolicyBuilder().MaxTargets(-1).RetryOpts(retry.Opts{Attempts: -1, MaxElapsedTime: -time.Second}).Build()
+	require.Error(t, err)
+	errs, ok := errors.Cause(err).(multi.Errors)
+	require.True(t, ok, "expecting all of the problems to be reported")
+	assert.Len(t, errs, 3)
+}
@@ -8,16 +8,26 @@ package comm
 
 import (
 	"crypto/tls"
+	"sync"
 
 	"crypto/x509"
 
 	cutil "github.com/hyperledger/fabric-sdk-go/internal/github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/logging"
 	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
 	"github.com/pkg/errors"
 )
 
+var logger = logging.NewLogger("fabsdk/core")
+
 // TLSConfig returns the appropriate config for TLS including the root CAs,
 // certs for mutual TLS, and server host override. Works with certs loaded either from a path or embedded pem.
+//
+// The client certificate for mutual TLS is fetched from the endpoint config on each TLS handshake
+// (see ClientCertificateGetter), so rotated client certificates are picked up by new handshakes
+// without having to tear down existing connections. Note that connections which are cached by the
+// connection pool keep the certificate with which they were established until they are closed
+// (e.g. after the connection idle timeout) and re-established.
 func TLSConfig(cert *x509.Certificate, serverName string, config fab.EndpointConfig) (*tls.Config, error) {
 	certPool, err := config.TLSCACertPool()
 	if err != nil {
@@ -40,7 +50,41 @@ func TLSConfig(cert *x509.Certificate, serverName string, config fab.EndpointCon
 		return nil, errors.Errorf("Error loading cert/key pair for TLS client credentials: %v", err)
 	}
 
-	return &tls.Config{RootCAs: tlsCaCertPool, Certificates: clientCerts, ServerName: serverName}, nil
+	return &tls.Config{
+		RootCAs:              tlsCaCertPool,
+		Certificates:         clientCerts,
+		GetClientCertificate: ClientCertificateGetter(config.TLSClientCerts, clientCerts),
+		ServerName:           serverName,
+	}, nil
+}
+
+// ClientCertificateGetter returns a callback for tls.Config.GetClientCertificate which fetches the
+// current client certificate using the given function on each TLS handshake. If the certificate can't
+// be fetched then the last certificate which was fetched successfully (initially the given certs) is used.
+func ClientCertificateGetter(getCerts func() ([]tls.Certificate, error), certs []tls.Certificate) func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+	var mutex sync.Mutex
+	lastCerts := certs
+
+	return func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		currentCerts, err := getCerts()
+		if err != nil {
+			logger.Warnf("Failed to load TLS client certificate - using the previously loaded certificate: %s", err)
+		} else {
+			lastCerts = currentCerts
+		}
+
+		for i := range lastCerts {
+			if len(lastCerts[i].Certificate) > 0 {
+				return &lastCerts[i], nil
+			}
+		}
+
+		// No client certificate is configured
+		return &tls.Certificate{}, nil
+	}
 }
 
 // TLSCertHash is a utility method to calculate the SHA256 hash of the configured certificate (for usage in channel headers)
@@ -19,6 +19,7 @@ import (
 
 	"github.com/golang/mock/gomock"
 	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/test/mockfab"
+	"github.com/pkg/errors"
 )
 
 func TestTLSConfigErrorAddingCertificate(t *testing.T) {
@@ -136,3 +137,59 @@ func TestTlsCertHash(t *testing.T) {
 		t.Fatal("Cert hash calculated incorrectly")
 	}
 }
+
+func TestTLSConfigClientCertRotation(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	config := mockfab.NewMockEndpointConfig(mockCtrl)
+
+	rotatedCert, err := tls.LoadX509KeyPair("testdata/server.crt", "testdata/server.key")
+	if err != nil {
+		t.Fatalf("Unexpected error loading cert %v", err)
+	}
+
+	config.EXPECT().TLSCACertPool().Return(mockfab.CertPool, nil).AnyTimes()
+	config.EXPECT().TLSCACertPool(mockfab.GoodCert).Return(mockfab.CertPool, nil).AnyTimes()
+	gomock.InOrder(
+		config.EXPECT().TLSClientCerts().Return([]tls.Certificate{mockfab.TLSCert}, nil).Times(2),
+		config.EXPECT().TLSClientCerts().Return([]tls.Certificate{rotatedCert}, nil),
+		config.EXPECT().TLSClientCerts().Return(nil, errors.New("cert file is being replaced")),
+	)
+
+	tlsConfig, err := TLSConfig(mockfab.GoodCert, "", config)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if tlsConfig.GetClientCertificate == nil {
+		t.Fatal("Expecting client certificate callback")
+	}
+
+	cert, err := tlsConfig.GetClientCertificate(&tls.CertificateRequestInfo{})
+	if err != nil || !reflect.DeepEqual(*cert, mockfab.TLSCert) {
+		t.Fatal("Expecting the current client cert")
+	}
+
+	cert, err = tlsConfig.GetClientCertificate(&tls.CertificateRequestInfo{})
+	if err != nil || !reflect.DeepEqual(*cert, rotatedCert) {
+		t.Fatal("Expecting the rotated client cert")
+	}
+
+	cert, err = tlsConfig.GetClientCertificate(&tls.CertificateRequestInfo{})
+	if err != nil || !reflect.DeepEqual(*cert, rotatedCert) {
+		t.Fatal("Expecting the previously loaded client cert when the cert can't be loaded")
+	}
+}
+
+func TestClientCertificateGetterNoCert(t *testing.T) {
+	getter := ClientCertificateGetter(func() ([]tls.Certificate, error) {
+		return []tls.Certificate{{}}, nil
+	}, nil)
+
+	cert, err := getter(&tls.CertificateRequestInfo{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if len(cert.Certificate) != 0 {
+		t.Fatal("Expecting no client cert")
+	}
+}
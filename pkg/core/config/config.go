@@ -19,12 +19,15 @@ import (
 	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/core"
 )
 
+var logger = logging.NewLogger("fabsdk/core")
+
 var logModules = [...]string{"fabsdk", "fabsdk/client", "fabsdk/core", "fabsdk/fab", "fabsdk/common",
 	"fabsdk/msp", "fabsdk/util", "fabsdk/context"}
 
 type options struct {
-	envPrefix    string
-	templatePath string
+	envPrefix     string
+	templatePath  string
+	overlayPrefix string
 }
 
 const (
@@ -65,7 +68,7 @@ func FromFile(name string, opts ...Option) core.ConfigProvider {
 
 		setLogLevel(backend)
 
-		return []core.ConfigBackend{backend}, nil
+		return backends(backend)
 	}
 }
 
@@ -96,7 +99,15 @@ func initFromReader(in io.Reader, configType string, opts ...Option) ([]core.Con
 	}
 	setLogLevel(backend)
 
-	return []core.ConfigBackend{backend}, nil
+	return backends(backend)
+}
+
+// backends returns the given backend with the environment overlay applied, if configured
+func backends(backend *defConfigBackend) ([]core.ConfigBackend, error) {
+	if backend.opts.overlayPrefix == "" {
+		return []core.ConfigBackend{backend}, nil
+	}
+	return overlay(backend.opts.overlayPrefix, backend)
 }
 
 // WithEnvPrefix defines the prefix for environment variable overrides.
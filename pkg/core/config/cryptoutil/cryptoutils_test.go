@@ -7,7 +7,15 @@ SPDX-License-Identifier: Apache-2.0
 package cryptoutil
 
 import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
 	"testing"
+	"time"
 
 	fabricCaUtil "github.com/hyperledger/fabric-sdk-go/internal/github.com/hyperledger/fabric-ca/util"
 	"github.com/hyperledger/fabric-sdk-go/pkg/core/cryptosuite"
@@ -79,6 +87,56 @@ func TestX509KeyPair(t *testing.T) {
 
 }
 
+func TestX509KeyPairEd25519(t *testing.T) {
+
+	cs := cryptosuite.GetDefault()
+
+	pubKey, privKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %s", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "ed25519"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, pubKey, privKey)
+	if err != nil {
+		t.Fatalf("Failed to create certificate: %s", err)
+	}
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+
+	keyDER, err := x509.MarshalPKCS8PrivateKey(privKey)
+	if err != nil {
+		t.Fatalf("Failed to marshal private key: %s", err)
+	}
+	key, err := fabricCaUtil.ImportBCCSPKeyFromPEMBytes(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER}), cs, true)
+	if err != nil {
+		t.Fatalf("Failed to import private key from pem: %s", err)
+	}
+
+	cert, err := X509KeyPair(certPEM, key, cs)
+	if err != nil {
+		t.Fatalf("Failed to load key pair: %s", err)
+	}
+
+	signer := cert.PrivateKey.(crypto.Signer)
+	if _, ok := signer.Public().(ed25519.PublicKey); !ok {
+		t.Fatalf("Expected Ed25519 public key but got %T", signer.Public())
+	}
+
+	// TLS passes the message itself (rather than a digest) for Ed25519
+	msg := []byte("Hello")
+	signature, err := signer.Sign(rand.Reader, msg, crypto.Hash(0))
+	if err != nil {
+		t.Fatalf("Error signing message: %s", err)
+	}
+	if !ed25519.Verify(pubKey, msg, signature) {
+		t.Fatalf("Failed to verify signature")
+	}
+}
+
 func TestPrivateKey(t *testing.T) {
 
 	// Private key without crypto suite
@@ -0,0 +1,334 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package config
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/errors/multi"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/core"
+	"github.com/pkg/errors"
+)
+
+// configSections are the top-level sections of the SDK config which may be overridden
+var configSections = []string{"name", "description", "version", "client", "channels", "organizations",
+	"orderers", "peers", "certificateAuthorities", "entityMatchers"}
+
+// WithEnvOverlay overrides the values of existing config keys with the values of the
+// environment variables which start with the given prefix followed by an underscore.
+// The rest of the variable name is the path of the config key in upper case, where
+// path separators, dots and dashes are all replaced with underscores. For example,
+// with prefix "FABSDK":
+//
+//	FABSDK_CLIENT_PEER_TIMEOUT_CONNECTION=5s
+//	FABSDK_PEERS_PEER0_ORG1_EXAMPLE_COM_URL=peer0.org1.example.com:8051
+//
+// Overrides are coerced to the type of the value they replace (durations, integers,
+// floats, booleans and comma-separated lists). Loading the config fails if a variable
+// does not match a config key or if its value cannot be coerced.
+func WithEnvOverlay(prefix string) Option {
+	return func(opts *options) error {
+		if prefix == "" {
+			return errors.New("environment overlay prefix is required")
+		}
+		opts.overlayPrefix = prefix
+		return nil
+	}
+}
+
+// EnvOverlay returns a config provider which applies the environment variable overrides
+// with the given prefix (see WithEnvOverlay) to the backends of the given provider
+func EnvOverlay(provider core.ConfigProvider, prefix string) core.ConfigProvider {
+	return func() ([]core.ConfigBackend, error) {
+		backends, err := provider()
+		if err != nil {
+			return nil, err
+		}
+		return overlay(prefix, backends...)
+	}
+}
+
+func overlay(prefix string, backends ...core.ConfigBackend) ([]core.ConfigBackend, error) {
+	backend, err := newEnvOverlayBackend(prefix, os.Environ(), backends...)
+	if err != nil {
+		return nil, err
+	}
+	return []core.ConfigBackend{backend}, nil
+}
+
+// envOverlayBackend returns the overridden value of each of the config sections
+// which have overrides and otherwise delegates to the underlying backends
+type envOverlayBackend struct {
+	backends []core.ConfigBackend
+	sections map[string]interface{}
+}
+
+func newEnvOverlayBackend(prefix string, environ []string, backends ...core.ConfigBackend) (*envOverlayBackend, error) {
+	b := &envOverlayBackend{
+		backends: backends,
+		sections: make(map[string]interface{}),
+	}
+
+	root := make(map[string]interface{})
+	for _, section := range configSections {
+		if value, ok := b.lookup(section); ok {
+			root[section] = value
+		}
+	}
+
+	var errs multi.Errors
+	for _, env := range environ {
+		kv := strings.SplitN(env, "=", 2)
+		if len(kv) != 2 || !strings.HasPrefix(kv[0], prefix+"_") {
+			continue
+		}
+
+		if err := b.override(root, strings.TrimPrefix(kv[0], prefix+"_"), kv[1]); err != nil {
+			errs = append(errs, errors.WithMessage(err, fmt.Sprintf("invalid environment override [%s]", kv[0])))
+		}
+	}
+
+	if err := errs.ToError(); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+func (b *envOverlayBackend) override(root map[string]interface{}, name, value string) error {
+	path, ok := matchPath(root, strings.ToUpper(name))
+	if !ok {
+		return errors.New("variable does not match any config key")
+	}
+
+	section := path[0]
+	if _, ok := b.sections[section]; !ok {
+		b.sections[section] = deepCopy(root[section])
+		root[section] = b.sections[section]
+	}
+
+	if len(path) == 1 {
+		coerced, err := coerce(root[section], value)
+		if err != nil {
+			return err
+		}
+		b.sections[section] = coerced
+		root[section] = coerced
+		return nil
+	}
+
+	parent := b.sections[section]
+	for _, key := range path[1 : len(path)-1] {
+		parent, _ = childOf(parent, key)
+	}
+
+	key := path[len(path)-1]
+	current, _ := childOf(parent, key)
+	coerced, err := coerce(current, value)
+	if err != nil {
+		return err
+	}
+
+	logger.Debugf("Overriding config key [%s] from the environment", strings.Join(path, "."))
+	setChild(parent, key, coerced)
+	return nil
+}
+
+// Lookup gets the config item value by Key
+func (b *envOverlayBackend) Lookup(key string) (interface{}, bool) {
+	for section, value := range b.sections {
+		if strings.EqualFold(key, section) {
+			return value, true
+		}
+		if len(key) > len(section) && strings.EqualFold(key[:len(section)+1], section+".") {
+			return lookupPath(value, key[len(section)+1:])
+		}
+	}
+	return b.lookup(key)
+}
+
+func (b *envOverlayBackend) lookup(key string) (interface{}, bool) {
+	for _, backend := range b.backends {
+		if value, ok := backend.Lookup(key); ok {
+			return value, true
+		}
+	}
+	return nil, false
+}
+
+// matchPath returns the keys of the path in the config tree which matches the given
+// environment variable name. Longer keys are matched first so that the result is deterministic.
+func matchPath(value interface{}, name string) ([]string, bool) {
+	for _, key := range sortedKeys(value) {
+		envKey := envName(key)
+		child, _ := childOf(value, key)
+		if name == envKey {
+			return []string{key}, true
+		}
+		if strings.HasPrefix(name, envKey+"_") {
+			if path, ok := matchPath(child, name[len(envKey)+1:]); ok {
+				return append([]string{key}, path...), true
+			}
+		}
+	}
+	return nil, false
+}
+
+// lookupPath returns the value at the given dot-separated path. Since keys may
+// themselves contain dots, the longest matching key is used at each level.
+func lookupPath(value interface{}, path string) (interface{}, bool) {
+	for _, key := range sortedKeys(value) {
+		child, _ := childOf(value, key)
+		if strings.EqualFold(path, key) {
+			return child, true
+		}
+		if len(path) > len(key) && strings.EqualFold(path[:len(key)+1], key+".") {
+			if v, ok := lookupPath(child, path[len(key)+1:]); ok {
+				return v, true
+			}
+		}
+	}
+	return nil, false
+}
+
+func envName(key string) string {
+	return strings.ToUpper(strings.NewReplacer(".", "_", "-", "_").Replace(key))
+}
+
+func sortedKeys(value interface{}) []string {
+	var keys []string
+	switch m := value.(type) {
+	case map[string]interface{}:
+		for k := range m {
+			keys = append(keys, k)
+		}
+	case map[interface{}]interface{}:
+		for k := range m {
+			keys = append(keys, fmt.Sprint(k))
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if len(keys[i]) != len(keys[j]) {
+			return len(keys[i]) > len(keys[j])
+		}
+		return keys[i] < keys[j]
+	})
+	return keys
+}
+
+func childOf(value interface{}, key string) (interface{}, bool) {
+	switch m := value.(type) {
+	case map[string]interface{}:
+		v, ok := m[key]
+		return v, ok
+	case map[interface{}]interface{}:
+		for k, v := range m {
+			if fmt.Sprint(k) == key {
+				return v, true
+			}
+		}
+	}
+	return nil, false
+}
+
+func setChild(value interface{}, key string, child interface{}) {
+	switch m := value.(type) {
+	case map[string]interface{}:
+		m[key] = child
+	case map[interface{}]interface{}:
+		for k := range m {
+			if fmt.Sprint(k) == key {
+				m[k] = child
+				return
+			}
+		}
+	}
+}
+
+func deepCopy(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for k, e := range v {
+			m[k] = deepCopy(e)
+		}
+		return m
+	case map[interface{}]interface{}:
+		m := make(map[interface{}]interface{}, len(v))
+		for k, e := range v {
+			m[k] = deepCopy(e)
+		}
+		return m
+	case []interface{}:
+		s := make([]interface{}, len(v))
+		for i, e := range v {
+			s[i] = deepCopy(e)
+		}
+		return s
+	default:
+		return v
+	}
+}
+
+// coerce converts the override to the type of the current value
+func coerce(current interface{}, value string) (interface{}, error) {
+	switch c := current.(type) {
+	case bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, errors.Errorf("expecting a boolean but got [%s]", value)
+		}
+		return b, nil
+	case int, int32, int64:
+		i, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, errors.Errorf("expecting an integer but got [%s]", value)
+		}
+		return i, nil
+	case float32, float64:
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, errors.Errorf("expecting a number but got [%s]", value)
+		}
+		return f, nil
+	case time.Duration:
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return nil, errors.Errorf("expecting a duration but got [%s]", value)
+		}
+		return d, nil
+	case string:
+		if isDuration(c) {
+			if _, err := time.ParseDuration(value); err != nil {
+				return nil, errors.Errorf("expecting a duration but got [%s]", value)
+			}
+		}
+		return value, nil
+	case []interface{}:
+		var items []interface{}
+		for _, item := range strings.Split(value, ",") {
+			items = append(items, strings.TrimSpace(item))
+		}
+		return items, nil
+	case map[string]interface{}, map[interface{}]interface{}:
+		return nil, errors.New("a config section cannot be overridden")
+	default:
+		return value, nil
+	}
+}
+
+// isDuration returns true if the value is a duration with units, e.g. "10s"
+func isDuration(value string) bool {
+	if _, err := time.ParseDuration(value); err != nil {
+		return false
+	}
+	return strings.IndexAny(value, "hmsuµn") >= 0
+}
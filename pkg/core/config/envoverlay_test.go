@@ -0,0 +1,101 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package config
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const overlayPrefix = "FABSDK_OVERLAY_TEST"
+
+func TestEnvOverlay(t *testing.T) {
+	defer setOverlayEnv(t, map[string]string{
+		"CLIENT_TLSCERTS_SYSTEMCERTPOOL":                              "true",
+		"CLIENT_BCCSP_SECURITY_LEVEL":                                 "384",
+		"PEERS_PEER0_ORG1_EXAMPLE_COM_URL":                            "peer0.org1.example.com:8051",
+		"PEERS_PEER0_ORG1_EXAMPLE_COM_GRPCOPTIONS_KEEP_ALIVE_TIMEOUT": "30s",
+	})()
+
+	backends, err := FromFile(configTestFilePath, WithEnvOverlay(overlayPrefix))()
+	require.NoError(t, err)
+	require.Len(t, backends, 1)
+	backend := backends[0]
+
+	value, ok := backend.Lookup("client.tlsCerts.systemCertPool")
+	require.True(t, ok)
+	assert.Equal(t, true, value)
+
+	value, ok = backend.Lookup("client.BCCSP.security.level")
+	require.True(t, ok)
+	assert.Equal(t, 384, value)
+
+	value, ok = backend.Lookup("peers.peer0.org1.example.com.url")
+	require.True(t, ok)
+	assert.Equal(t, "peer0.org1.example.com:8051", value)
+
+	value, ok = backend.Lookup("peers.peer0.org1.example.com.grpcOptions.keep-alive-timeout")
+	require.True(t, ok)
+	assert.Equal(t, "30s", value)
+
+	value, ok = backend.Lookup("peers.peer0.org2.example.com.url")
+	require.True(t, ok)
+	assert.Equal(t, "peer0.org2.example.com:8051", value, "expecting values which aren't overridden to be unchanged")
+
+	value, ok = backend.Lookup("organizations.org1.mspid")
+	require.True(t, ok)
+	assert.Equal(t, "Org1MSP", value)
+}
+
+func TestEnvOverlayProvider(t *testing.T) {
+	defer setOverlayEnv(t, map[string]string{"CLIENT_ORGANIZATION": "org2"})()
+
+	backends, err := EnvOverlay(FromFile(configTestFilePath), overlayPrefix)()
+	require.NoError(t, err)
+
+	value, ok := backends[0].Lookup("client.organization")
+	require.True(t, ok)
+	assert.Equal(t, "org2", value)
+}
+
+func TestEnvOverlayMalformed(t *testing.T) {
+	tests := map[string]string{
+		"CLIENT_TLSCERTS_SYSTEMCERTPOOL":                              "maybe",
+		"CLIENT_BCCSP_SECURITY_LEVEL":                                 "high",
+		"PEERS_PEER0_ORG1_EXAMPLE_COM_GRPCOPTIONS_KEEP_ALIVE_TIMEOUT": "soon",
+		"PEERS_PEER0_ORG1_EXAMPLE_COM":                                "peer0.org1.example.com:8051",
+		"CLIENT_NO_SUCH_KEY":                                          "value",
+	}
+
+	for name, value := range tests {
+		t.Run(name, func(t *testing.T) {
+			defer setOverlayEnv(t, map[string]string{name: value})()
+
+			_, err := FromFile(configTestFilePath, WithEnvOverlay(overlayPrefix))()
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestEnvOverlayNoPrefix(t *testing.T) {
+	_, err := FromFile(configTestFilePath, WithEnvOverlay(""))()
+	assert.Error(t, err)
+}
+
+func setOverlayEnv(t *testing.T, vars map[string]string) func() {
+	for name, value := range vars {
+		require.NoError(t, os.Setenv(overlayPrefix+"_"+name, value))
+	}
+	return func() {
+		for name := range vars {
+			os.Unsetenv(overlayPrefix + "_" + name)
+		}
+	}
+}
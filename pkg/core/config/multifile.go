@@ -0,0 +1,156 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/core"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+)
+
+// SourceTracker is implemented by config backends which record the file that supplied each value
+type SourceTracker interface {
+	// Source returns the file which supplied the value of the given key
+	Source(key string) (string, bool)
+	// Sources returns the file which supplied each of the values, keyed by the (lower case) key
+	Sources() map[string]string
+}
+
+// FromFiles reads the named config files and deep-merges them in order. Sections which
+// are maps (e.g. peers, orderers and organizations) are merged by key, and any other
+// value in a later file overrides the value of the same key in an earlier file.
+// As with FromFile, environment variables with the FABRIC_SDK prefix override the merged
+// values. The returned backend implements SourceTracker so that the file which supplied
+// each of the final values can be determined.
+func FromFiles(names ...string) core.ConfigProvider {
+	return func() ([]core.ConfigBackend, error) {
+		if len(names) == 0 {
+			return nil, errors.New("at least one filename is required")
+		}
+
+		backend := &mergedConfigBackend{
+			config:  make(map[string]interface{}),
+			sources: make(map[string]string),
+			env:     newViper(cmdRoot),
+		}
+
+		for _, name := range names {
+			if name == "" {
+				return nil, errors.New("filename is required")
+			}
+
+			v := viper.New()
+			v.SetConfigFile(name)
+			if err := v.ReadInConfig(); err != nil {
+				return nil, errors.Wrapf(err, "loading config file [%s] failed", name)
+			}
+
+			for _, section := range configSections {
+				if value := v.Get(section); value != nil {
+					backend.merge(strings.ToLower(section), backend.config, strings.ToLower(section), value, name)
+				}
+			}
+		}
+
+		setLogLevel(backend)
+
+		return []core.ConfigBackend{backend}, nil
+	}
+}
+
+// mergedConfigBackend is a config backend which holds the merged config of multiple files
+type mergedConfigBackend struct {
+	config  map[string]interface{}
+	sources map[string]string
+	env     *viper.Viper
+}
+
+// Lookup gets the config item value by Key. A value set in the environment overrides the merged value.
+func (c *mergedConfigBackend) Lookup(key string) (interface{}, bool) {
+	if value := c.env.Get(key); value != nil {
+		return value, true
+	}
+	return lookupPath(c.config, key)
+}
+
+// Source returns the file which supplied the value of the given key, or the name
+// of the environment variable if the value is overridden in the environment
+func (c *mergedConfigBackend) Source(key string) (string, bool) {
+	if c.env.Get(key) != nil {
+		return envVarName(key), true
+	}
+	source, ok := c.sources[strings.ToLower(key)]
+	return source, ok
+}
+
+// envVarName returns the name of the environment variable which overrides the given key
+func envVarName(key string) string {
+	return cmdRoot + "_" + strings.ToUpper(strings.Replace(key, ".", "_", -1))
+}
+
+// Sources returns the file which supplied each of the values, keyed by the (lower case) key
+func (c *mergedConfigBackend) Sources() map[string]string {
+	sources := make(map[string]string, len(c.sources))
+	for k, v := range c.sources {
+		sources[k] = v
+	}
+	return sources
+}
+
+// merge merges the value from the given file into the target map under the given key
+func (c *mergedConfigBackend) merge(path string, target map[string]interface{}, key string, value interface{}, source string) {
+	src, srcIsMap := toStringMap(value)
+	if existing, ok := target[key]; ok && srcIsMap {
+		if tgt, tgtIsMap := existing.(map[string]interface{}); tgtIsMap {
+			for k, v := range src {
+				c.merge(path+"."+strings.ToLower(k), tgt, strings.ToLower(k), v, source)
+			}
+			return
+		}
+	}
+
+	c.clearSources(path)
+
+	if !srcIsMap {
+		target[key] = value
+		c.sources[path] = source
+		return
+	}
+
+	merged := make(map[string]interface{})
+	target[key] = merged
+	for k, v := range src {
+		c.merge(path+"."+strings.ToLower(k), merged, strings.ToLower(k), v, source)
+	}
+}
+
+// clearSources removes the sources of the value at the given path and of any values nested under it
+func (c *mergedConfigBackend) clearSources(path string) {
+	for k := range c.sources {
+		if k == path || strings.HasPrefix(k, path+".") {
+			delete(c.sources, k)
+		}
+	}
+}
+
+func toStringMap(value interface{}) (map[string]interface{}, bool) {
+	switch m := value.(type) {
+	case map[string]interface{}:
+		return m, true
+	case map[interface{}]interface{}:
+		sm := make(map[string]interface{}, len(m))
+		for k, v := range m {
+			sm[fmt.Sprint(k)] = v
+		}
+		return sm, true
+	default:
+		return nil, false
+	}
+}
@@ -0,0 +1,124 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const org3Config = `
+client:
+  organization: org3
+  BCCSP:
+    security:
+      level: 384
+
+organizations:
+  org3:
+    mspid: Org3MSP
+    peers:
+      - peer0.org3.example.com
+
+peers:
+  peer0.org1.example.com:
+    url: peer0.org1.example.com:9051
+  peer0.org3.example.com:
+    url: peer0.org3.example.com:7051
+`
+
+func TestFromFiles(t *testing.T) {
+	dir, err := ioutil.TempDir("", "fromfiles")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	org3Path := filepath.Join(dir, "org3.yaml")
+	require.NoError(t, ioutil.WriteFile(org3Path, []byte(org3Config), 0600))
+
+	backends, err := FromFiles(configTestFilePath, org3Path)()
+	require.NoError(t, err)
+	require.Len(t, backends, 1)
+	backend := backends[0]
+
+	value, ok := backend.Lookup("client.organization")
+	require.True(t, ok)
+	assert.Equal(t, "org3", value, "expecting scalars from the last file to win")
+
+	value, ok = backend.Lookup("client.BCCSP.security.level")
+	require.True(t, ok)
+	assert.Equal(t, 384, value)
+
+	value, ok = backend.Lookup("client.BCCSP.security.hashAlgorithm")
+	require.True(t, ok)
+	assert.Equal(t, "SHA2", value, "expecting values which aren't overridden to be retained")
+
+	value, ok = backend.Lookup("peers.peer0.org1.example.com.url")
+	require.True(t, ok)
+	assert.Equal(t, "peer0.org1.example.com:9051", value)
+
+	value, ok = backend.Lookup("peers.peer0.org1.example.com.eventUrl")
+	require.True(t, ok)
+	assert.Equal(t, "peer0.org1.example.com:7053", value, "expecting peers to be merged by key")
+
+	peers, ok := backend.Lookup("peers")
+	require.True(t, ok)
+	assert.Len(t, peers, 3)
+
+	orgs, ok := backend.Lookup("organizations")
+	require.True(t, ok)
+	assert.Len(t, orgs, 4)
+
+	tracker, ok := backend.(SourceTracker)
+	require.True(t, ok)
+
+	source, ok := tracker.Source("peers.peer0.org1.example.com.url")
+	require.True(t, ok)
+	assert.Equal(t, org3Path, source)
+
+	source, ok = tracker.Source("peers.peer0.org1.example.com.eventUrl")
+	require.True(t, ok)
+	assert.Equal(t, configTestFilePath, source)
+
+	source, ok = tracker.Source("client.organization")
+	require.True(t, ok)
+	assert.Equal(t, org3Path, source)
+
+	assert.Equal(t, org3Path, tracker.Sources()["organizations.org3.mspid"])
+}
+
+func TestFromFilesEnvOverride(t *testing.T) {
+	require.NoError(t, os.Setenv("FABRIC_SDK_CLIENT_ORGANIZATION", "org2"))
+	defer os.Unsetenv("FABRIC_SDK_CLIENT_ORGANIZATION")
+
+	backends, err := FromFiles(configTestFilePath)()
+	require.NoError(t, err)
+	require.Len(t, backends, 1)
+
+	value, ok := backends[0].Lookup("client.organization")
+	require.True(t, ok)
+	assert.Equal(t, "org2", value, "expecting the environment to override the merged value")
+
+	source, ok := backends[0].(SourceTracker).Source("client.organization")
+	require.True(t, ok)
+	assert.Equal(t, "FABRIC_SDK_CLIENT_ORGANIZATION", source)
+}
+
+func TestFromFilesInvalid(t *testing.T) {
+	_, err := FromFiles()()
+	assert.Error(t, err)
+
+	_, err = FromFiles(configTestFilePath, "")()
+	assert.Error(t, err)
+
+	_, err = FromFiles(configTestFilePath, "testdata/no_such_file.yaml")()
+	assert.Error(t, err)
+}
@@ -0,0 +1,265 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package config
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"io/ioutil"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/errors/multi"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+	"github.com/hyperledger/fabric-sdk-go/pkg/core/config/endpoint"
+	"github.com/hyperledger/fabric-sdk-go/pkg/core/config/lookup"
+	"github.com/hyperledger/fabric-sdk-go/pkg/util/pathvar"
+	"github.com/pkg/errors"
+)
+
+// backendProvider is implemented by endpoint configs which expose their config backend
+type backendProvider interface {
+	Backend() *lookup.ConfigLookup
+}
+
+// Validate checks the given endpoint config for problems which would otherwise only be
+// detected on the first network call. It checks that every referenced certificate and key
+// (either a path or embedded PEM) can be loaded and parsed, that TLS-enabled peers, orderers
+// and CAs have TLS root certificates configured, that channels only reference defined orderers
+// and defined peers which belong to an organization, and that organizations only reference
+// defined peers and CAs. All of the problems found are returned in the error (as multi.Errors
+// if there is more than one).
+func Validate(cfg fab.EndpointConfig) error {
+	networkConfig, err := cfg.NetworkConfig()
+	if err != nil {
+		return errors.WithMessage(err, "unable to load network config")
+	}
+
+	v := &validator{
+		cfg:           cfg,
+		networkConfig: networkConfig,
+	}
+	if bp, ok := cfg.(backendProvider); ok {
+		v.systemCertPool = bp.Backend().GetBool("client.tlsCerts.systemCertPool")
+	}
+
+	v.validateClient()
+	v.validateOrderers()
+	v.validatePeers()
+	v.validateCAs()
+	v.validateOrganizations()
+	v.validateChannels()
+
+	return v.errs.ToError()
+}
+
+type validator struct {
+	cfg            fab.EndpointConfig
+	networkConfig  *fab.NetworkConfig
+	systemCertPool bool
+	errs           multi.Errors
+}
+
+func (v *validator) addError(format string, args ...interface{}) {
+	v.errs = append(v.errs, errors.Errorf(format, args...))
+}
+
+func (v *validator) validateClient() {
+	client := v.networkConfig.Client
+	if client.Organization != "" {
+		if _, ok := v.networkConfig.Organizations[strings.ToLower(client.Organization)]; !ok {
+			v.addError("client organization [%s] is not defined", client.Organization)
+		}
+	}
+
+	for _, path := range splitPaths(client.TLSCerts.Path) {
+		v.checkCert("client TLS root certificate", endpoint.TLSConfig{Path: path})
+	}
+	for _, p := range client.TLSCerts.Pem {
+		v.checkCert("client TLS root certificate", endpoint.TLSConfig{Pem: p})
+	}
+	v.checkKeyPair("client TLS", client.TLSCerts.Client)
+}
+
+func (v *validator) validateOrderers() {
+	for _, name := range sortedNames(v.networkConfig.Orderers) {
+		orderer := v.networkConfig.Orderers[name]
+		v.checkTLSRoots("orderer ["+name+"]", orderer.URL, orderer.GRPCOptions, orderer.TLSCACerts)
+	}
+}
+
+func (v *validator) validatePeers() {
+	for _, name := range sortedNames(v.networkConfig.Peers) {
+		peer := v.networkConfig.Peers[name]
+		v.checkTLSRoots("peer ["+name+"]", peer.URL, peer.GRPCOptions, peer.TLSCACerts)
+	}
+}
+
+func (v *validator) validateCAs() {
+	for _, name := range sortedNames(v.networkConfig.CertificateAuthorities) {
+		ca := v.networkConfig.CertificateAuthorities[name]
+		what := "certificate authority [" + name + "]"
+
+		paths := splitPaths(ca.TLSCACerts.Path)
+		for _, path := range paths {
+			v.checkCert(what+" TLS root certificate", endpoint.TLSConfig{Path: path})
+		}
+		for _, p := range ca.TLSCACerts.Pem {
+			v.checkCert(what+" TLS root certificate", endpoint.TLSConfig{Pem: p})
+		}
+		if endpoint.IsTLSEnabled(ca.URL) && len(paths) == 0 && len(ca.TLSCACerts.Pem) == 0 && !v.systemCertPool {
+			v.addError("%s is TLS-enabled but has no TLS root certificates configured", what)
+		}
+		v.checkKeyPair(what+" client TLS", ca.TLSCACerts.Client)
+	}
+}
+
+func (v *validator) validateOrganizations() {
+	for _, name := range sortedNames(v.networkConfig.Organizations) {
+		org := v.networkConfig.Organizations[name]
+		for _, peer := range org.Peers {
+			if _, err := v.cfg.PeerConfig(peer); err != nil {
+				v.addError("organization [%s] references peer [%s] which is not defined", name, peer)
+			}
+		}
+		for _, ca := range org.CertificateAuthorities {
+			if !v.caDefined(ca) {
+				v.addError("organization [%s] references certificate authority [%s] which is not defined", name, ca)
+			}
+		}
+		for _, user := range sortedNames(org.Users) {
+			v.checkKeyPair("organization ["+name+"] user ["+user+"]", org.Users[user])
+		}
+	}
+}
+
+func (v *validator) validateChannels() {
+	for _, name := range sortedNames(v.networkConfig.Channels) {
+		channel := v.networkConfig.Channels[name]
+		for _, peer := range sortedNames(channel.Peers) {
+			if _, err := v.cfg.PeerConfig(peer); err != nil {
+				v.addError("channel [%s] references peer [%s] which is not defined", name, peer)
+				continue
+			}
+			if mspID, err := v.cfg.PeerMSPID(peer); err != nil || mspID == "" {
+				v.addError("channel [%s] references peer [%s] which does not belong to any organization", name, peer)
+			}
+		}
+		for _, orderer := range channel.Orderers {
+			if o, err := v.cfg.OrdererConfig(orderer); err != nil || o == nil {
+				v.addError("channel [%s] references orderer [%s] which is not defined", name, orderer)
+			}
+		}
+	}
+}
+
+// caDefined returns true if the given CA is defined or is matched by a CA entity matcher
+func (v *validator) caDefined(name string) bool {
+	if _, ok := v.networkConfig.CertificateAuthorities[strings.ToLower(name)]; ok {
+		return true
+	}
+	for _, matcher := range v.networkConfig.EntityMatchers["certificateauthority"] {
+		if matched, err := regexp.MatchString(matcher.Pattern, name); err == nil && matched {
+			return true
+		}
+	}
+	return false
+}
+
+// checkTLSRoots checks that the TLS root certificate of the endpoint can be loaded and
+// that it is configured if the endpoint is TLS-enabled
+func (v *validator) checkTLSRoots(what, url string, grpcOptions map[string]interface{}, tlsCACerts endpoint.TLSConfig) {
+	if tlsCACerts.Path != "" || tlsCACerts.Pem != "" {
+		v.checkCert(what+" TLS root certificate", tlsCACerts)
+		return
+	}
+
+	allowInsecure, _ := grpcOptions["allow-insecure"].(bool)
+	if endpoint.AttemptSecured(url, allowInsecure) && !v.systemCertPool {
+		v.addError("%s is TLS-enabled but has no TLS root certificate configured", what)
+	}
+}
+
+func (v *validator) checkKeyPair(what string, keyPair endpoint.TLSKeyPair) {
+	if keyPair.Cert.Path != "" || keyPair.Cert.Pem != "" {
+		v.checkCert(what+" certificate", keyPair.Cert)
+	}
+	if keyPair.Key.Path != "" || keyPair.Key.Pem != "" {
+		v.checkKey(what+" key", keyPair.Key)
+	}
+}
+
+func (v *validator) checkCert(what string, certConfig endpoint.TLSConfig) {
+	certConfig.Path = pathvar.Subst(certConfig.Path)
+	if _, err := certConfig.TLSCert(); err != nil {
+		v.errs = append(v.errs, errors.WithMessage(err, what+" "+describe(certConfig)+" is invalid"))
+	}
+}
+
+func (v *validator) checkKey(what string, keyConfig endpoint.TLSConfig) {
+	var raw []byte
+	if keyConfig.Pem != "" {
+		raw = []byte(keyConfig.Pem)
+	} else {
+		var err error
+		raw, err = ioutil.ReadFile(pathvar.Subst(keyConfig.Path))
+		if err != nil {
+			v.errs = append(v.errs, errors.Wrapf(err, "%s %s is invalid", what, describe(keyConfig)))
+			return
+		}
+	}
+
+	if err := parsePrivateKey(raw); err != nil {
+		v.errs = append(v.errs, errors.WithMessage(err, what+" "+describe(keyConfig)+" is invalid"))
+	}
+}
+
+func parsePrivateKey(raw []byte) error {
+	block, _ := pem.Decode(raw)
+	if block == nil {
+		return errors.New("pem data missing")
+	}
+	if _, err := x509.ParsePKCS8PrivateKey(block.Bytes); err == nil {
+		return nil
+	}
+	if _, err := x509.ParseECPrivateKey(block.Bytes); err == nil {
+		return nil
+	}
+	if _, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return nil
+	}
+	return errors.New("private key parsing failed")
+}
+
+func describe(tlsConfig endpoint.TLSConfig) string {
+	if tlsConfig.Pem != "" {
+		return "(embedded PEM)"
+	}
+	return "[" + tlsConfig.Path + "]"
+}
+
+func splitPaths(paths string) []string {
+	var result []string
+	for _, path := range strings.Split(paths, ",") {
+		if path = strings.TrimSpace(path); path != "" {
+			result = append(result, path)
+		}
+	}
+	return result
+}
+
+// sortedNames returns the sorted keys of the given map so that problems are reported in a consistent order
+func sortedNames(m interface{}) []string {
+	var names []string
+	for _, key := range reflect.ValueOf(m).MapKeys() {
+		names = append(names, key.String())
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,91 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package config
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/errors/multi"
+	fabImpl "github.com/hyperledger/fabric-sdk-go/pkg/fab"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const invalidConfig = `
+client:
+  organization: org1
+  tlsCerts:
+    client:
+      key:
+        path: testdata/no_such_key.pem
+      cert:
+        pem: not a certificate
+
+organizations:
+  org1:
+    mspid: Org1MSP
+    peers:
+      - peer0.org1.example.com
+      - peer9.org1.example.com
+    certificateAuthorities:
+      - ca.org9.example.com
+
+orderers:
+  orderer.example.com:
+    url: grpcs://orderer.example.com:7050
+
+peers:
+  peer0.org1.example.com:
+    url: grpcs://peer0.org1.example.com:7051
+    tlsCACerts:
+      path: testdata/no_such_cert.pem
+
+channels:
+  mychannel:
+    orderers:
+      - orderer9.example.com
+    peers:
+      peer9.org2.example.com:
+        endorsingPeer: true
+`
+
+func TestValidate(t *testing.T) {
+	for _, path := range []string{configTestFilePath, "testdata/config_test_entity_matchers.yaml"} {
+		backends, err := FromFile(path)()
+		require.NoError(t, err)
+
+		cfg, err := fabImpl.ConfigFromBackend(backends...)
+		require.NoError(t, err)
+
+		assert.NoError(t, Validate(cfg), "expecting config [%s] to be valid", path)
+	}
+}
+
+func TestValidateInvalid(t *testing.T) {
+	backends, err := FromRaw([]byte(invalidConfig), configType)()
+	require.NoError(t, err)
+
+	cfg, err := fabImpl.ConfigFromBackend(backends...)
+	require.NoError(t, err)
+
+	err = Validate(cfg)
+	require.Error(t, err)
+
+	errs, ok := errors.Cause(err).(multi.Errors)
+	require.True(t, ok, "expecting all of the problems to be reported")
+	assert.Len(t, errs, 8)
+
+	assert.Contains(t, err.Error(), "client TLS key [testdata/no_such_key.pem] is invalid")
+	assert.Contains(t, err.Error(), "client TLS certificate (embedded PEM) is invalid")
+	assert.Contains(t, err.Error(), "organization [org1] references peer [peer9.org1.example.com] which is not defined")
+	assert.Contains(t, err.Error(), "organization [org1] references certificate authority [ca.org9.example.com] which is not defined")
+	assert.Contains(t, err.Error(), "orderer [orderer.example.com] is TLS-enabled but has no TLS root certificate configured")
+	assert.Contains(t, err.Error(), "peer [peer0.org1.example.com] TLS root certificate [testdata/no_such_cert.pem] is invalid")
+	assert.Contains(t, err.Error(), "channel [mychannel] references peer [peer9.org2.example.com] which is not defined")
+	assert.Contains(t, err.Error(), "channel [mychannel] references orderer [orderer9.example.com] which is not defined")
+}
@@ -0,0 +1,108 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package cryptosuite
+
+import (
+	"github.com/hyperledger/fabric-sdk-go/internal/github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/core"
+)
+
+// KeyType identifies a type of key which may be generated by a crypto suite
+type KeyType string
+
+const (
+	// ECDSAP256 is an ECDSA key on curve P-256
+	ECDSAP256 KeyType = "ECDSA-P256"
+	// ECDSAP384 is an ECDSA key on curve P-384
+	ECDSAP384 KeyType = "ECDSA-P384"
+	// ED25519 is an Ed25519 key
+	ED25519 KeyType = "ED25519"
+)
+
+// HashAlgorithm identifies a hash algorithm which may be computed by a crypto suite
+type HashAlgorithm string
+
+const (
+	// SHA2_256 is the SHA-256 hash algorithm
+	SHA2_256 HashAlgorithm = "SHA2-256"
+	// SHA2_384 is the SHA-384 hash algorithm
+	SHA2_384 HashAlgorithm = "SHA2-384"
+	// SHA3_256 is the SHA3-256 hash algorithm
+	SHA3_256 HashAlgorithm = "SHA3-256"
+	// SHA3_384 is the SHA3-384 hash algorithm
+	SHA3_384 HashAlgorithm = "SHA3-384"
+)
+
+// Algorithms describes the key types and hash algorithms supported by a crypto suite
+type Algorithms struct {
+	KeyTypes       []KeyType
+	HashAlgorithms []HashAlgorithm
+}
+
+// SupportsKeyType returns true if the given key type is supported
+func (a *Algorithms) SupportsKeyType(keyType KeyType) bool {
+	for _, kt := range a.KeyTypes {
+		if kt == keyType {
+			return true
+		}
+	}
+	return false
+}
+
+// SupportsHashAlgorithm returns true if the given hash algorithm is supported
+func (a *Algorithms) SupportsHashAlgorithm(algorithm HashAlgorithm) bool {
+	for _, ha := range a.HashAlgorithms {
+		if ha == algorithm {
+			return true
+		}
+	}
+	return false
+}
+
+var keyGenOpts = []struct {
+	keyType KeyType
+	opts    core.KeyGenOpts
+}{
+	{ECDSAP256, &bccsp.ECDSAP256KeyGenOpts{Temporary: true}},
+	{ECDSAP384, &bccsp.ECDSAP384KeyGenOpts{Temporary: true}},
+	{ED25519, &bccsp.ED25519KeyGenOpts{Temporary: true}},
+}
+
+var hashOpts = []struct {
+	algorithm HashAlgorithm
+	opts      core.HashOpts
+}{
+	{SHA2_256, &bccsp.SHA256Opts{}},
+	{SHA2_384, &bccsp.SHA384Opts{}},
+	{SHA3_256, &bccsp.SHA3_256Opts{}},
+	{SHA3_384, &bccsp.SHA3_384Opts{}},
+}
+
+// GetSupportedAlgorithms returns the key types and hash algorithms supported by the given crypto suite.
+// Key types are determined by generating an ephemeral key of each type, so this is intended to be
+// called once (e.g. at startup) rather than on every request.
+func GetSupportedAlgorithms(suite core.CryptoSuite) *Algorithms {
+	algorithms := &Algorithms{}
+
+	for _, kg := range keyGenOpts {
+		if _, err := suite.KeyGen(kg.opts); err != nil {
+			logger.Debugf("Key type [%s] is not supported by crypto suite: %s", kg.keyType, err)
+			continue
+		}
+		algorithms.KeyTypes = append(algorithms.KeyTypes, kg.keyType)
+	}
+
+	for _, h := range hashOpts {
+		if _, err := suite.GetHash(h.opts); err != nil {
+			logger.Debugf("Hash algorithm [%s] is not supported by crypto suite: %s", h.algorithm, err)
+			continue
+		}
+		algorithms.HashAlgorithms = append(algorithms.HashAlgorithms, h.algorithm)
+	}
+
+	return algorithms
+}
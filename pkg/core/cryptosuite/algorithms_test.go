@@ -0,0 +1,54 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package cryptosuite
+
+import (
+	"hash"
+	"strings"
+	"testing"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/core"
+	"github.com/hyperledger/fabric-sdk-go/pkg/core/cryptosuite/bccsp/sw"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetSupportedAlgorithms(t *testing.T) {
+	s, err := sw.GetSuiteWithDefaultEphemeral()
+	assert.Nil(t, err, "Not supposed to get error when creating SW suite")
+
+	algorithms := GetSupportedAlgorithms(s)
+	assert.Equal(t, []KeyType{ECDSAP256, ECDSAP384, ED25519}, algorithms.KeyTypes)
+	assert.Equal(t, []HashAlgorithm{SHA2_256, SHA2_384, SHA3_256, SHA3_384}, algorithms.HashAlgorithms)
+	assert.True(t, algorithms.SupportsKeyType(ECDSAP384))
+	assert.True(t, algorithms.SupportsHashAlgorithm(SHA3_256))
+
+	// Suite which supports neither P-384, Ed25519 nor SHA3
+	algorithms = GetSupportedAlgorithms(&restrictedSuite{CryptoSuite: s})
+	assert.Equal(t, []KeyType{ECDSAP256}, algorithms.KeyTypes)
+	assert.Equal(t, []HashAlgorithm{SHA2_256, SHA2_384}, algorithms.HashAlgorithms)
+	assert.False(t, algorithms.SupportsKeyType(ECDSAP384))
+	assert.False(t, algorithms.SupportsHashAlgorithm(SHA3_384))
+}
+
+type restrictedSuite struct {
+	core.CryptoSuite
+}
+
+func (s *restrictedSuite) KeyGen(opts core.KeyGenOpts) (core.Key, error) {
+	if opts.Algorithm() != ecdsap256KeyGenOpts {
+		return nil, errors.Errorf("unsupported key gen opts [%s]", opts.Algorithm())
+	}
+	return s.CryptoSuite.KeyGen(opts)
+}
+
+func (s *restrictedSuite) GetHash(opts core.HashOpts) (hash.Hash, error) {
+	if strings.HasPrefix(opts.Algorithm(), "SHA3_") {
+		return nil, errors.Errorf("unsupported hash opts [%s]", opts.Algorithm())
+	}
+	return s.CryptoSuite.GetHash(opts)
+}
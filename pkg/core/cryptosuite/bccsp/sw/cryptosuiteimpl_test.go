@@ -8,11 +8,17 @@ package sw
 
 import (
 	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
 	"crypto/sha256"
+	"crypto/x509"
+	"io/ioutil"
+	"os"
 	"testing"
 
 	"github.com/golang/mock/gomock"
 	"github.com/hyperledger/fabric-sdk-go/internal/github.com/hyperledger/fabric/bccsp"
+	bccspSw "github.com/hyperledger/fabric-sdk-go/internal/github.com/hyperledger/fabric/bccsp/sw"
 	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/core"
 	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/test/mockcore"
 )
@@ -76,6 +82,80 @@ func TestCryptoSuiteDefaultEphemeral(t *testing.T) {
 	verifyHashFn(t, c)
 }
 
+func TestCryptoSuiteEd25519(t *testing.T) {
+	keyStorePath, err := ioutil.TempDir("", "ed25519ks")
+	if err != nil {
+		t.Fatalf("Failed to create key store directory: %s", err)
+	}
+	defer os.RemoveAll(keyStorePath)
+
+	ks, err := bccspSw.NewFileBasedKeyStore(nil, keyStorePath, false)
+	if err != nil {
+		t.Fatalf("Failed to create key store: %s", err)
+	}
+	c, err := GetSuite(256, "SHA2", ks)
+	if err != nil {
+		t.Fatalf("Not supposed to get error, but got: %v", err)
+	}
+
+	// Generated keys are stored in the key store
+	key, err := c.KeyGen(&bccsp.ED25519KeyGenOpts{})
+	if err != nil {
+		t.Fatalf("Failed to generate Ed25519 key: %s", err)
+	}
+	key, err = c.GetKey(key.SKI())
+	if err != nil {
+		t.Fatalf("Failed to get Ed25519 key from key store: %s", err)
+	}
+
+	msg := []byte("Hello")
+	signature, err := c.Sign(key, msg, nil)
+	if err != nil {
+		t.Fatalf("Failed to sign with Ed25519 key: %s", err)
+	}
+	pubKey, err := key.PublicKey()
+	if err != nil {
+		t.Fatalf("Failed to get public key: %s", err)
+	}
+	valid, err := c.Verify(pubKey, signature, msg, nil)
+	if err != nil || !valid {
+		t.Fatalf("Failed to verify Ed25519 signature: %v", err)
+	}
+	valid, err = c.Verify(pubKey, signature, []byte("Goodbye"), nil)
+	if err != nil || valid {
+		t.Fatalf("Expected Ed25519 signature of a different message to be invalid")
+	}
+
+	// Import a PKCS#8 encoded key and verify with the public key of a certificate
+	goPubKey, goPrivKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate Ed25519 key: %s", err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(goPrivKey)
+	if err != nil {
+		t.Fatalf("Failed to marshal Ed25519 key: %s", err)
+	}
+	key, err = c.KeyImport(der, &bccsp.ED25519PrivateKeyImportOpts{Temporary: true})
+	if err != nil {
+		t.Fatalf("Failed to import Ed25519 key: %s", err)
+	}
+	signature, err = c.Sign(key, msg, nil)
+	if err != nil {
+		t.Fatalf("Failed to sign with Ed25519 key: %s", err)
+	}
+	if !ed25519.Verify(goPubKey, msg, signature) {
+		t.Fatalf("Failed to verify Ed25519 signature")
+	}
+
+	certKey, err := c.KeyImport(&x509.Certificate{PublicKey: goPubKey}, &bccsp.X509PublicKeyImportOpts{Temporary: true})
+	if err != nil {
+		t.Fatalf("Failed to import certificate's Ed25519 public key: %s", err)
+	}
+	if !bytes.Equal(certKey.SKI(), key.SKI()) {
+		t.Fatalf("Expected SKI of public and private key to match")
+	}
+}
+
 func verifyHashFn(t *testing.T, c core.CryptoSuite) {
 	msg := []byte("Hello")
 	e := sha256.Sum256(msg)
@@ -68,6 +68,15 @@ func (c *CryptoSuite) Verify(k core.Key, signature, digest []byte, opts core.Sig
 	return c.BCCSP.Verify(k.(*key).key, signature, digest, opts)
 }
 
+// TokenLabel returns the label of the PKCS#11 token whose slot is used by the BCCSP,
+// or an empty string if the BCCSP is not backed by a PKCS#11 token
+func (c *CryptoSuite) TokenLabel() string {
+	if t, ok := c.BCCSP.(interface{ TokenLabel() string }); ok {
+		return t.TokenLabel()
+	}
+	return ""
+}
+
 type key struct {
 	key bccsp.Key
 }
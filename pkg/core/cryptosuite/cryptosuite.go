@@ -7,6 +7,8 @@ SPDX-License-Identifier: Apache-2.0
 package cryptosuite
 
 import (
+	"crypto/ed25519"
+	"crypto/x509"
 	"sync/atomic"
 
 	"errors"
@@ -87,3 +89,30 @@ func GetSHAOpts() core.HashOpts {
 func GetECDSAP256KeyGenOpts(ephemeral bool) core.KeyGenOpts {
 	return &bccsp.ECDSAP256KeyGenOpts{Temporary: ephemeral}
 }
+
+//GetED25519KeyGenOpts returns options for Ed25519 key generation.
+func GetED25519KeyGenOpts(ephemeral bool) core.KeyGenOpts {
+	return &bccsp.ED25519KeyGenOpts{Temporary: ephemeral}
+}
+
+// IsEd25519Key returns true if the given asymmetric key is an Ed25519 key. Ed25519 keys
+// sign the message itself rather than a digest of it.
+func IsEd25519Key(key core.Key) bool {
+	if key == nil || key.Symmetric() {
+		return false
+	}
+	pubKey, err := key.PublicKey()
+	if err != nil {
+		return false
+	}
+	raw, err := pubKey.Bytes()
+	if err != nil {
+		return false
+	}
+	pk, err := x509.ParsePKIXPublicKey(raw)
+	if err != nil {
+		return false
+	}
+	_, ok := pk.(ed25519.PublicKey)
+	return ok
+}
@@ -0,0 +1,45 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kms
+
+import (
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/core"
+	"github.com/pkg/errors"
+)
+
+// key is a handle to a private key held by a KMS
+type key struct {
+	keyID  string
+	signer Signer
+	pubKey core.Key
+	lowS   bool
+}
+
+// Bytes is not supported since the private key doesn't leave the KMS
+func (k *key) Bytes() ([]byte, error) {
+	return nil, errors.New("not supported")
+}
+
+// SKI returns the subject key identifier of the public key
+func (k *key) SKI() []byte {
+	return k.pubKey.SKI()
+}
+
+// Symmetric returns false
+func (k *key) Symmetric() bool {
+	return false
+}
+
+// Private returns true
+func (k *key) Private() bool {
+	return true
+}
+
+// PublicKey returns the public key corresponding to the KMS key
+func (k *key) PublicKey() (core.Key, error) {
+	return k.pubKey, nil
+}
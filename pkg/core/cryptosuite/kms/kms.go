@@ -0,0 +1,261 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+/*
+Package kms provides a crypto suite which delegates signing to a remote key management
+service (e.g. AWS KMS or GCP Cloud KMS) so that the private key of a signing identity
+never leaves the KMS.
+
+The KMS is plugged in by registering a SignerFactory with the crypto suite. The private
+key of a signing identity is then loaded by calling LoadKey with the ID of the KMS key.
+Since the loaded key is also returned by GetKey for the SKI of its public key, identities
+whose enrollment certificate matches the KMS key (e.g. those returned by the MSP client's
+GetSigningIdentity or created by the identity manager's NewUser) sign with the KMS.
+
+The crypto suite is supplied to the SDK by overriding CreateCryptoSuiteProvider
+in a custom core provider factory (see fabsdk.WithCorePkg).
+*/
+package kms
+
+import (
+	reqContext "context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/hex"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/fabric-sdk-go/internal/github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric-sdk-go/internal/github.com/hyperledger/fabric/bccsp/utils"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/errors/retry"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/errors/status"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/logging"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/core"
+	"github.com/pkg/errors"
+)
+
+var logger = logging.NewLogger("fabsdk/core")
+
+const (
+	// DefaultTimeout is the default timeout of a single call to the KMS
+	DefaultTimeout = 10 * time.Second
+)
+
+// DefaultRetryOpts are the default options for retrying failed calls to the KMS.
+// Calls which time out or fail with a temporary error are retried.
+var DefaultRetryOpts = retry.Opts{
+	Attempts:       3,
+	InitialBackoff: 250 * time.Millisecond,
+	MaxBackoff:     5 * time.Second,
+	BackoffFactor:  2.0,
+	RetryableCodes: map[status.Group][]status.Code{
+		status.ClientStatus: {status.Timeout, status.ConnectionFailed},
+	},
+}
+
+// Signer signs digests with a private key which is held by a key management service
+type Signer interface {
+	// Public returns the public key corresponding to the private key in the KMS.
+	// ECDSA, RSA and Ed25519 public keys are supported.
+	Public() crypto.PublicKey
+
+	// Sign signs the given digest (or, for Ed25519 keys, the message itself).
+	// ECDSA signatures must be ASN.1 DER encoded. The context is done when the call times out.
+	// An error which implements Temporary() bool and returns true is retried.
+	Sign(ctx reqContext.Context, digest []byte) ([]byte, error)
+}
+
+// SignerFactory creates the signers for the keys held by a key management service
+type SignerFactory interface {
+	// NewSigner returns the signer for the KMS key with the given ID
+	// (e.g. the ARN of an AWS KMS key or the resource name of a GCP KMS key version).
+	NewSigner(keyID string) (Signer, error)
+}
+
+// CryptoSuite signs with KMS keys using the signers created by the registered signer factories.
+// All other operations are delegated to the underlying crypto suite.
+type CryptoSuite struct {
+	core.CryptoSuite
+	timeout   time.Duration
+	retryOpts retry.Opts
+
+	mutex     sync.RWMutex
+	factories map[string]SignerFactory
+	keys      map[string]*key
+}
+
+// Option configures the KMS crypto suite
+type Option func(c *CryptoSuite)
+
+// WithSignerFactory registers the signer factory for the KMS with the given name
+func WithSignerFactory(name string, factory SignerFactory) Option {
+	return func(c *CryptoSuite) {
+		c.factories[name] = factory
+	}
+}
+
+// WithTimeout sets the timeout of a single call to the KMS
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *CryptoSuite) {
+		c.timeout = timeout
+	}
+}
+
+// WithRetryOpts sets the options for retrying failed calls to the KMS.
+// If no retryable codes are given then those of DefaultRetryOpts are used.
+func WithRetryOpts(opts retry.Opts) Option {
+	return func(c *CryptoSuite) {
+		c.retryOpts = opts
+	}
+}
+
+// New returns a crypto suite which signs with KMS keys and delegates all other operations
+// to the given crypto suite
+func New(suite core.CryptoSuite, opts ...Option) *CryptoSuite {
+	c := &CryptoSuite{
+		CryptoSuite: suite,
+		timeout:     DefaultTimeout,
+		retryOpts:   DefaultRetryOpts,
+		factories:   make(map[string]SignerFactory),
+		keys:        make(map[string]*key),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	if len(c.retryOpts.RetryableCodes) == 0 {
+		c.retryOpts.RetryableCodes = DefaultRetryOpts.RetryableCodes
+	}
+	return c
+}
+
+// RegisterSignerFactory registers the signer factory for the KMS with the given name,
+// replacing any previously registered factory of that name
+func (c *CryptoSuite) RegisterSignerFactory(name string, factory SignerFactory) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.factories[name] = factory
+}
+
+// LoadKey returns a handle to the private key with the given ID in the KMS with the given name.
+// The key is also returned by subsequent calls to GetKey with the SKI of its public key.
+func (c *CryptoSuite) LoadKey(kmsName, keyID string) (core.Key, error) {
+	c.mutex.RLock()
+	factory, ok := c.factories[kmsName]
+	c.mutex.RUnlock()
+	if !ok {
+		return nil, errors.Errorf("no signer factory registered for KMS [%s]", kmsName)
+	}
+
+	signer, err := factory.NewSigner(keyID)
+	if err != nil {
+		return nil, errors.WithMessage(err, "creating KMS signer failed")
+	}
+
+	pubKey, err := c.importPublicKey(signer.Public())
+	if err != nil {
+		return nil, errors.WithMessage(err, "importing public key of KMS key failed")
+	}
+
+	k := &key{keyID: keyID, signer: signer, pubKey: pubKey}
+	if _, ok := signer.Public().(*ecdsa.PublicKey); ok {
+		k.lowS = true
+	}
+
+	c.mutex.Lock()
+	c.keys[hex.EncodeToString(pubKey.SKI())] = k
+	c.mutex.Unlock()
+
+	logger.Debugf("Loaded key [%s] from KMS [%s]", keyID, kmsName)
+	return k, nil
+}
+
+// GetKey returns the loaded KMS key with the given SKI or, if there is none,
+// the key of the underlying crypto suite
+func (c *CryptoSuite) GetKey(ski []byte) (core.Key, error) {
+	c.mutex.RLock()
+	k, ok := c.keys[hex.EncodeToString(ski)]
+	c.mutex.RUnlock()
+	if ok {
+		return k, nil
+	}
+	return c.CryptoSuite.GetKey(ski)
+}
+
+// Sign signs the digest with the given key. The KMS is called for KMS keys.
+func (c *CryptoSuite) Sign(k core.Key, digest []byte, opts core.SignerOpts) ([]byte, error) {
+	kk, ok := k.(*key)
+	if !ok {
+		return c.CryptoSuite.Sign(k, digest, opts)
+	}
+	if len(digest) == 0 {
+		return nil, errors.New("digest is required")
+	}
+
+	signature, err := retry.NewInvoker(retry.New(c.retryOpts)).Invoke(
+		func() (interface{}, error) {
+			return c.sign(kk, digest)
+		})
+	if err != nil {
+		return nil, errors.WithMessage(err, "signing with KMS key failed")
+	}
+	if !kk.lowS {
+		return signature.([]byte), nil
+	}
+	return toLowS(kk.signer.Public().(*ecdsa.PublicKey), signature.([]byte))
+}
+
+// Verify verifies the signature with the given key. The public key is used for KMS keys.
+func (c *CryptoSuite) Verify(k core.Key, signature, digest []byte, opts core.SignerOpts) (bool, error) {
+	if kk, ok := k.(*key); ok {
+		return c.CryptoSuite.Verify(kk.pubKey, signature, digest, opts)
+	}
+	return c.CryptoSuite.Verify(k, signature, digest, opts)
+}
+
+func (c *CryptoSuite) sign(k *key, digest []byte) ([]byte, error) {
+	ctx, cancel := reqContext.WithTimeout(reqContext.Background(), c.timeout)
+	defer cancel()
+
+	signature, err := k.signer.Sign(ctx, digest)
+	if err == nil {
+		return signature, nil
+	}
+	if ctx.Err() == reqContext.DeadlineExceeded {
+		return nil, status.New(status.ClientStatus, status.Timeout.ToInt32(), "KMS sign request timed out", nil)
+	}
+	if t, ok := errors.Cause(err).(interface{ Temporary() bool }); ok && t.Temporary() {
+		return nil, status.New(status.ClientStatus, status.ConnectionFailed.ToInt32(), err.Error(), nil)
+	}
+	return nil, err
+}
+
+func (c *CryptoSuite) importPublicKey(pubKey crypto.PublicKey) (core.Key, error) {
+	switch pk := pubKey.(type) {
+	case *ecdsa.PublicKey:
+		return c.CryptoSuite.KeyImport(pk, &bccsp.ECDSAGoPublicKeyImportOpts{Temporary: true})
+	case *rsa.PublicKey:
+		return c.CryptoSuite.KeyImport(pk, &bccsp.RSAGoPublicKeyImportOpts{Temporary: true})
+	case ed25519.PublicKey:
+		return c.CryptoSuite.KeyImport(pk, &bccsp.ED25519GoPublicKeyImportOpts{Temporary: true})
+	default:
+		return nil, errors.Errorf("unsupported public key type [%T]", pubKey)
+	}
+}
+
+// toLowS converts the ECDSA signature to its canonical (low-S) form, which is required by Fabric
+func toLowS(pubKey *ecdsa.PublicKey, signature []byte) ([]byte, error) {
+	r, s, err := utils.UnmarshalECDSASignature(signature)
+	if err != nil {
+		return nil, errors.Wrap(err, "KMS returned an invalid ECDSA signature")
+	}
+	s, _, err = utils.ToLowS(pubKey, s)
+	if err != nil {
+		return nil, errors.Wrap(err, "converting ECDSA signature to low-S failed")
+	}
+	return utils.MarshalECDSASignature(r, s)
+}
@@ -0,0 +1,174 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kms
+
+import (
+	reqContext "context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric-sdk-go/internal/github.com/hyperledger/fabric/bccsp/utils"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/errors/retry"
+	"github.com/hyperledger/fabric-sdk-go/pkg/core/cryptosuite/bccsp/sw"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSignWithKMSKey(t *testing.T) {
+	signer := newMockSigner(t)
+	c := newTestSuite(t, signer)
+
+	_, err := c.LoadKey("unknown", "key1")
+	assert.NotNil(t, err, "expected error for unregistered KMS")
+
+	k, err := c.LoadKey("mock", "key1")
+	assert.Nil(t, err)
+	assert.True(t, k.Private())
+	assert.False(t, k.Symmetric())
+
+	// The KMS key is found by the SKI of its public key
+	k2, err := c.GetKey(k.SKI())
+	assert.Nil(t, err)
+	assert.Equal(t, k, k2)
+
+	digest := sha256.Sum256([]byte("Hello"))
+	signature, err := c.Sign(k, digest[:], nil)
+	assert.Nil(t, err)
+	assert.EqualValues(t, 1, atomic.LoadInt32(&signer.calls))
+
+	// Signatures are converted to low-S
+	_, s, err := utils.UnmarshalECDSASignature(signature)
+	assert.Nil(t, err)
+	lowS, err := utils.IsLowS(&signer.privKey.PublicKey, s)
+	assert.Nil(t, err)
+	assert.True(t, lowS)
+
+	valid, err := c.Verify(k, signature, digest[:], nil)
+	assert.Nil(t, err)
+	assert.True(t, valid)
+
+	pubKey, err := k.PublicKey()
+	assert.Nil(t, err)
+	valid, err = c.Verify(pubKey, signature, digest[:], nil)
+	assert.Nil(t, err)
+	assert.True(t, valid)
+}
+
+func TestSignRetry(t *testing.T) {
+	digest := sha256.Sum256([]byte("Hello"))
+
+	// Temporary errors are retried
+	signer := newMockSigner(t)
+	signer.failures = 2
+	signer.err = temporaryError{}
+	c := newTestSuite(t, signer)
+	k, err := c.LoadKey("mock", "key1")
+	assert.Nil(t, err)
+	_, err = c.Sign(k, digest[:], nil)
+	assert.Nil(t, err)
+	assert.EqualValues(t, 3, atomic.LoadInt32(&signer.calls))
+
+	// Other errors are not retried
+	signer = newMockSigner(t)
+	signer.failures = 1
+	signer.err = errors.New("access denied")
+	c = newTestSuite(t, signer)
+	k, err = c.LoadKey("mock", "key1")
+	assert.Nil(t, err)
+	_, err = c.Sign(k, digest[:], nil)
+	if err == nil || !strings.Contains(err.Error(), "access denied") {
+		t.Fatalf("Expected access denied error but got: %v", err)
+	}
+	assert.EqualValues(t, 1, atomic.LoadInt32(&signer.calls))
+
+	// Calls which time out are retried up to the maximum number of attempts
+	signer = newMockSigner(t)
+	signer.failures = 10
+	signer.delay = time.Second
+	c = newTestSuite(t, signer, WithTimeout(20*time.Millisecond))
+	k, err = c.LoadKey("mock", "key1")
+	assert.Nil(t, err)
+	_, err = c.Sign(k, digest[:], nil)
+	if err == nil || !strings.Contains(err.Error(), "timed out") {
+		t.Fatalf("Expected timeout error but got: %v", err)
+	}
+	assert.EqualValues(t, 3, atomic.LoadInt32(&signer.calls))
+}
+
+func newTestSuite(t *testing.T, signer *mockSigner, opts ...Option) *CryptoSuite {
+	base, err := sw.GetSuiteWithDefaultEphemeral()
+	if err != nil {
+		t.Fatalf("Failed to create crypto suite: %s", err)
+	}
+	opts = append([]Option{
+		WithSignerFactory("mock", &mockSignerFactory{signer: signer}),
+		WithRetryOpts(retry.Opts{Attempts: 2, InitialBackoff: time.Millisecond, MaxBackoff: 10 * time.Millisecond, BackoffFactor: 2}),
+	}, opts...)
+	return New(base, opts...)
+}
+
+type mockSignerFactory struct {
+	signer *mockSigner
+}
+
+func (f *mockSignerFactory) NewSigner(keyID string) (Signer, error) {
+	return f.signer, nil
+}
+
+type mockSigner struct {
+	privKey  *ecdsa.PrivateKey
+	calls    int32
+	failures int32
+	err      error
+	delay    time.Duration
+}
+
+func newMockSigner(t *testing.T) *mockSigner {
+	privKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %s", err)
+	}
+	return &mockSigner{privKey: privKey}
+}
+
+func (s *mockSigner) Public() crypto.PublicKey {
+	return &s.privKey.PublicKey
+}
+
+func (s *mockSigner) Sign(ctx reqContext.Context, digest []byte) ([]byte, error) {
+	if atomic.AddInt32(&s.calls, 1) <= s.failures {
+		if s.delay > 0 {
+			select {
+			case <-time.After(s.delay):
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		}
+		return nil, s.err
+	}
+	r, sig, err := ecdsa.Sign(rand.Reader, s.privKey, digest)
+	if err != nil {
+		return nil, err
+	}
+	// Return the high-S form of the signature, as a KMS may do
+	if lowS, _ := utils.IsLowS(&s.privKey.PublicKey, sig); lowS {
+		sig.Sub(s.privKey.Params().N, sig)
+	}
+	return utils.MarshalECDSASignature(r, sig)
+}
+
+type temporaryError struct{}
+
+func (e temporaryError) Error() string   { return "service unavailable" }
+func (e temporaryError) Temporary() bool { return true }
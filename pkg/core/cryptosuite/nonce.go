@@ -0,0 +1,51 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package cryptosuite
+
+import (
+	"io"
+	"sync"
+
+	"github.com/hyperledger/fabric-sdk-go/internal/github.com/hyperledger/fabric/common/crypto"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/core"
+	"github.com/pkg/errors"
+)
+
+// GetNonce returns a nonce from the given crypto suite if it implements core.NonceGenerator,
+// otherwise a secure random nonce.
+func GetNonce(cs core.CryptoSuite) ([]byte, error) {
+	if ng, ok := cs.(core.NonceGenerator); ok {
+		return ng.GetNonce()
+	}
+	return crypto.GetRandomNonce()
+}
+
+// WithNonceSource returns a crypto suite which delegates to the given crypto suite and reads
+// the nonces of transaction and signature headers from the given source. It is intended for
+// tests which require reproducible transaction IDs (e.g. using a seeded math/rand source);
+// the secure random default must be kept in production.
+func WithNonceSource(cs core.CryptoSuite, source io.Reader) core.CryptoSuite {
+	return &nonceSourceSuite{CryptoSuite: cs, source: source}
+}
+
+type nonceSourceSuite struct {
+	core.CryptoSuite
+	mutex  sync.Mutex
+	source io.Reader
+}
+
+// GetNonce reads the next nonce from the source
+func (s *nonceSourceSuite) GetNonce() ([]byte, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	nonce := make([]byte, crypto.NonceSize)
+	if _, err := io.ReadFull(s.source, nonce); err != nil {
+		return nil, errors.Wrap(err, "reading nonce from source failed")
+	}
+	return nonce, nil
+}
@@ -63,6 +63,22 @@ type Logger interface {
 	Errorln(args ...interface{})
 }
 
+// Field is a key-value pair which provides context to a log message (e.g. the peer URL, channel or transaction ID)
+type Field struct {
+	Key   string
+	Value interface{}
+}
+
+// StructuredLogger is implemented by loggers which support logging messages along with
+// key-value fields, allowing the fields to be emitted in a machine-parseable format.
+// If the loggers created by a custom LoggerProvider implement this interface then the
+// SDK's structured log calls are passed to them along with the fields; otherwise the
+// fields are appended to the message.
+type StructuredLogger interface {
+	// Logw logs the message at the given level along with the given fields
+	Logw(level Level, msg string, fields ...Field)
+}
+
 // LoggerProvider is a factory for module loggers
 // TODO: should this be renamed to LoggerFactory?
 type LoggerProvider interface {
@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package modlog
 
 import (
+	"bytes"
 	"fmt"
 	"log"
 	"os"
@@ -392,6 +393,52 @@ func (l *Log) ChangeOutput(output io.Writer) {
 	l.deflogger.SetOutput(output)
 }
 
+// Logw logs the message at the given level along with the given fields. If the custom logger
+// is a structured logger then the fields are passed to it; otherwise they are appended to the message.
+func (l *Log) Logw(level api.Level, msg string, fields ...api.Field) {
+	opts := getLoggerOpts(l.module, level)
+	if !opts.levelEnabled {
+		return
+	}
+	if l.loadCustomLogger() {
+		if sl, ok := l.customLogger.(api.StructuredLogger); ok {
+			sl.Logw(level, msg, fields...)
+			return
+		}
+		LogMessage(l.customLogger, level, FormatMessage(msg, fields))
+		return
+	}
+	l.log(opts, level, FormatMessage(msg, fields))
+}
+
+// FormatMessage appends the given fields to the message in key=value format
+func FormatMessage(msg string, fields []api.Field) string {
+	if len(fields) == 0 {
+		return msg
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(msg)
+	for _, f := range fields {
+		fmt.Fprintf(&buf, " %s=%v", f.Key, f.Value)
+	}
+	return buf.String()
+}
+
+// LogMessage logs the given message at the given level using the given (unstructured) logger
+func LogMessage(logger api.Logger, level api.Level, msg interface{}) {
+	switch level {
+	case api.DEBUG:
+		logger.Debug(msg)
+	case api.INFO:
+		logger.Info(msg)
+	case api.WARNING:
+		logger.Warn(msg)
+	default:
+		logger.Error(msg)
+	}
+}
+
 func (l *Log) logf(opts *loggerOpts, level api.Level, format string, args ...interface{}) {
 	//Format prefix to show function name and log level and to indicate that timezone used is UTC
 	customPrefix := fmt.Sprintf(logLevelFormatter, l.getCallerInfo(opts), metadata.ParseString(level))
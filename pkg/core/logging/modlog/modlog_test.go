@@ -174,3 +174,10 @@ func TestCustomDefaultLoggingPanic(t *testing.T) {
 	VerifyBasicLogging(t, api.CRITICAL, nil, logger.Fatalf, &buf, true, moduleName2)
 
 }
+
+func TestFormatMessage(t *testing.T) {
+	assert.Equal(t, "brown fox jumps over the lazy dog", FormatMessage("brown fox jumps over the lazy dog", nil))
+
+	fields := []api.Field{{Key: "endpointURL", Value: "peer1"}, {Key: "attempt", Value: 2}}
+	assert.Equal(t, "brown fox jumps over the lazy dog endpointURL=peer1 attempt=2", FormatMessage("brown fox jumps over the lazy dog", fields))
+}
@@ -228,6 +228,27 @@ func (c *Ledger) QueryConfigBlock(reqCtx reqContext.Context, targets []fab.Propo
 	return createCommonBlock(tprs[0])
 }
 
+// QueryConfigBlockByNumber returns the config block at the given block number.
+// The responses from the targets are matched by the verifier.
+func (c *Ledger) QueryConfigBlockByNumber(reqCtx reqContext.Context, blockNumber uint64, targets []fab.ProposalProcessor, verifier ResponseVerifier) (*common.Block, error) {
+	if len(targets) == 0 {
+		return nil, errors.New("target(s) required")
+	}
+
+	cir := createBlockByNumberInvokeRequest(c.chName, blockNumber)
+	tprs, err := queryChaincode(reqCtx, c.chName, cir, targets, verifier)
+	if err != nil && len(tprs) == 0 {
+		return nil, errors.WithMessage(err, "queryChaincode failed")
+	}
+
+	matchErr := verifier.Match(tprs)
+	if matchErr != nil {
+		return nil, matchErr
+	}
+
+	return createCommonBlock(tprs[0])
+}
+
 func queryChaincode(reqCtx reqContext.Context, channelID string, request fab.ChaincodeInvokeRequest, targets []fab.ProposalProcessor, verifier ResponseVerifier) ([]*fab.TransactionProposalResponse, error) {
 	ctx, ok := contextImpl.RequestClientContext(reqCtx)
 	if !ok {
@@ -0,0 +1,148 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package membership
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	defaultCRLRefresh      = 10 * time.Minute
+	defaultCRLFetchTimeout = 30 * time.Second
+)
+
+// CRLSource loads a certificate revocation list (PEM or DER encoded) from a file or a URL.
+// The parsed CRL is cached and is reloaded once it is older than the refresh interval.
+// Note that the CRL's signature is not verified so the source must be trusted.
+type CRLSource struct {
+	location string
+	refresh  time.Duration
+	load     func() ([]byte, error)
+	client   *http.Client
+
+	mutex     sync.Mutex
+	crl       *pkix.CertificateList
+	loadedAt  time.Time
+	reloading bool
+}
+
+// NewCRLSource returns a CRL source for the given location, which is either
+// a file path or an http(s) URL. If refresh is 0 then a default of 10 minutes is used.
+func NewCRLSource(location string, refresh time.Duration) *CRLSource {
+	if refresh <= 0 {
+		refresh = defaultCRLRefresh
+	}
+
+	s := &CRLSource{location: location, refresh: refresh, client: &http.Client{Timeout: defaultCRLFetchTimeout}}
+	if strings.HasPrefix(location, "http://") || strings.HasPrefix(location, "https://") {
+		s.load = s.fetch
+	} else {
+		s.load = s.read
+	}
+	return s
+}
+
+// CRL returns the cached CRL, reloading it if it has expired. If the reload fails then
+// the previously loaded CRL continues to be used. The CRL is loaded without holding the
+// lock, and the previously loaded CRL is returned to other callers while it is being reloaded.
+func (s *CRLSource) CRL() (*pkix.CertificateList, error) {
+	current, reload := s.startReload()
+	if !reload {
+		return current, nil
+	}
+
+	crl, err := s.parse()
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.reloading = false
+	if err != nil {
+		if current != nil {
+			logger.Warnf("Failed to reload CRL from [%s] - using previously loaded CRL: %s", s.location, err)
+			return current, nil
+		}
+		return nil, err
+	}
+
+	s.crl = crl
+	s.loadedAt = time.Now()
+	return crl, nil
+}
+
+// startReload returns the cached CRL and whether or not the caller should reload it
+func (s *CRLSource) startReload() (*pkix.CertificateList, bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.crl != nil && (s.reloading || time.Since(s.loadedAt) < s.refresh) {
+		return s.crl, false
+	}
+	s.reloading = true
+	return s.crl, true
+}
+
+func (s *CRLSource) parse() (*pkix.CertificateList, error) {
+	crlBytes, err := s.load()
+	if err != nil {
+		return nil, errors.WithMessage(err, "loading CRL failed")
+	}
+	crl, err := x509.ParseCRL(crlBytes)
+	if err != nil {
+		return nil, errors.Wrapf(err, "parsing CRL from [%s] failed", s.location)
+	}
+	logger.Debugf("Loaded CRL from [%s] with %d revoked certificates", s.location, len(crl.TBSCertList.RevokedCertificates))
+	return crl, nil
+}
+
+func (s *CRLSource) read() ([]byte, error) {
+	crlBytes, err := ioutil.ReadFile(s.location)
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading CRL file [%s] failed", s.location)
+	}
+	return crlBytes, nil
+}
+
+func (s *CRLSource) fetch() ([]byte, error) {
+	resp, err := s.client.Get(s.location)
+	if err != nil {
+		return nil, errors.Wrapf(err, "fetching CRL from [%s] failed", s.location)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("fetching CRL from [%s] failed with status [%s]", s.location, resp.Status)
+	}
+	crlBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading CRL from [%s] failed", s.location)
+	}
+	return crlBytes, nil
+}
+
+// isRevoked returns true if the given certificate is in the given CRL
+func isRevoked(cert *x509.Certificate, crl *pkix.CertificateList) bool {
+	var issuer pkix.Name
+	issuer.FillFromRDNSequence(&crl.TBSCertList.Issuer)
+	if issuer.String() != cert.Issuer.String() {
+		return false
+	}
+	for _, rc := range crl.TBSCertList.RevokedCertificates {
+		if rc.SerialNumber.Cmp(cert.SerialNumber) == 0 {
+			return true
+		}
+	}
+	return false
+}
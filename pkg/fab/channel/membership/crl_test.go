@@ -0,0 +1,181 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package membership
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/mocks"
+	mb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/msp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCRLCheck(t *testing.T) {
+	goodMSPID := "GoodMSP"
+	ctx := mocks.NewMockProviderContext()
+	cfg := mocks.NewMockChannelCfg("")
+	cfg.MockMSPs = []*mb.MSPConfig{buildMSPConfig(goodMSPID, []byte(validRootCA))}
+
+	endorser, err := proto.Marshal(&mb.SerializedIdentity{Mspid: goodMSPID, IdBytes: []byte(certPem)})
+	assert.Nil(t, err)
+
+	crlFile, err := ioutil.TempFile("", "crl")
+	assert.Nil(t, err)
+	defer os.Remove(crlFile.Name())
+	_, err = crlFile.Write(createTestCRL(t, []byte(certPem)))
+	assert.Nil(t, err)
+	assert.Nil(t, crlFile.Close())
+
+	_, err = New(Context{Providers: ctx}, cfg, WithCRLCheck(true))
+	assert.NotNil(t, err, "expected error for CRL check without CRL sources")
+
+	// The CRL is ignored if the check is disabled
+	m, err := New(Context{Providers: ctx}, cfg, WithCRLCheck(false), WithCRLSources(NewCRLSource(crlFile.Name(), 0)))
+	assert.Nil(t, err)
+	assert.Nil(t, m.Validate(endorser))
+
+	m, err = New(Context{Providers: ctx}, cfg, WithCRLCheck(true), WithCRLSources(NewCRLSource(crlFile.Name(), 0)))
+	assert.Nil(t, err)
+	err = m.Validate(endorser)
+	if err == nil || !strings.Contains(err.Error(), "has been revoked") {
+		t.Fatalf("Expected error for revoked certificate, got: %v", err)
+	}
+	err = m.Verify(endorser, []byte("test"), []byte("test1"))
+	if err == nil || !strings.Contains(err.Error(), "has been revoked") {
+		t.Fatalf("Expected error for revoked certificate, got: %v", err)
+	}
+
+	// The CRL can't be loaded
+	m, err = New(Context{Providers: ctx}, cfg, WithCRLCheck(true), WithCRLSources(NewCRLSource(crlFile.Name()+".missing", 0)))
+	assert.Nil(t, err)
+	err = m.Validate(endorser)
+	if err == nil || !strings.Contains(err.Error(), "CRL check failed") {
+		t.Fatalf("Expected CRL check error, got: %v", err)
+	}
+}
+
+func TestCRLSourceURL(t *testing.T) {
+	// CRL which doesn't revoke the certificate
+	crl := createTestCRL(t, nil)
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		if _, err := w.Write(crl); err != nil {
+			t.Logf("Error writing CRL: %s", err)
+		}
+	}))
+	defer server.Close()
+
+	source := NewCRLSource(server.URL, 100*time.Millisecond)
+	for i := 0; i < 3; i++ {
+		_, err := source.CRL()
+		assert.Nil(t, err)
+	}
+	assert.EqualValues(t, 1, atomic.LoadInt32(&requests), "expected the CRL to be cached")
+
+	time.Sleep(200 * time.Millisecond)
+	_, err := source.CRL()
+	assert.Nil(t, err)
+	assert.EqualValues(t, 2, atomic.LoadInt32(&requests), "expected the CRL to be refreshed")
+
+	// The previous CRL continues to be used if the refresh fails
+	server.Close()
+	time.Sleep(200 * time.Millisecond)
+	_, err = source.CRL()
+	assert.Nil(t, err)
+
+	_, err = NewCRLSource(server.URL, 0).CRL()
+	assert.NotNil(t, err)
+}
+
+func TestCRLSourceSlowReload(t *testing.T) {
+	crl := createTestCRL(t, nil)
+	var requests int32
+	unblock := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) > 1 {
+			<-unblock
+		}
+		if _, err := w.Write(crl); err != nil {
+			t.Logf("Error writing CRL: %s", err)
+		}
+	}))
+	defer server.Close()
+
+	source := NewCRLSource(server.URL, 50*time.Millisecond)
+	loaded, err := source.CRL()
+	require.NoError(t, err)
+
+	time.Sleep(100 * time.Millisecond)
+	reloaded := make(chan error)
+	go func() {
+		_, err := source.CRL()
+		reloaded <- err
+	}()
+	for atomic.LoadInt32(&requests) < 2 {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	// The previously loaded CRL is returned while the reload is in progress
+	current, err := source.CRL()
+	assert.NoError(t, err)
+	assert.True(t, loaded == current, "expected the previously loaded CRL")
+	assert.EqualValues(t, 2, atomic.LoadInt32(&requests), "expected a single reload")
+
+	close(unblock)
+	assert.NoError(t, <-reloaded)
+}
+
+// createTestCRL creates a CRL whose issuer is the issuer of the given certificate and which revokes
+// the certificate. If the certificate is nil then an empty CRL is created.
+func createTestCRL(t *testing.T, certPEM []byte) []byte {
+	issuer := pkix.Name{CommonName: "ca.example.com"}
+	var revoked []pkix.RevokedCertificate
+	if certPEM != nil {
+		block, _ := pem.Decode(certPEM)
+		cert, err := x509.ParseCertificate(block.Bytes)
+		assert.Nil(t, err)
+		issuer = cert.Issuer
+		revoked = append(revoked, pkix.RevokedCertificate{SerialNumber: cert.SerialNumber, RevocationTime: time.Now()})
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.Nil(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               issuer,
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCRLSign | x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	assert.Nil(t, err)
+	caCert, err := x509.ParseCertificate(der)
+	assert.Nil(t, err)
+
+	crl, err := caCert.CreateCRL(rand.Reader, key, revoked, time.Now(), time.Now().Add(time.Hour))
+	assert.Nil(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "X509 CRL", Bytes: crl})
+}
@@ -24,6 +24,7 @@ var logger = logging.NewLogger("fabsdk/fab")
 
 type identityImpl struct {
 	mspManager msp.MSPManager
+	crlSources []*CRLSource
 }
 
 // Context holds the providers
@@ -32,22 +33,68 @@ type Context struct {
 	EndpointConfig fab.EndpointConfig
 }
 
+// Option configures the channel membership
+type Option func(opts *options)
+
+type options struct {
+	crlCheck   bool
+	crlSources []*CRLSource
+}
+
+// WithCRLCheck enables (or disables) checking identities against the CRLs of the configured
+// CRL sources, in addition to the CRLs in the channel's MSP config
+func WithCRLCheck(enabled bool) Option {
+	return func(opts *options) {
+		opts.crlCheck = enabled
+	}
+}
+
+// WithCRLSources sets the sources of the CRLs which are consulted if the CRL check is enabled
+func WithCRLSources(sources ...*CRLSource) Option {
+	return func(opts *options) {
+		opts.crlSources = append(opts.crlSources, sources...)
+	}
+}
+
 // New member identity
-func New(ctx Context, cfg fab.ChannelCfg) (fab.ChannelMembership, error) {
+func New(ctx Context, cfg fab.ChannelCfg, opts ...Option) (fab.ChannelMembership, error) {
+	o := options{}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.crlCheck && len(o.crlSources) == 0 {
+		return nil, errors.New("CRL check is enabled but no CRL sources are configured")
+	}
+
 	m, err := createMSPManager(ctx, cfg)
 	if err != nil {
 		return nil, err
 	}
-	return &identityImpl{mspManager: m}, nil
+
+	identity := &identityImpl{mspManager: m}
+	if o.crlCheck {
+		identity.crlSources = o.crlSources
+	}
+	return identity, nil
 }
 
 func (i *identityImpl) Validate(serializedID []byte) error {
-	err := areCertDatesValid(serializedID)
+	cert, err := parseCert(serializedID)
 	if err != nil {
 		logger.Errorf("Cert error %v", err)
 		return err
 	}
 
+	err = verifier.ValidateCertificateDates(cert)
+	if err != nil {
+		logger.Warnf("Certificate error '%v' for cert '%v'", err, cert.SerialNumber)
+		return err
+	}
+
+	if err := i.checkRevoked(cert); err != nil {
+		return err
+	}
+
 	id, err := i.mspManager.DeserializeIdentity(serializedID)
 	if err != nil {
 		return err
@@ -56,6 +103,16 @@ func (i *identityImpl) Validate(serializedID []byte) error {
 }
 
 func (i *identityImpl) Verify(serializedID []byte, msg []byte, sig []byte) error {
+	if len(i.crlSources) > 0 {
+		cert, err := parseCert(serializedID)
+		if err != nil {
+			return err
+		}
+		if err := i.checkRevoked(cert); err != nil {
+			return err
+		}
+	}
+
 	id, err := i.mspManager.DeserializeIdentity(serializedID)
 	if err != nil {
 		return err
@@ -64,28 +121,34 @@ func (i *identityImpl) Verify(serializedID []byte, msg []byte, sig []byte) error
 	return id.Verify(msg, sig)
 }
 
-func areCertDatesValid(serializedID []byte) error {
+// checkRevoked returns an error if the given certificate is revoked by one of the configured CRLs
+func (i *identityImpl) checkRevoked(cert *x509.Certificate) error {
+	for _, source := range i.crlSources {
+		crl, err := source.CRL()
+		if err != nil {
+			return errors.WithMessage(err, "CRL check failed")
+		}
+		if isRevoked(cert, crl) {
+			logger.Warnf("Certificate '%v' has been revoked", cert.SerialNumber)
+			return errors.Errorf("the certificate with serial number [%s] has been revoked", cert.SerialNumber)
+		}
+	}
+	return nil
+}
+
+func parseCert(serializedID []byte) (*x509.Certificate, error) {
 
 	sID := &mb.SerializedIdentity{}
 	err := proto.Unmarshal(serializedID, sID)
 	if err != nil {
-		return errors.Wrap(err, "could not deserialize a SerializedIdentity")
+		return nil, errors.Wrap(err, "could not deserialize a SerializedIdentity")
 	}
 
 	bl, _ := pem.Decode(sID.IdBytes)
 	if bl == nil {
-		return errors.New("could not decode the PEM structure")
-	}
-	cert, err := x509.ParseCertificate(bl.Bytes)
-	if err != nil {
-		return err
+		return nil, errors.New("could not decode the PEM structure")
 	}
-	err = verifier.ValidateCertificateDates(cert)
-	if err != nil {
-		logger.Warnf("Certificate error '%v' for cert '%v'", err, cert.SerialNumber)
-		return err
-	}
-	return nil
+	return x509.ParseCertificate(bl.Bytes)
 }
 
 func createMSPManager(ctx Context, cfg fab.ChannelCfg) (msp.MSPManager, error) {
@@ -24,9 +24,13 @@ import (
 	"encoding/pem"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-sdk-go/pkg/core/cryptosuite"
+	"github.com/hyperledger/fabric-sdk-go/pkg/core/cryptosuite/bccsp/sw"
 	"github.com/hyperledger/fabric-sdk-go/pkg/fab/mocks"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/signingmgr"
 	mb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/msp"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 //TestCertSignedWithUnknownAuthority
@@ -361,3 +365,75 @@ func generateSelfSignedCert(t *testing.T, now time.Time) string {
 	return encodeCertToMemory(newCert)
 
 }
+
+func TestVerifyEd25519Signature(t *testing.T) {
+	cs, err := sw.GetSuiteWithDefaultEphemeral()
+	require.NoError(t, err)
+
+	ctx := mocks.NewMockProviderContext()
+	ctx.SetCryptoSuite(cs)
+
+	rootCA, caCert, caKey := generateRootCA(t)
+
+	// Ed25519 identity issued by the root CA
+	key, err := cs.KeyGen(cryptosuite.GetED25519KeyGenOpts(true))
+	require.NoError(t, err)
+	pubKey, err := key.PublicKey()
+	require.NoError(t, err)
+	pubKeyRaw, err := pubKey.Bytes()
+	require.NoError(t, err)
+	pub, err := x509.ParsePKIXPublicKey(pubKeyRaw)
+	require.NoError(t, err)
+
+	template := x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "ed25519.securekey.com"},
+		NotBefore:    time.Now().Add(-1 * time.Hour),
+		NotAfter:     time.Now().Add(1 * time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}
+	certRaw, err := x509.CreateCertificate(rand.Reader, &template, caCert, pub, caKey)
+	require.NoError(t, err)
+	cert := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certRaw})
+
+	cfg := mocks.NewMockChannelCfg("")
+	cfg.MockMSPs = []*mb.MSPConfig{{Config: marshalOrPanic(&mb.FabricMSPConfig{Name: "Ed25519MSP", RootCerts: [][]byte{rootCA}})}}
+	m, err := New(Context{Providers: ctx}, cfg)
+	require.NoError(t, err)
+
+	serializedID, err := proto.Marshal(&mb.SerializedIdentity{Mspid: "Ed25519MSP", IdBytes: cert})
+	require.NoError(t, err)
+
+	signingMgr, err := signingmgr.New(cs)
+	require.NoError(t, err)
+
+	msg := []byte("message")
+	sig, err := signingMgr.Sign(msg, key)
+	require.NoError(t, err)
+
+	assert.NoError(t, m.Verify(serializedID, msg, sig))
+	assert.Error(t, m.Verify(serializedID, []byte("tampered message"), sig), "Expecting verification of tampered message to fail")
+}
+
+// generateRootCA returns the PEM encoded certificate, the certificate and the key of a new ECDSA root CA
+func generateRootCA(t *testing.T) ([]byte, *x509.Certificate, *ecdsa.PrivateKey) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "ca.securekey.com"},
+		NotBefore:             time.Now().Add(-1 * time.Hour),
+		NotAfter:              time.Now().Add(1 * time.Hour),
+		SubjectKeyId:          []byte{1, 2, 3, 4},
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	certRaw, err := x509.CreateCertificate(rand.Reader, &template, &template, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(certRaw)
+	require.NoError(t, err)
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certRaw}), cert, key
+}
@@ -19,16 +19,18 @@ type Ref struct {
 	*lazyref.Reference
 	chConfigRef *lazyref.Reference
 	context     Context
+	opts        []Option
 	// Note: the following variables are only accessed from Ref.initializer which is synchronized
 	configBlockNumber uint64
 	mem               fab.ChannelMembership
 }
 
 // NewRef returns a new membership reference
-func NewRef(refresh time.Duration, context Context, chConfigRef *lazyref.Reference) *Ref {
+func NewRef(refresh time.Duration, context Context, chConfigRef *lazyref.Reference, opts ...Option) *Ref {
 	ref := &Ref{
 		chConfigRef: chConfigRef,
 		context:     context,
+		opts:        opts,
 	}
 
 	ref.Reference = lazyref.New(
@@ -83,7 +85,7 @@ func (ref *Ref) initializer() lazyref.Initializer {
 		// Membership is refreshed only if we have a newer config block
 		if ref.mem == nil || cfg.BlockNumber() > ref.configBlockNumber {
 			logger.Debugf("Creating membership for channel [%s]...", cfg.ID())
-			ref.mem, err = New(ref.context, cfg)
+			ref.mem, err = New(ref.context, cfg, ref.opts...)
 			if err != nil {
 				return nil, err
 			}
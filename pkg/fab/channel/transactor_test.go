@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package channel
 
 import (
+	reqContext "context"
 	"testing"
 
 	"time"
@@ -64,6 +65,44 @@ func TestTransactionBadStatus(t *testing.T) {
 	assert.NotNil(t, err)
 }
 
+func TestTransactorWithSigningIdentity(t *testing.T) {
+	user := mspmocks.NewMockSigningIdentity("test", "test")
+	ctx := mocks.NewMockContext(user)
+	chConfig := mocks.NewMockChannelCfg("testChannel")
+
+	admin := &serializedSigningIdentity{MockSigningIdentity: mspmocks.NewMockSigningIdentity("admin", "test"), serialized: []byte("admin")}
+	parent := context.WithSigningIdentity(reqContext.Background(), admin)
+
+	reqCtx, cancel := context.NewRequest(ctx, context.WithTimeout(10*time.Second), context.WithParent(parent))
+	defer cancel()
+	transactor, err := NewTransactor(reqCtx, chConfig)
+	assert.Nil(t, err)
+
+	txh, err := transactor.CreateTransactionHeader()
+	assert.Nil(t, err)
+	assert.Equal(t, "admin", string(txh.Creator()), "expecting transaction to be created by the overriding identity")
+
+	// Transactors of requests created without the override use the client's identity
+	reqCtx, cancel = context.NewRequest(ctx, context.WithTimeout(10*time.Second), context.WithParent(reqContext.Background()))
+	defer cancel()
+	transactor, err = NewTransactor(reqCtx, chConfig)
+	assert.Nil(t, err)
+
+	txh, err = transactor.CreateTransactionHeader()
+	assert.Nil(t, err)
+	assert.Equal(t, "test", string(txh.Creator()), "expecting transaction to be created by the client identity")
+}
+
+// serializedSigningIdentity is a mock signing identity with the given serialized form
+type serializedSigningIdentity struct {
+	*mspmocks.MockSigningIdentity
+	serialized []byte
+}
+
+func (i *serializedSigningIdentity) Serialize() ([]byte, error) {
+	return i.serialized, nil
+}
+
 func createTransactor(t *testing.T) *Transactor {
 	user := mspmocks.NewMockSigningIdentity("test", "test")
 	ctx := mocks.NewMockContext(user)
@@ -8,17 +8,24 @@ package chconfig
 
 import (
 	reqContext "context"
+	"fmt"
 	"math/rand"
+	"sort"
+	"sync"
+	"time"
 
 	"github.com/golang/protobuf/proto"
 
 	channelConfig "github.com/hyperledger/fabric-sdk-go/internal/github.com/hyperledger/fabric/common/channelconfig"
 	imsp "github.com/hyperledger/fabric-sdk-go/internal/github.com/hyperledger/fabric/msp"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/clock"
 	"github.com/hyperledger/fabric-sdk-go/pkg/common/errors/retry"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/errors/status"
 	"github.com/hyperledger/fabric-sdk-go/pkg/common/logging"
 	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/context"
 	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
 	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/msp"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/tracing"
 	contextImpl "github.com/hyperledger/fabric-sdk-go/pkg/context"
 	"github.com/hyperledger/fabric-sdk-go/pkg/fab/channel"
 	"github.com/hyperledger/fabric-sdk-go/pkg/fab/resource"
@@ -30,12 +37,11 @@ import (
 
 var logger = logging.NewLogger("fabsdk/fab")
 
-//overrideRetryHandler is private and used for unit-tests to test query retry behaviors
-var overrideRetryHandler retry.Handler
-
 const (
 	defaultMinResponses = 1
 	defaultMaxTargets   = 2
+
+	applicationGroupKey = "Application"
 )
 
 // Opts contains options for retrieving channel configuration
@@ -45,6 +51,23 @@ type Opts struct {
 	MinResponses int         // used with targets option; min number of success responses (from targets/peers)
 	MaxTargets   int         //if configured, channel config will be retrieved for these number of random targets
 	RetryOpts    retry.Opts  //opts for channel query retry handler
+	BlockNumber  *uint64     //if configured, channel config will be retrieved from this config block instead of the latest one
+	EarlyQuorum  bool        //if enabled, query returns as soon as MinResponses matching config blocks are received
+	// PerTargetTimeout if configured, each target gets its own deadline (capped by the request deadline)
+	PerTargetTimeout time.Duration
+	// TargetSorter if configured, orders the targets from config before MaxTargets are selected (random shuffle by default)
+	TargetSorter func([]fab.ProposalProcessor)
+	// RetryObserver if configured, is invoked with the attempt number and the error before each query retry
+	RetryObserver func(attempt int, err error)
+	// TargetFilter if configured, excludes the targets from config which it doesn't accept (e.g. known-bad peers)
+	TargetFilter fab.TargetFilter
+	// SignatureMSPs if configured, the config block signatures are verified against these orderer MSPs
+	SignatureMSPs []*mb.MSPConfig
+	// Discovery if configured (and no targets are given), the targets are selected from the peers it discovers
+	// instead of the channel peers from config
+	Discovery fab.DiscoveryService
+	// TargetOrgs if configured, only the peers of these MSPs are selected as targets from config or discovery
+	TargetOrgs []string
 }
 
 // Option func for each Opts argument
@@ -60,6 +83,8 @@ type Context struct {
 type ChannelConfig struct {
 	channelID string
 	opts      Opts
+	initOpts  Opts //opts supplied through options, restored on reload
+	optsMutex sync.Mutex
 }
 
 // ChannelCfg contains channel configuration
@@ -70,6 +95,38 @@ type ChannelCfg struct {
 	anchorPeers []*fab.OrgAnchorPeer
 	orderers    []string
 	versions    *fab.Versions
+	mspConfigs  map[string]*mb.FabricMSPConfig
+	// orgAnchorPeers holds the anchor peers of each application org, keyed by MSP ID
+	orgAnchorPeers map[string][]fab.AnchorPeer
+	// ordererEndpoints holds the endpoints defined in each orderer org, keyed by MSP ID
+	ordererEndpoints map[string][]string
+	consensusType    *fab.ConsensusType
+	capabilities     map[string][]string
+}
+
+// ErrConfigMismatch is returned by Query when the config block payloads
+// returned by the target peers do not match
+type ErrConfigMismatch struct {
+	// Peers contains the URLs of the peers whose responses were compared
+	Peers []string
+	// DistinctPayloads is the number of distinct config block payloads received
+	DistinctPayloads int
+	status           *status.Status
+}
+
+func (e *ErrConfigMismatch) Error() string {
+	return fmt.Sprintf("%s (%d distinct payloads from peers %v)", e.status, e.DistinctPayloads, e.Peers)
+}
+
+// Cause returns the underlying ENDORSEMENT_MISMATCH status so that
+// the error is still recognized by status.FromError and the retry handlers
+func (e *ErrConfigMismatch) Cause() error {
+	return e.status
+}
+
+// Unwrap returns the underlying ENDORSEMENT_MISMATCH status
+func (e *ErrConfigMismatch) Unwrap() error {
+	return e.status
 }
 
 // NewChannelCfg creates channel cfg
@@ -93,16 +150,47 @@ func (cfg *ChannelCfg) MSPs() []*mb.MSPConfig {
 	return cfg.msps
 }
 
+// MSPConfigs returns the decoded MSP configuration of each application and orderer org, keyed by MSP ID
+func (cfg *ChannelCfg) MSPConfigs() map[string]*mb.FabricMSPConfig {
+	return cfg.mspConfigs
+}
+
 // AnchorPeers returns anchor peers
 func (cfg *ChannelCfg) AnchorPeers() []*fab.OrgAnchorPeer {
 	return cfg.anchorPeers
 }
 
+// AnchorPeersByMSP returns the anchor peers of each application org, keyed by MSP ID. Orgs
+// without anchor peers are included with an empty slice.
+func (cfg *ChannelCfg) AnchorPeersByMSP() map[string][]fab.AnchorPeer {
+	return cfg.orgAnchorPeers
+}
+
 // Orderers returns orderers
 func (cfg *ChannelCfg) Orderers() []string {
 	return cfg.orderers
 }
 
+// OrdererEndpoints returns the endpoints defined in each orderer org, keyed by MSP ID. Orderer orgs
+// without endpoints (e.g. in channels which only define the global addresses returned by Orderers)
+// are included with an empty slice.
+func (cfg *ChannelCfg) OrdererEndpoints() map[string][]string {
+	return cfg.ordererEndpoints
+}
+
+// ConsensusType returns the consensus type of the ordering service along with its metadata
+// (e.g. the raft consenters), or nil if the config doesn't define the consensus type
+func (cfg *ChannelCfg) ConsensusType() *fab.ConsensusType {
+	return cfg.consensusType
+}
+
+// Capabilities returns the enabled capabilities (e.g. V1_2) of the Channel, Orderer and Application
+// groups, keyed by group name. The capabilities of each group are sorted; groups which don't declare
+// any capabilities are not included.
+func (cfg *ChannelCfg) Capabilities() map[string][]string {
+	return cfg.capabilities
+}
+
 // Versions returns versions
 func (cfg *ChannelCfg) Versions() *fab.Versions {
 	return cfg.versions
@@ -115,20 +203,98 @@ func New(channelID string, options ...Option) (*ChannelConfig, error) {
 		return nil, err
 	}
 
-	return &ChannelConfig{channelID: channelID, opts: opts}, nil
+	if opts.Targets != nil && opts.MinResponses > len(opts.Targets) {
+		return nil, errors.Errorf("minimum responses [%d] exceeds the number of targets [%d]", opts.MinResponses, len(opts.Targets))
+	}
+
+	return &ChannelConfig{channelID: channelID, opts: opts, initOpts: opts}, nil
+}
+
+// Reload clears the options resolved from the endpoint config so that
+// they are read again from the endpoint config on the next Query
+func (c *ChannelConfig) Reload() {
+	c.optsMutex.Lock()
+	defer c.optsMutex.Unlock()
+
+	c.opts = c.initOpts
 }
 
 // Query returns channel configuration
 func (c *ChannelConfig) Query(reqCtx reqContext.Context) (fab.ChannelCfg, error) {
+	logger.Debugw("Querying channel config", contextImpl.LogFields(reqCtx, logging.ChannelID(c.channelID))...)
+
+	reqCtx, span := tracing.StartSpan(reqCtx, "chconfig.Query")
+	span.SetAttribute(tracing.ChannelKey, c.channelID)
+	span.SetAttribute(tracing.AttemptsKey, 1)
+
+	cfg, err := c.query(reqCtx)
+	tracing.EndSpan(span, err)
+	return cfg, err
+}
 
-	if c.opts.Orderer != nil {
-		return c.queryOrderer(reqCtx)
+func (c *ChannelConfig) query(reqCtx reqContext.Context) (fab.ChannelCfg, error) {
+	block, err := c.QueryBlock(reqCtx)
+	if err != nil {
+		return nil, err
 	}
 
-	return c.queryPeers(reqCtx)
+	return extractConfig(c.channelID, block)
 }
 
-func (c *ChannelConfig) queryPeers(reqCtx reqContext.Context) (*ChannelCfg, error) {
+// QueryBlock returns the validated config block from which the channel configuration is extracted
+func (c *ChannelConfig) QueryBlock(reqCtx reqContext.Context) (*common.Block, error) {
+	opts, err := c.queryOpts(reqCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	var block *common.Block
+	if opts.Orderer != nil {
+		block, err = c.queryOrderer(reqCtx, &opts)
+	} else {
+		block, err = c.queryPeers(reqCtx, &opts)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if len(opts.SignatureMSPs) > 0 {
+		ctx, ok := contextImpl.RequestClientContext(reqCtx)
+		if !ok {
+			return nil, errors.New("failed get client context from reqContext for config block signature verification")
+		}
+		if err := verifyBlockSignatures(block, opts.SignatureMSPs, ctx.CryptoSuite()); err != nil {
+			return nil, err
+		}
+	}
+
+	return block, nil
+}
+
+// queryOpts returns a copy of the options for a single query, so that the query isn't affected
+// by a concurrent Reload. The options which aren't set are resolved from the endpoint config
+// when the config block is queried from peers.
+func (c *ChannelConfig) queryOpts(reqCtx reqContext.Context) (Opts, error) {
+	if c.initOpts.Orderer != nil {
+		c.optsMutex.Lock()
+		defer c.optsMutex.Unlock()
+		return c.opts, nil
+	}
+
+	ctx, ok := contextImpl.RequestClientContext(reqCtx)
+	if !ok {
+		return Opts{}, errors.New("failed get client context from reqContext for signPayload")
+	}
+
+	opts, err := c.resolveOptsFromConfig(ctx)
+	if err != nil {
+		return Opts{}, errors.WithMessage(err, "failed to resolve opts from config")
+	}
+
+	return opts, nil
+}
+
+func (c *ChannelConfig) queryPeers(reqCtx reqContext.Context, opts *Opts) (*common.Block, error) {
 
 	ctx, ok := contextImpl.RequestClientContext(reqCtx)
 	if !ok {
@@ -140,84 +306,186 @@ func (c *ChannelConfig) queryPeers(reqCtx reqContext.Context) (*ChannelCfg, erro
 		return nil, errors.WithMessage(err, "ledger client creation failed")
 	}
 
-	if err = c.resolveOptsFromConfig(ctx); err != nil {
-		return nil, errors.WithMessage(err, "failed to resolve opts from config")
-	}
-
 	targets := []fab.ProposalProcessor{}
-	if c.opts.Targets == nil {
+	if opts.Targets == nil && opts.Discovery != nil {
+		targets, err = c.calculateTargetsFromDiscovery(opts)
+		if err != nil {
+			return nil, err
+		}
+	} else if opts.Targets == nil {
 		// Calculate targets from config
-		targets, err = c.calculateTargetsFromConfig(ctx)
+		targets, err = c.calculateTargetsFromConfig(ctx, opts)
 		if err != nil {
 			return nil, err
 		}
 	} else {
-		targets = peersToTxnProcessors(c.opts.Targets)
+		targets = peersToTxnProcessors(opts.Targets)
 	}
 
-	retryHandler := retry.New(c.opts.RetryOpts)
+	if span := tracing.SpanFromContext(reqCtx); tracing.IsRecording(span) {
+		span.SetAttribute(tracing.TargetsKey, tracing.Targets(processorURLs(targets)))
+	}
 
-	//Unit test purpose only
-	if overrideRetryHandler != nil {
-		retryHandler = overrideRetryHandler
+	if opts.PerTargetTimeout > 0 {
+		targets = withTargetTimeout(targets, opts.PerTargetTimeout)
 	}
 
-	block, err := retry.NewInvoker(retryHandler).Invoke(
+	retryHandler := retry.New(opts.RetryOpts, retry.WithClock(clock.FromContext(reqCtx)))
+
+	verifier := &configBlockVerifier{TransactionProposalResponseVerifier: channel.TransactionProposalResponseVerifier{MinResponses: opts.MinResponses}}
+
+	block, err := retry.NewInvoker(retryHandler, retry.WithBeforeRetry(c.beforeRetry(reqCtx, opts))).InvokeWithContext(reqCtx,
 		func() (interface{}, error) {
-			return l.QueryConfigBlock(reqCtx, targets, &channel.TransactionProposalResponseVerifier{MinResponses: c.opts.MinResponses})
+			if opts.EarlyQuorum {
+				return c.queryConfigBlockWithQuorum(reqCtx, opts, l, targets)
+			}
+			return c.queryConfigBlock(reqCtx, opts, l, targets, verifier)
 		},
 	)
 
 	if err != nil {
+		if mismatchErr, ok := err.(*ErrConfigMismatch); ok {
+			return nil, mismatchErr
+		}
 		return nil, errors.WithMessage(err, "QueryBlockConfig failed")
 	}
-	return extractConfig(c.channelID, block.(*common.Block))
 
+	if opts.BlockNumber != nil {
+		if err := resource.VerifyConfigBlock(block.(*common.Block), *opts.BlockNumber); err != nil {
+			return nil, err
+		}
+	}
+
+	return block.(*common.Block), nil
 }
 
-func (c *ChannelConfig) calculateTargetsFromConfig(ctx context.Client) ([]fab.ProposalProcessor, error) {
-	targets := []fab.ProposalProcessor{}
+func (c *ChannelConfig) queryConfigBlock(reqCtx reqContext.Context, opts *Opts, l *channel.Ledger, targets []fab.ProposalProcessor, verifier channel.ResponseVerifier) (*common.Block, error) {
+	if opts.BlockNumber != nil {
+		return l.QueryConfigBlockByNumber(reqCtx, *opts.BlockNumber, targets, verifier)
+	}
+	return l.QueryConfigBlock(reqCtx, targets, verifier)
+}
+
+func (c *ChannelConfig) calculateTargetsFromConfig(ctx context.Client, opts *Opts) ([]fab.ProposalProcessor, error) {
 	chPeers, err := ctx.EndpointConfig().ChannelPeers(c.channelID)
 	if err != nil {
 		return nil, errors.WithMessage(err, "read configuration for channel peers failed")
 	}
 
+	peers := []fab.Peer{}
 	for _, p := range chPeers {
 		newPeer, err := ctx.InfraProvider().CreatePeerFromConfig((&p.NetworkPeer))
 		if err != nil || newPeer == nil {
 			return nil, errors.WithMessage(err, "NewPeer failed")
 		}
+		peers = append(peers, newPeer)
+	}
 
-		targets = append(targets, newPeer)
+	return selectTargets(opts, peers), nil
+}
+
+func (c *ChannelConfig) calculateTargetsFromDiscovery(opts *Opts) ([]fab.ProposalProcessor, error) {
+	peers, err := opts.Discovery.GetPeers()
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to discover channel peers")
+	}
+
+	targets := selectTargets(opts, peers)
+	if len(targets) < opts.MinResponses {
+		return nil, status.New(status.ClientStatus, status.NoPeersFound.ToInt32(),
+			fmt.Sprintf("%d targets were discovered but at least %d are required", len(targets), opts.MinResponses), nil)
 	}
 
-	targets = randomMaxTargets(targets, c.opts.MaxTargets)
 	return targets, nil
 }
 
-func (c *ChannelConfig) queryOrderer(reqCtx reqContext.Context) (*ChannelCfg, error) {
+// selectTargets selects at most MaxTargets of the given peers which are accepted by the target filter
+func selectTargets(opts *Opts, peers []fab.Peer) []fab.ProposalProcessor {
+	targets := []fab.ProposalProcessor{}
+	for _, peer := range peers {
+		if len(opts.TargetOrgs) > 0 && !containsString(opts.TargetOrgs, peer.MSPID()) {
+			logger.Debugf("Excluding target [%s] of MSP [%s] which is not one of the target orgs", peer.URL(), peer.MSPID())
+			continue
+		}
+
+		if opts.TargetFilter != nil && !opts.TargetFilter.Accept(peer) {
+			logger.Debugf("Excluding target [%s] which was not accepted by the target filter", peer.URL())
+			continue
+		}
+
+		targets = append(targets, peer)
+	}
+
+	if opts.TargetSorter != nil {
+		return maxTargets(targets, opts.MaxTargets, opts.TargetSorter)
+	}
+
+	return randomMaxTargets(targets, opts.MaxTargets)
+}
+
+func (c *ChannelConfig) queryOrderer(reqCtx reqContext.Context, opts *Opts) (*common.Block, error) {
+	tracing.SpanFromContext(reqCtx).SetAttribute(tracing.TargetsKey, opts.Orderer.URL())
+
+	if opts.BlockNumber != nil {
+		block, err := resource.ConfigBlockFromOrderer(reqCtx, c.channelID, opts.Orderer, *opts.BlockNumber, resource.WithRetry(opts.RetryOpts), resource.WithBeforeRetry(c.beforeRetry(reqCtx, opts)))
+		if err != nil {
+			return nil, errors.WithMessage(err, "ConfigBlockFromOrderer failed")
+		}
+		return block, nil
+	}
 
-	block, err := resource.LastConfigFromOrderer(reqCtx, c.channelID, c.opts.Orderer, resource.WithRetry(c.opts.RetryOpts))
+	block, err := resource.LastConfigFromOrderer(reqCtx, c.channelID, opts.Orderer, resource.WithRetry(opts.RetryOpts), resource.WithBeforeRetry(c.beforeRetry(reqCtx, opts)))
 	if err != nil {
 		return nil, errors.WithMessage(err, "LastConfigFromOrderer failed")
 	}
 
-	return extractConfig(c.channelID, block)
+	return block, nil
+}
+
+// beforeRetry returns a handler which notifies the retry observer (if any) of each retry attempt
+// and records the number of attempts on the current span
+func (c *ChannelConfig) beforeRetry(reqCtx reqContext.Context, opts *Opts) retry.BeforeRetryHandler {
+	span := tracing.SpanFromContext(reqCtx)
+	if opts.RetryObserver == nil && !tracing.IsRecording(span) {
+		return nil
+	}
+
+	attempt := 0
+	return func(err error) {
+		attempt++
+		span.SetAttribute(tracing.AttemptsKey, attempt+1)
+		if opts.RetryObserver != nil {
+			opts.RetryObserver(attempt, err)
+		}
+	}
 }
 
-//resolveOptsFromConfig loads opts from config if not loaded/initialized
-func (c *ChannelConfig) resolveOptsFromConfig(ctx context.Client) error {
+// processorURLs returns the URLs of the given proposal processors which are peers
+func processorURLs(targets []fab.ProposalProcessor) []string {
+	var urls []string
+	for _, target := range targets {
+		if p, ok := target.(fab.Peer); ok {
+			urls = append(urls, p.URL())
+		}
+	}
+	return urls
+}
+
+//resolveOptsFromConfig loads opts from config if not loaded/initialized and returns a copy of the resolved opts
+func (c *ChannelConfig) resolveOptsFromConfig(ctx context.Client) (Opts, error) {
+	c.optsMutex.Lock()
+	defer c.optsMutex.Unlock()
 
 	if c.opts.MaxTargets != 0 && c.opts.MinResponses != 0 && c.opts.RetryOpts.RetryableCodes != nil {
 		//already loaded
-		return nil
+		return c.opts, nil
 	}
 
 	//If missing from opts, check config and update opts from config
 	chSdkCfg, err := ctx.EndpointConfig().ChannelConfig(c.channelID)
 	if err != nil {
 		//very rare, but return default in case of error
-		return err
+		return Opts{}, err
 	}
 
 	if c.opts.MaxTargets == 0 {
@@ -231,7 +499,7 @@ func (c *ChannelConfig) resolveOptsFromConfig(ctx context.Client) error {
 	c.resolveMinResponsesOptsFromConfig(chSdkCfg)
 	c.resolveRetryOptsFromConfig(chSdkCfg)
 
-	return nil
+	return c.opts, nil
 }
 
 func (c *ChannelConfig) resolveMinResponsesOptsFromConfig(chSdkCfg *fab.ChannelNetworkConfig) {
@@ -313,6 +581,85 @@ func WithRetryOpts(retryOpts retry.Opts) Option {
 	}
 }
 
+// WithConfigBlockNumber encapsulates config block number to Option. When set, the channel
+// config is retrieved from the config block at the given height instead of the latest one.
+func WithConfigBlockNumber(blockNumber uint64) Option {
+	return func(opts *Opts) error {
+		opts.BlockNumber = &blockNumber
+		return nil
+	}
+}
+
+// WithEarlyQuorum encapsulates early quorum to Option. When enabled, Query returns as soon as
+// MinResponses matching config blocks are received and cancels the remaining requests.
+func WithEarlyQuorum(earlyQuorum bool) Option {
+	return func(opts *Opts) error {
+		opts.EarlyQuorum = earlyQuorum
+		return nil
+	}
+}
+
+// WithPerTargetTimeout encapsulates per target timeout to Option. Each target
+// request gets its own deadline derived from (and capped by) the request context.
+func WithPerTargetTimeout(timeout time.Duration) Option {
+	return func(opts *Opts) error {
+		opts.PerTargetTimeout = timeout
+		return nil
+	}
+}
+
+// WithTargetSorter encapsulates target sorter to Option. The sorter orders the targets
+// resolved from config before the first MaxTargets are selected, replacing the default random shuffle.
+func WithTargetSorter(sorter func([]fab.ProposalProcessor)) Option {
+	return func(opts *Opts) error {
+		opts.TargetSorter = sorter
+		return nil
+	}
+}
+
+// WithRetryObserver encapsulates retry observer to Option. The observer is invoked
+// with the attempt number and the error that caused the retry before each query retry.
+func WithRetryObserver(observer func(attempt int, err error)) Option {
+	return func(opts *Opts) error {
+		opts.RetryObserver = observer
+		return nil
+	}
+}
+
+// WithTargetFilter encapsulates target filter to Option. Targets from config or discovery which are not
+// accepted by the filter are excluded before MaxTargets are selected.
+func WithTargetFilter(filter fab.TargetFilter) Option {
+	return func(opts *Opts) error {
+		opts.TargetFilter = filter
+		return nil
+	}
+}
+
+// WithDiscovery encapsulates discovery service to Option. When no peers are given (see WithPeers),
+// the targets are selected from the channel peers returned by the discovery service, instead of the
+// channel peers from config, applying the target filter and MaxTargets. An error is returned if fewer
+// than MinResponses targets are discovered.
+func WithDiscovery(discovery fab.DiscoveryService) Option {
+	return func(opts *Opts) error {
+		opts.Discovery = discovery
+		return nil
+	}
+}
+
+// WithTargetOrgs encapsulates the MSP IDs of the orgs whose peers may be queried to Option. When no
+// peers are given (see WithPeers), only the peers from config or discovery (see WithDiscovery) which
+// belong to one of these orgs are selected as targets, before MaxTargets are selected. This is useful
+// when the peers of other orgs are untrusted or unreachable.
+func WithTargetOrgs(mspIDs []string) Option {
+	return func(opts *Opts) error {
+		if len(mspIDs) == 0 {
+			return errors.New("at least one target org is required")
+		}
+		opts.TargetOrgs = mspIDs
+		return nil
+	}
+}
+
 // prepareQueryConfigOpts Reads channel config options from Option array
 func prepareOpts(options ...Option) (Opts, error) {
 	opts := Opts{}
@@ -343,12 +690,15 @@ func extractConfig(channelID string, block *common.Block) (*ChannelCfg, error) {
 	}
 
 	config := &ChannelCfg{
-		id:          channelID,
-		blockNumber: block.Header.Number,
-		msps:        []*mb.MSPConfig{},
-		anchorPeers: []*fab.OrgAnchorPeer{},
-		orderers:    []string{},
-		versions:    versions,
+		id:               channelID,
+		blockNumber:      block.Header.Number,
+		msps:             []*mb.MSPConfig{},
+		anchorPeers:      []*fab.OrgAnchorPeer{},
+		orderers:         []string{},
+		versions:         versions,
+		mspConfigs:       make(map[string]*mb.FabricMSPConfig),
+		ordererEndpoints: make(map[string][]string),
+		capabilities:     make(map[string][]string),
 	}
 
 	err = loadConfig(config, config.versions.Channel, group, "base", "")
@@ -356,6 +706,21 @@ func extractConfig(channelID string, block *common.Block) (*ChannelCfg, error) {
 		return nil, errors.WithMessage(err, "load config items from config group failed")
 	}
 
+	config.orgAnchorPeers, err = loadAnchorPeersByMSP(group.GetGroups()[applicationGroupKey])
+	if err != nil {
+		return nil, errors.WithMessage(err, "load anchor peers from config group failed")
+	}
+
+	err = loadOrdererConfig(config, group.GetGroups()[channelConfig.OrdererGroupKey])
+	if err != nil {
+		return nil, errors.WithMessage(err, "load orderer config from config group failed")
+	}
+
+	err = loadCapabilities(config, group)
+	if err != nil {
+		return nil, errors.WithMessage(err, "load capabilities from config group failed")
+	}
+
 	logger.Debugf("channel config: %v", config)
 
 	return config, err
@@ -499,6 +864,82 @@ func loadAnchorPeers(configValue *common.ConfigValue, configItems *ChannelCfg, g
 	return nil
 }
 
+// loadAnchorPeersByMSP returns the anchor peers of each org in the given application group, keyed by
+// the MSP ID of the org. The org group name is used if the org doesn't have an MSP value.
+func loadAnchorPeersByMSP(appGroup *common.ConfigGroup) (map[string][]fab.AnchorPeer, error) {
+	orgAnchorPeers := make(map[string][]fab.AnchorPeer)
+
+	for orgName, orgGroup := range appGroup.GetGroups() {
+		mspID := orgName
+		if mspValue, ok := orgGroup.GetValues()[channelConfig.MSPKey]; ok {
+			fabricMSPConfig, err := unmarshalFabricMSPConfig(mspValue.Value)
+			if err != nil {
+				return nil, err
+			}
+			mspID = fabricMSPConfig.Name
+		}
+
+		peers := []fab.AnchorPeer{}
+		if anchorPeersValue, ok := orgGroup.GetValues()[channelConfig.AnchorPeersKey]; ok {
+			anchorPeers := &pb.AnchorPeers{}
+			if err := proto.Unmarshal(anchorPeersValue.Value, anchorPeers); err != nil {
+				return nil, errors.Wrap(err, "unmarshal anchor peers from config failed")
+			}
+			for _, anchorPeer := range anchorPeers.AnchorPeers {
+				peers = append(peers, fab.AnchorPeer{Host: anchorPeer.Host, Port: anchorPeer.Port})
+			}
+		}
+
+		orgAnchorPeers[mspID] = peers
+	}
+
+	return orgAnchorPeers, nil
+}
+
+// loadCapabilities loads the capabilities declared by the channel group and by the orderer and application groups
+func loadCapabilities(configItems *ChannelCfg, channelGroup *common.ConfigGroup) error {
+	groups := map[string]*common.ConfigGroup{
+		channelConfig.ChannelGroupKey: channelGroup,
+		channelConfig.OrdererGroupKey: channelGroup.GetGroups()[channelConfig.OrdererGroupKey],
+		applicationGroupKey:           channelGroup.GetGroups()[applicationGroupKey],
+	}
+
+	for groupName, group := range groups {
+		value, ok := group.GetValues()[channelConfig.CapabilitiesKey]
+		if !ok {
+			continue
+		}
+
+		capabilities := &common.Capabilities{}
+		if err := proto.Unmarshal(value.Value, capabilities); err != nil {
+			return errors.Wrapf(err, "unmarshal %s capabilities from config failed", groupName)
+		}
+
+		names := make([]string, 0, len(capabilities.Capabilities))
+		for name := range capabilities.Capabilities {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		configItems.capabilities[groupName] = names
+	}
+
+	return nil
+}
+
+func unmarshalFabricMSPConfig(value []byte) (*mb.FabricMSPConfig, error) {
+	mspConfig := &mb.MSPConfig{}
+	if err := proto.Unmarshal(value, mspConfig); err != nil {
+		return nil, errors.Wrap(err, "unmarshal MSPConfig from config failed")
+	}
+
+	fabricMSPConfig := &mb.FabricMSPConfig{}
+	if err := proto.Unmarshal(mspConfig.Config, fabricMSPConfig); err != nil {
+		return nil, errors.Wrap(err, "unmarshal FabricMSPConfig from config failed")
+	}
+	return fabricMSPConfig, nil
+}
+
 func loadMSPKey(configValue *common.ConfigValue, configItems *ChannelCfg, groupName string) error {
 	mspConfig := &mb.MSPConfig{}
 	err := proto.Unmarshal(configValue.Value, mspConfig)
@@ -513,7 +954,13 @@ func loadMSPKey(configValue *common.ConfigValue, configItems *ChannelCfg, groupN
 		return errors.Errorf("unsupported MSP type (%v)", mspType)
 	}
 
+	fabricMSPConfig := &mb.FabricMSPConfig{}
+	if err := proto.Unmarshal(mspConfig.Config, fabricMSPConfig); err != nil {
+		return errors.Wrap(err, "unmarshal FabricMSPConfig from config failed")
+	}
+
 	configItems.msps = append(configItems.msps, mspConfig)
+	configItems.mspConfigs[fabricMSPConfig.Name] = fabricMSPConfig
 	return nil
 
 }
@@ -548,15 +995,6 @@ func loadConfigValue(configItems *ChannelCfg, key string, versionsValue *common.
 		if err := loadMSPKey(configValue, configItems, groupName); err != nil {
 			return err
 		}
-	//case channelConfig.ConsensusTypeKey:
-	//	consensusType := &ab.ConsensusType{}
-	//	err := proto.Unmarshal(configValue.Value, consensusType)
-	//	if err != nil {
-	//		return errors.Wrap(err, "unmarshal ConsensusType from config failed")
-	//	}
-	//
-	//	logger.Debugf("loadConfigValue - %s   - Consensus type value :: %s", groupName, consensusType.Type)
-	//	// TODO: Do something with this value
 	//case channelConfig.BatchSizeKey:
 	//	batchSize := &ab.BatchSize{}
 	//	err := proto.Unmarshal(configValue.Value, batchSize)
@@ -625,6 +1063,63 @@ func loadConfigValue(configItems *ChannelCfg, key string, versionsValue *common.
 	return nil
 }
 
+// configBlockVerifier matches config block responses and reports
+// mismatching payloads as ErrConfigMismatch
+type configBlockVerifier struct {
+	channel.TransactionProposalResponseVerifier
+}
+
+// Match verifies and matches transaction proposal responses
+func (v *configBlockVerifier) Match(responses []*fab.TransactionProposalResponse) error {
+	err := v.TransactionProposalResponseVerifier.Match(responses)
+	if err == nil {
+		return nil
+	}
+
+	s, ok := status.FromError(err)
+	if !ok || s.Group != status.EndorserClientStatus || s.Code != status.EndorsementMismatch.ToInt32() {
+		return err
+	}
+
+	return newConfigMismatchError(responses, s)
+}
+
+func newConfigMismatchError(responses []*fab.TransactionProposalResponse, s *status.Status) *ErrConfigMismatch {
+	var peers []string
+	var blocks []*common.Block
+	for _, response := range responses {
+		peers = append(peers, response.Endorser)
+
+		block := &common.Block{}
+		if err := proto.Unmarshal(response.ProposalResponse.GetResponse().Payload, block); err != nil {
+			logger.Debugf("unmarshal of config block from %s failed: %s", response.Endorser, err)
+			continue
+		}
+		blocks = append(blocks, block)
+	}
+
+	return &ErrConfigMismatch{Peers: peers, DistinctPayloads: len(distinctBlockData(blocks)), status: s}
+}
+
+// distinctBlockData groups the given blocks by their block data
+func distinctBlockData(blocks []*common.Block) [][]*common.Block {
+	var groups [][]*common.Block
+	for _, block := range blocks {
+		found := false
+		for i, group := range groups {
+			if proto.Equal(group[0].Data, block.Data) {
+				groups[i] = append(group, block)
+				found = true
+				break
+			}
+		}
+		if !found {
+			groups = append(groups, []*common.Block{block})
+		}
+	}
+	return groups
+}
+
 // peersToTxnProcessors converts a slice of Peers to a slice of ProposalProcessors
 func peersToTxnProcessors(peers []fab.Peer) []fab.ProposalProcessor {
 	tpp := make([]fab.ProposalProcessor, len(peers))
@@ -635,14 +1130,66 @@ func peersToTxnProcessors(peers []fab.Peer) []fab.ProposalProcessor {
 	return tpp
 }
 
+// timeoutProposalProcessor processes proposals with a per-target timeout
+type timeoutProposalProcessor struct {
+	target  fab.ProposalProcessor
+	timeout time.Duration
+}
+
+// ProcessTransactionProposal processes the proposal with a deadline derived from the request context
+func (p *timeoutProposalProcessor) ProcessTransactionProposal(reqCtx reqContext.Context, request fab.ProcessProposalRequest) (*fab.TransactionProposalResponse, error) {
+	ctx, cancel := reqContext.WithTimeout(reqCtx, p.timeout)
+	defer cancel()
+
+	return p.target.ProcessTransactionProposal(ctx, request)
+}
+
+// URL returns the URL of the wrapped target
+func (p *timeoutProposalProcessor) URL() string {
+	return targetURL(p.target)
+}
+
+// withTargetTimeout wraps each of the given targets so that it is given its own timeout
+func withTargetTimeout(targets []fab.ProposalProcessor, timeout time.Duration) []fab.ProposalProcessor {
+	tpp := make([]fab.ProposalProcessor, len(targets))
+
+	for i := range targets {
+		tpp[i] = &timeoutProposalProcessor{target: targets[i], timeout: timeout}
+	}
+	return tpp
+}
+
 //randomMaxTargets returns random sub set of max length targets
 func randomMaxTargets(targets []fab.ProposalProcessor, max int) []fab.ProposalProcessor {
 	if len(targets) <= max {
 		return targets
 	}
+	return maxTargets(targets, max, shuffleTargets)
+}
+
+//maxTargets orders the targets using the given sorter and returns the first max targets.
+//The targets are sorted even if there are no more than max targets, since their order matters.
+func maxTargets(targets []fab.ProposalProcessor, max int, sorter func([]fab.ProposalProcessor)) []fab.ProposalProcessor {
+	sorter(targets)
+	if len(targets) <= max {
+		return targets
+	}
+	return targets[:max]
+}
+
+//shuffleTargets randomly shuffles the given targets
+func shuffleTargets(targets []fab.ProposalProcessor) {
 	for i := range targets {
 		j := rand.Intn(i + 1)
 		targets[i], targets[j] = targets[j], targets[i]
 	}
-	return targets[:max]
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
 }
@@ -12,8 +12,10 @@ import (
 	"time"
 
 	"github.com/golang/protobuf/proto"
+	channelConfig "github.com/hyperledger/fabric-sdk-go/internal/github.com/hyperledger/fabric/common/channelconfig"
 	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/context"
 	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/tracing"
 	contextImpl "github.com/hyperledger/fabric-sdk-go/pkg/context"
 	"github.com/hyperledger/fabric-sdk-go/pkg/fab/mocks"
 	"github.com/hyperledger/fabric-sdk-go/pkg/fab/orderer"
@@ -22,7 +24,11 @@ import (
 
 	"strings"
 
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/clock"
 	"github.com/hyperledger/fabric-sdk-go/pkg/common/errors/retry"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/errors/status"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/peer"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -53,6 +59,266 @@ func TestChannelConfigWithPeer(t *testing.T) {
 	}
 }
 
+func TestChannelConfigMSPConfigs(t *testing.T) {
+	ctx := setupTestContext()
+	peer := getPeerWithConfigBlockPayload(t)
+
+	channelConfig, err := New(channelID, WithPeers([]fab.Peer{peer}), WithMinResponses(1))
+	if err != nil {
+		t.Fatalf("Failed to create new channel config: %s", err)
+	}
+
+	reqCtx, cancel := contextImpl.NewRequest(ctx, contextImpl.WithTimeout(10*time.Second))
+	defer cancel()
+
+	cfg, err := channelConfig.Query(reqCtx)
+	if err != nil {
+		t.Fatalf("Failed to query channel config: %s", err)
+	}
+
+	mspConfigs := cfg.MSPConfigs()
+	assert.Len(t, mspConfigs, 3, "expecting application and orderer org MSPs")
+	for _, mspID := range []string{"Org1MSP", "Org2MSP", "OrdererMSP"} {
+		mspConfig, ok := mspConfigs[mspID]
+		if assert.True(t, ok, "expecting MSP config for %s", mspID) {
+			assert.Equal(t, mspID, mspConfig.Name)
+			assert.Equal(t, [][]byte{[]byte(validRootCA)}, mspConfig.RootCerts)
+		}
+	}
+}
+
+func TestLoadAnchorPeersByMSP(t *testing.T) {
+	anchorPeers, err := proto.Marshal(&pb.AnchorPeers{
+		AnchorPeers: []*pb.AnchorPeer{{Host: "peer0.org1.example.com", Port: 7051}, {Host: "peer1.org1.example.com", Port: 8051}},
+	})
+	assert.NoError(t, err)
+
+	appGroup := &common.ConfigGroup{
+		Groups: map[string]*common.ConfigGroup{
+			"Org1": {
+				Values: map[string]*common.ConfigValue{
+					channelConfig.AnchorPeersKey: {Value: anchorPeers},
+				},
+			},
+			"Org2": {},
+		},
+	}
+
+	orgAnchorPeers, err := loadAnchorPeersByMSP(appGroup)
+	assert.NoError(t, err)
+	assert.Len(t, orgAnchorPeers, 2)
+	assert.Equal(t, []fab.AnchorPeer{{Host: "peer0.org1.example.com", Port: 7051}, {Host: "peer1.org1.example.com", Port: 8051}}, orgAnchorPeers["Org1"],
+		"expecting org name to be used if the org has no MSP")
+
+	org2Peers, ok := orgAnchorPeers["Org2"]
+	assert.True(t, ok, "expecting entry for org without anchor peers")
+	assert.NotNil(t, org2Peers, "expecting empty slice for org without anchor peers")
+	assert.Empty(t, org2Peers)
+
+	appGroup.Groups["Org1"].Values[channelConfig.AnchorPeersKey].Value = []byte("invalid")
+	_, err = loadAnchorPeersByMSP(appGroup)
+	assert.Error(t, err, "expecting error for invalid anchor peers")
+}
+
+func TestLoadCapabilities(t *testing.T) {
+	capabilitiesValue := func(names ...string) *common.ConfigValue {
+		capabilities := &common.Capabilities{Capabilities: make(map[string]*common.Capability)}
+		for _, name := range names {
+			capabilities.Capabilities[name] = &common.Capability{}
+		}
+		value, err := proto.Marshal(capabilities)
+		if err != nil {
+			t.Fatalf("Failed to marshal capabilities: %s", err)
+		}
+		return &common.ConfigValue{Value: value}
+	}
+
+	channelGroup := &common.ConfigGroup{
+		Groups: map[string]*common.ConfigGroup{
+			"Orderer":     {Values: map[string]*common.ConfigValue{"Capabilities": capabilitiesValue("V1_1")}},
+			"Application": {Values: map[string]*common.ConfigValue{"Capabilities": capabilitiesValue("V1_2", "V1_1", "V1_3")}},
+		},
+		Values: map[string]*common.ConfigValue{"Capabilities": capabilitiesValue("V1_3")},
+	}
+
+	cfg := &ChannelCfg{capabilities: make(map[string][]string)}
+	if err := loadCapabilities(cfg, channelGroup); err != nil {
+		t.Fatalf("Failed to load capabilities: %s", err)
+	}
+
+	assert.Equal(t, map[string][]string{
+		"Channel":     {"V1_3"},
+		"Orderer":     {"V1_1"},
+		"Application": {"V1_1", "V1_2", "V1_3"},
+	}, cfg.Capabilities())
+
+	// Groups without capabilities are not included
+	cfg = &ChannelCfg{capabilities: make(map[string][]string)}
+	delete(channelGroup.Groups, "Orderer")
+	delete(channelGroup.Values, "Capabilities")
+	if err := loadCapabilities(cfg, channelGroup); err != nil {
+		t.Fatalf("Failed to load capabilities: %s", err)
+	}
+	assert.Equal(t, map[string][]string{"Application": {"V1_1", "V1_2", "V1_3"}}, cfg.Capabilities())
+
+	channelGroup.Values["Capabilities"] = &common.ConfigValue{Value: []byte("invalid")}
+	assert.Error(t, loadCapabilities(cfg, channelGroup), "expecting error for invalid capabilities")
+}
+
+func TestChannelConfigCapabilities(t *testing.T) {
+	builder := &mocks.MockConfigBlockBuilder{
+		MockConfigGroupBuilder: mocks.MockConfigGroupBuilder{
+			ModPolicy:      "Admins",
+			MSPNames:       []string{"Org1MSP"},
+			OrdererAddress: "localhost:7054",
+			RootCA:         validRootCA,
+			Capabilities: map[string][]string{
+				"Channel":     {"V1_3"},
+				"Application": {"V1_2", "V1_1"},
+			},
+		},
+	}
+
+	cfg, err := extractConfig(channelID, builder.Build())
+	if err != nil {
+		t.Fatalf("Failed to extract config: %s", err)
+	}
+
+	assert.Equal(t, map[string][]string{
+		"Channel":     {"V1_3"},
+		"Application": {"V1_1", "V1_2"},
+	}, cfg.Capabilities())
+}
+
+func TestChannelConfigAnchorPeersByMSP(t *testing.T) {
+	builder := &mocks.MockConfigBlockBuilder{
+		MockConfigGroupBuilder: mocks.MockConfigGroupBuilder{
+			ModPolicy:      "Admins",
+			MSPNames:       []string{"Org1MSP", "Org2MSP"},
+			OrdererAddress: "localhost:7054",
+			RootCA:         validRootCA,
+			AnchorPeers: map[string][]*pb.AnchorPeer{
+				"Org1MSP": {{Host: "peer0.org1.example.com", Port: 7051}},
+			},
+		},
+	}
+
+	cfg, err := extractConfig(channelID, builder.Build())
+	if err != nil {
+		t.Fatalf("Failed to extract config: %s", err)
+	}
+
+	anchorPeers := cfg.AnchorPeersByMSP()
+	assert.Equal(t, []fab.AnchorPeer{{Host: "peer0.org1.example.com", Port: 7051}}, anchorPeers["Org1MSP"])
+	assert.Empty(t, anchorPeers["Org2MSP"], "expecting no anchor peers for Org2MSP")
+}
+
+func TestChannelConfigQueryBlock(t *testing.T) {
+
+	ctx := setupTestContext()
+	peer := getPeerWithConfigBlockPayload(t)
+
+	channelConfig, err := New(channelID, WithPeers([]fab.Peer{peer}), WithMinResponses(1), WithMaxTargets(1))
+	if err != nil {
+		t.Fatalf("Failed to create new channel client: %s", err)
+	}
+
+	reqCtx, cancel := contextImpl.NewRequest(ctx, contextImpl.WithTimeout(10*time.Second))
+	defer cancel()
+
+	block, err := channelConfig.QueryBlock(reqCtx)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	expected := &common.Block{}
+	if err := proto.Unmarshal(peer.(*mocks.MockPeer).Payload, expected); err != nil {
+		t.Fatalf("Failed to unmarshal mock block")
+	}
+	assert.True(t, proto.Equal(expected, block), "expecting block returned by peer")
+}
+
+func TestChannelConfigWithPeerAndBlockNumber(t *testing.T) {
+
+	ctx := setupTestContext()
+
+	builder := &mocks.MockConfigBlockBuilder{
+		MockConfigGroupBuilder: mocks.MockConfigGroupBuilder{
+			ModPolicy:      "Admins",
+			MSPNames:       []string{"Org1MSP"},
+			OrdererAddress: "localhost:7054",
+			RootCA:         validRootCA,
+		},
+		Index:           5,
+		LastConfigIndex: 5,
+	}
+	payload, err := proto.Marshal(builder.Build())
+	if err != nil {
+		t.Fatalf("Failed to marshal mock block")
+	}
+	peer := &mocks.MockPeer{MockName: "Peer1", MockURL: "http://peer1.com", Payload: payload, Status: 200}
+
+	channelConfig, err := New(channelID, WithPeers([]fab.Peer{peer}), WithMinResponses(1), WithConfigBlockNumber(5))
+	if err != nil {
+		t.Fatalf("Failed to create new channel client: %s", err)
+	}
+
+	reqCtx, cancel := contextImpl.NewRequest(ctx, contextImpl.WithTimeout(10*time.Second))
+	defer cancel()
+
+	cfg, err := channelConfig.Query(reqCtx)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	assert.Equal(t, uint64(5), cfg.BlockNumber(), "unexpected config block number")
+
+	// peer returning a block which is not a config block
+	payload, err = proto.Marshal(mocks.NewSimpleMockBlock())
+	if err != nil {
+		t.Fatalf("Failed to marshal mock block")
+	}
+	peer = &mocks.MockPeer{MockName: "Peer1", MockURL: "http://peer1.com", Payload: payload, Status: 200}
+
+	channelConfig, err = New(channelID, WithPeers([]fab.Peer{peer}), WithMinResponses(1), WithConfigBlockNumber(1))
+	if err != nil {
+		t.Fatalf("Failed to create new channel client: %s", err)
+	}
+
+	_, err = channelConfig.Query(reqCtx)
+	if err == nil || !strings.Contains(err.Error(), "block 1 is not a config block") {
+		t.Fatalf("Supposed to fail since block is not a config block, got: %v", err)
+	}
+}
+
+func TestChannelConfigWithPerTargetTimeout(t *testing.T) {
+
+	ctx := setupTestContext()
+	peer := getPeerWithConfigBlockPayload(t)
+	slowPeer := &slowMockPeer{MockPeer: mocks.MockPeer{MockName: "Peer2", MockURL: "http://peer2.com"}, cancelled: make(chan struct{})}
+
+	channelConfig, err := New(channelID, WithPeers([]fab.Peer{peer, slowPeer}), WithMinResponses(1), WithPerTargetTimeout(100*time.Millisecond))
+	if err != nil {
+		t.Fatalf("Failed to create new channel client: %s", err)
+	}
+
+	reqCtx, cancel := contextImpl.NewRequest(ctx, contextImpl.WithTimeout(10*time.Second))
+	defer cancel()
+
+	start := time.Now()
+	cfg, err := channelConfig.Query(reqCtx)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	assert.Equal(t, channelID, cfg.ID())
+	assert.True(t, time.Since(start) < 5*time.Second, "slow peer should have timed out before the request deadline")
+
+	select {
+	case <-slowPeer.cancelled:
+	default:
+		t.Fatalf("Expecting request to slow peer to time out")
+	}
+}
+
 func TestChannelConfigWithPeerWithRetries(t *testing.T) {
 
 	numberOfAttempts := 7
@@ -61,7 +327,8 @@ func TestChannelConfigWithPeerWithRetries(t *testing.T) {
 
 	defRetryOpts := retry.DefaultOpts
 	defRetryOpts.Attempts = numberOfAttempts
-	defRetryOpts.InitialBackoff = 5 * time.Millisecond
+	defRetryOpts.InitialBackoff = time.Minute
+	defRetryOpts.MaxBackoff = time.Minute
 	defRetryOpts.BackoffFactor = 1.0
 
 	chConfig := &fab.ChannelNetworkConfig{
@@ -78,24 +345,78 @@ func TestChannelConfigWithPeerWithRetries(t *testing.T) {
 	peer1 := getPeerWithConfigBlockPayload(t)
 	peer2 := getPeerWithConfigBlockPayload(t)
 
-	channelConfig, err := New(channelID, WithPeers([]fab.Peer{peer1, peer2}))
+	//Observe retries for tracking number of attempts
+	var attempts []int
+	retryObserver := func(attempt int, err error) {
+		attempts = append(attempts, attempt)
+		assert.NotNil(t, err, "expecting error which caused the retry")
+	}
+
+	channelConfig, err := New(channelID, WithPeers([]fab.Peer{peer1, peer2}), WithRetryObserver(retryObserver))
 	if err != nil {
 		t.Fatalf("Failed to create new channel client: %s", err)
 	}
 
-	//Set custom retry handler for tracking number of attempts
-	retryHandler := retry.New(defRetryOpts)
-	overrideRetryHandler = &customRetryHandler{handler: retryHandler, retries: 0}
-
-	reqCtx, cancel := contextImpl.NewRequest(ctx, contextImpl.WithTimeout(100*time.Second))
+	// Backoffs elapse instantly on the mock clock
+	start := time.Unix(1000, 0)
+	clk := clock.NewMock(start)
+	reqCtx, cancel := contextImpl.NewRequest(ctx, contextImpl.WithTimeout(100*time.Second), contextImpl.WithParent(clock.WithClock(reqContext.Background(), clk)))
 	defer cancel()
 
 	_, err = channelConfig.Query(reqCtx)
-	if err == nil || !strings.Contains(err.Error(), "ENDORSEMENT_MISMATCH") {
-		t.Fatalf("Supposed to fail with ENDORSEMENT_MISMATCH. Description: payloads for config block do not match")
+	mismatchErr, ok := err.(*ErrConfigMismatch)
+	if !ok {
+		t.Fatalf("Supposed to fail with ErrConfigMismatch, got: %v", err)
+	}
+	assert.Equal(t, 2, len(mismatchErr.Peers), "expecting both peers to be reported")
+	assert.Equal(t, 2, mismatchErr.DistinctPayloads, "expecting two distinct payloads")
+
+	s, ok := status.FromError(err)
+	assert.True(t, ok, "expected status error")
+	assert.EqualValues(t, status.EndorsementMismatch.ToInt32(), s.Code, "expected ENDORSEMENT_MISMATCH code")
+
+	assert.True(t, len(attempts) == numberOfAttempts, "number of attempts missmatching")
+	for i, attempt := range attempts {
+		assert.Equal(t, i+1, attempt, "unexpected attempt number")
+	}
+	assert.Equal(t, time.Duration(numberOfAttempts)*time.Minute, clk.Now().Sub(start), "expecting backoffs on the mock clock")
+}
+
+func TestChannelConfigQueryTracing(t *testing.T) {
+	user := mspmocks.NewMockSigningIdentity("test", "test")
+	ctx := mocks.NewMockContext(user)
+
+	retryOpts := retry.DefaultOpts
+	retryOpts.Attempts = 2
+	retryOpts.InitialBackoff = 5 * time.Millisecond
+	retryOpts.BackoffFactor = 1.0
+
+	peer1 := getPeerWithConfigBlockPayload(t)
+	peer2 := getPeerWithConfigBlockPayload(t)
+
+	channelConfig, err := New(channelID, WithPeers([]fab.Peer{peer1, peer2}), WithMinResponses(2), WithRetryOpts(retryOpts))
+	if err != nil {
+		t.Fatalf("Failed to create new channel client: %s", err)
 	}
 
-	assert.True(t, overrideRetryHandler.(*customRetryHandler).retries-1 == numberOfAttempts, "number of attempts missmatching")
+	tracer := &mockTracer{}
+	reqCtx, cancel := contextImpl.NewRequest(ctx, contextImpl.WithTimeout(10*time.Second), contextImpl.WithParent(tracing.WithTracer(reqContext.Background(), tracer)))
+	defer cancel()
+
+	_, err = channelConfig.Query(reqCtx)
+	assert.Error(t, err, "expecting config mismatch")
+
+	span := tracer.span
+	if span == nil {
+		t.Fatalf("Expecting span to be started")
+	}
+	assert.Equal(t, "chconfig.Query", span.name)
+	assert.True(t, span.ended)
+	assert.Equal(t, err, span.err)
+	assert.Equal(t, channelID, span.attributes[tracing.ChannelKey])
+	assert.Equal(t, 3, span.attributes[tracing.AttemptsKey])
+	assert.Equal(t, tracing.Targets([]string{peer1.URL(), peer2.URL()}), span.attributes[tracing.TargetsKey])
+	assert.EqualValues(t, status.EndorsementMismatch.ToInt32(), span.attributes[tracing.StatusCodeKey])
 }
 
 func TestChannelConfigWithPeerError(t *testing.T) {
@@ -103,7 +424,13 @@ func TestChannelConfigWithPeerError(t *testing.T) {
 	ctx := setupTestContext()
 	peer := getPeerWithConfigBlockPayload(t)
 
-	channelConfig, err := New(channelID, WithPeers([]fab.Peer{peer}), WithMinResponses(2))
+	_, err := New(channelID, WithPeers([]fab.Peer{peer}), WithMinResponses(2))
+	if err == nil {
+		t.Fatalf("Should have failed with since there's one endorser and at least two are required")
+	}
+
+	peer.(*mocks.MockPeer).Status = 500
+	channelConfig, err := New(channelID, WithPeers([]fab.Peer{peer}), WithMinResponses(1))
 	if err != nil {
 		t.Fatalf("Failed to create new channel client: %s", err)
 	}
@@ -113,7 +440,7 @@ func TestChannelConfigWithPeerError(t *testing.T) {
 
 	_, err = channelConfig.Query(reqCtx)
 	if err == nil {
-		t.Fatalf("Should have failed with since there's one endorser and at least two are required")
+		t.Fatalf("Should have failed since the endorser returned an error status")
 	}
 }
 
@@ -138,6 +465,79 @@ func TestChannelConfigWithOrdererError(t *testing.T) {
 
 }
 
+func TestChannelConfigWithOrderer(t *testing.T) {
+
+	ctx := setupTestContext()
+	builder := &mocks.MockConfigBlockBuilder{
+		MockConfigGroupBuilder: mocks.MockConfigGroupBuilder{
+			ModPolicy:      "Admins",
+			MSPNames:       []string{"Org1MSP"},
+			OrdererAddress: "localhost:7054",
+			RootCA:         validRootCA,
+		},
+	}
+	block := builder.Build()
+
+	// The config block is retrieved as the newest block and then as the last config block
+	o := mocks.NewMockOrderer("localhost:7054", nil)
+	defer o.Close()
+	o.EnqueueDeliverResponse([]*common.Block{block}, nil)
+	o.EnqueueDeliverResponse([]*common.Block{block}, nil)
+
+	channelConfig, err := New(channelID, WithOrderer(o))
+	if err != nil {
+		t.Fatalf("Failed to create new channel client: %s", err)
+	}
+
+	reqCtx, cancel := contextImpl.NewRequest(ctx, contextImpl.WithTimeout(10*time.Second))
+	defer cancel()
+
+	cfg, err := channelConfig.Query(reqCtx)
+	if err != nil {
+		t.Fatalf("Failed to query channel config from orderer: %s", err)
+	}
+	if cfg.ID() != channelID {
+		t.Fatalf("Channel name error. Expecting %s, got %s ", channelID, cfg.ID())
+	}
+	if len(cfg.Orderers()) != 1 || cfg.Orderers()[0] != "localhost:7054" {
+		t.Fatalf("Unexpected orderers: %v", cfg.Orderers())
+	}
+
+	// The orderer is slower than the request timeout
+	o.Delay = 5 * time.Second
+	o.EnqueueDeliverResponse([]*common.Block{block}, nil)
+	o.EnqueueDeliverResponse([]*common.Block{block}, nil)
+
+	reqCtx, cancel = contextImpl.NewRequest(ctx, contextImpl.WithTimeout(100*time.Millisecond))
+	defer cancel()
+
+	if _, err = channelConfig.Query(reqCtx); err == nil {
+		t.Fatalf("Should have failed since the orderer didn't respond before the timeout")
+	}
+}
+
+func TestChannelConfigWithOrdererDeliverError(t *testing.T) {
+
+	ctx := setupTestContext()
+	o := mocks.NewMockOrderer("localhost:7054", nil)
+	defer o.Close()
+	o.EnqueueDeliverResponse(nil, status.New(status.OrdererServerStatus, int32(common.Status_BAD_REQUEST), "bad request", nil))
+
+	channelConfig, err := New(channelID, WithOrderer(o), WithRetryOpts(retry.Opts{Attempts: 0}))
+	if err != nil {
+		t.Fatalf("Failed to create new channel client: %s", err)
+	}
+
+	reqCtx, cancel := contextImpl.NewRequest(ctx, contextImpl.WithTimeout(10*time.Second))
+	defer cancel()
+
+	_, err = channelConfig.Query(reqCtx)
+	s, ok := status.FromError(err)
+	if !ok || s.Code != int32(common.Status_BAD_REQUEST) {
+		t.Fatalf("Expecting bad request status error but got %v", err)
+	}
+}
+
 func TestRandomMaxTargetsSelections(t *testing.T) {
 
 	testTargets := []fab.ProposalProcessor{
@@ -172,6 +572,146 @@ func TestRandomMaxTargetsSelections(t *testing.T) {
 
 }
 
+func TestMaxTargetsWithSorter(t *testing.T) {
+
+	testTargets := []fab.ProposalProcessor{
+		&mockProposalProcessor{"ONE"}, &mockProposalProcessor{"TWO"}, &mockProposalProcessor{"THREE"},
+		&mockProposalProcessor{"FOUR"}, &mockProposalProcessor{"FIVE"},
+	}
+
+	reverse := func(targets []fab.ProposalProcessor) {
+		for i, j := 0, len(targets)-1; i < j; i, j = i+1, j-1 {
+			targets[i], targets[j] = targets[j], targets[i]
+		}
+	}
+
+	channelConfig, err := New(channelID, WithTargetSorter(reverse))
+	if err != nil {
+		t.Fatal("Failed to create channel config")
+	}
+	assert.NotNil(t, channelConfig.opts.TargetSorter, "target sorter supposed to be loaded with options")
+
+	responseTargets := maxTargets(testTargets, 2, channelConfig.opts.TargetSorter)
+	assert.Equal(t, 2, len(responseTargets), "response target not as expected")
+	assert.Equal(t, "FIVE", responseTargets[0].(*mockProposalProcessor).name)
+	assert.Equal(t, "FOUR", responseTargets[1].(*mockProposalProcessor).name)
+
+	// the targets are sorted even if there are no more than max targets
+	responseTargets = maxTargets(testTargets[:2], 2, channelConfig.opts.TargetSorter)
+	assert.Equal(t, 2, len(responseTargets), "response target not as expected")
+	assert.Equal(t, "FOUR", responseTargets[0].(*mockProposalProcessor).name)
+	assert.Equal(t, "FIVE", responseTargets[1].(*mockProposalProcessor).name)
+}
+
+func TestCalculateTargetsWithFilter(t *testing.T) {
+	ctx := setupTestContext()
+
+	channelConfig, err := New(channelID, WithMaxTargets(2))
+	if err != nil {
+		t.Fatal("Failed to create channel config")
+	}
+	targets, err := channelConfig.calculateTargetsFromConfig(ctx, &channelConfig.opts)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(targets), "expecting the channel peer from config")
+
+	channelConfig, err = New(channelID, WithMaxTargets(2), WithTargetFilter(&rejectURLFilter{url: "example.com"}))
+	if err != nil {
+		t.Fatal("Failed to create channel config")
+	}
+	assert.NotNil(t, channelConfig.opts.TargetFilter, "target filter supposed to be loaded with options")
+
+	targets, err = channelConfig.calculateTargetsFromConfig(ctx, &channelConfig.opts)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(targets), "expecting the rejected channel peer to be excluded")
+}
+
+func TestChannelConfigWithDiscovery(t *testing.T) {
+	ctx := setupTestContext()
+
+	peer1 := getPeerWithConfigBlockPayload(t).(*mocks.MockPeer)
+	peer2 := &mocks.MockPeer{MockName: "Peer2", MockURL: "http://peer2.com", Payload: peer1.Payload, Status: 200}
+	peer3 := &mocks.MockPeer{MockName: "Peer3", MockURL: "http://peer3.example.com", Payload: peer1.Payload, Status: 200}
+	discovery := mocks.NewMockDiscoveryService(nil, []fab.Peer{peer1, peer2, peer3})
+
+	channelConfig, err := New(channelID, WithDiscovery(discovery), WithMinResponses(1), WithMaxTargets(2))
+	if err != nil {
+		t.Fatal("Failed to create channel config")
+	}
+
+	targets, err := channelConfig.calculateTargetsFromDiscovery(&channelConfig.opts)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(targets), "expecting MaxTargets of the discovered peers")
+
+	reqCtx, cancel := contextImpl.NewRequest(ctx, contextImpl.WithTimeout(10*time.Second))
+	defer cancel()
+
+	cfg, err := channelConfig.Query(reqCtx)
+	assert.Nil(t, err)
+	assert.Equal(t, channelID, cfg.ID())
+
+	// Not enough discovered peers accepted by the target filter
+	channelConfig, err = New(channelID, WithDiscovery(discovery), WithMinResponses(3), WithMaxTargets(3),
+		WithTargetFilter(&rejectURLFilter{url: "http://peer3.example.com"}))
+	if err != nil {
+		t.Fatal("Failed to create channel config")
+	}
+	_, err = channelConfig.Query(reqCtx)
+	s, ok := status.FromError(err)
+	assert.True(t, ok, "expected status error")
+	assert.EqualValues(t, status.NoPeersFound.ToInt32(), s.Code, "expected NoPeersFound error")
+
+	// Discovery error
+	channelConfig, err = New(channelID, WithDiscovery(mocks.NewMockDiscoveryService(errors.New("discovery failed"), nil)))
+	if err != nil {
+		t.Fatal("Failed to create channel config")
+	}
+	_, err = channelConfig.Query(reqCtx)
+	assert.NotNil(t, err)
+	assert.True(t, strings.Contains(err.Error(), "discovery failed"), "expected discovery error")
+}
+
+func TestChannelConfigWithTargetOrgs(t *testing.T) {
+	ctx := setupTestContext()
+
+	peer1 := getPeerWithConfigBlockPayload(t).(*mocks.MockPeer)
+	peer1.MockMSP = "Org1MSP"
+	peer2 := &mocks.MockPeer{MockName: "Peer2", MockURL: "http://peer2.com", MockMSP: "Org2MSP", Payload: peer1.Payload, Status: 200}
+	peer3 := &mocks.MockPeer{MockName: "Peer3", MockURL: "http://peer3.com", MockMSP: "Org2MSP", Payload: peer1.Payload, Status: 200}
+	discovery := mocks.NewMockDiscoveryService(nil, []fab.Peer{peer1, peer2, peer3})
+
+	channelConfig, err := New(channelID, WithDiscovery(discovery), WithTargetOrgs([]string{"Org2MSP"}), WithMinResponses(2), WithMaxTargets(3))
+	if err != nil {
+		t.Fatal("Failed to create channel config")
+	}
+
+	targets, err := channelConfig.calculateTargetsFromDiscovery(&channelConfig.opts)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(targets), "expecting the peers of the target orgs")
+	for _, target := range targets {
+		assert.Equal(t, "Org2MSP", target.(fab.Peer).MSPID())
+	}
+
+	reqCtx, cancel := contextImpl.NewRequest(ctx, contextImpl.WithTimeout(10*time.Second))
+	defer cancel()
+
+	cfg, err := channelConfig.Query(reqCtx)
+	assert.Nil(t, err)
+	assert.Equal(t, channelID, cfg.ID())
+
+	// Fewer peers in the target orgs than MinResponses
+	channelConfig, err = New(channelID, WithDiscovery(discovery), WithTargetOrgs([]string{"Org1MSP", "Org3MSP"}), WithMinResponses(2))
+	if err != nil {
+		t.Fatal("Failed to create channel config")
+	}
+	_, err = channelConfig.Query(reqCtx)
+	s, ok := status.FromError(err)
+	assert.True(t, ok, "expected status error")
+	assert.EqualValues(t, status.NoPeersFound.ToInt32(), s.Code, "expected NoPeersFound error")
+
+	_, err = New(channelID, WithTargetOrgs(nil))
+	assert.NotNil(t, err, "expecting error for missing target orgs")
+}
+
 func TestResolveOptsFromConfig(t *testing.T) {
 	user := mspmocks.NewMockSigningIdentity("test", "test")
 	ctx := mocks.NewMockContext(user)
@@ -198,7 +738,8 @@ func TestResolveOptsFromConfig(t *testing.T) {
 	assert.True(t, channelConfig.opts.MinResponses == 0, "supposed to be zero when not resolved")
 	assert.True(t, channelConfig.opts.RetryOpts.RetryableCodes == nil, "supposed to be nil when not resolved")
 
-	channelConfig, err = New(channelID, WithPeers([]fab.Peer{}), WithMinResponses(2))
+	peers := []fab.Peer{getPeerWithConfigBlockPayload(t), getPeerWithConfigBlockPayload(t)}
+	channelConfig, err = New(channelID, WithPeers(peers), WithMinResponses(2))
 	if err != nil {
 		t.Fatal("Failed to create channel config")
 	}
@@ -219,6 +760,17 @@ func TestResolveOptsFromConfig(t *testing.T) {
 	mockConfig.called = false
 	channelConfig.resolveOptsFromConfig(ctx)
 	assert.False(t, mockConfig.called, "config.ChannelConfig() should not be used by resolve opts function once opts are loaded")
+
+	//Reload, opts should get reloaded from config except the ones supplied through options
+	chConfig.Policies.QueryChannelConfig.MaxTargets = 5
+	channelConfig.Reload()
+	assert.True(t, channelConfig.opts.MaxTargets == 0, "supposed to be zero after reload")
+
+	mockConfig.called = false
+	channelConfig.resolveOptsFromConfig(ctx)
+	assert.True(t, mockConfig.called, "config.ChannelConfig() supposed to be used by resolve opts function after reload")
+	assert.True(t, channelConfig.opts.MaxTargets == 5, "supposed to be reloaded from config")
+	assert.True(t, channelConfig.opts.MinResponses == 2, "supposed to keep value supplied through options")
 }
 
 func TestResolveOptsDefaultValues(t *testing.T) {
@@ -232,7 +784,7 @@ func TestResolveOptsDefaultValues(t *testing.T) {
 	if err != nil {
 		t.Fatal("Failed to create channel config")
 	}
-	err = channelConfig.resolveOptsFromConfig(ctx)
+	_, err = channelConfig.resolveOptsFromConfig(ctx)
 	if err != nil {
 		t.Fatal("Failed to resolve opts from config")
 	}
@@ -241,6 +793,33 @@ func TestResolveOptsDefaultValues(t *testing.T) {
 	assert.True(t, channelConfig.opts.RetryOpts.RetryableCodes != nil, "supposed to be loaded once opts resolved from config")
 }
 
+func TestChannelConfigQueryWithConcurrentReload(t *testing.T) {
+	ctx := setupTestContext()
+	peer := getPeerWithConfigBlockPayload(t)
+
+	channelConfig, err := New(channelID, WithPeers([]fab.Peer{peer}))
+	if err != nil {
+		t.Fatal("Failed to create channel config")
+	}
+
+	reqCtx, cancel := contextImpl.NewRequest(ctx, contextImpl.WithTimeout(10*time.Second))
+	defer cancel()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 100; i++ {
+			channelConfig.Reload()
+		}
+	}()
+
+	for i := 0; i < 10; i++ {
+		_, err := channelConfig.Query(reqCtx)
+		assert.Nil(t, err)
+	}
+	<-done
+}
+
 func setupTestContext() context.Client {
 	user := mspmocks.NewMockSigningIdentity("test", "test")
 	ctx := mocks.NewMockContext(user)
@@ -248,6 +827,42 @@ func setupTestContext() context.Client {
 	return ctx
 }
 
+func TestChannelConfigWithFlappingPeer(t *testing.T) {
+	user := mspmocks.NewMockSigningIdentity("test", "test")
+	ctx := mocks.NewMockContext(user)
+
+	retryOpts := retry.DefaultOpts
+	retryOpts.Attempts = 3
+	retryOpts.InitialBackoff = 5 * time.Millisecond
+	retryOpts.BackoffFactor = 1.0
+
+	peer1 := getPeerWithConfigBlockPayload(t).(*mocks.MockPeer)
+	peer1.ResponseDelay = 10 * time.Millisecond
+	peer1.FailFirstN = 2
+	peer1.FailureErr = status.New(status.EndorserServerStatus, int32(common.Status_SERVICE_UNAVAILABLE), "unavailable", nil)
+
+	var attempts []int
+	retryObserver := func(attempt int, err error) {
+		attempts = append(attempts, attempt)
+	}
+
+	channelConfig, err := New(channelID, WithPeers([]fab.Peer{peer1}), WithMinResponses(1),
+		WithRetryOpts(retryOpts), WithRetryObserver(retryObserver))
+	if err != nil {
+		t.Fatalf("Failed to create new channel client: %s", err)
+	}
+
+	reqCtx, cancel := contextImpl.NewRequest(ctx, contextImpl.WithTimeout(10*time.Second))
+	defer cancel()
+
+	_, err = channelConfig.Query(reqCtx)
+	if err != nil {
+		t.Fatalf("Expected query to succeed once the peer recovers: %s", err)
+	}
+	assert.Equal(t, []int{1, 2}, attempts, "expecting a retry for each failed call")
+	assert.Equal(t, 3, peer1.ProcessProposalCalls)
+}
+
 func getPeerWithConfigBlockPayload(t *testing.T) fab.Peer {
 
 	// create config block builder in order to create valid payload
@@ -292,20 +907,17 @@ type customMockConfig struct {
 	called   bool
 }
 
-func (c *customMockConfig) ChannelConfig(name string) (*fab.ChannelNetworkConfig, error) {
-	c.called = true
-	return c.chConfig, nil
+type rejectURLFilter struct {
+	url string
 }
 
-//customRetryHandler is wrapper around retry handler which keeps count of attempts for unit-testing
-type customRetryHandler struct {
-	handler retry.Handler
-	retries int
+func (f *rejectURLFilter) Accept(peer fab.Peer) bool {
+	return peer.URL() != f.url
 }
 
-func (c *customRetryHandler) Required(err error) bool {
-	c.retries++
-	return c.handler.Required(err)
+func (c *customMockConfig) ChannelConfig(name string) (*fab.ChannelNetworkConfig, error) {
+	c.called = true
+	return c.chConfig, nil
 }
 
 var validRootCA = `-----BEGIN CERTIFICATE-----
@@ -324,3 +936,31 @@ EDAKBggqhkjOPQQDAgNHADBEAiAHp5Rbp9Em1G/UmKn8WsCbqDfWecVbZPQj3RK4
 oG5kQQIgQAe4OOKYhJdh3f7URaKfGTf492/nmRmtK+ySKjpHSrU=
 -----END CERTIFICATE-----
 `
+
+type mockTracer struct {
+	span *mockSpan
+}
+
+func (m *mockTracer) Start(ctx reqContext.Context, name string) (reqContext.Context, tracing.Span) {
+	m.span = &mockSpan{name: name, attributes: make(map[string]interface{})}
+	return ctx, m.span
+}
+
+type mockSpan struct {
+	name       string
+	attributes map[string]interface{}
+	err        error
+	ended      bool
+}
+
+func (s *mockSpan) SetAttribute(key string, value interface{}) {
+	s.attributes[key] = value
+}
+
+func (s *mockSpan) RecordError(err error) {
+	s.err = err
+}
+
+func (s *mockSpan) End() {
+	s.ended = true
+}
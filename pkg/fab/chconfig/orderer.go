@@ -0,0 +1,153 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chconfig
+
+import (
+	"github.com/golang/protobuf/proto"
+	channelConfig "github.com/hyperledger/fabric-sdk-go/internal/github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/common"
+	ab "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/orderer"
+	"github.com/pkg/errors"
+)
+
+const (
+	// endpointsKey is the key of the orderer org value holding the org's orderer endpoints
+	endpointsKey = "Endpoints"
+
+	// etcdRaftConsensusType is the consensus type of a raft ordering service
+	etcdRaftConsensusType = "etcdraft"
+)
+
+// consensusStates maps the consensus state enum values to their names
+var consensusStates = map[int32]string{
+	0: "STATE_NORMAL",
+	1: "STATE_MAINTENANCE",
+}
+
+// loadOrdererConfig loads the consensus type and the per-org orderer endpoints from the given orderer group
+func loadOrdererConfig(configItems *ChannelCfg, ordererGroup *common.ConfigGroup) error {
+	if ordererGroup == nil {
+		return nil
+	}
+
+	if value, ok := ordererGroup.GetValues()[channelConfig.ConsensusTypeKey]; ok {
+		consensusType, err := unmarshalConsensusType(value.Value)
+		if err != nil {
+			return err
+		}
+
+		if value, ok := ordererGroup.GetValues()[channelConfig.KafkaBrokersKey]; ok {
+			kafkaBrokers := &ab.KafkaBrokers{}
+			if err := proto.Unmarshal(value.Value, kafkaBrokers); err != nil {
+				return errors.Wrap(err, "unmarshal kafka brokers from config failed")
+			}
+			consensusType.KafkaBrokers = kafkaBrokers.Brokers
+		}
+
+		configItems.consensusType = consensusType
+	}
+
+	for orgName, orgGroup := range ordererGroup.GetGroups() {
+		mspID := orgName
+		if mspValue, ok := orgGroup.GetValues()[channelConfig.MSPKey]; ok {
+			fabricMSPConfig, err := unmarshalFabricMSPConfig(mspValue.Value)
+			if err != nil {
+				return err
+			}
+			mspID = fabricMSPConfig.Name
+		}
+
+		endpoints := []string{}
+		if value, ok := orgGroup.GetValues()[endpointsKey]; ok {
+			addresses := &common.OrdererAddresses{}
+			if err := proto.Unmarshal(value.Value, addresses); err != nil {
+				return errors.Wrap(err, "unmarshal orderer endpoints from config failed")
+			}
+			endpoints = append(endpoints, addresses.Addresses...)
+		}
+
+		configItems.ordererEndpoints[mspID] = endpoints
+	}
+
+	return nil
+}
+
+func unmarshalConsensusType(value []byte) (*fab.ConsensusType, error) {
+	ct := &consensusTypeValue{}
+	if err := proto.Unmarshal(value, ct); err != nil {
+		return nil, errors.Wrap(err, "unmarshal consensus type from config failed")
+	}
+
+	consensusType := &fab.ConsensusType{
+		Type:     ct.Type,
+		Metadata: ct.Metadata,
+	}
+
+	if state, ok := consensusStates[ct.State]; ok {
+		consensusType.State = state
+	} else {
+		logger.Warnf("Unknown consensus state [%d] in channel config - consensus state will not be set", ct.State)
+	}
+
+	if ct.Type == etcdRaftConsensusType && len(ct.Metadata) > 0 {
+		metadata := &raftConfigMetadata{}
+		if err := proto.Unmarshal(ct.Metadata, metadata); err != nil {
+			logger.Warnf("Unable to unmarshal etcdraft metadata from channel config - consenters will not be set: %s", err)
+			return consensusType, nil
+		}
+		for _, c := range metadata.Consenters {
+			if c == nil {
+				continue
+			}
+			consensusType.Consenters = append(consensusType.Consenters, &fab.Consenter{
+				Host:          c.Host,
+				Port:          c.Port,
+				ClientTLSCert: c.ClientTLSCert,
+				ServerTLSCert: c.ServerTLSCert,
+			})
+		}
+	}
+
+	return consensusType, nil
+}
+
+// The protos below mirror the ConsensusType and etcdraft messages of newer Fabric versions, which
+// add the metadata and state fields to the ConsensusType config value. They are decoded using the
+// wire format so that channel configs created by newer orderers can be read.
+
+// consensusTypeValue mirrors orderer.ConsensusType
+type consensusTypeValue struct {
+	Type     string `protobuf:"bytes,1,opt,name=type" json:"type,omitempty"`
+	Metadata []byte `protobuf:"bytes,2,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	State    int32  `protobuf:"varint,3,opt,name=state" json:"state,omitempty"`
+}
+
+func (m *consensusTypeValue) Reset()         { *m = consensusTypeValue{} }
+func (m *consensusTypeValue) String() string { return proto.CompactTextString(m) }
+func (*consensusTypeValue) ProtoMessage()    {}
+
+// raftConfigMetadata mirrors etcdraft.ConfigMetadata (the options are not decoded)
+type raftConfigMetadata struct {
+	Consenters []*raftConsenter `protobuf:"bytes,1,rep,name=consenters" json:"consenters,omitempty"`
+}
+
+func (m *raftConfigMetadata) Reset()         { *m = raftConfigMetadata{} }
+func (m *raftConfigMetadata) String() string { return proto.CompactTextString(m) }
+func (*raftConfigMetadata) ProtoMessage()    {}
+
+// raftConsenter mirrors etcdraft.Consenter
+type raftConsenter struct {
+	Host          string `protobuf:"bytes,1,opt,name=host" json:"host,omitempty"`
+	Port          uint32 `protobuf:"varint,2,opt,name=port" json:"port,omitempty"`
+	ClientTLSCert []byte `protobuf:"bytes,3,opt,name=client_tls_cert,json=clientTlsCert,proto3" json:"client_tls_cert,omitempty"`
+	ServerTLSCert []byte `protobuf:"bytes,4,opt,name=server_tls_cert,json=serverTlsCert,proto3" json:"server_tls_cert,omitempty"`
+}
+
+func (m *raftConsenter) Reset()         { *m = raftConsenter{} }
+func (m *raftConsenter) String() string { return proto.CompactTextString(m) }
+func (*raftConsenter) ProtoMessage()    {}
@@ -0,0 +1,162 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chconfig
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	channelConfig "github.com/hyperledger/fabric-sdk-go/internal/github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/mocks"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/common"
+	ab "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/orderer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChannelConfigOrdererInfo(t *testing.T) {
+	builder := &mocks.MockConfigBlockBuilder{
+		MockConfigGroupBuilder: mocks.MockConfigGroupBuilder{
+			ModPolicy:      "Admins",
+			MSPNames:       []string{"Org1MSP"},
+			OrdererAddress: "localhost:7054",
+			RootCA:         validRootCA,
+		},
+	}
+
+	cfg, err := extractConfig(channelID, builder.Build())
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"localhost:7054"}, cfg.Orderers())
+	assert.Equal(t, map[string][]string{"OrdererMSP": {}}, cfg.OrdererEndpoints())
+
+	consensusType := cfg.ConsensusType()
+	require.NotNil(t, consensusType)
+	assert.Equal(t, "sample-Consensus-Type", consensusType.Type)
+	assert.Equal(t, "STATE_NORMAL", consensusType.State)
+	assert.Empty(t, consensusType.Consenters)
+}
+
+func TestChannelConfigConsensusType(t *testing.T) {
+	builder := &mocks.MockConfigBlockBuilder{
+		MockConfigGroupBuilder: mocks.MockConfigGroupBuilder{
+			ModPolicy:      "Admins",
+			MSPNames:       []string{"Org1MSP"},
+			OrdererAddress: "localhost:7054",
+			RootCA:         validRootCA,
+			ConsensusType:  "kafka",
+		},
+	}
+
+	cfg, err := extractConfig(channelID, builder.Build())
+	require.NoError(t, err)
+
+	consensusType := cfg.ConsensusType()
+	require.NotNil(t, consensusType)
+	assert.Equal(t, "kafka", consensusType.Type)
+}
+
+func TestLoadOrdererConfigRaft(t *testing.T) {
+	metadata, err := proto.Marshal(&raftConfigMetadata{
+		Consenters: []*raftConsenter{
+			{Host: "orderer1.example.com", Port: 7050, ClientTLSCert: []byte("client1"), ServerTLSCert: []byte("server1")},
+			{Host: "orderer2.example.com", Port: 8050, ClientTLSCert: []byte("client2"), ServerTLSCert: []byte("server2")},
+		},
+	})
+	require.NoError(t, err)
+
+	ordererGroup := &common.ConfigGroup{
+		Groups: map[string]*common.ConfigGroup{
+			"OrdererOrg": {
+				Values: map[string]*common.ConfigValue{
+					endpointsKey: {Value: marshalOrFail(t, &common.OrdererAddresses{Addresses: []string{"orderer1.example.com:7050", "orderer2.example.com:8050"}})},
+				},
+			},
+		},
+		Values: map[string]*common.ConfigValue{
+			channelConfig.ConsensusTypeKey: {Value: marshalOrFail(t, &consensusTypeValue{Type: "etcdraft", Metadata: metadata, State: 1})},
+		},
+	}
+
+	cfg := &ChannelCfg{ordererEndpoints: make(map[string][]string)}
+	require.NoError(t, loadOrdererConfig(cfg, ordererGroup))
+
+	assert.Equal(t, []string{"orderer1.example.com:7050", "orderer2.example.com:8050"}, cfg.OrdererEndpoints()["OrdererOrg"],
+		"expecting org name to be used if the org has no MSP")
+
+	consensusType := cfg.ConsensusType()
+	require.NotNil(t, consensusType)
+	assert.Equal(t, "etcdraft", consensusType.Type)
+	assert.Equal(t, "STATE_MAINTENANCE", consensusType.State)
+	assert.Equal(t, metadata, consensusType.Metadata)
+	assert.Equal(t, []*fab.Consenter{
+		{Host: "orderer1.example.com", Port: 7050, ClientTLSCert: []byte("client1"), ServerTLSCert: []byte("server1")},
+		{Host: "orderer2.example.com", Port: 8050, ClientTLSCert: []byte("client2"), ServerTLSCert: []byte("server2")},
+	}, consensusType.Consenters)
+}
+
+func TestLoadOrdererConfigKafka(t *testing.T) {
+	ordererGroup := &common.ConfigGroup{
+		Values: map[string]*common.ConfigValue{
+			channelConfig.ConsensusTypeKey: {Value: marshalOrFail(t, &ab.ConsensusType{Type: "kafka"})},
+			channelConfig.KafkaBrokersKey:  {Value: marshalOrFail(t, &ab.KafkaBrokers{Brokers: []string{"kafka0:9092", "kafka1:9092"}})},
+		},
+	}
+
+	cfg := &ChannelCfg{ordererEndpoints: make(map[string][]string)}
+	require.NoError(t, loadOrdererConfig(cfg, ordererGroup))
+
+	consensusType := cfg.ConsensusType()
+	require.NotNil(t, consensusType)
+	assert.Equal(t, "kafka", consensusType.Type)
+	assert.Equal(t, "STATE_NORMAL", consensusType.State)
+	assert.Equal(t, []string{"kafka0:9092", "kafka1:9092"}, consensusType.KafkaBrokers)
+}
+
+func TestLoadOrdererConfigInvalid(t *testing.T) {
+	cfg := &ChannelCfg{ordererEndpoints: make(map[string][]string)}
+
+	ordererGroup := &common.ConfigGroup{
+		Values: map[string]*common.ConfigValue{
+			channelConfig.ConsensusTypeKey: {Value: marshalOrFail(t, &ab.ConsensusType{Type: "kafka"})},
+		},
+	}
+	ordererGroup.Values[channelConfig.ConsensusTypeKey].Value = []byte("invalid")
+	assert.Error(t, loadOrdererConfig(cfg, ordererGroup), "expecting error for invalid consensus type")
+
+	assert.NoError(t, loadOrdererConfig(cfg, nil))
+}
+
+func TestLoadOrdererConfigUnknownValues(t *testing.T) {
+	cfg := &ChannelCfg{ordererEndpoints: make(map[string][]string)}
+
+	ordererGroup := &common.ConfigGroup{
+		Values: map[string]*common.ConfigValue{
+			channelConfig.ConsensusTypeKey: {Value: marshalOrFail(t, &consensusTypeValue{Type: "etcdraft", Metadata: []byte("invalid")})},
+		},
+	}
+	require.NoError(t, loadOrdererConfig(cfg, ordererGroup), "invalid raft metadata should not fail extraction")
+	consensusType := cfg.ConsensusType()
+	require.NotNil(t, consensusType)
+	assert.Equal(t, "etcdraft", consensusType.Type)
+	assert.Equal(t, []byte("invalid"), consensusType.Metadata, "expecting raw metadata to be kept")
+	assert.Empty(t, consensusType.Consenters)
+
+	ordererGroup.Values[channelConfig.ConsensusTypeKey].Value = marshalOrFail(t, &consensusTypeValue{Type: "solo", State: 5})
+	require.NoError(t, loadOrdererConfig(cfg, ordererGroup), "unknown consensus state should not fail extraction")
+	consensusType = cfg.ConsensusType()
+	require.NotNil(t, consensusType)
+	assert.Equal(t, "solo", consensusType.Type)
+	assert.Empty(t, consensusType.State)
+}
+
+func marshalOrFail(t *testing.T, msg proto.Message) []byte {
+	bytes, err := proto.Marshal(msg)
+	require.NoError(t, err)
+	return bytes
+}
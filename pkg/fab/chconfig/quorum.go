@@ -0,0 +1,97 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chconfig
+
+import (
+	reqContext "context"
+	"fmt"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/errors/multi"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/errors/status"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/channel"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/common"
+	"github.com/pkg/errors"
+)
+
+// configBlockResponse holds the config block (or error) returned by a single target
+type configBlockResponse struct {
+	target string
+	block  *common.Block
+	err    error
+}
+
+// queryConfigBlockWithQuorum queries each target separately and returns as soon as MinResponses
+// matching config blocks are received. The remaining in-flight requests are cancelled.
+func (c *ChannelConfig) queryConfigBlockWithQuorum(reqCtx reqContext.Context, opts *Opts, l *channel.Ledger, targets []fab.ProposalProcessor) (*common.Block, error) {
+	if len(targets) == 0 {
+		return nil, errors.New("target(s) required")
+	}
+
+	if opts.MinResponses <= 0 {
+		return nil, errors.New("minimum Responses has to be greater than zero")
+	}
+
+	ctx, cancel := reqContext.WithCancel(reqCtx)
+	defer cancel()
+
+	// The channel is buffered for all targets so that requests which complete
+	// after the quorum has been reached never block
+	responses := make(chan *configBlockResponse, len(targets))
+	verifier := &channel.TransactionProposalResponseVerifier{MinResponses: 1}
+
+	for _, target := range targets {
+		go func(target fab.ProposalProcessor) {
+			block, err := c.queryConfigBlock(ctx, opts, l, []fab.ProposalProcessor{target}, verifier)
+			responses <- &configBlockResponse{target: targetURL(target), block: block, err: err}
+		}(target)
+	}
+
+	var errs error
+	var peers []string
+	var blocks []*common.Block
+	for range targets {
+		response := <-responses
+		if response.err != nil {
+			logger.Debugf("query config block from %s failed: %s", response.target, response.err)
+			errs = multi.Append(errs, response.err)
+			continue
+		}
+
+		peers = append(peers, response.target)
+		blocks = append(blocks, response.block)
+
+		for _, group := range distinctBlockData(blocks) {
+			if len(group) >= opts.MinResponses {
+				logger.Debugf("received %d matching config blocks out of %d targets", len(group), len(targets))
+				return group[0], nil
+			}
+		}
+	}
+
+	groups := distinctBlockData(blocks)
+	if len(groups) > 1 {
+		s := status.New(status.EndorserClientStatus, status.EndorsementMismatch.ToInt32(), "payloads for config block do not match", nil)
+		return nil, &ErrConfigMismatch{Peers: peers, DistinctPayloads: len(groups), status: s}
+	}
+
+	msg := fmt.Sprintf("required minimum %d endorsments got %d", opts.MinResponses, len(blocks))
+	if errs != nil {
+		return nil, errors.WithMessage(errs, msg)
+	}
+	return nil, errors.New(msg)
+}
+
+// targetURL returns the URL of the given target if it has one
+func targetURL(target fab.ProposalProcessor) string {
+	if t, ok := target.(interface {
+		URL() string
+	}); ok {
+		return t.URL()
+	}
+	return ""
+}
@@ -0,0 +1,115 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chconfig
+
+import (
+	reqContext "context"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/errors/retry"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+	contextImpl "github.com/hyperledger/fabric-sdk-go/pkg/context"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChannelConfigWithEarlyQuorum(t *testing.T) {
+
+	ctx := setupTestContext()
+
+	payload := getConfigBlockPayload(t, "Org1MSP")
+	peer1 := &mocks.MockPeer{MockName: "Peer1", MockURL: "http://peer1.com", Payload: payload, Status: 200}
+	peer2 := &mocks.MockPeer{MockName: "Peer2", MockURL: "http://peer2.com", Payload: payload, Status: 200}
+	slowPeer := &slowMockPeer{MockPeer: mocks.MockPeer{MockName: "Peer3", MockURL: "http://peer3.com"}, cancelled: make(chan struct{})}
+
+	channelConfig, err := New(channelID, WithPeers([]fab.Peer{peer1, slowPeer, peer2}), WithMinResponses(2), WithEarlyQuorum(true))
+	if err != nil {
+		t.Fatalf("Failed to create new channel client: %s", err)
+	}
+
+	reqCtx, cancel := contextImpl.NewRequest(ctx, contextImpl.WithTimeout(10*time.Second))
+	defer cancel()
+
+	cfg, err := channelConfig.Query(reqCtx)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	assert.Equal(t, channelID, cfg.ID())
+
+	select {
+	case <-slowPeer.cancelled:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Expecting in-flight request to slow peer to be cancelled")
+	}
+}
+
+func TestChannelConfigWithEarlyQuorumMismatch(t *testing.T) {
+
+	ctx := setupTestContext()
+
+	peer1 := &mocks.MockPeer{MockName: "Peer1", MockURL: "http://peer1.com", Payload: getConfigBlockPayload(t, "Org1MSP"), Status: 200}
+	peer2 := &mocks.MockPeer{MockName: "Peer2", MockURL: "http://peer2.com", Payload: getConfigBlockPayload(t, "Org2MSP"), Status: 200}
+
+	noRetries := retry.Opts{RetryableCodes: retry.ChannelConfigRetryableCodes}
+	channelConfig, err := New(channelID, WithPeers([]fab.Peer{peer1, peer2}), WithMinResponses(2), WithEarlyQuorum(true), WithRetryOpts(noRetries))
+	if err != nil {
+		t.Fatalf("Failed to create new channel client: %s", err)
+	}
+
+	reqCtx, cancel := contextImpl.NewRequest(ctx, contextImpl.WithTimeout(10*time.Second))
+	defer cancel()
+
+	_, err = channelConfig.Query(reqCtx)
+	mismatchErr, ok := err.(*ErrConfigMismatch)
+	if !ok {
+		t.Fatalf("Supposed to fail with ErrConfigMismatch, got: %v", err)
+	}
+	assert.ElementsMatch(t, []string{"http://peer1.com", "http://peer2.com"}, mismatchErr.Peers)
+	assert.Equal(t, 2, mismatchErr.DistinctPayloads)
+
+	// not enough responses
+	peer2.Status = 500
+	_, err = channelConfig.Query(reqCtx)
+	if err == nil {
+		t.Fatalf("Should have failed since only one peer returned the config block")
+	}
+	_, ok = err.(*ErrConfigMismatch)
+	assert.False(t, ok, "not expecting a mismatch error")
+}
+
+func getConfigBlockPayload(t *testing.T, mspNames ...string) []byte {
+	builder := &mocks.MockConfigBlockBuilder{
+		MockConfigGroupBuilder: mocks.MockConfigGroupBuilder{
+			ModPolicy:      "Admins",
+			MSPNames:       mspNames,
+			OrdererAddress: "localhost:7054",
+			RootCA:         validRootCA,
+		},
+		Index:           0,
+		LastConfigIndex: 0,
+	}
+
+	payload, err := proto.Marshal(builder.Build())
+	if err != nil {
+		t.Fatalf("Failed to marshal mock block")
+	}
+	return payload
+}
+
+//slowMockPeer is a mock peer which only returns once its request is cancelled
+type slowMockPeer struct {
+	mocks.MockPeer
+	cancelled chan struct{}
+}
+
+func (p *slowMockPeer) ProcessTransactionProposal(reqCtx reqContext.Context, request fab.ProcessProposalRequest) (*fab.TransactionProposalResponse, error) {
+	<-reqCtx.Done()
+	close(p.cancelled)
+	return nil, reqCtx.Err()
+}
@@ -0,0 +1,259 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chconfig
+
+import (
+	"bytes"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/common"
+	"github.com/pkg/errors"
+)
+
+// ComputeUpdate computes the config update which transforms the current channel config into the
+// desired channel config (as 'configtxlator compute_update' does). Groups, values and policies which
+// were added, removed or modified are included in the write set with their versions incremented,
+// and the elements on which the update depends are included in the read set at their current versions.
+//
+// The channel ID of the returned config update is not set since it isn't part of the config; the
+// caller must set it before the update is signed and submitted.
+//  Parameters:
+//  current is the current channel config (e.g. from the latest config block)
+//  desired is the modified copy of the current config
+//
+//  Returns:
+//  the config update
+func ComputeUpdate(current, desired *common.Config) (*common.ConfigUpdate, error) {
+	if current == nil || current.ChannelGroup == nil {
+		return nil, errors.New("no channel group included for current config")
+	}
+	if desired == nil || desired.ChannelGroup == nil {
+		return nil, errors.New("no channel group included for desired config")
+	}
+
+	readSet, writeSet, updated := computeGroupUpdate(current.ChannelGroup, desired.ChannelGroup)
+	if !updated {
+		return nil, errors.New("no differences detected between current and desired config")
+	}
+
+	return &common.ConfigUpdate{
+		ReadSet:  readSet,
+		WriteSet: writeSet,
+	}, nil
+}
+
+// computeGroupUpdate returns the read and write sets for updating the current group to the desired group,
+// and whether the group was updated at all
+func computeGroupUpdate(current, desired *common.ConfigGroup) (readSet, writeSet *common.ConfigGroup, updated bool) {
+	policies := computePoliciesUpdate(current.Policies, desired.Policies)
+	values := computeValuesUpdate(current.Values, desired.Values)
+	groups := computeGroupsUpdate(current.Groups, desired.Groups)
+
+	membersUpdated := policies.membersUpdated || values.membersUpdated || groups.membersUpdated
+	if !membersUpdated && current.ModPolicy == desired.ModPolicy {
+		if len(policies.writeSet) == 0 && len(values.writeSet) == 0 && len(groups.writeSet) == 0 {
+			// Nothing was changed in this group or below
+			return &common.ConfigGroup{Version: current.Version}, &common.ConfigGroup{Version: current.Version}, false
+		}
+
+		// Only the elements of the group were modified so the group itself keeps its version
+		readSet = &common.ConfigGroup{
+			Version:  current.Version,
+			Policies: policies.readSet,
+			Values:   values.readSet,
+			Groups:   groups.readSet,
+		}
+		writeSet = &common.ConfigGroup{
+			Version:  current.Version,
+			Policies: policies.writeSet,
+			Values:   values.writeSet,
+			Groups:   groups.writeSet,
+		}
+		return readSet, writeSet, true
+	}
+
+	// The group itself was modified (members added/removed or the mod policy changed) so the write set
+	// must contain all of the remaining members of the group, including those which were not modified
+	for name, policy := range policies.sameSet {
+		policies.readSet[name] = policy
+		policies.writeSet[name] = policy
+	}
+	for name, value := range values.sameSet {
+		values.readSet[name] = value
+		values.writeSet[name] = value
+	}
+	for name, group := range groups.sameSet {
+		groups.readSet[name] = group
+		groups.writeSet[name] = group
+	}
+
+	readSet = &common.ConfigGroup{
+		Version:  current.Version,
+		Policies: policies.readSet,
+		Values:   values.readSet,
+		Groups:   groups.readSet,
+	}
+	writeSet = &common.ConfigGroup{
+		Version:   current.Version + 1,
+		Policies:  policies.writeSet,
+		Values:    values.writeSet,
+		Groups:    groups.writeSet,
+		ModPolicy: desired.ModPolicy,
+	}
+	return readSet, writeSet, true
+}
+
+type policiesUpdate struct {
+	readSet        map[string]*common.ConfigPolicy
+	writeSet       map[string]*common.ConfigPolicy
+	sameSet        map[string]*common.ConfigPolicy
+	membersUpdated bool
+}
+
+func computePoliciesUpdate(current, desired map[string]*common.ConfigPolicy) policiesUpdate {
+	update := policiesUpdate{
+		readSet:  make(map[string]*common.ConfigPolicy),
+		writeSet: make(map[string]*common.ConfigPolicy),
+		sameSet:  make(map[string]*common.ConfigPolicy),
+	}
+
+	for name, currentPolicy := range current {
+		desiredPolicy, ok := desired[name]
+		if !ok {
+			// Policy was removed
+			update.membersUpdated = true
+			continue
+		}
+
+		if currentPolicy.ModPolicy == desiredPolicy.ModPolicy && proto.Equal(currentPolicy.Policy, desiredPolicy.Policy) {
+			update.sameSet[name] = &common.ConfigPolicy{Version: currentPolicy.Version}
+			continue
+		}
+
+		update.writeSet[name] = &common.ConfigPolicy{
+			Version:   currentPolicy.Version + 1,
+			ModPolicy: desiredPolicy.ModPolicy,
+			Policy:    desiredPolicy.Policy,
+		}
+	}
+
+	for name, desiredPolicy := range desired {
+		if _, ok := current[name]; ok {
+			continue
+		}
+
+		// Policy was added
+		update.membersUpdated = true
+		update.writeSet[name] = &common.ConfigPolicy{
+			ModPolicy: desiredPolicy.ModPolicy,
+			Policy:    desiredPolicy.Policy,
+		}
+	}
+
+	return update
+}
+
+type valuesUpdate struct {
+	readSet        map[string]*common.ConfigValue
+	writeSet       map[string]*common.ConfigValue
+	sameSet        map[string]*common.ConfigValue
+	membersUpdated bool
+}
+
+func computeValuesUpdate(current, desired map[string]*common.ConfigValue) valuesUpdate {
+	update := valuesUpdate{
+		readSet:  make(map[string]*common.ConfigValue),
+		writeSet: make(map[string]*common.ConfigValue),
+		sameSet:  make(map[string]*common.ConfigValue),
+	}
+
+	for name, currentValue := range current {
+		desiredValue, ok := desired[name]
+		if !ok {
+			// Value was removed
+			update.membersUpdated = true
+			continue
+		}
+
+		if currentValue.ModPolicy == desiredValue.ModPolicy && bytes.Equal(currentValue.Value, desiredValue.Value) {
+			update.sameSet[name] = &common.ConfigValue{Version: currentValue.Version}
+			continue
+		}
+
+		update.writeSet[name] = &common.ConfigValue{
+			Version:   currentValue.Version + 1,
+			ModPolicy: desiredValue.ModPolicy,
+			Value:     desiredValue.Value,
+		}
+	}
+
+	for name, desiredValue := range desired {
+		if _, ok := current[name]; ok {
+			continue
+		}
+
+		// Value was added
+		update.membersUpdated = true
+		update.writeSet[name] = &common.ConfigValue{
+			ModPolicy: desiredValue.ModPolicy,
+			Value:     desiredValue.Value,
+		}
+	}
+
+	return update
+}
+
+type groupsUpdate struct {
+	readSet        map[string]*common.ConfigGroup
+	writeSet       map[string]*common.ConfigGroup
+	sameSet        map[string]*common.ConfigGroup
+	membersUpdated bool
+}
+
+func computeGroupsUpdate(current, desired map[string]*common.ConfigGroup) groupsUpdate {
+	update := groupsUpdate{
+		readSet:  make(map[string]*common.ConfigGroup),
+		writeSet: make(map[string]*common.ConfigGroup),
+		sameSet:  make(map[string]*common.ConfigGroup),
+	}
+
+	for name, currentGroup := range current {
+		desiredGroup, ok := desired[name]
+		if !ok {
+			// Group was removed
+			update.membersUpdated = true
+			continue
+		}
+
+		groupReadSet, groupWriteSet, updated := computeGroupUpdate(currentGroup, desiredGroup)
+		if !updated {
+			update.sameSet[name] = groupReadSet
+			continue
+		}
+
+		update.readSet[name] = groupReadSet
+		update.writeSet[name] = groupWriteSet
+	}
+
+	for name, desiredGroup := range desired {
+		if _, ok := current[name]; ok {
+			continue
+		}
+
+		// Group was added, so all of its elements are written at version 0
+		update.membersUpdated = true
+		_, groupWriteSet, _ := computeGroupUpdate(&common.ConfigGroup{}, desiredGroup)
+		update.writeSet[name] = &common.ConfigGroup{
+			ModPolicy: desiredGroup.ModPolicy,
+			Policies:  groupWriteSet.Policies,
+			Values:    groupWriteSet.Values,
+			Groups:    groupWriteSet.Groups,
+		}
+	}
+
+	return update
+}
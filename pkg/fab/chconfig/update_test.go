@@ -0,0 +1,176 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chconfig
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComputeUpdateErrors(t *testing.T) {
+	_, err := ComputeUpdate(nil, newTestConfig())
+	assert.Error(t, err, "expecting error for nil current config")
+
+	_, err = ComputeUpdate(newTestConfig(), &common.Config{})
+	assert.Error(t, err, "expecting error for desired config without channel group")
+
+	_, err = ComputeUpdate(newTestConfig(), newTestConfig())
+	assert.Error(t, err, "expecting error for identical configs")
+}
+
+func TestComputeUpdateModifiedValue(t *testing.T) {
+	current := newTestConfig()
+	desired := newTestConfig()
+	desired.ChannelGroup.Groups["Application"].Groups["Org1MSP"].Values["AnchorPeers"].Value = []byte("peer1.org1:7051")
+
+	update, err := ComputeUpdate(current, desired)
+	require.NoError(t, err)
+
+	// Only the value is written with a new version; the enclosing groups are read at their current versions
+	org := update.WriteSet.Groups["Application"].Groups["Org1MSP"]
+	assert.EqualValues(t, 2, org.Values["AnchorPeers"].Version)
+	assert.Equal(t, []byte("peer1.org1:7051"), org.Values["AnchorPeers"].Value)
+	assert.Equal(t, "Admins", org.Values["AnchorPeers"].ModPolicy)
+	assert.EqualValues(t, 1, org.Version)
+	assert.Empty(t, org.Values["MSP"], "unmodified value should not be in the write set")
+	assert.EqualValues(t, 3, update.WriteSet.Groups["Application"].Version)
+	assert.EqualValues(t, 5, update.WriteSet.Version)
+
+	readOrg := update.ReadSet.Groups["Application"].Groups["Org1MSP"]
+	assert.EqualValues(t, 1, readOrg.Version)
+	assert.Empty(t, readOrg.Values, "modified value should not be in the read set")
+	assert.Empty(t, update.ReadSet.Groups["Application"].Groups["Org2MSP"], "unmodified group should not be in the read set")
+}
+
+func TestComputeUpdateModifiedPolicy(t *testing.T) {
+	current := newTestConfig()
+	desired := newTestConfig()
+	desired.ChannelGroup.Groups["Application"].Policies["Admins"].Policy.Value = []byte("MAJORITY Admins")
+
+	update, err := ComputeUpdate(current, desired)
+	require.NoError(t, err)
+
+	policy := update.WriteSet.Groups["Application"].Policies["Admins"]
+	require.NotNil(t, policy)
+	assert.EqualValues(t, 1, policy.Version)
+	assert.Equal(t, []byte("MAJORITY Admins"), policy.Policy.Value)
+	assert.EqualValues(t, 3, update.WriteSet.Groups["Application"].Version)
+}
+
+func TestComputeUpdateAddedGroup(t *testing.T) {
+	current := newTestConfig()
+	desired := newTestConfig()
+	desired.ChannelGroup.Groups["Application"].Groups["Org3MSP"] = newTestOrgGroup(0)
+
+	update, err := ComputeUpdate(current, desired)
+	require.NoError(t, err)
+
+	// Adding a member bumps the version of the enclosing group, which must then include all of its members
+	app := update.WriteSet.Groups["Application"]
+	assert.EqualValues(t, 4, app.Version)
+	assert.Equal(t, "Admins", app.ModPolicy)
+	assert.Len(t, app.Groups, 3)
+	assert.Len(t, app.Policies, 1)
+	assert.EqualValues(t, 1, app.Groups["Org1MSP"].Version, "unmodified group should be included at its current version")
+
+	org3 := app.Groups["Org3MSP"]
+	assert.EqualValues(t, 0, org3.Version)
+	assert.Equal(t, "Admins", org3.ModPolicy)
+	assert.EqualValues(t, 0, org3.Values["MSP"].Version)
+	assert.Equal(t, []byte("msp"), org3.Values["MSP"].Value)
+
+	readApp := update.ReadSet.Groups["Application"]
+	assert.EqualValues(t, 3, readApp.Version)
+	assert.Len(t, readApp.Groups, 2)
+	assert.Empty(t, readApp.Groups["Org3MSP"], "added group should not be in the read set")
+	assert.EqualValues(t, 5, update.WriteSet.Version, "channel group version should not change")
+}
+
+func TestComputeUpdateRemovedGroup(t *testing.T) {
+	current := newTestConfig()
+	desired := newTestConfig()
+	delete(desired.ChannelGroup.Groups["Application"].Groups, "Org2MSP")
+
+	update, err := ComputeUpdate(current, desired)
+	require.NoError(t, err)
+
+	app := update.WriteSet.Groups["Application"]
+	assert.EqualValues(t, 4, app.Version)
+	assert.Len(t, app.Groups, 1)
+	assert.NotNil(t, app.Groups["Org1MSP"])
+	assert.Len(t, update.ReadSet.Groups["Application"].Groups, 1)
+}
+
+func TestComputeUpdateModPolicy(t *testing.T) {
+	current := newTestConfig()
+	desired := newTestConfig()
+	desired.ChannelGroup.Groups["Application"].Groups["Org1MSP"].ModPolicy = "Writers"
+
+	update, err := ComputeUpdate(current, desired)
+	require.NoError(t, err)
+
+	org := update.WriteSet.Groups["Application"].Groups["Org1MSP"]
+	assert.EqualValues(t, 2, org.Version)
+	assert.Equal(t, "Writers", org.ModPolicy)
+	assert.Len(t, org.Values, 2, "all members should be included when the group is modified")
+}
+
+// Ensure that the computed update doesn't share state with the configs it was computed from
+func TestComputeUpdateIsolation(t *testing.T) {
+	current := newTestConfig()
+	desired := newTestConfig()
+	desired.ChannelGroup.Values["Consortium"].Value = []byte("OtherConsortium")
+	original := proto.Clone(current)
+
+	_, err := ComputeUpdate(current, desired)
+	require.NoError(t, err)
+	assert.True(t, proto.Equal(original, current), "current config should not be modified")
+}
+
+func newTestConfig() *common.Config {
+	return &common.Config{
+		Sequence: 7,
+		ChannelGroup: &common.ConfigGroup{
+			Version: 5,
+			Groups: map[string]*common.ConfigGroup{
+				"Application": {
+					Version: 3,
+					Groups: map[string]*common.ConfigGroup{
+						"Org1MSP": newTestOrgGroup(1),
+						"Org2MSP": newTestOrgGroup(1),
+					},
+					Policies: map[string]*common.ConfigPolicy{
+						"Admins": {
+							Policy:    &common.Policy{Type: int32(common.Policy_IMPLICIT_META), Value: []byte("ANY Admins")},
+							ModPolicy: "Admins",
+						},
+					},
+					ModPolicy: "Admins",
+				},
+			},
+			Values: map[string]*common.ConfigValue{
+				"Consortium": {Version: 1, Value: []byte("SampleConsortium"), ModPolicy: "Admins"},
+			},
+			ModPolicy: "Admins",
+		},
+	}
+}
+
+func newTestOrgGroup(version uint64) *common.ConfigGroup {
+	return &common.ConfigGroup{
+		Version: version,
+		Values: map[string]*common.ConfigValue{
+			"MSP":         {Version: version, Value: []byte("msp"), ModPolicy: "Admins"},
+			"AnchorPeers": {Version: version, Value: []byte("peer0.org1:7051"), ModPolicy: "Admins"},
+		},
+		ModPolicy: "Admins",
+	}
+}
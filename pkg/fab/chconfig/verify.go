@@ -0,0 +1,196 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chconfig
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/asn1"
+	"fmt"
+	"math/big"
+
+	"github.com/golang/protobuf/proto"
+	imsp "github.com/hyperledger/fabric-sdk-go/internal/github.com/hyperledger/fabric/msp"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/core"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/common"
+	mb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/msp"
+	"github.com/pkg/errors"
+)
+
+// ErrInvalidBlockSignature is returned by Query and QueryBlock when signature verification
+// is enabled (see WithVerifySignatures) and the config block isn't signed by the ordering service
+type ErrInvalidBlockSignature struct {
+	// BlockNumber is the number of the config block which failed verification
+	BlockNumber uint64
+	cause       error
+}
+
+func (e *ErrInvalidBlockSignature) Error() string {
+	return fmt.Sprintf("signature verification of config block %d failed: %s", e.BlockNumber, e.cause)
+}
+
+// Cause returns the reason for which the verification failed
+func (e *ErrInvalidBlockSignature) Cause() error {
+	return e.cause
+}
+
+// Unwrap returns the reason for which the verification failed
+func (e *ErrInvalidBlockSignature) Unwrap() error {
+	return e.cause
+}
+
+// WithVerifySignatures encapsulates the orderer MSPs against which the config block signatures
+// are verified to Option. When set, the config block must carry at least one signature and each
+// of its signatures must be made by a valid identity of one of the given MSPs, otherwise
+// ErrInvalidBlockSignature is returned. This protects against forged config blocks returned by
+// compromised peers.
+func WithVerifySignatures(ordererMSPs []*mb.MSPConfig) Option {
+	return func(opts *Opts) error {
+		if len(ordererMSPs) == 0 {
+			return errors.New("at least one orderer MSP is required to verify config block signatures")
+		}
+		opts.SignatureMSPs = ordererMSPs
+		return nil
+	}
+}
+
+// verifyBlockSignatures verifies the signatures in the metadata of the given block against the given MSPs
+func verifyBlockSignatures(block *common.Block, mspConfigs []*mb.MSPConfig, cs core.CryptoSuite) error {
+	if block.Header == nil {
+		return errors.New("expected header in block")
+	}
+
+	// The signatures only cover the block header, so the data must match the hash in the header
+	if err := verifyBlockDataHash(block); err != nil {
+		return &ErrInvalidBlockSignature{BlockNumber: block.Header.Number, cause: err}
+	}
+
+	mspManager, err := newMSPManager(mspConfigs, cs)
+	if err != nil {
+		return err
+	}
+
+	if err := verifyMetadataSignatures(block, mspManager); err != nil {
+		return &ErrInvalidBlockSignature{BlockNumber: block.Header.Number, cause: err}
+	}
+
+	return nil
+}
+
+// verifyBlockDataHash checks that the data hash in the block header matches the block data
+func verifyBlockDataHash(block *common.Block) error {
+	if block.Data == nil {
+		return errors.New("expected data in block")
+	}
+
+	if !bytes.Equal(blockDataHash(block.Data), block.Header.DataHash) {
+		return errors.New("block data hash doesn't match the data hash in the block header")
+	}
+
+	return nil
+}
+
+// blockDataHash returns the SHA-256 hash of the concatenated block data (as common.BlockData.Hash() in Fabric)
+func blockDataHash(data *common.BlockData) []byte {
+	sum := sha256.Sum256(bytes.Join(data.Data, nil))
+	return sum[:]
+}
+
+func verifyMetadataSignatures(block *common.Block, mspManager imsp.MSPManager) error {
+	if block.Metadata == nil || len(block.Metadata.Metadata) <= int(common.BlockMetadataIndex_SIGNATURES) {
+		return errors.New("block has no signatures metadata")
+	}
+
+	metadata := &common.Metadata{}
+	if err := proto.Unmarshal(block.Metadata.Metadata[common.BlockMetadataIndex_SIGNATURES], metadata); err != nil {
+		return errors.Wrap(err, "unmarshal signatures metadata failed")
+	}
+
+	if len(metadata.Signatures) == 0 {
+		return errors.New("block is not signed")
+	}
+
+	headerBytes, err := blockHeaderBytes(block.Header)
+	if err != nil {
+		return err
+	}
+
+	for i, signature := range metadata.Signatures {
+		if err := verifyMetadataSignature(mspManager, metadata.Value, headerBytes, signature); err != nil {
+			return errors.WithMessage(err, fmt.Sprintf("signature %d is invalid", i))
+		}
+	}
+
+	return nil
+}
+
+func verifyMetadataSignature(mspManager imsp.MSPManager, value, headerBytes []byte, signature *common.MetadataSignature) error {
+	signatureHeader := &common.SignatureHeader{}
+	if err := proto.Unmarshal(signature.SignatureHeader, signatureHeader); err != nil {
+		return errors.Wrap(err, "unmarshal signature header failed")
+	}
+
+	identity, err := mspManager.DeserializeIdentity(signatureHeader.Creator)
+	if err != nil {
+		return errors.WithMessage(err, "signer is not an identity of the orderer MSPs")
+	}
+
+	if err := identity.Validate(); err != nil {
+		return errors.WithMessage(err, "signer identity is not valid")
+	}
+
+	// The signed message is the metadata value, the signature header and the block header (in that order)
+	msg := make([]byte, 0, len(value)+len(signature.SignatureHeader)+len(headerBytes))
+	msg = append(msg, value...)
+	msg = append(msg, signature.SignatureHeader...)
+	msg = append(msg, headerBytes...)
+
+	return identity.Verify(msg, signature.Signature)
+}
+
+func newMSPManager(mspConfigs []*mb.MSPConfig, cs core.CryptoSuite) (imsp.MSPManager, error) {
+	msps := []imsp.MSP{}
+	for _, config := range mspConfigs {
+		newMSP, err := imsp.NewBccspMsp(imsp.MSPv1_0, cs)
+		if err != nil {
+			return nil, errors.Wrap(err, "instantiate MSP failed")
+		}
+
+		if err := newMSP.Setup(config); err != nil {
+			return nil, errors.Wrap(err, "configure MSP failed")
+		}
+
+		msps = append(msps, newMSP)
+	}
+
+	mspManager := imsp.NewMSPManager()
+	if err := mspManager.Setup(msps); err != nil {
+		return nil, errors.WithMessage(err, "MSP manager setup failed")
+	}
+
+	return mspManager, nil
+}
+
+// asn1Header is the ASN.1 structure of the block header over which the orderer signs
+type asn1Header struct {
+	Number       *big.Int
+	PreviousHash []byte
+	DataHash     []byte
+}
+
+// blockHeaderBytes returns the ASN.1 marshaled block header (as common.BlockHeader.Bytes() in Fabric)
+func blockHeaderBytes(header *common.BlockHeader) ([]byte, error) {
+	headerBytes, err := asn1.Marshal(asn1Header{
+		Number:       new(big.Int).SetUint64(header.Number),
+		PreviousHash: header.PreviousHash,
+		DataHash:     header.DataHash,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal block header failed")
+	}
+	return headerBytes, nil
+}
@@ -0,0 +1,222 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chconfig
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+	contextImpl "github.com/hyperledger/fabric-sdk-go/pkg/context"
+	"github.com/hyperledger/fabric-sdk-go/pkg/core/cryptosuite/bccsp/sw"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/mocks"
+	mspmocks "github.com/hyperledger/fabric-sdk-go/pkg/msp/test/mockmsp"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/common"
+	mb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/msp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyBlockSignatures(t *testing.T) {
+	cs, err := sw.GetSuiteWithDefaultEphemeral()
+	require.NoError(t, err)
+
+	orderer := newTestSigner(t, "OrdererMSP")
+	other := newTestSigner(t, "OtherMSP")
+	msps := []*mb.MSPConfig{orderer.mspConfig(t)}
+
+	block := newTestConfigBlock()
+	orderer.sign(t, block)
+	assert.NoError(t, verifyBlockSignatures(block, msps, cs))
+
+	// Signed by an org which is not one of the given orderer MSPs
+	block = newTestConfigBlock()
+	other.sign(t, block)
+	assertInvalidBlockSignature(t, verifyBlockSignatures(block, msps, cs))
+
+	// Block header modified after it was signed
+	block = newTestConfigBlock()
+	orderer.sign(t, block)
+	block.Header.Number++
+	assertInvalidBlockSignature(t, verifyBlockSignatures(block, msps, cs))
+
+	// Block data modified after the header was signed
+	block = newTestConfigBlock()
+	orderer.sign(t, block)
+	block.Data.Data = append(block.Data.Data, []byte("forged"))
+	assertInvalidBlockSignature(t, verifyBlockSignatures(block, msps, cs))
+
+	// Block without signatures
+	assertInvalidBlockSignature(t, verifyBlockSignatures(newTestConfigBlock(), msps, cs))
+
+	_, err = New(channelID, WithVerifySignatures(nil))
+	assert.Error(t, err, "expecting error for missing orderer MSPs")
+}
+
+func TestChannelConfigWithVerifySignatures(t *testing.T) {
+	cs, err := sw.GetSuiteWithDefaultEphemeral()
+	require.NoError(t, err)
+
+	ctx := mocks.NewMockContext(mspmocks.NewMockSigningIdentity("test", "test"))
+	ctx.SetEndpointConfig(mocks.NewMockEndpointConfig())
+	ctx.SetCryptoSuite(cs)
+
+	orderer := newTestSigner(t, "OrdererMSP")
+	msps := []*mb.MSPConfig{orderer.mspConfig(t)}
+
+	block := newTestConfigBlock()
+	orderer.sign(t, block)
+	peer := newTestPeerWithBlock(t, block)
+
+	channelConfig, err := New(channelID, WithPeers([]fab.Peer{peer}), WithMinResponses(1), WithVerifySignatures(msps))
+	require.NoError(t, err)
+
+	reqCtx, cancel := contextImpl.NewRequest(ctx, contextImpl.WithTimeout(10*time.Second))
+	defer cancel()
+
+	cfg, err := channelConfig.Query(reqCtx)
+	require.NoError(t, err)
+	assert.Equal(t, channelID, cfg.ID())
+
+	// The peer returns an unsigned (forged) config block
+	peer = newTestPeerWithBlock(t, newTestConfigBlock())
+	channelConfig, err = New(channelID, WithPeers([]fab.Peer{peer}), WithMinResponses(1), WithVerifySignatures(msps))
+	require.NoError(t, err)
+
+	_, err = channelConfig.Query(reqCtx)
+	assertInvalidBlockSignature(t, err)
+}
+
+func assertInvalidBlockSignature(t *testing.T, err error) {
+	require.Error(t, err)
+	_, ok := err.(*ErrInvalidBlockSignature)
+	assert.True(t, ok, "expecting ErrInvalidBlockSignature but got %v", err)
+}
+
+func newTestConfigBlock() *common.Block {
+	builder := &mocks.MockConfigBlockBuilder{
+		MockConfigGroupBuilder: mocks.MockConfigGroupBuilder{
+			ModPolicy:      "Admins",
+			MSPNames:       []string{"Org1MSP"},
+			OrdererAddress: "localhost:7054",
+			RootCA:         validRootCA,
+		},
+	}
+	block := builder.Build()
+	block.Header.DataHash = blockDataHash(block.Data)
+	return block
+}
+
+func newTestPeerWithBlock(t *testing.T, block *common.Block) fab.Peer {
+	payload, err := proto.Marshal(block)
+	require.NoError(t, err)
+	return &mocks.MockPeer{MockName: "Peer1", MockURL: "http://peer1.com", MockRoles: []string{}, Payload: payload, Status: 200}
+}
+
+// testSigner is an orderer identity issued by its own root CA
+type testSigner struct {
+	mspID  string
+	rootCA []byte
+	cert   []byte
+	key    *ecdsa.PrivateKey
+}
+
+func newTestSigner(t *testing.T, mspID string) *testSigner {
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "ca." + mspID},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		SubjectKeyId:          []byte{1, 2, 3, 4},
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	require.NoError(t, err)
+	caCert, err := x509.ParseCertificate(caDER)
+	require.NoError(t, err)
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "orderer." + mspID},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}
+	certDER, err := x509.CreateCertificate(rand.Reader, template, caCert, &key.PublicKey, caKey)
+	require.NoError(t, err)
+
+	return &testSigner{
+		mspID:  mspID,
+		rootCA: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: caDER}),
+		cert:   pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER}),
+		key:    key,
+	}
+}
+
+func (s *testSigner) mspConfig(t *testing.T) *mb.MSPConfig {
+	config := &mb.FabricMSPConfig{
+		Name:      s.mspID,
+		RootCerts: [][]byte{s.rootCA},
+	}
+	return &mb.MSPConfig{Config: marshalOrFail(t, config)}
+}
+
+// sign adds the signature of the signer to the signatures metadata of the block
+func (s *testSigner) sign(t *testing.T, block *common.Block) {
+	creator := marshalOrFail(t, &mb.SerializedIdentity{Mspid: s.mspID, IdBytes: s.cert})
+	signatureHeader := marshalOrFail(t, &common.SignatureHeader{Creator: creator, Nonce: []byte("nonce")})
+
+	value := []byte("value")
+	headerBytes, err := blockHeaderBytes(block.Header)
+	require.NoError(t, err)
+
+	msg := append(append(append([]byte{}, value...), signatureHeader...), headerBytes...)
+	digest := sha256.Sum256(msg)
+
+	r, sig, err := ecdsa.Sign(rand.Reader, s.key, digest[:])
+	require.NoError(t, err)
+
+	// Signatures must be in low-S form
+	halfOrder := new(big.Int).Rsh(elliptic.P256().Params().N, 1)
+	if sig.Cmp(halfOrder) > 0 {
+		sig.Sub(elliptic.P256().Params().N, sig)
+	}
+
+	signature, err := asn1.Marshal(struct{ R, S *big.Int }{r, sig})
+	require.NoError(t, err)
+
+	metadata := marshalOrFail(t, &common.Metadata{
+		Value:      value,
+		Signatures: []*common.MetadataSignature{{SignatureHeader: signatureHeader, Signature: signature}},
+	})
+
+	if block.Metadata == nil {
+		block.Metadata = &common.BlockMetadata{}
+	}
+	for len(block.Metadata.Metadata) <= int(common.BlockMetadataIndex_SIGNATURES) {
+		block.Metadata.Metadata = append(block.Metadata.Metadata, nil)
+	}
+	block.Metadata.Metadata[common.BlockMetadataIndex_SIGNATURES] = metadata
+}
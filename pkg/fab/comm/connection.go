@@ -63,7 +63,7 @@ func NewConnection(ctx fabcontext.Client, url string, opts ...options.Opt) (*GRP
 		return nil, errors.New("unable to get comm manager")
 	}
 
-	grpcconn, err := commManager.DialContext(reqCtx, endpoint.ToAddress(url), dialOpts...)
+	grpcconn, err := commManager.DialContext(reqCtx, DialTarget(endpoint.ToAddress(url), params.dnsResolution), dialOpts...)
 	if err != nil {
 		return nil, errors.Wrapf(err, "could not connect to %s", url)
 	}
@@ -142,5 +142,19 @@ func newDialOpts(config fab.EndpointConfig, url string, params *params) ([]grpc.
 	dialOpts = append(dialOpts, grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(maxCallRecvMsgSize),
 		grpc.MaxCallSendMsgSize(maxCallSendMsgSize)))
 
+	dialOpts = append(dialOpts, MetadataDialOptions(endpoint.ToAddress(url))...)
+
+	proxyOpts, err := ProxyDialOptions(params.proxyURL)
+	if err != nil {
+		return nil, err
+	}
+	dialOpts = append(dialOpts, proxyOpts...)
+
+	balancerOpts, err := BalancerDialOptions(params.balancerPolicy)
+	if err != nil {
+		return nil, err
+	}
+	dialOpts = append(dialOpts, balancerOpts...)
+
 	return dialOpts, nil
 }
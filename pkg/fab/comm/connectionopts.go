@@ -24,6 +24,9 @@ type params struct {
 	failFast        bool
 	insecure        bool
 	connectTimeout  time.Duration
+	proxyURL        string
+	dnsResolution   bool
+	balancerPolicy  string
 }
 
 func defaultParams() *params {
@@ -88,6 +91,36 @@ func WithInsecure() options.Opt {
 	}
 }
 
+// WithProxy routes the connection through the SOCKS5 (socks5://host:port) or HTTP CONNECT
+// (http://host:port) proxy with the given URL (see ProxyDialOptions)
+func WithProxy(proxyURL string) options.Opt {
+	return func(p options.Params) {
+		if setter, ok := p.(proxySetter); ok {
+			setter.SetProxy(proxyURL)
+		}
+	}
+}
+
+// WithDNSResolution resolves the host of the connection URL with gRPC's DNS resolver, so
+// that the connection is balanced over all of its addresses (see DialTarget)
+func WithDNSResolution() options.Opt {
+	return func(p options.Params) {
+		if setter, ok := p.(dnsResolutionSetter); ok {
+			setter.SetDNSResolution(true)
+		}
+	}
+}
+
+// WithBalancerPolicy sets the load-balancing policy (PickFirstBalancer or RoundRobinBalancer)
+// used when the connection URL resolves to multiple addresses (see WithDNSResolution)
+func WithBalancerPolicy(policy string) options.Opt {
+	return func(p options.Params) {
+		if setter, ok := p.(balancerPolicySetter); ok {
+			setter.SetBalancerPolicy(policy)
+		}
+	}
+}
+
 func (p *params) SetHostOverride(value string) {
 	logger.Debugf("HostOverride: %s", value)
 	p.hostOverride = value
@@ -122,6 +155,21 @@ func (p *params) SetInsecure(value bool) {
 	p.insecure = value
 }
 
+func (p *params) SetProxy(value string) {
+	logger.Debugf("Proxy: %s", proxyHost(value))
+	p.proxyURL = value
+}
+
+func (p *params) SetDNSResolution(value bool) {
+	logger.Debugf("DNSResolution: %t", value)
+	p.dnsResolution = value
+}
+
+func (p *params) SetBalancerPolicy(value string) {
+	logger.Debugf("BalancerPolicy: %s", value)
+	p.balancerPolicy = value
+}
+
 type hostOverrideSetter interface {
 	SetHostOverride(value string)
 }
@@ -146,6 +194,18 @@ type connectTimeoutSetter interface {
 	SetConnectTimeout(value time.Duration)
 }
 
+type proxySetter interface {
+	SetProxy(value string)
+}
+
+type dnsResolutionSetter interface {
+	SetDNSResolution(value bool)
+}
+
+type balancerPolicySetter interface {
+	SetBalancerPolicy(value string)
+}
+
 // OptsFromPeerConfig returns a set of connection options from the given peer config
 func OptsFromPeerConfig(peerCfg *fab.PeerConfig) ([]options.Opt, error) {
 	certificate, err := peerCfg.TLSCACerts.TLSCert()
@@ -166,6 +226,15 @@ func OptsFromPeerConfig(peerCfg *fab.PeerConfig) ([]options.Opt, error) {
 	if isInsecureAllowed(peerCfg) {
 		opts = append(opts, WithInsecure())
 	}
+	if proxyURL := getProxyURL(peerCfg); proxyURL != "" {
+		opts = append(opts, WithProxy(proxyURL))
+	}
+	if isDNSResolutionEnabled(peerCfg) {
+		opts = append(opts, WithDNSResolution())
+	}
+	if policy := getBalancerPolicy(peerCfg); policy != "" {
+		opts = append(opts, WithBalancerPolicy(policy))
+	}
 
 	return opts, nil
 }
@@ -177,6 +246,13 @@ func getServerNameOverride(peerCfg *fab.PeerConfig) string {
 	return ""
 }
 
+func getProxyURL(peerCfg *fab.PeerConfig) string {
+	if str, ok := peerCfg.GRPCOptions["proxy-url"].(string); ok {
+		return str
+	}
+	return ""
+}
+
 func getFailFast(peerCfg *fab.PeerConfig) bool {
 	if ff, ok := peerCfg.GRPCOptions["fail-fast"].(bool); ok {
 		return cast.ToBool(ff)
@@ -198,6 +274,20 @@ func getKeepAliveOptions(peerCfg *fab.PeerConfig) keepalive.ClientParameters {
 	return kap
 }
 
+func getBalancerPolicy(peerCfg *fab.PeerConfig) string {
+	if str, ok := peerCfg.GRPCOptions["balancer-policy"].(string); ok {
+		return str
+	}
+	return ""
+}
+
+func isDNSResolutionEnabled(peerCfg *fab.PeerConfig) bool {
+	if dns, ok := peerCfg.GRPCOptions["dns-resolution"]; ok {
+		return cast.ToBool(dns)
+	}
+	return false
+}
+
 func isInsecureAllowed(peerCfg *fab.PeerConfig) bool {
 	allowInsecure, ok := peerCfg.GRPCOptions["allow-insecure"].(bool)
 	if ok {
@@ -11,6 +11,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/metrics"
 	"github.com/pkg/errors"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/connectivity"
@@ -20,6 +21,11 @@ const (
 	connShutdownTimeout = 50 * time.Millisecond
 )
 
+var (
+	errConnectorClosed = errors.New("caching connector is closed")
+	errConnEvicted     = errors.New("connection was evicted")
+)
+
 // CachingConnector provides the ability to cache GRPC connections.
 // It provides a GRPC compatible Context Dialer interface via the "DialContext" method.
 // Connections provided by this component are monitored for becoming idle or entering shutdown state.
@@ -28,6 +34,10 @@ const (
 // The Close method will flush all remaining open connections. This component should be considered
 // unusable after calling Close.
 //
+// When the TLS client certificate is rotated, cached connections keep the certificate with which they
+// were established; new connections (including connections re-established after being evicted) use the
+// rotated certificate since it is fetched on each TLS handshake.
+//
 // This component has been designed to be safe for concurrency.
 type CachingConnector struct {
 	conns         sync.Map
@@ -97,25 +107,40 @@ func (cc *CachingConnector) Close() {
 	close(cc.janitorClosed)
 	close(cc.janitorDone)
 	cc.janitorDone = nil
+
+	// The cached connections were closed by the janitor
+	metrics.Get().AddActiveConnections(-len(cc.index))
+	cc.index = make(map[*grpc.ClientConn]*cachedConn)
 }
 
 // DialContext is a wrapper for grpc.DialContext where connections are cached.
 func (cc *CachingConnector) DialContext(ctx context.Context, target string, opts ...grpc.DialOption) (*grpc.ClientConn, error) {
 	logger.Debugf("DialContext: %s", target)
 
-	c, ok := cc.loadConn(target)
-	if !ok {
-		createdConn, err := cc.createConn(ctx, target, opts...)
-		if err != nil {
-			return nil, errors.WithMessage(err, "connection creation failed")
+	for {
+		c, ok := cc.loadConn(target)
+		if !ok {
+			createdConn, err := cc.createConn(ctx, target, opts...)
+			if err != nil {
+				return nil, errors.WithMessage(err, "connection creation failed")
+			}
+			c = createdConn
 		}
-		c = createdConn
-	}
 
-	if err := cc.openConn(ctx, c); err != nil {
-		return nil, errors.Errorf("dialing connection timed out [%s]", target)
+		err := cc.openConn(ctx, c)
+		if err == errConnEvicted {
+			// The connection was evicted from the cache while it was being opened
+			logger.Debugf("connection was evicted while opening - retrying [%s]", target)
+			continue
+		}
+		if err == errConnectorClosed {
+			return nil, err
+		}
+		if err != nil {
+			return nil, errors.Errorf("dialing connection timed out [%s]", target)
+		}
+		return c.conn, nil
 	}
-	return c.conn, nil
 }
 
 // ReleaseConn notifies the cache that the connection is no longer in use.
@@ -172,7 +197,7 @@ func (cc *CachingConnector) createConn(ctx context.Context, target string, opts
 	defer cc.lock.Unlock()
 
 	if cc.janitorDone == nil {
-		return nil, errors.New("caching connector is closed")
+		return nil, errConnectorClosed
 	}
 
 	cconn, ok := cc.loadConn(target)
@@ -193,6 +218,7 @@ func (cc *CachingConnector) createConn(ctx context.Context, target string, opts
 	}
 	cc.conns.Store(target, cconn)
 	cc.index[conn] = cconn
+	metrics.Get().AddActiveConnections(1)
 
 	return cconn, nil
 }
@@ -206,6 +232,14 @@ func (cc *CachingConnector) openConn(ctx context.Context, c *cachedConn) error {
 
 	cc.lock.Lock()
 	defer cc.lock.Unlock()
+
+	if cc.janitorDone == nil {
+		return errConnectorClosed
+	}
+	if _, ok := cc.index[c.conn]; !ok {
+		return errConnEvicted
+	}
+
 	c.open++
 	c.lastOpen = time.Now()
 	cc.updateJanitor(c)
@@ -238,7 +272,7 @@ func (cc *CachingConnector) shutdownConn(cconn *cachedConn) {
 
 	logger.Debugf("connection was shutdown [%s]", cconn.target)
 	cc.conns.Delete(cconn.target)
-	delete(cc.index, cconn.conn)
+	cc.deleteIndex(cconn.conn)
 
 	cconn.open = 0
 	cconn.lastClose = time.Time{}
@@ -250,12 +284,19 @@ func (cc *CachingConnector) removeConn(target string) {
 	cc.lock.Lock()
 	defer cc.lock.Unlock()
 
-	logger.Debugf("removing connection [%s]", target)
 	connRaw, ok := cc.conns.Load(target)
 	if ok {
 		c, ok := connRaw.(*cachedConn)
 		if ok {
-			delete(cc.index, c.conn)
+			// The janitor decides on a copy of the connection state which may be stale.
+			// Don't remove the connection if it has been used since then.
+			if c.conn.GetState() != connectivity.Shutdown && (c.open > 0 || time.Since(c.lastClose) < cc.idleTime) {
+				logger.Debugf("connection is in use - not removing [%s]", target)
+				return
+			}
+
+			logger.Debugf("removing connection [%s]", target)
+			cc.deleteIndex(c.conn)
 			cc.conns.Delete(target)
 			if err := c.conn.Close(); err != nil {
 				logger.Debugf("unable to close connection [%s]", err)
@@ -264,6 +305,14 @@ func (cc *CachingConnector) removeConn(target string) {
 	}
 }
 
+// deleteIndex removes the given connection from the index. The caller must hold the lock.
+func (cc *CachingConnector) deleteIndex(conn *grpc.ClientConn) {
+	if _, ok := cc.index[conn]; ok {
+		delete(cc.index, conn)
+		metrics.Get().AddActiveConnections(-1)
+	}
+}
+
 func (cc *CachingConnector) updateJanitor(c *cachedConn) {
 	select {
 	case <-cc.janitorClosed:
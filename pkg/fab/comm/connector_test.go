@@ -10,10 +10,12 @@ import (
 	"context"
 	"math/rand"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 	"unsafe"
 
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/metrics"
 	pb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/peer"
 	"github.com/stretchr/testify/assert"
 	"google.golang.org/grpc"
@@ -59,6 +61,49 @@ func TestConnectorHappyPath(t *testing.T) {
 	assert.NotEqual(t, unsafe.Pointer(conn1), unsafe.Pointer(conn3), "connections should not match")
 }
 
+func TestConnectorActiveConnectionsMetric(t *testing.T) {
+	collector := &connCollector{}
+	metrics.SetCollector(collector)
+	defer metrics.SetCollector(nil)
+
+	connector := NewCachingConnector(shortSweepTime, shortIdleTime)
+
+	ctx, cancel := context.WithTimeout(context.Background(), normalTimeout)
+	conn1, err := connector.DialContext(ctx, endorserAddr[0], grpc.WithInsecure())
+	cancel()
+	assert.Nil(t, err, "DialContext should have succeeded")
+
+	ctx, cancel = context.WithTimeout(context.Background(), normalTimeout)
+	conn2, err := connector.DialContext(ctx, endorserAddr[1], grpc.WithInsecure())
+	cancel()
+	assert.Nil(t, err, "DialContext should have succeeded")
+	assert.Equal(t, int64(2), collector.Active(), "expecting two active connections")
+
+	// The idle connection should be swept
+	connector.ReleaseConn(conn1)
+	time.Sleep(shortIdleTime * 3)
+	assert.Equal(t, int64(1), collector.Active(), "expecting one active connection")
+
+	connector.ReleaseConn(conn2)
+	connector.Close()
+	assert.Equal(t, int64(0), collector.Active(), "expecting no active connections after close")
+}
+
+type connCollector struct {
+	active int64
+}
+
+func (c *connCollector) ObserveProposalLatency(target string, latency time.Duration, err error) {}
+func (c *connCollector) IncRetries(err error)                                                   {}
+
+func (c *connCollector) AddActiveConnections(delta int) {
+	atomic.AddInt64(&c.active, int64(delta))
+}
+
+func (c *connCollector) Active() int64 {
+	return atomic.LoadInt64(&c.active)
+}
+
 func TestConnectorDoubleClose(t *testing.T) {
 	connector := NewCachingConnector(normalSweepTime, normalIdleTime)
 	defer connector.Close()
@@ -176,6 +221,64 @@ func TestConnectorShouldSweep(t *testing.T) {
 	assert.NotEqual(t, unsafe.Pointer(conn1), unsafe.Pointer(conn4), "connections should be different due to disconnect")
 }
 
+func TestConnectorInFlightNotSwept(t *testing.T) {
+	connector := NewCachingConnector(shortSweepTime, shortIdleTime)
+	defer connector.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), normalTimeout)
+	conn1, err := connector.DialContext(ctx, endorserAddr[0], grpc.WithInsecure())
+	cancel()
+	assert.Nil(t, err, "DialContext should have succeeded")
+
+	time.Sleep(shortIdleTime * 3)
+	assert.NotEqual(t, connectivity.Shutdown, conn1.GetState(), "connection in use should not be shutdown")
+
+	// A stale removal request from the janitor must not close a connection which is in use
+	connector.removeConn(endorserAddr[0])
+	assert.NotEqual(t, connectivity.Shutdown, conn1.GetState(), "connection in use should not be removed")
+
+	// Releasing the connection restarts the idle timer
+	connector.ReleaseConn(conn1)
+	connector.removeConn(endorserAddr[0])
+	assert.NotEqual(t, connectivity.Shutdown, conn1.GetState(), "recently used connection should not be removed")
+
+	ctx, cancel = context.WithTimeout(context.Background(), normalTimeout)
+	conn2, err := connector.DialContext(ctx, endorserAddr[0], grpc.WithInsecure())
+	cancel()
+	assert.Nil(t, err, "DialContext should have succeeded")
+	assert.Equal(t, unsafe.Pointer(conn1), unsafe.Pointer(conn2), "connections should match")
+	connector.ReleaseConn(conn2)
+}
+
+func TestConnectorDialDuringClose(t *testing.T) {
+	const goroutines = 10
+
+	connector := NewCachingConnector(shortSweepTime, shortIdleTime)
+
+	wg := sync.WaitGroup{}
+	wg.Add(goroutines)
+	for i := 0; i < goroutines; i++ {
+		go func(addr string) {
+			defer wg.Done()
+			for j := 0; j < 10; j++ {
+				ctx, cancel := context.WithTimeout(context.Background(), normalTimeout)
+				conn, err := connector.DialContext(ctx, addr, grpc.WithInsecure())
+				cancel()
+				if err != nil {
+					return
+				}
+				connector.ReleaseConn(conn)
+			}
+		}(endorserAddr[i%2])
+	}
+
+	connector.Close()
+	wg.Wait()
+
+	_, err := connector.DialContext(context.Background(), endorserAddr[0], grpc.WithInsecure())
+	assert.Error(t, err, "expecting error when dialing after connector is closed")
+}
+
 func TestConnectorConcurrent(t *testing.T) {
 	const goroutines = 50
 
@@ -0,0 +1,85 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package comm
+
+import (
+	reqContext "context"
+	"sync"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// MetadataMutator is invoked before each outgoing gRPC call (endorsement proposals, deliver and
+// broadcast) with the outgoing metadata of the call, which it may modify in place, for example to
+// add authorization tokens or routing hints for a gateway or proxy in front of the Fabric nodes.
+// The target is the address of the peer or orderer and the method is the full gRPC method name.
+// If an error is returned then the call is not made.
+type MetadataMutator func(ctx reqContext.Context, target, method string, md metadata.MD) error
+
+var (
+	metadataMutator MetadataMutator
+	mutatorLock     sync.RWMutex
+)
+
+// SetMetadataMutator sets the mutator which is invoked before each outgoing gRPC call made by the SDK.
+// The mutator applies to existing (e.g. pooled) connections as well as new ones. A nil mutator
+// removes the mutator.
+func SetMetadataMutator(mutator MetadataMutator) {
+	mutatorLock.Lock()
+	defer mutatorLock.Unlock()
+	metadataMutator = mutator
+}
+
+func getMetadataMutator() MetadataMutator {
+	mutatorLock.RLock()
+	defer mutatorLock.RUnlock()
+	return metadataMutator
+}
+
+// MetadataDialOptions returns the dial options which invoke the metadata mutator (see SetMetadataMutator)
+// on the calls made on a connection to the given target.
+func MetadataDialOptions(target string) []grpc.DialOption {
+	return []grpc.DialOption{
+		grpc.WithUnaryInterceptor(func(ctx reqContext.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+			ctx, err := mutateMetadata(ctx, target, method)
+			if err != nil {
+				return err
+			}
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}),
+		grpc.WithStreamInterceptor(func(ctx reqContext.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+			ctx, err := mutateMetadata(ctx, target, method)
+			if err != nil {
+				return nil, err
+			}
+			return streamer(ctx, desc, cc, method, opts...)
+		}),
+	}
+}
+
+// mutateMetadata returns a copy of the context with the outgoing metadata modified by the mutator
+func mutateMetadata(ctx reqContext.Context, target, method string) (reqContext.Context, error) {
+	mutator := getMetadataMutator()
+	if mutator == nil {
+		return ctx, nil
+	}
+
+	md, ok := metadata.FromOutgoingContext(ctx)
+	if ok {
+		md = md.Copy()
+	} else {
+		md = metadata.MD{}
+	}
+
+	if err := mutator(ctx, target, method, md); err != nil {
+		return nil, errors.WithMessage(err, "metadata mutator failed")
+	}
+
+	return metadata.NewOutgoingContext(ctx, md), nil
+}
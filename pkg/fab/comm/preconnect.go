@@ -0,0 +1,92 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package comm
+
+import (
+	reqContext "context"
+	"sort"
+	"sync"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/errors/multi"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+	"github.com/pkg/errors"
+)
+
+// Endpoint is a peer or orderer to which a connection may be established up front.
+// Peers and orderers created by the SDK implement this interface.
+type Endpoint interface {
+	fab.HealthChecker
+	URL() string
+}
+
+// PreConnectResults contains the outcome of PreConnect for each endpoint, keyed by endpoint URL.
+// A nil error means that a connection to the endpoint was established.
+type PreConnectResults map[string]error
+
+// Connected returns the (sorted) URLs of the endpoints to which a connection was established
+func (r PreConnectResults) Connected() []string {
+	var urls []string
+	for url, err := range r {
+		if err == nil {
+			urls = append(urls, url)
+		}
+	}
+	sort.Strings(urls)
+	return urls
+}
+
+// Err returns an error containing the failure of each endpoint to which a connection
+// could not be established, or nil if all of the connections were established
+func (r PreConnectResults) Err() error {
+	var urls []string
+	for url, err := range r {
+		if err != nil {
+			urls = append(urls, url)
+		}
+	}
+	sort.Strings(urls)
+
+	var errs error
+	for _, url := range urls {
+		errs = multi.Append(errs, errors.WithMessage(r[url], "failed to connect to "+url))
+	}
+	return errs
+}
+
+// PreConnect establishes connections to the given endpoints up front (in parallel) so that the
+// first request to each endpoint doesn't incur the cost of dialing. The given context should be
+// a request context (see context.NewRequest) so that the connections are established through
+// the SDK's connection cache, where they remain until they have been idle for the configured
+// idle timeout. A failure to connect to one endpoint doesn't prevent connections to the others;
+// the outcome for each endpoint is reported in the results.
+func PreConnect(ctx reqContext.Context, endpoints ...Endpoint) PreConnectResults {
+	results := make(PreConnectResults)
+
+	var mutex sync.Mutex
+	var wg sync.WaitGroup
+
+	for _, endpoint := range endpoints {
+		wg.Add(1)
+		go func(endpoint Endpoint) {
+			defer wg.Done()
+
+			err := endpoint.Health(ctx)
+			if err != nil {
+				logger.Debugf("Pre-connect to [%s] failed: %s", endpoint.URL(), err)
+			} else {
+				logger.Debugf("Pre-connected to [%s]", endpoint.URL())
+			}
+
+			mutex.Lock()
+			defer mutex.Unlock()
+			results[endpoint.URL()] = err
+		}(endpoint)
+	}
+	wg.Wait()
+
+	return results
+}
@@ -0,0 +1,78 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package comm
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"unsafe"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+)
+
+func TestPreConnect(t *testing.T) {
+	connector := NewCachingConnector(normalSweepTime, normalIdleTime)
+	defer connector.Close()
+
+	endpoints := []Endpoint{
+		&mockEndpoint{url: endorserAddr[1], connector: connector},
+		&mockEndpoint{url: "unreachable:1", err: errors.New("connection refused")},
+		&mockEndpoint{url: endorserAddr[0], connector: connector},
+	}
+
+	results := PreConnect(context.Background(), endpoints...)
+	assert.Len(t, results, 3)
+	connected := []string{endorserAddr[0], endorserAddr[1]}
+	sort.Strings(connected)
+	assert.Equal(t, connected, results.Connected())
+
+	err := results.Err()
+	assert.Error(t, err, "expecting error for unreachable endpoint")
+	assert.Contains(t, err.Error(), "failed to connect to unreachable:1")
+	assert.EqualError(t, results["unreachable:1"], "connection refused")
+
+	// The pre-connected connection should be served from the cache
+	ctx, cancel := context.WithTimeout(context.Background(), normalTimeout)
+	conn, err := connector.DialContext(ctx, endorserAddr[0], grpc.WithInsecure())
+	cancel()
+	assert.Nil(t, err, "DialContext should have succeeded")
+	assert.Equal(t, unsafe.Pointer(endpoints[2].(*mockEndpoint).conn), unsafe.Pointer(conn), "connections should match")
+	connector.ReleaseConn(conn)
+
+	assert.Nil(t, PreConnect(context.Background(), endpoints[0]).Err())
+}
+
+type mockEndpoint struct {
+	url       string
+	connector *CachingConnector
+	conn      *grpc.ClientConn
+	err       error
+}
+
+func (e *mockEndpoint) URL() string {
+	return e.url
+}
+
+func (e *mockEndpoint) Health(ctx context.Context) error {
+	if e.err != nil {
+		return e.err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, normalTimeout)
+	defer cancel()
+
+	conn, err := e.connector.DialContext(ctx, e.url, grpc.WithInsecure())
+	if err != nil {
+		return err
+	}
+	e.conn = conn
+	e.connector.ReleaseConn(conn)
+	return nil
+}
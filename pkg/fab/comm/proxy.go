@@ -0,0 +1,282 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package comm
+
+import (
+	"bufio"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+)
+
+const (
+	socks5Version         = 0x05
+	socks5NoAuth          = 0x00
+	socks5UserPassAuth    = 0x02
+	socks5NoAcceptable    = 0xff
+	socks5UserPassVersion = 0x01
+	socks5Connect         = 0x01
+	socks5IPv4            = 0x01
+	socks5DomainName      = 0x03
+	socks5IPv6            = 0x04
+)
+
+// ProxyDialOptions returns the dial options which route the connection through the proxy with the given URL.
+// Supported proxies are SOCKS5 (socks5://[user:password@]host:port) and HTTP CONNECT
+// (http://[user:password@]host:port). The target host name is passed to the proxy, which resolves it.
+//
+// The proxy only tunnels the TCP connection: TLS is still negotiated end-to-end with the target, and the
+// server certificate is verified against the target host (or the ssl-target-name-override), never
+// against the proxy. An empty URL returns no dial options, i.e. the target is dialed directly.
+func ProxyDialOptions(proxyURL string) ([]grpc.DialOption, error) {
+	if proxyURL == "" {
+		return nil, nil
+	}
+
+	dialer, err := newProxyDialer(proxyURL)
+	if err != nil {
+		return nil, err
+	}
+	return []grpc.DialOption{grpc.WithDialer(dialer)}, nil
+}
+
+type proxyHandshake func(conn net.Conn, proxy *url.URL, target string) (net.Conn, error)
+
+func newProxyDialer(proxyURL string) (func(string, time.Duration) (net.Conn, error), error) {
+	proxy, err := url.Parse(proxyURL)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid proxy URL")
+	}
+	if proxy.Host == "" {
+		return nil, errors.New("invalid proxy URL: host is required")
+	}
+
+	var handshake proxyHandshake
+	switch proxy.Scheme {
+	case "socks5":
+		handshake = socks5Handshake
+	case "http":
+		handshake = httpConnectHandshake
+	default:
+		return nil, errors.Errorf("unsupported proxy scheme [%s]", proxy.Scheme)
+	}
+
+	return func(target string, timeout time.Duration) (net.Conn, error) {
+		logger.Debugf("Dialing [%s] through proxy [%s]", target, proxy.Host)
+
+		conn, err := net.DialTimeout("tcp", proxy.Host, timeout)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to connect to proxy [%s]", proxy.Host)
+		}
+		if timeout > 0 {
+			if err := conn.SetDeadline(time.Now().Add(timeout)); err != nil {
+				closeProxyConn(conn)
+				return nil, errors.Wrap(err, "failed to set proxy handshake deadline")
+			}
+		}
+
+		tunnel, err := handshake(conn, proxy, target)
+		if err != nil {
+			closeProxyConn(conn)
+			return nil, errors.WithMessage(err, fmt.Sprintf("proxy [%s] failed to connect to [%s]", proxy.Host, target))
+		}
+
+		if err := conn.SetDeadline(time.Time{}); err != nil {
+			closeProxyConn(conn)
+			return nil, errors.Wrap(err, "failed to clear proxy handshake deadline")
+		}
+		return tunnel, nil
+	}, nil
+}
+
+// proxyHost returns the host of the given proxy URL, i.e. without the credentials, for logging
+func proxyHost(proxyURL string) string {
+	proxy, err := url.Parse(proxyURL)
+	if err != nil {
+		return ""
+	}
+	return proxy.Host
+}
+
+func closeProxyConn(conn net.Conn) {
+	if err := conn.Close(); err != nil {
+		logger.Debugf("unable to close proxy connection [%s]", err)
+	}
+}
+
+// httpConnectHandshake establishes a tunnel to the target with an HTTP CONNECT request
+func httpConnectHandshake(conn net.Conn, proxy *url.URL, target string) (net.Conn, error) {
+	req := &http.Request{
+		Method: http.MethodConnect,
+		URL:    &url.URL{Opaque: target},
+		Host:   target,
+		Header: make(http.Header),
+	}
+	if proxy.User != nil {
+		password, _ := proxy.User.Password()
+		credentials := base64.StdEncoding.EncodeToString([]byte(proxy.User.Username() + ":" + password))
+		req.Header.Set("Proxy-Authorization", "Basic "+credentials)
+	}
+	if err := req.Write(conn); err != nil {
+		return nil, errors.Wrap(err, "failed to send CONNECT request")
+	}
+
+	reader := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(reader, req)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read CONNECT response")
+	}
+	if resp.StatusCode != http.StatusOK {
+		if err := resp.Body.Close(); err != nil {
+			logger.Debugf("unable to close CONNECT response body [%s]", err)
+		}
+		return nil, errors.Errorf("CONNECT request failed: %s", resp.Status)
+	}
+	// A successful response to CONNECT has no body: the connection is now a tunnel to the target
+
+	if reader.Buffered() > 0 {
+		// The target has already sent data which was read along with the response
+		return &bufferedConn{Conn: conn, reader: reader}, nil
+	}
+	return conn, nil
+}
+
+// bufferedConn is a connection whose first bytes are read from a buffer
+type bufferedConn struct {
+	net.Conn
+	reader *bufio.Reader
+}
+
+func (c *bufferedConn) Read(b []byte) (int, error) {
+	return c.reader.Read(b)
+}
+
+// socks5Handshake establishes a tunnel to the target as described in RFC 1928 (and RFC 1929
+// for username/password authentication)
+func socks5Handshake(conn net.Conn, proxy *url.URL, target string) (net.Conn, error) {
+	host, portStr, err := net.SplitHostPort(target)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid target address [%s]", target)
+	}
+	port, err := strconv.ParseUint(portStr, 10, 16)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid target port [%s]", portStr)
+	}
+
+	methods := []byte{socks5NoAuth}
+	if proxy.User != nil {
+		methods = append(methods, socks5UserPassAuth)
+	}
+	if _, err := conn.Write(append([]byte{socks5Version, byte(len(methods))}, methods...)); err != nil {
+		return nil, errors.Wrap(err, "failed to send SOCKS5 greeting")
+	}
+
+	reply := make([]byte, 2)
+	if _, err := io.ReadFull(conn, reply); err != nil {
+		return nil, errors.Wrap(err, "failed to read SOCKS5 greeting")
+	}
+	if reply[0] != socks5Version {
+		return nil, errors.Errorf("unexpected SOCKS version %d", reply[0])
+	}
+
+	switch reply[1] {
+	case socks5NoAuth:
+	case socks5UserPassAuth:
+		if proxy.User == nil {
+			return nil, errors.New("SOCKS5 proxy requires authentication")
+		}
+		if err := socks5Authenticate(conn, proxy.User); err != nil {
+			return nil, err
+		}
+	case socks5NoAcceptable:
+		return nil, errors.New("no acceptable SOCKS5 authentication method")
+	default:
+		return nil, errors.Errorf("unsupported SOCKS5 authentication method %d", reply[1])
+	}
+
+	req := []byte{socks5Version, socks5Connect, 0x00}
+	if ip := net.ParseIP(host); ip == nil {
+		if len(host) > 255 {
+			return nil, errors.Errorf("target host name [%s] is too long", host)
+		}
+		req = append(req, socks5DomainName, byte(len(host)))
+		req = append(req, host...)
+	} else if ip4 := ip.To4(); ip4 != nil {
+		req = append(req, socks5IPv4)
+		req = append(req, ip4...)
+	} else {
+		req = append(req, socks5IPv6)
+		req = append(req, ip.To16()...)
+	}
+	req = append(req, byte(port>>8), byte(port))
+	if _, err := conn.Write(req); err != nil {
+		return nil, errors.Wrap(err, "failed to send SOCKS5 connect request")
+	}
+
+	header := make([]byte, 4)
+	if _, err := io.ReadFull(conn, header); err != nil {
+		return nil, errors.Wrap(err, "failed to read SOCKS5 connect reply")
+	}
+	if header[1] != 0x00 {
+		return nil, errors.Errorf("SOCKS5 connect request failed with code %d", header[1])
+	}
+
+	// Discard the bound address
+	var addrLen int
+	switch header[3] {
+	case socks5IPv4:
+		addrLen = net.IPv4len
+	case socks5IPv6:
+		addrLen = net.IPv6len
+	case socks5DomainName:
+		l := make([]byte, 1)
+		if _, err := io.ReadFull(conn, l); err != nil {
+			return nil, errors.Wrap(err, "failed to read SOCKS5 bound address")
+		}
+		addrLen = int(l[0])
+	default:
+		return nil, errors.Errorf("unsupported SOCKS5 address type %d", header[3])
+	}
+	if _, err := io.ReadFull(conn, make([]byte, addrLen+2)); err != nil {
+		return nil, errors.Wrap(err, "failed to read SOCKS5 bound address")
+	}
+
+	return conn, nil
+}
+
+func socks5Authenticate(conn net.Conn, user *url.Userinfo) error {
+	username := user.Username()
+	password, _ := user.Password()
+	if len(username) > 255 || len(password) > 255 {
+		return errors.New("SOCKS5 username or password is too long")
+	}
+
+	req := []byte{socks5UserPassVersion, byte(len(username))}
+	req = append(req, username...)
+	req = append(req, byte(len(password)))
+	req = append(req, password...)
+	if _, err := conn.Write(req); err != nil {
+		return errors.Wrap(err, "failed to send SOCKS5 credentials")
+	}
+
+	reply := make([]byte, 2)
+	if _, err := io.ReadFull(conn, reply); err != nil {
+		return errors.Wrap(err, "failed to read SOCKS5 authentication reply")
+	}
+	if reply[1] != 0x00 {
+		return errors.New("SOCKS5 authentication failed")
+	}
+	return nil
+}
@@ -0,0 +1,267 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package comm
+
+import (
+	"encoding/binary"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProxyDialOptions(t *testing.T) {
+	opts, err := ProxyDialOptions("")
+	require.NoError(t, err)
+	assert.Empty(t, opts)
+
+	opts, err = ProxyDialOptions("socks5://localhost:1080")
+	require.NoError(t, err)
+	assert.Len(t, opts, 1)
+
+	_, err = ProxyDialOptions("https://localhost:8443")
+	assert.Error(t, err, "expecting error for unsupported scheme")
+
+	_, err = ProxyDialOptions("socks5://")
+	assert.Error(t, err, "expecting error for missing host")
+
+	_, err = ProxyDialOptions("http://local host:%")
+	assert.Error(t, err, "expecting error for invalid URL")
+}
+
+func TestHTTPConnectProxy(t *testing.T) {
+	target := startEchoServer(t)
+	proxy := startHTTPConnectProxy(t, "user:secret")
+
+	dialer, err := newProxyDialer("http://user:secret@" + proxy.addr)
+	require.NoError(t, err)
+	testEcho(t, dialer, target)
+	assert.Equal(t, target, proxy.lastTarget())
+
+	dialer, err = newProxyDialer("http://" + proxy.addr)
+	require.NoError(t, err)
+	_, err = dialer(target, time.Second)
+	assert.Error(t, err, "expecting error for missing proxy credentials")
+}
+
+func TestSOCKS5Proxy(t *testing.T) {
+	target := startEchoServer(t)
+	proxy := startSOCKS5Proxy(t, "")
+
+	dialer, err := newProxyDialer("socks5://" + proxy.addr)
+	require.NoError(t, err)
+	testEcho(t, dialer, target)
+	assert.Equal(t, target, proxy.lastTarget())
+
+	_, port, err := net.SplitHostPort(target)
+	require.NoError(t, err)
+	testEcho(t, dialer, net.JoinHostPort("localhost", port))
+	assert.Equal(t, net.JoinHostPort("localhost", port), proxy.lastTarget(), "expecting host name to be resolved by the proxy")
+
+	authProxy := startSOCKS5Proxy(t, "user:secret")
+
+	dialer, err = newProxyDialer("socks5://user:secret@" + authProxy.addr)
+	require.NoError(t, err)
+	testEcho(t, dialer, target)
+
+	dialer, err = newProxyDialer("socks5://user:wrong@" + authProxy.addr)
+	require.NoError(t, err)
+	_, err = dialer(target, time.Second)
+	assert.Error(t, err, "expecting error for invalid proxy credentials")
+
+	dialer, err = newProxyDialer("socks5://" + authProxy.addr)
+	require.NoError(t, err)
+	_, err = dialer(target, time.Second)
+	assert.Error(t, err, "expecting error for missing proxy credentials")
+}
+
+func testEcho(t *testing.T, dialer func(string, time.Duration) (net.Conn, error), target string) {
+	conn, err := dialer(target, time.Second)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	_, err = conn.Write([]byte("ping"))
+	require.NoError(t, err)
+
+	reply := make([]byte, 4)
+	_, err = io.ReadFull(conn, reply)
+	require.NoError(t, err)
+	assert.Equal(t, "ping", string(reply))
+}
+
+func startEchoServer(t *testing.T) string {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	go func() {
+		for {
+			conn, err := lis.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				io.Copy(conn, conn)
+			}()
+		}
+	}()
+	return lis.Addr().String()
+}
+
+type testProxy struct {
+	addr   string
+	mutex  sync.Mutex
+	target string
+}
+
+func (p *testProxy) setTarget(target string) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.target = target
+}
+
+func (p *testProxy) lastTarget() string {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return p.target
+}
+
+// relay connects to the target and copies data in both directions
+func relay(conn net.Conn, target string) {
+	targetConn, err := net.Dial("tcp", target)
+	if err != nil {
+		conn.Close()
+		return
+	}
+	go func() {
+		io.Copy(targetConn, conn)
+		targetConn.Close()
+	}()
+	io.Copy(conn, targetConn)
+	conn.Close()
+}
+
+func startHTTPConnectProxy(t *testing.T, credentials string) *testProxy {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	proxy := &testProxy{addr: lis.Addr().String()}
+	go http.Serve(lis, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodConnect {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		user, password, ok := (&http.Request{Header: http.Header{"Authorization": r.Header["Proxy-Authorization"]}}).BasicAuth()
+		if !ok || user+":"+password != credentials {
+			w.WriteHeader(http.StatusProxyAuthRequired)
+			return
+		}
+		proxy.setTarget(r.Host)
+
+		w.WriteHeader(http.StatusOK)
+		conn, _, err := w.(http.Hijacker).Hijack()
+		if err != nil {
+			return
+		}
+		relay(conn, r.Host)
+	}))
+	return proxy
+}
+
+func startSOCKS5Proxy(t *testing.T, credentials string) *testProxy {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	proxy := &testProxy{addr: lis.Addr().String()}
+	go func() {
+		for {
+			conn, err := lis.Accept()
+			if err != nil {
+				return
+			}
+			go proxy.serveSOCKS5(conn, credentials)
+		}
+	}()
+	return proxy
+}
+
+func (p *testProxy) serveSOCKS5(conn net.Conn, credentials string) {
+	greeting := make([]byte, 2)
+	if _, err := io.ReadFull(conn, greeting); err != nil {
+		conn.Close()
+		return
+	}
+	methods := make([]byte, greeting[1])
+	if _, err := io.ReadFull(conn, methods); err != nil {
+		conn.Close()
+		return
+	}
+
+	method := byte(socks5NoAuth)
+	if credentials != "" {
+		method = socks5NoAcceptable
+		for _, m := range methods {
+			if m == socks5UserPassAuth {
+				method = socks5UserPassAuth
+			}
+		}
+	}
+	conn.Write([]byte{socks5Version, method})
+	if method == socks5NoAcceptable {
+		conn.Close()
+		return
+	}
+
+	if method == socks5UserPassAuth {
+		header := make([]byte, 2)
+		io.ReadFull(conn, header)
+		user := make([]byte, header[1])
+		io.ReadFull(conn, user)
+		l := make([]byte, 1)
+		io.ReadFull(conn, l)
+		password := make([]byte, l[0])
+		io.ReadFull(conn, password)
+		if string(user)+":"+string(password) != credentials {
+			conn.Write([]byte{socks5UserPassVersion, 0x01})
+			conn.Close()
+			return
+		}
+		conn.Write([]byte{socks5UserPassVersion, 0x00})
+	}
+
+	req := make([]byte, 4)
+	if _, err := io.ReadFull(conn, req); err != nil {
+		conn.Close()
+		return
+	}
+	var host string
+	switch req[3] {
+	case socks5IPv4:
+		ip := make([]byte, net.IPv4len)
+		io.ReadFull(conn, ip)
+		host = net.IP(ip).String()
+	case socks5DomainName:
+		l := make([]byte, 1)
+		io.ReadFull(conn, l)
+		name := make([]byte, l[0])
+		io.ReadFull(conn, name)
+		host = string(name)
+	}
+	port := make([]byte, 2)
+	io.ReadFull(conn, port)
+	target := net.JoinHostPort(host, strconv.Itoa(int(binary.BigEndian.Uint16(port))))
+	p.setTarget(target)
+
+	conn.Write([]byte{socks5Version, 0x00, 0x00, socks5IPv4, 0, 0, 0, 0, 0, 0})
+	relay(conn, target)
+}
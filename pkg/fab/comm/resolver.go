@@ -0,0 +1,66 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package comm
+
+import (
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/balancer/roundrobin"
+)
+
+const dnsScheme = "dns"
+
+const (
+	// PickFirstBalancer connects to the first of the resolved addresses which is reachable, and
+	// sends all calls over that connection (the default)
+	PickFirstBalancer = "pick_first"
+
+	// RoundRobinBalancer connects to all of the resolved addresses and sends the calls to
+	// them in turn
+	RoundRobinBalancer = roundrobin.Name
+)
+
+// DialTarget returns the gRPC target to dial for the given address (host:port). If DNS resolution is
+// enabled then the host is resolved with gRPC's DNS resolver, so that a single logical endpoint (e.g. a
+// Kubernetes headless service) maps to all of the addresses of the name, which are then load balanced by
+// the balancer policy of the connection. gRPC re-resolves the name periodically and when connections to
+// the resolved addresses fail, so that the set of backends follows the DNS records. Otherwise the address
+// is dialed as is, i.e. resolved to a single address when the connection is established.
+//
+// Note that TLS is verified against the host of the address (or the ssl-target-name-override)
+// and not against the individual addresses it resolves to.
+func DialTarget(address string, dnsResolution bool) string {
+	if !dnsResolution {
+		return address
+	}
+	return dnsScheme + ":///" + address
+}
+
+// ValidateBalancerPolicy returns an error if the given load-balancing policy isn't supported. Supported
+// policies are PickFirstBalancer and RoundRobinBalancer, and the empty string for the default (pick first).
+func ValidateBalancerPolicy(policy string) error {
+	switch policy {
+	case "", PickFirstBalancer, RoundRobinBalancer:
+		return nil
+	default:
+		return errors.Errorf("unsupported balancer policy [%s]", policy)
+	}
+}
+
+// BalancerDialOptions returns the dial options which balance the calls made on a connection over the
+// addresses of the target with the given policy (see ValidateBalancerPolicy). The policy only matters
+// for targets which resolve to more than one address, i.e. when DNS resolution is enabled (see DialTarget).
+func BalancerDialOptions(policy string) ([]grpc.DialOption, error) {
+	if err := ValidateBalancerPolicy(policy); err != nil {
+		return nil, err
+	}
+	if policy == "" || policy == PickFirstBalancer {
+		// pick first is gRPC's default
+		return nil, nil
+	}
+	return []grpc.DialOption{grpc.WithBalancerName(policy)}, nil
+}
@@ -0,0 +1,36 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package comm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDialTarget(t *testing.T) {
+	assert.Equal(t, "peer0.org1.example.com:7051", DialTarget("peer0.org1.example.com:7051", false))
+	assert.Equal(t, "dns:///peer0.org1.example.com:7051", DialTarget("peer0.org1.example.com:7051", true))
+}
+
+func TestBalancerDialOptions(t *testing.T) {
+	opts, err := BalancerDialOptions("")
+	require.NoError(t, err)
+	assert.Empty(t, opts, "expecting gRPC's default balancer")
+
+	opts, err = BalancerDialOptions(PickFirstBalancer)
+	require.NoError(t, err)
+	assert.Empty(t, opts, "expecting gRPC's default balancer")
+
+	opts, err = BalancerDialOptions(RoundRobinBalancer)
+	require.NoError(t, err)
+	assert.Len(t, opts, 1)
+
+	_, err = BalancerDialOptions("random")
+	assert.Error(t, err, "expecting error for unsupported policy")
+}
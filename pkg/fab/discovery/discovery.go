@@ -9,6 +9,7 @@ package discovery
 import (
 	"context"
 	"sync"
+	"time"
 
 	discclient "github.com/hyperledger/fabric-sdk-go/internal/github.com/hyperledger/fabric/discovery/client"
 	"github.com/hyperledger/fabric-sdk-go/internal/github.com/hyperledger/fabric/protos/discovery"
@@ -82,6 +83,49 @@ func (c *Client) Send(ctx context.Context, req *discclient.Request, targets ...f
 	return responses, errs
 }
 
+// SendWithFallback sends the request to the given targets one at a time, in the given order,
+// until a successful response is received. The Target of the returned response is the peer
+// which served it. If the context has a deadline then the remaining time is divided evenly
+// between the remaining targets so that an unreachable target doesn't prevent the others
+// from being tried. If none of the targets return a successful response then the error from
+// each of the targets is returned (note that if more than one peer returned an error then the
+// returned error may be cast to multi.Errors).
+func (c *Client) SendWithFallback(ctx context.Context, req *discclient.Request, targets ...fab.PeerConfig) (Response, error) {
+	if len(targets) == 0 {
+		return nil, errors.New("no targets specified")
+	}
+
+	var errs error
+	for i, target := range targets {
+		if ctx.Err() != nil {
+			errs = multi.Append(errs, errors.Wrap(ctx.Err(), "discovery request aborted"))
+			break
+		}
+
+		resp, err := c.sendAttempt(ctx, req, target, len(targets)-i)
+		if err != nil {
+			logger.Debugf("Discovery request to [%s] failed - trying next target: %s", target.URL, err)
+			errs = multi.Append(errs, errors.WithMessage(err, "From target: "+target.URL))
+			continue
+		}
+		return &response{Response: resp, target: target.URL}, nil
+	}
+
+	return nil, errs
+}
+
+func (c *Client) sendAttempt(ctx context.Context, req *discclient.Request, target fab.PeerConfig, remainingTargets int) (discclient.Response, error) {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return c.send(ctx, req, target)
+	}
+
+	attemptCtx, cancel := context.WithTimeout(ctx, time.Until(deadline)/time.Duration(remainingTargets))
+	defer cancel()
+
+	return c.send(attemptCtx, req, target)
+}
+
 func (c *Client) send(reqCtx context.Context, req *discclient.Request, target fab.PeerConfig) (discclient.Response, error) {
 	opts, err := comm.OptsFromPeerConfig(&target)
 	if err != nil {
@@ -23,6 +23,7 @@ import (
 	"github.com/hyperledger/fabric-sdk-go/pkg/fab/mocks"
 	mspmocks "github.com/hyperledger/fabric-sdk-go/pkg/msp/test/mockmsp"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc"
 )
 
@@ -87,6 +88,54 @@ func TestDiscoveryClient(t *testing.T) {
 	t.Logf("Got error responses: %v", errs)
 }
 
+func TestDiscoveryClientWithFallback(t *testing.T) {
+	channelID := "mychannel"
+	clientCtx := newMockContext()
+
+	client, err := New(clientCtx)
+	assert.NoError(t, err)
+
+	req := discclient.NewRequest().OfChannel(channelID).AddPeersQuery()
+
+	grpcOptions := map[string]interface{}{
+		"allow-insecure": true,
+	}
+	target1 := fab.PeerConfig{
+		URL:         peer2Address,
+		GRPCOptions: grpcOptions,
+	}
+	target2 := fab.PeerConfig{
+		URL:         peerAddress,
+		GRPCOptions: grpcOptions,
+	}
+	target3 := fab.PeerConfig{
+		URL:         peer3Address,
+		GRPCOptions: grpcOptions,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	response, err := client.SendWithFallback(ctx, req, target1, target2, target3)
+	cancel()
+
+	require.NoError(t, err)
+	assert.Equal(t, peerAddress, response.Target(), "expecting the response to be served by the first available target")
+	peers, err := response.ForChannel(channelID).Peers()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(peers))
+
+	ctx, cancel = context.WithTimeout(context.Background(), 5*time.Second)
+	_, err = client.SendWithFallback(ctx, req, target1, target3)
+	cancel()
+
+	assert.Error(t, err)
+	errs, ok := err.(multi.Errors)
+	assert.True(t, ok)
+	assert.Equal(t, 2, len(errs))
+
+	_, err = client.SendWithFallback(context.Background(), req)
+	assert.Error(t, err)
+}
+
 var discoverServer *discmocks.MockDiscoveryServer
 
 func TestMain(m *testing.M) {
@@ -0,0 +1,92 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package discovery
+
+import (
+	"sort"
+
+	discclient "github.com/hyperledger/fabric-sdk-go/internal/github.com/hyperledger/fabric/discovery/client"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+	"github.com/pkg/errors"
+)
+
+// PeerGroup is a group of peers of which at least Required peers must endorse
+type PeerGroup struct {
+	Name     string
+	Peers    []fab.Peer
+	Required int
+}
+
+// EndorsementPlan (or layout) is a combination of peer groups which, if the required
+// number of peers in each group endorse, satisfies the endorsement policy of a chaincode
+type EndorsementPlan struct {
+	Groups []*PeerGroup
+}
+
+// Satisfiable returns true if each of the groups has at least the required number of peers
+func (p *EndorsementPlan) Satisfiable() bool {
+	for _, group := range p.Groups {
+		if len(group.Peers) < group.Required {
+			return false
+		}
+	}
+	return true
+}
+
+type endorsementDescriptorResponse interface {
+	EndorsementDescriptor(cc string) (*discclient.EndorsementDescriptor, error)
+}
+
+// ComputeEndorsementPlans returns all of the endorsement plans which satisfy the endorsement
+// policy of the given chaincode, as computed by the Discovery service, without sending a
+// proposal. Only peers which are currently members of the channel are included in the groups,
+// so a plan which is not Satisfiable indicates that the policy cannot currently be satisfied
+// using that combination of groups.
+func (s *EndorsementService) ComputeEndorsementPlans(ccID string) ([]*EndorsementPlan, error) {
+	filter, err := s.membershipFilter()
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := s.channelResponse(ccID)
+	if err != nil {
+		return nil, err
+	}
+
+	descResponse, ok := response.(endorsementDescriptorResponse)
+	if !ok {
+		return nil, errors.New("discovery response does not provide endorsement layouts")
+	}
+
+	desc, err := descResponse.EndorsementDescriptor(ccID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error getting endorsement layouts for chaincode [%s] from discovery response", ccID)
+	}
+
+	var plans []*EndorsementPlan
+	for _, layout := range desc.Layouts {
+		plan := &EndorsementPlan{}
+		for _, name := range sortedGroups(layout) {
+			plan.Groups = append(plan.Groups, &PeerGroup{
+				Name:     name,
+				Peers:    s.asPeers(discclient.Endorsers(desc.EndorsersByGroups[name]).Filter(filter)),
+				Required: layout[name],
+			})
+		}
+		plans = append(plans, plan)
+	}
+	return plans, nil
+}
+
+func sortedGroups(layout map[string]int) []string {
+	var names []string
+	for name := range layout {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
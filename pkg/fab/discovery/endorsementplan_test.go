@@ -0,0 +1,75 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package discovery
+
+import (
+	"testing"
+
+	discclient "github.com/hyperledger/fabric-sdk-go/internal/github.com/hyperledger/fabric/discovery/client"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComputeEndorsementPlans(t *testing.T) {
+	discClient := newMockEndorsersClient()
+	discClient.descriptor = &discclient.EndorsementDescriptor{
+		EndorsersByGroups: map[string][]*discclient.Peer{
+			"G1": {newEndorser(peer1URL), newEndorser(peer2URL)},
+			"G2": {newEndorser(peer3URL)},
+		},
+		Layouts: []map[string]int{
+			{"G1": 1, "G2": 1},
+			{"G1": 2},
+		},
+	}
+	ctx := newMockChannelContext(discClient, peer1URL, peer2URL, peer3URL)
+
+	service, err := NewEndorsementService(ctx)
+	require.NoError(t, err)
+	defer service.Close()
+
+	plans, err := service.ComputeEndorsementPlans(ccID)
+	require.NoError(t, err)
+	require.Len(t, plans, 2)
+
+	plan := plans[0]
+	require.Len(t, plan.Groups, 2)
+	assert.Equal(t, "G1", plan.Groups[0].Name)
+	assert.Equal(t, 1, plan.Groups[0].Required)
+	assert.Equal(t, []string{peer1URL, peer2URL}, urls(plan.Groups[0].Peers))
+	assert.Equal(t, "G2", plan.Groups[1].Name)
+	assert.Equal(t, 1, plan.Groups[1].Required)
+	assert.Equal(t, []string{peer3URL}, urls(plan.Groups[1].Peers))
+	assert.True(t, plan.Satisfiable())
+
+	plan = plans[1]
+	require.Len(t, plan.Groups, 1)
+	assert.Equal(t, 2, plan.Groups[0].Required)
+	assert.True(t, plan.Satisfiable())
+
+	// peer2 leaves the channel so the second plan can no longer be satisfied
+	ctx.Discovery = mocks.NewMockDiscoveryService(nil, []fab.Peer{mocks.NewMockPeer("p1", peer1URL), mocks.NewMockPeer("p3", peer3URL)})
+	plans, err = service.ComputeEndorsementPlans(ccID)
+	require.NoError(t, err)
+	require.Len(t, plans, 2)
+	assert.True(t, plans[0].Satisfiable())
+	assert.False(t, plans[1].Satisfiable())
+	assert.Equal(t, []string{peer1URL}, urls(plans[1].Groups[0].Peers))
+}
+
+func TestComputeEndorsementPlansNotFound(t *testing.T) {
+	ctx := newMockChannelContext(newMockEndorsersClient(), peer1URL)
+
+	service, err := NewEndorsementService(ctx)
+	require.NoError(t, err)
+	defer service.Close()
+
+	_, err = service.ComputeEndorsementPlans(ccID)
+	assert.Error(t, err)
+}
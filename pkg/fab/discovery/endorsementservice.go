@@ -0,0 +1,383 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package discovery
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	discclient "github.com/hyperledger/fabric-sdk-go/internal/github.com/hyperledger/fabric/discovery/client"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/logging"
+	fabcontext "github.com/hyperledger/fabric-sdk-go/pkg/common/providers/context"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+	reqContext "github.com/hyperledger/fabric-sdk-go/pkg/context"
+	"github.com/hyperledger/fabric-sdk-go/pkg/util/concurrent/lazyref"
+	"github.com/pkg/errors"
+)
+
+var logger = logging.NewLogger("fabsdk/fab")
+
+type discoveryClient interface {
+	Send(ctx context.Context, req *discclient.Request, targets ...fab.PeerConfig) ([]Response, error)
+	SendWithFallback(ctx context.Context, req *discclient.Request, targets ...fab.PeerConfig) (Response, error)
+}
+
+// clientProvider is overridden by unit tests
+var clientProvider = func(ctx fabcontext.Client) (discoveryClient, error) {
+	return New(ctx)
+}
+
+// Opt is an EndorsementService option
+type Opt func(o *options)
+
+type options struct {
+	cacheTTL        time.Duration
+	refreshInterval time.Duration
+	responseTimeout time.Duration
+	bootstrapPeers  []fab.Peer
+}
+
+// WithCacheTTL sets the time for which the endorsers of a chaincode are cached
+// before the Discovery service is queried again
+func WithCacheTTL(value time.Duration) Opt {
+	return func(o *options) {
+		o.cacheTTL = value
+	}
+}
+
+// WithRefreshInterval specifies that cached endorsers should be refreshed in the
+// background at the given interval instead of expiring. Callers are never blocked
+// on a refresh; the previous endorsers are used until the refresh completes.
+func WithRefreshInterval(value time.Duration) Opt {
+	return func(o *options) {
+		o.refreshInterval = value
+	}
+}
+
+// WithResponseTimeout sets the Discovery service response timeout
+func WithResponseTimeout(value time.Duration) Opt {
+	return func(o *options) {
+		o.responseTimeout = value
+	}
+}
+
+// WithBootstrapPeers sets the peers which are queried for endorsers instead of the peers
+// configured for the channel. The peers are tried one at a time, in the given order, until
+// one of them returns a successful response.
+func WithBootstrapPeers(peers []fab.Peer) Opt {
+	return func(o *options) {
+		o.bootstrapPeers = peers
+	}
+}
+
+// EndorsementService queries the Discovery service for the peers which are able to
+// satisfy the endorsement policy of a chaincode. Discovery responses are cached per
+// channel and chaincode so that the Discovery service is not queried on every request.
+type EndorsementService struct {
+	ctx        fabcontext.Channel
+	discClient discoveryClient
+	options    options
+	lock       sync.RWMutex
+	responses  map[cacheKey]*lazyref.Reference
+	closed     bool
+}
+
+type cacheKey struct {
+	channelID string
+	ccID      string
+}
+
+// NewEndorsementService returns a new EndorsementService for the channel of the given context.
+// Unless specified otherwise, cached endorsers expire after the configured discovery service
+// refresh interval.
+func NewEndorsementService(ctx fabcontext.Channel, opts ...Opt) (*EndorsementService, error) {
+	options := options{}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	if options.cacheTTL == 0 {
+		options.cacheTTL = ctx.EndpointConfig().Timeout(fab.DiscoveryServiceRefresh)
+	}
+	if options.responseTimeout == 0 {
+		options.responseTimeout = ctx.EndpointConfig().Timeout(fab.DiscoveryResponse)
+	}
+
+	discClient, err := clientProvider(ctx)
+	if err != nil {
+		return nil, errors.WithMessage(err, "error creating discovery client")
+	}
+
+	return &EndorsementService{
+		ctx:        ctx,
+		discClient: discClient,
+		options:    options,
+		responses:  make(map[cacheKey]*lazyref.Reference),
+	}, nil
+}
+
+// GetEndorsers returns a set of peers which together satisfy the endorsement policy of the
+// given chaincode. Endorsers which are no longer members of the channel (according to the
+// channel's discovery service) are excluded, so a layout which can no longer be satisfied
+// since it was cached causes the Discovery service to be queried again.
+func (s *EndorsementService) GetEndorsers(ccID string) ([]fab.Peer, error) {
+	filter, err := s.membershipFilter()
+	if err != nil {
+		return nil, err
+	}
+
+	endorsers, err := s.getEndorsers(ccID, filter)
+	if err != nil {
+		logger.Debugf("Unable to get endorsers for chaincode [%s] from cached response - querying Discovery service: %s", ccID, err)
+		s.Invalidate(ccID)
+		endorsers, err = s.getEndorsers(ccID, filter)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return s.asPeers(endorsers), nil
+}
+
+// Invalidate removes the cached endorsers of the given chaincodes so that the next call to
+// GetEndorsers queries the Discovery service. If no chaincodes are specified then the
+// endorsers of all chaincodes are removed. This should be called when a change in channel
+// membership is detected.
+func (s *EndorsementService) Invalidate(ccIDs ...string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if len(ccIDs) == 0 {
+		for key, ref := range s.responses {
+			ref.Close()
+			delete(s.responses, key)
+		}
+		return
+	}
+
+	for _, ccID := range ccIDs {
+		key := s.key(ccID)
+		if ref, ok := s.responses[key]; ok {
+			ref.Close()
+			delete(s.responses, key)
+		}
+	}
+}
+
+// Close stops the background refresh of all cached endorsers
+func (s *EndorsementService) Close() {
+	s.Invalidate()
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.closed = true
+}
+
+func (s *EndorsementService) getEndorsers(ccID string, filter discclient.ExclusionFilter) (discclient.Endorsers, error) {
+	response, err := s.channelResponse(ccID)
+	if err != nil {
+		return nil, err
+	}
+
+	endorsers, err := response.Endorsers(ccID, discclient.NoPriorities, filter)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error getting endorsers for chaincode [%s] from discovery response", ccID)
+	}
+	return endorsers, nil
+}
+
+// channelResponse returns the (possibly cached) discovery response for the given chaincode
+func (s *EndorsementService) channelResponse(ccID string) (discclient.ChannelResponse, error) {
+	ref, err := s.ref(ccID)
+	if err != nil {
+		return nil, err
+	}
+
+	value, err := ref.Get()
+	if err != nil {
+		return nil, err
+	}
+	return value.(discclient.ChannelResponse), nil
+}
+
+func (s *EndorsementService) ref(ccID string) (*lazyref.Reference, error) {
+	key := s.key(ccID)
+
+	s.lock.RLock()
+	ref, ok := s.responses[key]
+	closed := s.closed
+	s.lock.RUnlock()
+
+	if closed {
+		return nil, errors.New("endorsement service is closed")
+	}
+	if ok {
+		return ref, nil
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if ref, ok := s.responses[key]; ok {
+		return ref, nil
+	}
+
+	ref = lazyref.New(
+		func() (interface{}, error) {
+			return s.queryEndorsers(ccID)
+		},
+		s.expiration(),
+	)
+	s.responses[key] = ref
+	return ref, nil
+}
+
+func (s *EndorsementService) expiration() lazyref.Opt {
+	if s.options.refreshInterval > 0 {
+		return lazyref.WithRefreshInterval(lazyref.InitOnFirstAccess, s.options.refreshInterval)
+	}
+	return lazyref.WithAbsoluteExpiration(s.options.cacheTTL)
+}
+
+func (s *EndorsementService) key(ccID string) cacheKey {
+	return cacheKey{channelID: s.ctx.ChannelID(), ccID: ccID}
+}
+
+func (s *EndorsementService) queryEndorsers(ccID string) (discclient.ChannelResponse, error) {
+	channelID := s.ctx.ChannelID()
+
+	logger.Debugf("Querying Discovery service for endorsers of chaincode [%s] on channel [%s]...", ccID, channelID)
+
+	reqCtx, cancel := reqContext.NewRequest(s.ctx, reqContext.WithTimeout(s.options.responseTimeout))
+	defer cancel()
+
+	req := discclient.NewRequest().OfChannel(channelID).AddEndorsersQuery(ccID)
+
+	query := s.queryChannelPeers
+	if len(s.options.bootstrapPeers) > 0 {
+		query = s.queryBootstrapPeers
+	}
+
+	response, err := query(reqCtx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Debugf("Got endorsers of chaincode [%s] on channel [%s] from [%s]", ccID, channelID, response.Target())
+	return response.ForChannel(channelID), nil
+}
+
+func (s *EndorsementService) queryChannelPeers(reqCtx context.Context, req *discclient.Request) (Response, error) {
+	targets, err := s.getTargets()
+	if err != nil {
+		return nil, err
+	}
+
+	responses, err := s.discClient.Send(reqCtx, req, targets...)
+	if err != nil {
+		if len(responses) == 0 {
+			return nil, errors.Wrapf(err, "error calling discover service send")
+		}
+		logger.Warnf("Received %d response(s) and one or more errors from discovery client: %s", len(responses), err)
+	}
+	if len(responses) == 0 {
+		return nil, errors.New("no successful response received from any peer")
+	}
+	return responses[0], nil
+}
+
+func (s *EndorsementService) queryBootstrapPeers(reqCtx context.Context, req *discclient.Request) (Response, error) {
+	var targets []fab.PeerConfig
+	for _, peer := range s.options.bootstrapPeers {
+		peerConfig, err := s.ctx.EndpointConfig().PeerConfig(peer.URL())
+		if err != nil {
+			logger.Warnf("Error getting peer config for bootstrap peer [%s] - peer will not be queried: %s", peer.URL(), err)
+			continue
+		}
+		targets = append(targets, *peerConfig)
+	}
+	if len(targets) == 0 {
+		return nil, errors.New("no peer config found for any of the bootstrap peers")
+	}
+
+	response, err := s.discClient.SendWithFallback(reqCtx, req, targets...)
+	if err != nil {
+		return nil, errors.WithMessage(err, "no bootstrap peer returned a successful response")
+	}
+	return response, nil
+}
+
+func (s *EndorsementService) getTargets() ([]fab.PeerConfig, error) {
+	chpeers, err := s.ctx.EndpointConfig().ChannelPeers(s.ctx.ChannelID())
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get peer configs for channel [%s]", s.ctx.ChannelID())
+	}
+	if len(chpeers) == 0 {
+		return nil, errors.Errorf("no peers configured for channel [%s]", s.ctx.ChannelID())
+	}
+
+	targets := make([]fab.PeerConfig, len(chpeers))
+	for i := 0; i < len(targets); i++ {
+		targets[i] = chpeers[i].NetworkPeer.PeerConfig
+	}
+	return targets, nil
+}
+
+// membershipFilter returns a filter which excludes endorsers which are
+// not currently members of the channel
+func (s *EndorsementService) membershipFilter() (discclient.ExclusionFilter, error) {
+	peers, err := s.ctx.DiscoveryService().GetPeers()
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to get channel peers")
+	}
+
+	members := make(map[string]bool)
+	for _, peer := range peers {
+		members[peer.URL()] = true
+	}
+
+	return exclusionFunc(func(endorser discclient.Peer) bool {
+		peerConfig, err := s.ctx.EndpointConfig().PeerConfig(endpoint(&endorser))
+		if err != nil {
+			return true
+		}
+		return !members[peerConfig.URL]
+	}), nil
+}
+
+func (s *EndorsementService) asPeers(endorsers discclient.Endorsers) []fab.Peer {
+	var peers []fab.Peer
+	for _, endorser := range endorsers {
+		url := endpoint(endorser)
+
+		peerConfig, err := s.ctx.EndpointConfig().PeerConfig(url)
+		if err != nil {
+			logger.Warnf("Error getting peer config for url [%s]: %s", url, err)
+			continue
+		}
+
+		peer, err := s.ctx.InfraProvider().CreatePeerFromConfig(&fab.NetworkPeer{PeerConfig: *peerConfig, MSPID: endorser.MSPID})
+		if err != nil {
+			logger.Warnf("Unable to create peer config for [%s]: %s", url, err)
+			continue
+		}
+		peers = append(peers, NewPeerState(peer, endorser))
+	}
+	return peers
+}
+
+func endpoint(peer *discclient.Peer) string {
+	return peer.AliveMessage.GetAliveMsg().Membership.Endpoint
+}
+
+type exclusionFunc func(peer discclient.Peer) bool
+
+// Exclude returns true if the given peer is to be excluded
+func (f exclusionFunc) Exclude(peer discclient.Peer) bool {
+	return f(peer)
+}
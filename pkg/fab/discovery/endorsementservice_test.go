@@ -0,0 +1,274 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package discovery
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	discclient "github.com/hyperledger/fabric-sdk-go/internal/github.com/hyperledger/fabric/discovery/client"
+	"github.com/hyperledger/fabric-sdk-go/internal/github.com/hyperledger/fabric/protos/gossip"
+	fabcontext "github.com/hyperledger/fabric-sdk-go/pkg/common/providers/context"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/mocks"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	ccID      = "testcc"
+	channelID = "mychannel"
+	peer1URL  = "peer1.org1.com:7051"
+	peer2URL  = "peer2.org1.com:7051"
+	peer3URL  = "peer1.org2.com:7051"
+)
+
+func TestEndorsementServiceCache(t *testing.T) {
+	discClient := newMockEndorsersClient([]string{peer1URL, peer3URL}, []string{peer2URL, peer3URL})
+	ctx := newMockChannelContext(discClient, peer1URL, peer2URL, peer3URL)
+
+	service, err := NewEndorsementService(ctx, WithCacheTTL(200*time.Millisecond))
+	require.NoError(t, err)
+	defer service.Close()
+
+	peers, err := service.GetEndorsers(ccID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{peer1URL, peer3URL}, urls(peers))
+
+	_, err = service.GetEndorsers(ccID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, discClient.NumQueries(), "expecting the cached response to be used")
+
+	service.Invalidate(ccID)
+	_, err = service.GetEndorsers(ccID)
+	require.NoError(t, err)
+	assert.Equal(t, 2, discClient.NumQueries(), "expecting the Discovery service to be queried after invalidation")
+
+	time.Sleep(400 * time.Millisecond)
+	_, err = service.GetEndorsers(ccID)
+	require.NoError(t, err)
+	assert.Equal(t, 3, discClient.NumQueries(), "expecting the Discovery service to be queried after the cache expired")
+}
+
+func TestEndorsementServiceRefresh(t *testing.T) {
+	discClient := newMockEndorsersClient([]string{peer1URL})
+	ctx := newMockChannelContext(discClient, peer1URL)
+
+	service, err := NewEndorsementService(ctx, WithRefreshInterval(50*time.Millisecond))
+	require.NoError(t, err)
+	defer service.Close()
+
+	_, err = service.GetEndorsers(ccID)
+	require.NoError(t, err)
+
+	time.Sleep(300 * time.Millisecond)
+	assert.True(t, discClient.NumQueries() > 1, "expecting the cached response to be refreshed in the background")
+}
+
+func TestEndorsementServicePeerLeft(t *testing.T) {
+	discClient := newMockEndorsersClient([]string{peer1URL, peer3URL}, []string{peer2URL, peer3URL})
+	ctx := newMockChannelContext(discClient, peer1URL, peer2URL, peer3URL)
+
+	service, err := NewEndorsementService(ctx, WithCacheTTL(time.Minute))
+	require.NoError(t, err)
+	defer service.Close()
+
+	_, err = service.GetEndorsers(ccID)
+	require.NoError(t, err)
+
+	// peer1 leaves the channel - the cached layout which includes it must not be used
+	ctx.Discovery = mocks.NewMockDiscoveryService(nil, []fab.Peer{mocks.NewMockPeer("p2", peer2URL), mocks.NewMockPeer("p3", peer3URL)})
+	peers, err := service.GetEndorsers(ccID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{peer2URL, peer3URL}, urls(peers))
+
+	// peer3 leaves the channel - no layout can be satisfied so the Discovery service is queried again
+	ctx.Discovery = mocks.NewMockDiscoveryService(nil, []fab.Peer{mocks.NewMockPeer("p1", peer1URL), mocks.NewMockPeer("p2", peer2URL)})
+	_, err = service.GetEndorsers(ccID)
+	assert.Error(t, err)
+	assert.Equal(t, 2, discClient.NumQueries())
+}
+
+func TestEndorsementServiceBootstrapPeers(t *testing.T) {
+	discClient := newMockEndorsersClient([]string{peer1URL, peer3URL})
+	discClient.unavailable[peer3URL] = true
+	ctx := newMockChannelContext(discClient, peer1URL, peer2URL, peer3URL)
+
+	bootstrapPeers := []fab.Peer{mocks.NewMockPeer("p3", peer3URL), mocks.NewMockPeer("p2", peer2URL), mocks.NewMockPeer("p1", peer1URL)}
+	service, err := NewEndorsementService(ctx, WithBootstrapPeers(bootstrapPeers))
+	require.NoError(t, err)
+	defer service.Close()
+
+	_, err = service.GetEndorsers(ccID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{peer3URL, peer2URL}, discClient.Targets(), "expecting bootstrap peers to be tried in order until one succeeds")
+
+	discClient.unavailable[peer2URL] = true
+	discClient.unavailable[peer1URL] = true
+	service.Invalidate()
+	_, err = service.GetEndorsers(ccID)
+	assert.Error(t, err)
+}
+
+func TestEndorsementServiceClosed(t *testing.T) {
+	ctx := newMockChannelContext(newMockEndorsersClient([]string{peer1URL}), peer1URL)
+
+	service, err := NewEndorsementService(ctx)
+	require.NoError(t, err)
+
+	service.Close()
+	_, err = service.GetEndorsers(ccID)
+	assert.Error(t, err)
+}
+
+func newMockChannelContext(discClient *mockEndorsersClient, members ...string) *mocks.MockChannelContext {
+	clientProvider = func(ctx fabcontext.Client) (discoveryClient, error) {
+		return discClient, nil
+	}
+
+	clientCtx := newMockContext()
+	clientCtx.SetEndpointConfig(&endpointConfig{EndpointConfig: mocks.NewMockEndpointConfig()})
+
+	var peers []fab.Peer
+	for _, url := range members {
+		peers = append(peers, mocks.NewMockPeer(url, url))
+	}
+
+	ctx := mocks.NewMockChannelContext(clientCtx, channelID)
+	ctx.Discovery = mocks.NewMockDiscoveryService(nil, peers)
+	return ctx
+}
+
+func urls(peers []fab.Peer) []string {
+	var urls []string
+	for _, peer := range peers {
+		urls = append(urls, peer.URL())
+	}
+	return urls
+}
+
+// endpointConfig resolves peer configs by URL
+type endpointConfig struct {
+	fab.EndpointConfig
+}
+
+func (c *endpointConfig) PeerConfig(nameOrURL string) (*fab.PeerConfig, error) {
+	return &fab.PeerConfig{URL: nameOrURL}, nil
+}
+
+// mockEndorsersClient returns the first of the given layouts which isn't excluded
+type mockEndorsersClient struct {
+	layouts     [][]*discclient.Peer
+	descriptor  *discclient.EndorsementDescriptor
+	unavailable map[string]bool
+	lock        sync.Mutex
+	queries     int
+	targets     []string
+}
+
+func newMockEndorsersClient(layouts ...[]string) *mockEndorsersClient {
+	c := &mockEndorsersClient{unavailable: make(map[string]bool)}
+	for _, layout := range layouts {
+		var peers []*discclient.Peer
+		for _, url := range layout {
+			peers = append(peers, newEndorser(url))
+		}
+		c.layouts = append(c.layouts, peers)
+	}
+	return c
+}
+
+func (c *mockEndorsersClient) Send(ctx context.Context, req *discclient.Request, targets ...fab.PeerConfig) ([]Response, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.queries++
+	return []Response{&mockEndorsersResponse{layouts: c.layouts, descriptor: c.descriptor, target: targets[0].URL}}, nil
+}
+
+func (c *mockEndorsersClient) SendWithFallback(ctx context.Context, req *discclient.Request, targets ...fab.PeerConfig) (Response, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.queries++
+	for _, target := range targets {
+		c.targets = append(c.targets, target.URL)
+		if !c.unavailable[target.URL] {
+			return &mockEndorsersResponse{layouts: c.layouts, descriptor: c.descriptor, target: target.URL}, nil
+		}
+	}
+	return nil, errors.New("all targets are unavailable")
+}
+
+func (c *mockEndorsersClient) Targets() []string {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.targets
+}
+
+func (c *mockEndorsersClient) NumQueries() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.queries
+}
+
+type mockEndorsersResponse struct {
+	discclient.Response
+	discclient.ChannelResponse
+	layouts    [][]*discclient.Peer
+	descriptor *discclient.EndorsementDescriptor
+	target     string
+}
+
+func (r *mockEndorsersResponse) Target() string {
+	return r.target
+}
+
+func (r *mockEndorsersResponse) ForChannel(string) discclient.ChannelResponse {
+	return r
+}
+
+func (r *mockEndorsersResponse) Endorsers(cc string, ps discclient.PrioritySelector, ef discclient.ExclusionFilter) (discclient.Endorsers, error) {
+	for _, layout := range r.layouts {
+		satisfied := true
+		for _, peer := range layout {
+			if ef.Exclude(*peer) {
+				satisfied = false
+				break
+			}
+		}
+		if satisfied {
+			return layout, nil
+		}
+	}
+	return nil, errors.New("no endorsement combination can be satisfied")
+}
+
+func (r *mockEndorsersResponse) EndorsementDescriptor(cc string) (*discclient.EndorsementDescriptor, error) {
+	if r.descriptor == nil {
+		return nil, discclient.ErrNotFound
+	}
+	return r.descriptor, nil
+}
+
+func newEndorser(url string) *discclient.Peer {
+	return &discclient.Peer{
+		MSPID: "Org1MSP",
+		AliveMessage: &gossip.SignedGossipMessage{
+			GossipMessage: &gossip.GossipMessage{
+				Content: &gossip.GossipMessage_AliveMsg{
+					AliveMsg: &gossip.AliveMessage{
+						Membership: &gossip.Member{
+							Endpoint: url,
+						},
+					},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,50 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package discovery
+
+import (
+	reqContext "context"
+
+	discclient "github.com/hyperledger/fabric-sdk-go/internal/github.com/hyperledger/fabric/discovery/client"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+)
+
+// peerState adds the state reported by the Discovery service to a peer
+type peerState struct {
+	fab.Peer
+	blockHeight uint64
+}
+
+// NewPeerState returns the given peer along with the state (ledger height) reported
+// for it by the Discovery service (see fab.PeerState). If the Discovery service did
+// not report the state of the peer then the given peer is returned as is.
+func NewPeerState(peer fab.Peer, endpoint *discclient.Peer) fab.Peer {
+	if endpoint.StateInfoMessage == nil || endpoint.StateInfoMessage.GetStateInfo().GetProperties() == nil {
+		return peer
+	}
+	return &peerState{
+		Peer:        peer,
+		blockHeight: endpoint.StateInfoMessage.GetStateInfo().GetProperties().GetLedgerHeight(),
+	}
+}
+
+// BlockHeight returns the height of the peer's ledger
+func (p *peerState) BlockHeight() uint64 {
+	return p.blockHeight
+}
+
+// Health checks whether the peer is reachable (if supported by the wrapped peer)
+func (p *peerState) Health(ctx reqContext.Context) error {
+	if hc, ok := p.Peer.(fab.HealthChecker); ok {
+		return hc.Health(ctx)
+	}
+	return nil
+}
+
+func (p *peerState) String() string {
+	return p.URL()
+}
@@ -0,0 +1,52 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package discovery
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric-sdk-go/internal/github.com/hyperledger/fabric/protos/gossip"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPeerState(t *testing.T) {
+	peer := mocks.NewMockPeer("p1", peer1URL)
+
+	endpoint := newEndorser(peer1URL)
+	assert.Equal(t, peer, NewPeerState(peer, endpoint), "expecting the peer to be returned as is if no state was reported")
+
+	endpoint.StateInfoMessage = &gossip.SignedGossipMessage{
+		GossipMessage: &gossip.GossipMessage{
+			Content: &gossip.GossipMessage_StateInfo{
+				StateInfo: &gossip.StateInfo{},
+			},
+		},
+	}
+	assert.Equal(t, peer, NewPeerState(peer, endpoint), "expecting the peer to be returned as is if no properties were reported")
+
+	endpoint.StateInfoMessage = &gossip.SignedGossipMessage{
+		GossipMessage: &gossip.GossipMessage{
+			Content: &gossip.GossipMessage_StateInfo{
+				StateInfo: &gossip.StateInfo{
+					Properties: &gossip.Properties{
+						LedgerHeight: 1001,
+					},
+				},
+			},
+		},
+	}
+
+	p := NewPeerState(peer, endpoint)
+	state, ok := p.(fab.PeerState)
+	require.True(t, ok, "expecting peer to implement PeerState")
+	assert.Equal(t, uint64(1001), state.BlockHeight())
+	assert.Equal(t, peer1URL, p.URL())
+	assert.NoError(t, p.(fab.HealthChecker).Health(nil))
+}
@@ -34,6 +34,10 @@ const (
 	Connected
 )
 
+// ConnectionStateHandler is invoked when the connection state of the client changes. If the client
+// transitioned to Disconnected due to an error then err contains the error, otherwise it is nil.
+type ConnectionStateHandler func(from, to ConnectionState, err error)
+
 // Client connects to an event server and receives events, such as block, filtered block,
 // chaincode, and transaction status events. Client also monitors the connection to the
 // event server and attempts to reconnect if the connection is closed.
@@ -168,7 +172,7 @@ func (c *Client) close(force bool) bool {
 
 	c.Stop()
 
-	c.mustSetConnectionState(Disconnected)
+	c.mustSetConnectionState(Disconnected, nil)
 
 	logger.Debugf("... event client is stopped")
 
@@ -180,7 +184,7 @@ func (c *Client) connect() error {
 		return errors.New("event client is closed")
 	}
 
-	if !c.setConnectionState(Disconnected, Connecting) {
+	if !c.setConnectionState(Disconnected, Connecting, nil) {
 		return errors.Errorf("unable to connect event client since client is [%s]. Expecting client to be in state [%s]", c.ConnectionState(), Disconnected)
 	}
 
@@ -194,7 +198,7 @@ func (c *Client) connect() error {
 	err := <-errch
 
 	if err != nil {
-		c.mustSetConnectionState(Disconnected)
+		c.mustSetConnectionState(Disconnected, err)
 		logger.Debugf("... got error in connection response: %s", err)
 		return err
 	}
@@ -218,7 +222,7 @@ func (c *Client) connect() error {
 		}
 	}
 
-	c.setConnectionState(Connecting, Connected)
+	c.setConnectionState(Connecting, Connected, nil)
 
 	logger.Debugf("Submitting connected event")
 	err2 := c.Submit(dispatcher.NewConnectedEvent())
@@ -247,7 +251,7 @@ func (c *Client) t(handlerImp handler, errch chan error) error {
 			logger.Warnf("Timed out waiting for disconnect response")
 		}
 
-		c.setConnectionState(Connecting, Disconnected)
+		c.setConnectionState(Connecting, Disconnected, err1)
 
 		return errors.WithMessage(err1, "error invoking afterConnect handler")
 	}
@@ -329,12 +333,30 @@ func (c *Client) ConnectionState() ConnectionState {
 
 // setConnectionState sets the connection state only if the given currentState
 // matches the actual state. True is returned if the connection state was successfully set.
-func (c *Client) setConnectionState(currentState, newState ConnectionState) bool {
-	return atomic.CompareAndSwapInt32(&c.connectionState, int32(currentState), int32(newState))
+func (c *Client) setConnectionState(currentState, newState ConnectionState, err error) bool {
+	if !atomic.CompareAndSwapInt32(&c.connectionState, int32(currentState), int32(newState)) {
+		return false
+	}
+	c.notifyConnectionState(currentState, newState, err)
+	return true
 }
 
-func (c *Client) mustSetConnectionState(newState ConnectionState) {
-	atomic.StoreInt32(&c.connectionState, int32(newState))
+func (c *Client) mustSetConnectionState(newState ConnectionState, err error) {
+	currentState := ConnectionState(atomic.SwapInt32(&c.connectionState, int32(newState)))
+	if currentState != newState {
+		c.notifyConnectionState(currentState, newState, err)
+	}
+}
+
+func (c *Client) notifyConnectionState(from, to ConnectionState, err error) {
+	if c.connStateHandler == nil {
+		return
+	}
+	if to != Disconnected {
+		err = nil
+	}
+	logger.Debugf("Notifying connection state handler of transition from [%s] to [%s]", from, to)
+	c.connStateHandler(from, to, err)
 }
 
 func (c *Client) monitorConnection() {
@@ -357,10 +379,10 @@ func (c *Client) monitorConnection() {
 			logger.Debugf("Event client has connected")
 		} else if c.reconn {
 			logger.Warnf("Event client has disconnected. Details: %s", event.Err)
-			if c.setConnectionState(Connected, Disconnected) {
+			if c.setConnectionState(Connected, Disconnected, event.Err) {
 				logger.Warnf("Attempting to reconnect...")
 				go c.reconnect()
-			} else if c.setConnectionState(Connecting, Disconnected) {
+			} else if c.setConnectionState(Connecting, Disconnected, event.Err) {
 				logger.Warnf("Reconnect already in progress. Setting state to disconnected")
 			}
 		} else {
@@ -105,6 +105,97 @@ func TestFailConnect(t *testing.T) {
 	}
 }
 
+type stateTransition struct {
+	from, to ConnectionState
+	err      error
+}
+
+type stateRecorder struct {
+	mutex       sync.Mutex
+	transitions []stateTransition
+}
+
+func (r *stateRecorder) handle(from, to ConnectionState, err error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.transitions = append(r.transitions, stateTransition{from: from, to: to, err: err})
+}
+
+func (r *stateRecorder) get() []stateTransition {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return append([]stateTransition(nil), r.transitions...)
+}
+
+func TestConnectionStateHandler(t *testing.T) {
+	recorder := &stateRecorder{}
+
+	eventClient, _, err := newClientWithMockConnAndOpts(
+		fabmocks.NewMockContextWithCustomDiscovery(
+			mspmocks.NewMockSigningIdentity("user1", "Org1MSP"),
+			clientmocks.NewDiscoveryProvider(peer1, peer2),
+		),
+		fabmocks.NewMockChannelCfg("mychannel"),
+		clientmocks.NewProviderFactory().Provider(
+			clientmocks.NewMockConnection(
+				clientmocks.WithLedger(servicemocks.NewMockLedger(servicemocks.FilteredBlockEventFactory, sourceURL)),
+			),
+		),
+		filteredClientProvider, []options.Opt{WithConnectionStateHandler(recorder.handle)},
+	)
+	if err != nil {
+		t.Fatalf("error creating channel event client: %s", err)
+	}
+	if err := eventClient.Connect(); err != nil {
+		t.Fatalf("error connecting: %s", err)
+	}
+	eventClient.Close()
+
+	expected := []stateTransition{
+		{from: Disconnected, to: Connecting},
+		{from: Connecting, to: Connected},
+		{from: Connected, to: Disconnected},
+	}
+	transitions := recorder.get()
+	if len(transitions) != len(expected) {
+		t.Fatalf("expecting transitions %v but got %v", expected, transitions)
+	}
+	for i, transition := range transitions {
+		if transition != expected[i] {
+			t.Fatalf("expecting transition %v but got %v", expected[i], transition)
+		}
+	}
+
+	recorder = &stateRecorder{}
+	eventClient, _, err = newClientWithMockConnAndOpts(
+		fabmocks.NewMockContextWithCustomDiscovery(
+			mspmocks.NewMockSigningIdentity("user1", "Org1MSP"),
+			clientmocks.NewDiscoveryProvider(peer1, peer2),
+		),
+		fabmocks.NewMockChannelCfg("mychannel"),
+		mockconn.NewProviderFactory().Provider(
+			mockconn.NewMockConnection(
+				mockconn.WithLedger(servicemocks.NewMockLedger(servicemocks.FilteredBlockEventFactory, sourceURL)),
+			),
+		),
+		failAfterConnectClientProvider, []options.Opt{WithConnectionStateHandler(recorder.handle)},
+	)
+	if err != nil {
+		t.Fatalf("error creating client: %s", err)
+	}
+	if err := eventClient.Connect(); err == nil {
+		t.Fatalf("expecting error connecting client but got none")
+	}
+
+	transitions = recorder.get()
+	if len(transitions) != 2 {
+		t.Fatalf("expecting two transitions but got %v", transitions)
+	}
+	if transitions[1].from != Connecting || transitions[1].to != Disconnected || transitions[1].err == nil {
+		t.Fatalf("expecting transition from %s to %s with error but got %v", Connecting, Disconnected, transitions[1])
+	}
+}
+
 func TestCallsOnClosedClient(t *testing.T) {
 	eventClient, _, err := newClientWithMockConn(
 		fabmocks.NewMockContextWithCustomDiscovery(
@@ -15,6 +15,7 @@ import (
 
 type params struct {
 	connEventCh             chan *dispatcher.ConnectionEvent
+	connStateHandler        ConnectionStateHandler
 	reconnInitialDelay      time.Duration
 	timeBetweenConnAttempts time.Duration
 	respTimeout             time.Duration
@@ -97,6 +98,18 @@ func WithConnectionEvent(value chan *dispatcher.ConnectionEvent) options.Opt {
 	}
 }
 
+// WithConnectionStateHandler sets the handler that is invoked whenever the connection state of the
+// client changes, i.e. when the client is connecting, connected or disconnected. On a transition to
+// Disconnected the handler is passed the error which caused the disconnect (if any).
+// Note that the handler is invoked synchronously and must therefore not block.
+func WithConnectionStateHandler(value ConnectionStateHandler) options.Opt {
+	return func(p options.Params) {
+		if setter, ok := p.(connectionStateHandlerSetter); ok {
+			setter.SetConnectionStateHandler(value)
+		}
+	}
+}
+
 // WithTimeBetweenConnectAttempts sets the time between connection attempts.
 func WithTimeBetweenConnectAttempts(value time.Duration) options.Opt {
 	return func(p options.Params) {
@@ -149,6 +162,11 @@ func (p *params) SetConnectEventCh(value chan *dispatcher.ConnectionEvent) {
 	p.connEventCh = value
 }
 
+func (p *params) SetConnectionStateHandler(value ConnectionStateHandler) {
+	logger.Debugf("ConnectionStateHandler: %t", value != nil)
+	p.connStateHandler = value
+}
+
 func (p *params) SetResponseTimeout(value time.Duration) {
 	logger.Debugf("ResponseTimeout: %s", value)
 	p.respTimeout = value
@@ -179,6 +197,10 @@ type connectEventChSetter interface {
 	SetConnectEventCh(value chan *dispatcher.ConnectionEvent)
 }
 
+type connectionStateHandlerSetter interface {
+	SetConnectionStateHandler(value ConnectionStateHandler)
+}
+
 type timeBetweenConnectAttemptsSetter interface {
 	SetTimeBetweenConnectAttempts(value time.Duration)
 }
@@ -114,8 +114,9 @@ func (c *Client) setSeekFromLastBlockReceived() error {
 		c.seekType = seek.FromBlock
 		c.fromBlock = c.Dispatcher().LastBlockNum() + 1
 	} else {
-		// We haven't received any blocks yet. Just ask for the newest
-		c.seekType = seek.Newest
+		// We haven't received any blocks yet. Keep the configured seek position (Newest by default)
+		// so that no blocks are skipped.
+		logger.Debugf("No blocks received yet. Reconnecting with seek type [%s]", c.seekType)
 	}
 	return nil
 }
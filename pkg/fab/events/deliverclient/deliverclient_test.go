@@ -52,6 +52,34 @@ func TestOptionsInNewClient(t *testing.T) {
 	client.Close()
 }
 
+func TestSeekOnReconnectWithoutBlocks(t *testing.T) {
+	channelID := "mychannel"
+
+	eventClient, err := New(newMockContext(), fabmocks.NewMockChannelCfg(channelID), client.WithBlockEvents(), WithSeekFrom(5))
+	if err != nil {
+		t.Fatalf("error creating deliver client: %s", err)
+	}
+	defer eventClient.Close()
+	if err := eventClient.setSeekFromLastBlockReceived(); err != nil {
+		t.Fatalf("error setting seek position: %s", err)
+	}
+	if eventClient.seekType != seek.FromBlock || eventClient.fromBlock != 5 {
+		t.Fatalf("expecting seek from block 5 but got %s from block %d", eventClient.seekType, eventClient.fromBlock)
+	}
+
+	defaultClient, err := New(newMockContext(), fabmocks.NewMockChannelCfg(channelID), client.WithBlockEvents())
+	if err != nil {
+		t.Fatalf("error creating deliver client: %s", err)
+	}
+	defer defaultClient.Close()
+	if err := defaultClient.setSeekFromLastBlockReceived(); err != nil {
+		t.Fatalf("error setting seek position: %s", err)
+	}
+	if defaultClient.seekType != seek.Newest {
+		t.Fatalf("expecting seek type %s but got %s", seek.Newest, defaultClient.seekType)
+	}
+}
+
 func TestClientConnect(t *testing.T) {
 	channelID := "mychannel"
 	eventClient, err := New(
@@ -48,6 +48,15 @@ func WithBlockNum(value uint64) options.Opt {
 	}
 }
 
+// WithSeekFrom specifies that events are to be received starting from the given block number.
+// It is equivalent to WithSeekType(seek.FromBlock) and WithBlockNum(value).
+func WithSeekFrom(value uint64) options.Opt {
+	return func(p options.Params) {
+		WithSeekType(seek.FromBlock)(p)
+		WithBlockNum(value)(p)
+	}
+}
+
 type seekTypeSetter interface {
 	SetSeekType(value seek.Type)
 }
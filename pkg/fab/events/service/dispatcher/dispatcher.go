@@ -52,6 +52,8 @@ type Dispatcher struct {
 	ccRegistrations            map[string]*ChaincodeReg
 	state                      int32
 	lastBlockNum               uint64
+	firstBlockNum              uint64
+	workers                    *workerPool
 }
 
 // New creates a new Dispatcher.
@@ -61,7 +63,7 @@ func New(opts ...options.Opt) *Dispatcher {
 	params := defaultParams()
 	options.Apply(params, opts)
 
-	return &Dispatcher{
+	dispatcher := &Dispatcher{
 		params:          *params,
 		handlers:        make(map[reflect.Type]Handler),
 		eventch:         make(chan interface{}, params.eventConsumerBufferSize),
@@ -69,7 +71,12 @@ func New(opts ...options.Opt) *Dispatcher {
 		ccRegistrations: make(map[string]*ChaincodeReg),
 		state:           dispatcherStateInitial,
 		lastBlockNum:    math.MaxUint64,
+		firstBlockNum:   math.MaxUint64,
 	}
+	if params.dispatchWorkers > 0 {
+		dispatcher.workers = newWorkerPool(params.dispatchWorkers, params.eventConsumerBufferSize, params.dispatchPolicy)
+	}
+	return dispatcher
 }
 
 // RegisterHandlers registers all of the handlers by event type
@@ -136,6 +143,12 @@ func (ed *Dispatcher) LastBlockNum() uint64 {
 	return atomic.LoadUint64(&ed.lastBlockNum)
 }
 
+// FirstBlockNum returns the block number of the first block for which an event was received
+// or math.MaxUint64 if no block has been received yet.
+func (ed *Dispatcher) FirstBlockNum() uint64 {
+	return atomic.LoadUint64(&ed.firstBlockNum)
+}
+
 // updateLastBlockNum updates the value of lastBlockNum and
 // returns the updated value.
 func (ed *Dispatcher) updateLastBlockNum(blockNum uint64) error {
@@ -143,17 +156,21 @@ func (ed *Dispatcher) updateLastBlockNum(blockNum uint64) error {
 	// Log an error if we detect this happening.
 	lastBlockNum := atomic.LoadUint64(&ed.lastBlockNum)
 	if lastBlockNum == math.MaxUint64 || blockNum > lastBlockNum {
+		if lastBlockNum == math.MaxUint64 {
+			atomic.StoreUint64(&ed.firstBlockNum, blockNum)
+		}
 		atomic.StoreUint64(&ed.lastBlockNum, blockNum)
 		return nil
 	}
-	return errors.Errorf("Expecting a block number greater than %d but received block number %d", lastBlockNum, lastBlockNum)
+	return errors.Errorf("Expecting a block number greater than %d but received block number %d", lastBlockNum, blockNum)
 }
 
 // clearBlockRegistrations removes all block registrations and closes the corresponding event channels.
 // The listener will receive a 'closed' event to indicate that the channel has been closed.
 func (ed *Dispatcher) clearBlockRegistrations() {
 	for _, reg := range ed.blockRegistrations {
-		close(reg.Eventch)
+		eventch := reg.Eventch
+		ed.closeEventch(eventch, func() { close(eventch) })
 	}
 	ed.blockRegistrations = nil
 }
@@ -162,7 +179,8 @@ func (ed *Dispatcher) clearBlockRegistrations() {
 // The listener will receive a 'closed' event to indicate that the channel has been closed.
 func (ed *Dispatcher) clearFilteredBlockRegistrations() {
 	for _, reg := range ed.filteredBlockRegistrations {
-		close(reg.Eventch)
+		eventch := reg.Eventch
+		ed.closeEventch(eventch, func() { close(eventch) })
 	}
 	ed.filteredBlockRegistrations = nil
 }
@@ -172,7 +190,8 @@ func (ed *Dispatcher) clearFilteredBlockRegistrations() {
 func (ed *Dispatcher) clearTxRegistrations() {
 	for _, reg := range ed.txRegistrations {
 		logger.Debugf("Closing TX registration event channel for TxID [%s].", reg.TxID)
-		close(reg.Eventch)
+		eventch := reg.Eventch
+		ed.closeEventch(eventch, func() { close(eventch) })
 	}
 	ed.txRegistrations = make(map[string]*TxStatusReg)
 }
@@ -182,7 +201,8 @@ func (ed *Dispatcher) clearTxRegistrations() {
 func (ed *Dispatcher) clearChaincodeRegistrations() {
 	for _, reg := range ed.ccRegistrations {
 		logger.Debugf("Closing chaincode registration event channel for CC ID [%s] and event filter [%s].", reg.ChaincodeID, reg.EventFilter)
-		close(reg.Eventch)
+		eventch := reg.Eventch
+		ed.closeEventch(eventch, func() { close(eventch) })
 	}
 	ed.ccRegistrations = make(map[string]*ChaincodeReg)
 }
@@ -205,6 +225,10 @@ func (ed *Dispatcher) HandleStopEvent(e Event) {
 	ed.clearTxRegistrations()
 	ed.clearChaincodeRegistrations()
 
+	if ed.workers != nil {
+		ed.workers.stop()
+	}
+
 	event.ErrCh <- nil
 }
 
@@ -227,16 +251,20 @@ func (ed *Dispatcher) handleRegisterCCEvent(e Event) {
 	key := getCCKey(event.Reg.ChaincodeID, event.Reg.EventFilter)
 	if _, exists := ed.ccRegistrations[key]; exists {
 		event.ErrCh <- errors.Errorf("registration already exists for chaincode [%s] and event [%s]", event.Reg.ChaincodeID, event.Reg.EventFilter)
-	} else {
+		return
+	}
+
+	if event.Reg.EventRegExp == nil {
 		regExp, err := regexp.Compile(event.Reg.EventFilter)
 		if err != nil {
 			event.ErrCh <- errors.Wrapf(err, "error compiling regular expression for event filter [%s]", event.Reg.EventFilter)
-		} else {
-			event.Reg.EventRegExp = regExp
-			ed.ccRegistrations[key] = event.Reg
-			event.RegCh <- event.Reg
+			return
 		}
+		event.Reg.EventRegExp = regExp
 	}
+
+	ed.ccRegistrations[key] = event.Reg
+	event.RegCh <- event.Reg
 }
 
 func (ed *Dispatcher) handleRegisterTxStatusEvent(e Event) {
@@ -329,7 +357,7 @@ func (ed *Dispatcher) unregisterBlockEvents(registration *BlockReg) error {
 			// Move the 0'th item to i and then delete the 0'th item
 			ed.blockRegistrations[i] = ed.blockRegistrations[0]
 			ed.blockRegistrations = ed.blockRegistrations[1:]
-			close(reg.Eventch)
+			ed.closeEventch(reg.Eventch, func() { close(reg.Eventch) })
 			return nil
 		}
 	}
@@ -342,7 +370,7 @@ func (ed *Dispatcher) unregisterFilteredBlockEvents(registration *FilteredBlockR
 			// Move the 0'th item to i and then delete the 0'th item
 			ed.filteredBlockRegistrations[i] = ed.filteredBlockRegistrations[0]
 			ed.filteredBlockRegistrations = ed.filteredBlockRegistrations[1:]
-			close(reg.Eventch)
+			ed.closeEventch(reg.Eventch, func() { close(reg.Eventch) })
 			return nil
 		}
 	}
@@ -357,7 +385,7 @@ func (ed *Dispatcher) unregisterCCEvents(registration *ChaincodeReg) error {
 	}
 
 	logger.Debugf("Unregistering CC event for CC ID [%s] and event filter [%s]...", registration.ChaincodeID, registration.EventFilter)
-	close(reg.Eventch)
+	ed.closeEventch(reg.Eventch, func() { close(reg.Eventch) })
 	delete(ed.ccRegistrations, key)
 	return nil
 }
@@ -369,7 +397,7 @@ func (ed *Dispatcher) unregisterTXEvents(registration *TxStatusReg) error {
 	}
 
 	logger.Debugf("Unregistering Tx Status event for TxID [%s]...", registration.TxID)
-	close(reg.Eventch)
+	ed.closeEventch(reg.Eventch, func() { close(reg.Eventch) })
 	delete(ed.txRegistrations, registration.TxID)
 	return nil
 }
@@ -381,21 +409,24 @@ func (ed *Dispatcher) publishBlockEvents(block *cb.Block, sourceURL string) {
 			continue
 		}
 
-		if ed.eventConsumerTimeout < 0 {
-			select {
-			case reg.Eventch <- NewBlockEvent(block, sourceURL):
-			default:
-				logger.Warnf("Unable to send to block event channel.")
-			}
-		} else if ed.eventConsumerTimeout == 0 {
-			reg.Eventch <- NewBlockEvent(block, sourceURL)
-		} else {
-			select {
-			case reg.Eventch <- NewBlockEvent(block, sourceURL):
-			case <-time.After(ed.eventConsumerTimeout):
-				logger.Warnf("Timed out sending block event.")
+		eventch := reg.Eventch
+		ed.dispatch(eventch, func() {
+			if ed.eventConsumerTimeout < 0 {
+				select {
+				case eventch <- NewBlockEvent(block, sourceURL):
+				default:
+					logger.Warnf("Unable to send to block event channel.")
+				}
+			} else if ed.eventConsumerTimeout == 0 {
+				eventch <- NewBlockEvent(block, sourceURL)
+			} else {
+				select {
+				case eventch <- NewBlockEvent(block, sourceURL):
+				case <-time.After(ed.eventConsumerTimeout):
+					logger.Warnf("Timed out sending block event.")
+				}
 			}
-		}
+		})
 	}
 }
 
@@ -429,21 +460,24 @@ func (ed *Dispatcher) publishFilteredBlockEvents(fblock *pb.FilteredBlock, sourc
 
 func checkFilteredBlockRegistrations(ed *Dispatcher, fblock *pb.FilteredBlock, sourceURL string) {
 	for _, reg := range ed.filteredBlockRegistrations {
-		if ed.eventConsumerTimeout < 0 {
-			select {
-			case reg.Eventch <- NewFilteredBlockEvent(fblock, sourceURL):
-			default:
-				logger.Warnf("Unable to send to filtered block event channel.")
-			}
-		} else if ed.eventConsumerTimeout == 0 {
-			reg.Eventch <- NewFilteredBlockEvent(fblock, sourceURL)
-		} else {
-			select {
-			case reg.Eventch <- NewFilteredBlockEvent(fblock, sourceURL):
-			case <-time.After(ed.eventConsumerTimeout):
-				logger.Warnf("Timed out sending filtered block event.")
+		eventch := reg.Eventch
+		ed.dispatch(eventch, func() {
+			if ed.eventConsumerTimeout < 0 {
+				select {
+				case eventch <- NewFilteredBlockEvent(fblock, sourceURL):
+				default:
+					logger.Warnf("Unable to send to filtered block event channel.")
+				}
+			} else if ed.eventConsumerTimeout == 0 {
+				eventch <- NewFilteredBlockEvent(fblock, sourceURL)
+			} else {
+				select {
+				case eventch <- NewFilteredBlockEvent(fblock, sourceURL):
+				case <-time.After(ed.eventConsumerTimeout):
+					logger.Warnf("Timed out sending filtered block event.")
+				}
 			}
-		}
+		})
 	}
 }
 
@@ -452,21 +486,23 @@ func (ed *Dispatcher) publishTxStatusEvents(tx *pb.FilteredTransaction, blockNum
 	if reg, ok := ed.txRegistrations[tx.Txid]; ok {
 		logger.Debugf("Sending Tx Status event for TxID [%s] to registrant...", tx.Txid)
 
-		if ed.eventConsumerTimeout < 0 {
-			select {
-			case reg.Eventch <- NewTxStatusEvent(tx.Txid, tx.TxValidationCode, blockNum, sourceURL):
-			default:
-				logger.Warnf("Unable to send to Tx Status event channel.")
-			}
-		} else if ed.eventConsumerTimeout == 0 {
-			reg.Eventch <- NewTxStatusEvent(tx.Txid, tx.TxValidationCode, blockNum, sourceURL)
-		} else {
-			select {
-			case reg.Eventch <- NewTxStatusEvent(tx.Txid, tx.TxValidationCode, blockNum, sourceURL):
-			case <-time.After(ed.eventConsumerTimeout):
-				logger.Warnf("Timed out sending Tx Status event.")
+		ed.dispatch(reg.Eventch, func() {
+			if ed.eventConsumerTimeout < 0 {
+				select {
+				case reg.Eventch <- NewTxStatusEvent(tx.Txid, tx.TxValidationCode, blockNum, sourceURL):
+				default:
+					logger.Warnf("Unable to send to Tx Status event channel.")
+				}
+			} else if ed.eventConsumerTimeout == 0 {
+				reg.Eventch <- NewTxStatusEvent(tx.Txid, tx.TxValidationCode, blockNum, sourceURL)
+			} else {
+				select {
+				case reg.Eventch <- NewTxStatusEvent(tx.Txid, tx.TxValidationCode, blockNum, sourceURL):
+				case <-time.After(ed.eventConsumerTimeout):
+					logger.Warnf("Timed out sending Tx Status event.")
+				}
 			}
-		}
+		})
 	}
 }
 
@@ -476,25 +512,48 @@ func (ed *Dispatcher) publishCCEvents(ccEvent *pb.ChaincodeEvent, blockNum uint6
 		if reg.ChaincodeID == ccEvent.ChaincodeId && reg.EventRegExp.MatchString(ccEvent.EventName) {
 			logger.Debugf("... matched CCEvent[%s,%s] against Reg[%s,%s]", ccEvent.ChaincodeId, ccEvent.EventName, reg.ChaincodeID, reg.EventFilter)
 
-			if ed.eventConsumerTimeout < 0 {
-				select {
-				case reg.Eventch <- NewChaincodeEvent(ccEvent.ChaincodeId, ccEvent.EventName, ccEvent.TxId, ccEvent.Payload, blockNum, sourceURL):
-				default:
-					logger.Warnf("Unable to send to CC event channel.")
-				}
-			} else if ed.eventConsumerTimeout == 0 {
-				reg.Eventch <- NewChaincodeEvent(ccEvent.ChaincodeId, ccEvent.EventName, ccEvent.TxId, ccEvent.Payload, blockNum, sourceURL)
-			} else {
-				select {
-				case reg.Eventch <- NewChaincodeEvent(ccEvent.ChaincodeId, ccEvent.EventName, ccEvent.TxId, ccEvent.Payload, blockNum, sourceURL):
-				case <-time.After(ed.eventConsumerTimeout):
-					logger.Warnf("Timed out sending CC event.")
+			eventch := reg.Eventch
+			ed.dispatch(eventch, func() {
+				if ed.eventConsumerTimeout < 0 {
+					select {
+					case eventch <- NewChaincodeEvent(ccEvent.ChaincodeId, ccEvent.EventName, ccEvent.TxId, ccEvent.Payload, blockNum, sourceURL):
+					default:
+						logger.Warnf("Unable to send to CC event channel.")
+					}
+				} else if ed.eventConsumerTimeout == 0 {
+					eventch <- NewChaincodeEvent(ccEvent.ChaincodeId, ccEvent.EventName, ccEvent.TxId, ccEvent.Payload, blockNum, sourceURL)
+				} else {
+					select {
+					case eventch <- NewChaincodeEvent(ccEvent.ChaincodeId, ccEvent.EventName, ccEvent.TxId, ccEvent.Payload, blockNum, sourceURL):
+					case <-time.After(ed.eventConsumerTimeout):
+						logger.Warnf("Timed out sending CC event.")
+					}
 				}
-			}
+			})
 		}
 	}
 }
 
+// dispatch sends an event to the given consumer using the given function. If dispatch workers are
+// configured then the event is sent by the consumer's worker, otherwise it is sent immediately.
+func (ed *Dispatcher) dispatch(consumer interface{}, send func()) {
+	if ed.workers == nil {
+		send()
+		return
+	}
+	ed.workers.submit(consumer, send)
+}
+
+// closeEventch closes the given consumer's event channel using the given function. If dispatch workers
+// are configured then the channel is closed by the consumer's worker after all pending events have been sent.
+func (ed *Dispatcher) closeEventch(consumer interface{}, closeFunc func()) {
+	if ed.workers == nil {
+		closeFunc()
+		return
+	}
+	ed.workers.release(consumer, closeFunc)
+}
+
 // RegisterHandler registers an event handler
 func (ed *Dispatcher) RegisterHandler(t interface{}, h Handler) {
 	htype := reflect.TypeOf(t)
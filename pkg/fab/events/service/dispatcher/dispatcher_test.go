@@ -8,6 +8,7 @@ package dispatcher
 
 import (
 	"bytes"
+	"math"
 	"testing"
 	"time"
 
@@ -87,6 +88,30 @@ func TestBlockEvents(t *testing.T) {
 	}
 }
 
+func TestFirstBlockNum(t *testing.T) {
+	dispatcher := New()
+	if dispatcher.FirstBlockNum() != math.MaxUint64 {
+		t.Fatalf("expecting no first block number but got %d", dispatcher.FirstBlockNum())
+	}
+
+	// The peer no longer has blocks prior to block #5
+	dispatcher.HandleBlock(newBlockWithNumber(5), sourceURL)
+	dispatcher.HandleBlock(newBlockWithNumber(6), sourceURL)
+
+	if dispatcher.FirstBlockNum() != 5 {
+		t.Fatalf("expecting first block number 5 but got %d", dispatcher.FirstBlockNum())
+	}
+	if dispatcher.LastBlockNum() != 6 {
+		t.Fatalf("expecting last block number 6 but got %d", dispatcher.LastBlockNum())
+	}
+}
+
+func newBlockWithNumber(blockNum uint64) *cb.Block {
+	block := servicemocks.NewBlock("testchannel")
+	block.Header.Number = blockNum
+	return block
+}
+
 func TestBlockEventsWithFilter(t *testing.T) {
 	channelID := "testchannel"
 	dispatcher := New()
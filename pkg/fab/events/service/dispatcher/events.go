@@ -7,6 +7,8 @@ SPDX-License-Identifier: Apache-2.0
 package dispatcher
 
 import (
+	"regexp"
+
 	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
 	cb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/common"
 	pb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/peer"
@@ -105,6 +107,19 @@ func NewRegisterChaincodeEvent(ccID, eventFilter string, eventch chan<- *fab.CCE
 	}
 }
 
+// NewRegisterChaincodeEventByPattern creates a new RegisterChaincodeEvent for the given compiled event pattern
+func NewRegisterChaincodeEventByPattern(ccID string, pattern *regexp.Regexp, eventch chan<- *fab.CCEvent, respch chan<- fab.Registration, errCh chan<- error) *RegisterChaincodeEvent {
+	return &RegisterChaincodeEvent{
+		Reg: &ChaincodeReg{
+			ChaincodeID: ccID,
+			EventFilter: pattern.String(),
+			EventRegExp: pattern,
+			Eventch:     eventch,
+		},
+		RegisterEvent: NewRegisterEvent(respch, errCh),
+	}
+}
+
 // NewRegisterTxStatusEvent creates a new RegisterTxStatusEvent
 func NewRegisterTxStatusEvent(txID string, eventch chan<- *fab.TxStatusEvent, respch chan<- fab.Registration, errCh chan<- error) *RegisterTxStatusEvent {
 	return &RegisterTxStatusEvent{
@@ -15,6 +15,8 @@ import (
 type params struct {
 	eventConsumerBufferSize uint
 	eventConsumerTimeout    time.Duration
+	dispatchWorkers         int
+	dispatchPolicy          DispatchPolicy
 }
 
 func defaultParams() *params {
@@ -45,6 +47,29 @@ func WithEventConsumerTimeout(value time.Duration) options.Opt {
 	}
 }
 
+// WithDispatchWorkers sets the number of workers which deliver events to the registered consumers.
+// Events for a given consumer are always delivered by the same worker so that they remain in order, while
+// a slow consumer only stalls the delivery of events to the consumers which share its worker.
+// If <= 0 (default), events are delivered by the dispatcher itself.
+func WithDispatchWorkers(value int) options.Opt {
+	return func(p options.Params) {
+		if setter, ok := p.(dispatchWorkersSetter); ok {
+			setter.SetDispatchWorkers(value)
+		}
+	}
+}
+
+// WithDispatchPolicy sets the policy which is applied when the queue of a dispatch worker is full,
+// i.e. either block the dispatcher until there is room in the queue (default) or drop the event.
+// Note that this option only applies if dispatch workers are configured.
+func WithDispatchPolicy(value DispatchPolicy) options.Opt {
+	return func(p options.Params) {
+		if setter, ok := p.(dispatchPolicySetter); ok {
+			setter.SetDispatchPolicy(value)
+		}
+	}
+}
+
 type eventConsumerBufferSizeSetter interface {
 	SetEventConsumerBufferSize(value uint)
 }
@@ -53,6 +78,14 @@ type eventEventConsumerTimeoutSetter interface {
 	SetEventConsumerTimeout(value time.Duration)
 }
 
+type dispatchWorkersSetter interface {
+	SetDispatchWorkers(value int)
+}
+
+type dispatchPolicySetter interface {
+	SetDispatchPolicy(value DispatchPolicy)
+}
+
 func (p *params) SetEventConsumerBufferSize(value uint) {
 	logger.Debugf("EventConsumerBufferSize: %d", value)
 	p.eventConsumerBufferSize = value
@@ -62,3 +95,13 @@ func (p *params) SetEventConsumerTimeout(value time.Duration) {
 	logger.Debugf("EventConsumerTimeout: %s", value)
 	p.eventConsumerTimeout = value
 }
+
+func (p *params) SetDispatchWorkers(value int) {
+	logger.Debugf("DispatchWorkers: %d", value)
+	p.dispatchWorkers = value
+}
+
+func (p *params) SetDispatchPolicy(value DispatchPolicy) {
+	logger.Debugf("DispatchPolicy: %s", value)
+	p.dispatchPolicy = value
+}
@@ -0,0 +1,100 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package dispatcher
+
+// DispatchPolicy determines the behaviour of the dispatcher when the queue
+// of the dispatch worker of a consumer is full
+type DispatchPolicy int
+
+const (
+	// BlockWhenFull blocks the dispatcher until there is room in the worker's queue
+	BlockWhenFull DispatchPolicy = iota
+	// DropWhenFull drops the event if the worker's queue is full
+	DropWhenFull
+)
+
+// String returns the string representation of the policy
+func (p DispatchPolicy) String() string {
+	switch p {
+	case BlockWhenFull:
+		return "BlockWhenFull"
+	case DropWhenFull:
+		return "DropWhenFull"
+	default:
+		return "unknown"
+	}
+}
+
+// workerPool delivers events to consumers on a fixed set of Go routines so that a
+// slow consumer doesn't stall the delivery of events to all other consumers. All events
+// for a given consumer are delivered by the same worker so that they remain in order.
+// The pool is only accessed from the dispatcher's Go routine and therefore requires no synchronization.
+type workerPool struct {
+	queues   []chan func()
+	assigned map[interface{}]int
+	next     int
+	policy   DispatchPolicy
+}
+
+func newWorkerPool(numWorkers int, queueSize uint, policy DispatchPolicy) *workerPool {
+	p := &workerPool{
+		queues:   make([]chan func(), numWorkers),
+		assigned: make(map[interface{}]int),
+		policy:   policy,
+	}
+	for i := range p.queues {
+		p.queues[i] = make(chan func(), queueSize)
+		go work(p.queues[i])
+	}
+	return p
+}
+
+func work(queue chan func()) {
+	for job := range queue {
+		job()
+	}
+}
+
+// submit queues the job which delivers an event to the given consumer. If the worker's queue is full
+// then the job is either dropped or the caller is blocked, depending on the dispatch policy.
+func (p *workerPool) submit(consumer interface{}, job func()) {
+	queue := p.queues[p.worker(consumer)]
+	if p.policy == DropWhenFull {
+		select {
+		case queue <- job:
+		default:
+			logger.Warnf("Dispatch worker queue is full. Dropping event.")
+		}
+		return
+	}
+	queue <- job
+}
+
+// release queues the job which closes the given consumer. The job is queued after all pending
+// deliveries to the consumer and is never dropped.
+func (p *workerPool) release(consumer interface{}, job func()) {
+	queue := p.queues[p.worker(consumer)]
+	delete(p.assigned, consumer)
+	queue <- job
+}
+
+// stop stops the workers once they have processed all queued jobs
+func (p *workerPool) stop() {
+	for _, queue := range p.queues {
+		close(queue)
+	}
+}
+
+func (p *workerPool) worker(consumer interface{}) int {
+	if i, ok := p.assigned[consumer]; ok {
+		return i
+	}
+	i := p.next
+	p.next = (p.next + 1) % len(p.queues)
+	p.assigned[consumer] = i
+	return i
+}
@@ -0,0 +1,113 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package dispatcher
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/events/service/blockfilter"
+	servicemocks "github.com/hyperledger/fabric-sdk-go/pkg/fab/events/service/mocks"
+)
+
+func TestDispatchWorkers(t *testing.T) {
+	channelID := "testchannel"
+	dispatcher := New(
+		WithEventConsumerBufferSize(10),
+		WithEventConsumerTimeout(0),
+		WithDispatchWorkers(2),
+	)
+	if err := dispatcher.Start(); err != nil {
+		t.Fatalf("Error starting dispatcher: %s", err)
+	}
+
+	dispatcherEventch, err := dispatcher.EventCh()
+	if err != nil {
+		t.Fatalf("Error getting event channel from dispatcher: %s", err)
+	}
+
+	// The slow consumer doesn't read any events until all events have been sent
+	slowch := make(chan *fab.BlockEvent)
+	fastch := make(chan *fab.BlockEvent, 10)
+
+	regch := make(chan fab.Registration)
+	errch := make(chan error)
+	dispatcherEventch <- NewRegisterBlockEvent(blockfilter.AcceptAny, slowch, regch, errch)
+	slowReg := getRegistration(regch, errch, t)
+	dispatcherEventch <- NewRegisterBlockEvent(blockfilter.AcceptAny, fastch, regch, errch)
+	getRegistration(regch, errch, t)
+
+	blockProducer := servicemocks.NewBlockProducer()
+	for i := 0; i < 3; i++ {
+		dispatcherEventch <- NewBlockEvent(blockProducer.NewBlock(channelID), sourceURL)
+	}
+
+	for i := uint64(0); i < 3; i++ {
+		select {
+		case event := <-fastch:
+			if event.Block.Header.Number != i {
+				t.Fatalf("expecting block #%d but got block #%d", i, event.Block.Header.Number)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for block event - the slow consumer is stalling delivery")
+		}
+	}
+
+	dispatcherEventch <- NewUnregisterEvent(slowReg)
+
+	for i := uint64(0); i < 3; i++ {
+		select {
+		case event := <-slowch:
+			if event.Block.Header.Number != i {
+				t.Fatalf("expecting block #%d but got block #%d", i, event.Block.Header.Number)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for block event")
+		}
+	}
+
+	select {
+	case _, ok := <-slowch:
+		if ok {
+			t.Fatalf("expecting event channel to be closed after unregister")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("timed out waiting for event channel to be closed")
+	}
+}
+
+func TestWorkerPoolDropWhenFull(t *testing.T) {
+	pool := newWorkerPool(1, 1, DropWhenFull)
+	defer pool.stop()
+
+	started := make(chan struct{})
+	unblock := make(chan struct{})
+	pool.submit("consumer", func() {
+		close(started)
+		<-unblock
+	})
+	<-started
+
+	executed := 0
+	pool.submit("consumer", func() { executed++ })
+	// The queue is full so this job is dropped
+	pool.submit("consumer", func() { executed++ })
+	close(unblock)
+
+	done := make(chan struct{})
+	pool.release("consumer", func() { close(done) })
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("timed out waiting for release")
+	}
+	if executed != 1 {
+		t.Fatalf("expecting one job to be executed but got %d", executed)
+	}
+}
@@ -44,6 +44,7 @@ type TxInfo struct {
 	ChaincodeID      string
 	EventName        string
 	Payload          []byte
+	ConfigSequence   uint64
 }
 
 // NewTransaction creates a new transaction
@@ -67,6 +68,16 @@ func NewTransactionWithCCEvent(txID string, txValidationCode pb.TxValidationCode
 	}
 }
 
+// NewConfigTransaction creates a new config transaction with the given config sequence
+func NewConfigTransaction(txID string, sequence uint64) *TxInfo {
+	return &TxInfo{
+		TxID:             txID,
+		TxValidationCode: pb.TxValidationCode_VALID,
+		HeaderType:       cb.HeaderType_CONFIG,
+		ConfigSequence:   sequence,
+	}
+}
+
 // NewFilteredBlock returns a new mock filtered block initialized with the given channel
 // and filtered transactions
 func NewFilteredBlock(channelID string, filteredTx ...*pb.FilteredTransaction) *pb.FilteredBlock {
@@ -106,10 +117,15 @@ func NewFilteredTxWithCCEvent(txID, ccID, event string) *pb.FilteredTransaction
 }
 
 func newEnvelope(channelID string, txInfo *TxInfo) *cb.Envelope {
-	tx := &pb.Transaction{
-		Actions: []*pb.TransactionAction{newTxAction(txInfo.TxID, txInfo.ChaincodeID, txInfo.EventName, txInfo.Payload)},
+	var data proto.Message
+	if txInfo.HeaderType == cb.HeaderType_CONFIG {
+		data = &cb.ConfigEnvelope{Config: &cb.Config{Sequence: txInfo.ConfigSequence}}
+	} else {
+		data = &pb.Transaction{
+			Actions: []*pb.TransactionAction{newTxAction(txInfo.TxID, txInfo.ChaincodeID, txInfo.EventName, txInfo.Payload)},
+		}
 	}
-	txBytes, err := proto.Marshal(tx)
+	txBytes, err := proto.Marshal(data)
 	if err != nil {
 		panic(err)
 	}
@@ -7,6 +7,8 @@ SPDX-License-Identifier: Apache-2.0
 package service
 
 import (
+	"math"
+	"regexp"
 	"runtime/debug"
 	"time"
 
@@ -44,6 +46,9 @@ type Dispatcher interface {
 
 	// LastBlockNum returns the block number of the last block for which an event was received.
 	LastBlockNum() uint64
+
+	// FirstBlockNum returns the block number of the first block for which an event was received.
+	FirstBlockNum() uint64
 }
 
 // Service allows clients to register for channel events, such as filtered block, chaincode, and transaction status events.
@@ -63,6 +68,15 @@ func New(dispatcher Dispatcher, opts ...options.Opt) *Service {
 	}
 }
 
+// StartBlockNum returns the number of the first block for which an event was received. This allows
+// the caller to determine whether the events started at the requested block or, for example, at a later
+// block since earlier blocks are no longer available on the peer.
+// False is returned if no block has been received yet.
+func (s *Service) StartBlockNum() (uint64, bool) {
+	blockNum := s.dispatcher.FirstBlockNum()
+	return blockNum, blockNum != math.MaxUint64
+}
+
 // Start starts the event service
 func (s *Service) Start() error {
 	return s.dispatcher.Start()
@@ -189,6 +203,34 @@ func (s *Service) RegisterChaincodeEvent(ccID, eventFilter string) (fab.Registra
 	}
 }
 
+// RegisterChaincodeEventByPattern registers for chaincode events whose name matches the given
+// compiled regular expression.
+// - ccID is the chaincode ID for which events are to be received
+// - pattern is the regular expression which is matched against the chaincode event name
+func (s *Service) RegisterChaincodeEventByPattern(ccID string, pattern *regexp.Regexp) (fab.Registration, <-chan *fab.CCEvent, error) {
+	if ccID == "" {
+		return nil, nil, errors.New("chaincode ID is required")
+	}
+	if pattern == nil {
+		return nil, nil, errors.New("event pattern is required")
+	}
+
+	eventch := make(chan *fab.CCEvent, s.eventConsumerBufferSize)
+	regch := make(chan fab.Registration)
+	errch := make(chan error)
+
+	if err := s.Submit(dispatcher.NewRegisterChaincodeEventByPattern(ccID, pattern, eventch, regch, errch)); err != nil {
+		return nil, nil, errors.WithMessage(err, "error registering for chaincode events")
+	}
+
+	select {
+	case response := <-regch:
+		return response, eventch, nil
+	case err := <-errch:
+		return nil, nil, err
+	}
+}
+
 // RegisterTxStatusEvent registers for transaction status events. If the client is not authorized to receive
 // transaction status events then an error is returned.
 // - txID is the transaction ID for which events are to be received
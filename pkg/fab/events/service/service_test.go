@@ -8,6 +8,7 @@ package service
 
 import (
 	"fmt"
+	"regexp"
 	"sync"
 	"sync/atomic"
 	"testing"
@@ -382,6 +383,64 @@ func TestCCEvents(t *testing.T) {
 	checkCCEvents(eventch1, t, ccID1, event1, eventch2, ccID2, event2, event3)
 }
 
+func TestCCEventsByPattern(t *testing.T) {
+	channelID := "mychannel"
+	eventService, eventProducer, err := newServiceWithMockProducer(defaultOpts, withFilteredBlockLedger(sourceURL))
+	if err != nil {
+		t.Fatalf("error creating channel event client: %s", err)
+	}
+	defer eventProducer.Close()
+	defer eventService.Stop()
+
+	ccID := "mycc1"
+	pattern := regexp.MustCompile("^transfer.*")
+
+	if _, _, err1 := eventService.RegisterChaincodeEventByPattern("", pattern); err1 == nil {
+		t.Fatalf("expecting error registering for chaincode events without CC ID but got none")
+	}
+	if _, _, err2 := eventService.RegisterChaincodeEventByPattern(ccID, nil); err2 == nil {
+		t.Fatalf("expecting error registering for chaincode events without pattern but got none")
+	}
+
+	reg, _, err := eventService.RegisterChaincodeEventByPattern(ccID, pattern)
+	if err != nil {
+		t.Fatalf("error registering for chaincode events: %s", err)
+	}
+	if _, _, err = eventService.RegisterChaincodeEvent(ccID, pattern.String()); err == nil {
+		t.Fatalf("expecting error registering multiple times for the same chaincode event pattern")
+	}
+	eventService.Unregister(reg)
+
+	reg, eventch, err := eventService.RegisterChaincodeEventByPattern(ccID, pattern)
+	if err != nil {
+		t.Fatalf("error registering for chaincode events after unregister: %s", err)
+	}
+	defer eventService.Unregister(reg)
+
+	eventProducer.Ledger().NewFilteredBlock(
+		channelID,
+		servicemocks.NewFilteredTxWithCCEvent("txid1", ccID, "approve"),
+		servicemocks.NewFilteredTxWithCCEvent("txid2", ccID, "transferFrom"),
+		servicemocks.NewFilteredTxWithCCEvent("txid3", "mycc2", "transfer"),
+	)
+
+	select {
+	case event, ok := <-eventch:
+		if !ok {
+			t.Fatalf("unexpected closed channel")
+		}
+		checkCCEvent(t, event, ccID, "transferFrom")
+	case <-time.After(5 * time.Second):
+		t.Fatalf("timed out waiting for CC event")
+	}
+
+	select {
+	case event := <-eventch:
+		t.Fatalf("unexpected CC event [%s] for chaincode [%s]", event.EventName, event.ChaincodeID)
+	case <-time.After(200 * time.Millisecond):
+	}
+}
+
 func checkCCEvents(eventch1 <-chan *fab.CCEvent, t *testing.T, ccID1 string, event1 string, eventch2 <-chan *fab.CCEvent, ccID2 string, event2 string, event3 string) {
 	numExpected := 3
 	numReceived := 0
@@ -27,6 +27,11 @@ func (mgr *MockCAClient) Enroll(enrollmentID string, enrollmentSecret string) er
 	return errors.New("not implemented")
 }
 
+// EnrollWithCSR enrolls a user with a Fabric network using a pre-built CSR
+func (mgr *MockCAClient) EnrollWithCSR(enrollmentID string, enrollmentSecret string, csr []byte, key core.Key) error {
+	return errors.New("not implemented")
+}
+
 // Reenroll re-enrolls a user
 func (mgr *MockCAClient) Reenroll(enrollmentID string) error {
 	return errors.New("not implemented")
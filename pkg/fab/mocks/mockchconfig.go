@@ -19,10 +19,16 @@ type MockChannelCfg struct {
 	MockID          string
 	MockBlockNumber uint64
 	MockMSPs        []*msp.MSPConfig
+	MockMSPConfigs  map[string]*msp.FabricMSPConfig
 	MockAnchorPeers []*fab.OrgAnchorPeer
 	MockOrderers    []string
 	MockVersions    *fab.Versions
 	MockMembership  fab.ChannelMembership
+
+	MockAnchorPeersByMSP map[string][]fab.AnchorPeer
+	MockOrdererEndpoints map[string][]string
+	MockConsensusType    *fab.ConsensusType
+	MockCapabilities     map[string][]string
 }
 
 // NewMockChannelCfg ...
@@ -45,16 +51,41 @@ func (cfg *MockChannelCfg) MSPs() []*msp.MSPConfig {
 	return cfg.MockMSPs
 }
 
+// MSPConfigs returns the MSP configs keyed by MSP ID
+func (cfg *MockChannelCfg) MSPConfigs() map[string]*msp.FabricMSPConfig {
+	return cfg.MockMSPConfigs
+}
+
 // AnchorPeers returns anchor peers
 func (cfg *MockChannelCfg) AnchorPeers() []*fab.OrgAnchorPeer {
 	return cfg.MockAnchorPeers
 }
 
+// AnchorPeersByMSP returns anchor peers keyed by MSP ID
+func (cfg *MockChannelCfg) AnchorPeersByMSP() map[string][]fab.AnchorPeer {
+	return cfg.MockAnchorPeersByMSP
+}
+
 // Orderers returns orderers
 func (cfg *MockChannelCfg) Orderers() []string {
 	return cfg.MockOrderers
 }
 
+// OrdererEndpoints returns orderer endpoints keyed by MSP ID
+func (cfg *MockChannelCfg) OrdererEndpoints() map[string][]string {
+	return cfg.MockOrdererEndpoints
+}
+
+// ConsensusType returns the consensus type
+func (cfg *MockChannelCfg) ConsensusType() *fab.ConsensusType {
+	return cfg.MockConsensusType
+}
+
+// Capabilities returns capabilities keyed by group
+func (cfg *MockChannelCfg) Capabilities() map[string][]string {
+	return cfg.MockCapabilities
+}
+
 // Versions returns versions
 func (cfg *MockChannelCfg) Versions() *fab.Versions {
 	return cfg.MockVersions
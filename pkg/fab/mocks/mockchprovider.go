@@ -16,6 +16,7 @@ import (
 type MockChannelProvider struct {
 	ctx                    core.Providers
 	transactor             fab.Transactor
+	capabilities           map[string][]string
 	customSelectionService fab.ChannelService
 }
 
@@ -25,6 +26,7 @@ type MockChannelService struct {
 	channelID    string
 	transactor   fab.Transactor
 	mockOrderers []string
+	capabilities map[string][]string
 }
 
 // NewMockChannelProvider returns a mock ChannelProvider
@@ -41,6 +43,11 @@ func (cp *MockChannelProvider) SetTransactor(transactor fab.Transactor) {
 	cp.transactor = transactor
 }
 
+// SetCapabilities sets the channel config capabilities (keyed by group) for all mock channel services
+func (cp *MockChannelProvider) SetCapabilities(capabilities map[string][]string) {
+	cp.capabilities = capabilities
+}
+
 // ChannelService returns a mock ChannelService
 func (cp *MockChannelProvider) ChannelService(ctx fab.ClientContext, channelID string) (fab.ChannelService, error) {
 
@@ -49,9 +56,10 @@ func (cp *MockChannelProvider) ChannelService(ctx fab.ClientContext, channelID s
 	}
 
 	cs := MockChannelService{
-		provider:   cp,
-		channelID:  channelID,
-		transactor: cp.transactor,
+		provider:     cp,
+		channelID:    channelID,
+		transactor:   cp.transactor,
+		capabilities: cp.capabilities,
 	}
 	return &cs, nil
 }
@@ -88,5 +96,5 @@ func (cs *MockChannelService) Membership() (fab.ChannelMembership, error) {
 
 //ChannelConfig returns channel config
 func (cs *MockChannelService) ChannelConfig() (fab.ChannelCfg, error) {
-	return &MockChannelCfg{MockID: cs.channelID, MockOrderers: cs.mockOrderers}, nil
+	return &MockChannelCfg{MockID: cs.channelID, MockOrderers: cs.mockOrderers, MockCapabilities: cs.capabilities}, nil
 }
@@ -123,6 +123,11 @@ func (pc *MockProviderContext) SetCryptoSuiteConfig(config core.CryptoSuiteConfi
 	pc.cryptoSuiteConfig = config
 }
 
+// SetCryptoSuite sets the mock cryptosuite.
+func (pc *MockProviderContext) SetCryptoSuite(cryptoSuite core.CryptoSuite) {
+	pc.cryptoSuite = cryptoSuite
+}
+
 // SetEndpointConfig sets the mock endpoint configuration.
 func (pc *MockProviderContext) SetEndpointConfig(config fab.EndpointConfig) {
 	pc.endpointConfig = config
@@ -258,6 +263,14 @@ func (m MockContext) EnrollmentCertificate() []byte {
 	return m.SigningIdentity.EnrollmentCertificate()
 }
 
+// Attributes returns the attributes of the identity
+func (m MockContext) Attributes() (map[string]string, error) {
+	if m.SigningIdentity == nil {
+		return nil, errors.New("anonymous countext")
+	}
+	return m.SigningIdentity.Attributes()
+}
+
 // Sign the message
 func (m MockContext) Sign(msg []byte) ([]byte, error) {
 	if m.SigningIdentity == nil {
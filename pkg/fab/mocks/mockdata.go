@@ -59,6 +59,12 @@ type MockConfigGroupBuilder struct {
 	MSPNames       []string
 	RootCA         string
 	Groups         map[string]*common.ConfigGroup
+	// AnchorPeers holds the anchor peers of the application orgs, keyed by MSP name
+	AnchorPeers map[string][]*pp.AnchorPeer
+	// Capabilities holds the capabilities declared by the "Channel", "Orderer" and "Application" groups, keyed by group name
+	Capabilities map[string][]string
+	// ConsensusType is the consensus type of the orderer group ("sample-Consensus-Type" if not set)
+	ConsensusType string
 }
 
 // MockConfigBlockBuilder is used to build a mock Chain configuration block
@@ -66,6 +72,7 @@ type MockConfigBlockBuilder struct {
 	MockConfigGroupBuilder
 	Index           uint64
 	LastConfigIndex uint64
+	LastUpdate      *common.Envelope
 }
 
 // MockConfigUpdateEnvelopeBuilder builds a mock ConfigUpdateEnvelope
@@ -156,7 +163,7 @@ func (b *MockConfigBlockBuilder) buildChannelHeader() *common.ChannelHeader {
 }
 
 func (b *MockConfigBlockBuilder) buildConfigEnvelope() *common.ConfigEnvelope {
-	return &common.ConfigEnvelope{Config: b.buildConfig()}
+	return &common.ConfigEnvelope{Config: b.buildConfig(), LastUpdate: b.LastUpdate}
 }
 
 func (b *MockConfigBlockBuilder) buildConfig() *common.Config {
@@ -167,7 +174,7 @@ func (b *MockConfigBlockBuilder) buildConfig() *common.Config {
 }
 
 func (b *MockConfigGroupBuilder) buildConfigGroup() *common.ConfigGroup {
-	return &common.ConfigGroup{
+	group := &common.ConfigGroup{
 		Groups: map[string]*common.ConfigGroup{
 			"Orderer":     b.buildOrdererGroup(),
 			"Application": b.buildApplicationGroup(),
@@ -184,6 +191,8 @@ func (b *MockConfigGroupBuilder) buildConfigGroup() *common.ConfigGroup {
 		Version:   b.Version,
 		ModPolicy: b.ModPolicy,
 	}
+	b.addCapabilities(group, channelConfig.ChannelGroupKey)
+	return group
 }
 
 func (b *MockConfigGroupBuilder) buildOrdererAddressesConfigValue() *common.ConfigValue {
@@ -200,7 +209,7 @@ func (b *MockConfigGroupBuilder) buildOrdererAddresses() *common.OrdererAddresse
 }
 
 func (b *MockConfigGroupBuilder) buildOrdererGroup() *common.ConfigGroup {
-	return &common.ConfigGroup{
+	group := &common.ConfigGroup{
 		Groups: map[string]*common.ConfigGroup{
 			"OrdererMSP": b.buildMSPGroup("OrdererMSP"),
 		},
@@ -222,6 +231,17 @@ func (b *MockConfigGroupBuilder) buildOrdererGroup() *common.ConfigGroup {
 		Version:   b.Version,
 		ModPolicy: b.ModPolicy,
 	}
+	b.addCapabilities(group, channelConfig.OrdererGroupKey)
+	return group
+}
+
+// addCapabilities adds the capabilities configured for the given group name (if any) to the group
+func (b *MockConfigGroupBuilder) addCapabilities(group *common.ConfigGroup, groupName string) {
+	names, ok := b.Capabilities[groupName]
+	if !ok {
+		return
+	}
+	group.Values[channelConfig.CapabilitiesKey] = b.buildCapabilitiesConfigValue(names)
 }
 
 func (b *MockConfigGroupBuilder) buildMSPGroup(mspName string) *common.ConfigGroup {
@@ -262,6 +282,24 @@ func (b *MockConfigGroupBuilder) buildAnchorPeerConfigValue() *common.ConfigValu
 		Value:     marshalOrPanic(b.buildAnchorPeer())}
 }
 
+func (b *MockConfigGroupBuilder) buildOrgAnchorPeersConfigValue(anchorPeers []*pp.AnchorPeer) *common.ConfigValue {
+	return &common.ConfigValue{
+		Version:   b.Version,
+		ModPolicy: b.ModPolicy,
+		Value:     marshalOrPanic(&pp.AnchorPeers{AnchorPeers: anchorPeers})}
+}
+
+func (b *MockConfigGroupBuilder) buildCapabilitiesConfigValue(names []string) *common.ConfigValue {
+	capabilities := &common.Capabilities{Capabilities: make(map[string]*common.Capability)}
+	for _, name := range names {
+		capabilities.Capabilities[name] = &common.Capability{}
+	}
+	return &common.ConfigValue{
+		Version:   b.Version,
+		ModPolicy: b.ModPolicy,
+		Value:     marshalOrPanic(capabilities)}
+}
+
 func (b *MockConfigGroupBuilder) buildConsensusTypeConfigValue() *common.ConfigValue {
 	return &common.ConfigValue{
 		Version:   b.Version,
@@ -313,8 +351,12 @@ func (b *MockConfigGroupBuilder) buildAnchorPeer() *pp.AnchorPeers {
 }
 
 func (b *MockConfigGroupBuilder) buildConsensusType() *ab.ConsensusType {
+	consensusType := b.ConsensusType
+	if consensusType == "" {
+		consensusType = "sample-Consensus-Type"
+	}
 	return &ab.ConsensusType{
-		Type: "sample-Consensus-Type",
+		Type: consensusType,
 	}
 }
 
@@ -396,9 +438,12 @@ func (b *MockConfigGroupBuilder) buildApplicationGroup() *common.ConfigGroup {
 	groups := make(map[string]*common.ConfigGroup)
 	for _, name := range b.MSPNames {
 		groups[name] = b.buildMSPGroup(name)
+		if anchorPeers, ok := b.AnchorPeers[name]; ok {
+			groups[name].Values[channelConfig.AnchorPeersKey] = b.buildOrgAnchorPeersConfigValue(anchorPeers)
+		}
 	}
 
-	return &common.ConfigGroup{
+	group := &common.ConfigGroup{
 		Groups: groups,
 		Policies: map[string]*common.ConfigPolicy{
 			"Admins":  b.buildSignatureConfigPolicy(),
@@ -412,6 +457,8 @@ func (b *MockConfigGroupBuilder) buildApplicationGroup() *common.ConfigGroup {
 		Version:   b.Version,
 		ModPolicy: b.ModPolicy,
 	}
+	b.addCapabilities(group, "Application")
+	return group
 }
 
 // Build builds an Envelope that contains a mock ConfigUpdateEnvelope
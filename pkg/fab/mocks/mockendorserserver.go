@@ -9,10 +9,12 @@ package mocks
 import (
 	"fmt"
 	"net"
+	"sync"
 
 	"github.com/golang/protobuf/proto"
 	"golang.org/x/net/context"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
 
 	rwsetutil "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
 	kvrwset "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/ledger/rwset/kvrwset"
@@ -23,12 +25,26 @@ import (
 type MockEndorserServer struct {
 	ProposalError error
 	AddkvWrite    bool
+	mutex         sync.RWMutex
+	lastMetadata  metadata.MD
+}
+
+// LastRequestMetadata returns the gRPC metadata of the last proposal that was processed
+func (m *MockEndorserServer) LastRequestMetadata() metadata.MD {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	return m.lastMetadata
 }
 
 // ProcessProposal mock implementation that returns success if error is not set
 // error if it is
 func (m *MockEndorserServer) ProcessProposal(context context.Context,
 	proposal *pb.SignedProposal) (*pb.ProposalResponse, error) {
+	md, _ := metadata.FromIncomingContext(context)
+	m.mutex.Lock()
+	m.lastMetadata = md
+	m.mutex.Unlock()
+
 	if m.ProposalError == nil {
 		return &pb.ProposalResponse{Response: &pb.Response{
 			Status: 200,
@@ -9,9 +9,11 @@ package mocks
 import (
 	reqContext "context"
 	"fmt"
+	"time"
 
 	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
 	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/common"
+	"github.com/pkg/errors"
 )
 
 // MockOrderer is a mock fabricclient.Orderer
@@ -27,6 +29,22 @@ type MockOrderer struct {
 	// These queues are used to detach the client, to avoid deadlocks
 	BroadcastQueue chan *fab.SignedEnvelope
 	DeliveryQueue  chan interface{}
+
+	// BroadcastResponses holds the statuses returned by SendBroadcast (after any enqueued errors)
+	BroadcastResponses chan *common.Status
+	// DeliverResponses holds the scripted responses of SendDeliver, one per call. When no
+	// response is enqueued, SendDeliver returns the shared Deliveries/DeliveryErrors channels.
+	DeliverResponses chan *MockDeliverResponse
+	// Delay if set, delays each SendBroadcast and SendDeliver call (or until the request context is done)
+	Delay time.Duration
+}
+
+// MockDeliverResponse is the scripted response of a single SendDeliver call. The blocks
+// are delivered in order followed by the error (if any); the block channel is closed
+// once all of the blocks were delivered without error.
+type MockDeliverResponse struct {
+	Blocks []*common.Block
+	Err    error
 }
 
 // NewMockOrderer ...
@@ -39,6 +57,9 @@ func NewMockOrderer(url string, broadcastListener chan *fab.SignedEnvelope) *Moc
 		DeliveryErrors:    make(chan error, 1),
 		BroadcastQueue:    make(chan *fab.SignedEnvelope, 100),
 		DeliveryQueue:     make(chan interface{}, 100),
+
+		BroadcastResponses: make(chan *common.Status, 100),
+		DeliverResponses:   make(chan *MockDeliverResponse, 100),
 	}
 
 	if broadcastListener != nil {
@@ -86,25 +107,83 @@ func (o *MockOrderer) URL() string {
 }
 
 // SendBroadcast accepts client broadcast calls and reports them to the listener channel
-// Returns the first enqueued error, or nil if there are no enqueued errors
+// Returns the first enqueued error, otherwise the first enqueued status (or nil if none are enqueued)
 func (o *MockOrderer) SendBroadcast(ctx reqContext.Context, envelope *fab.SignedEnvelope) (*common.Status, error) {
 	// Report this call to the listener
 	if o.BroadcastListener != nil {
 		o.BroadcastQueue <- envelope
 	}
+
+	if err := o.wait(ctx); err != nil {
+		return nil, err
+	}
+
 	select {
 	case err := <-o.BroadcastErrors:
 		return nil, err
+	default:
+	}
+
+	select {
+	case status := <-o.BroadcastResponses:
+		return status, nil
 	default:
 		return nil, nil
 	}
 }
 
-// SendDeliver returns the channels for delivery of prepared mock values and errors (if any)
+// SendDeliver returns the channels for delivery of the next scripted response if one was enqueued
+// (see EnqueueDeliverResponse), otherwise the channels for delivery of prepared mock values and errors (if any)
 func (o *MockOrderer) SendDeliver(ctx reqContext.Context, envelope *fab.SignedEnvelope) (chan *common.Block, chan error) {
+	select {
+	case resp := <-o.DeliverResponses:
+		blocks := make(chan *common.Block, len(resp.Blocks))
+		errs := make(chan error, 1)
+		go o.deliverResponse(ctx, resp, blocks, errs)
+		return blocks, errs
+	default:
+	}
+
+	if err := o.wait(ctx); err != nil {
+		errs := make(chan error, 1)
+		errs <- err
+		return make(chan *common.Block), errs
+	}
+
 	return o.Deliveries, o.DeliveryErrors
 }
 
+func (o *MockOrderer) deliverResponse(ctx reqContext.Context, resp *MockDeliverResponse, blocks chan *common.Block, errs chan error) {
+	if err := o.wait(ctx); err != nil {
+		errs <- err
+		return
+	}
+
+	for _, block := range resp.Blocks {
+		blocks <- block
+	}
+
+	if resp.Err != nil {
+		errs <- resp.Err
+		return
+	}
+	close(blocks)
+}
+
+// wait waits for the configured delay, returning an error if the context is done first
+func (o *MockOrderer) wait(ctx reqContext.Context) error {
+	if o.Delay <= 0 {
+		return nil
+	}
+
+	select {
+	case <-time.After(o.Delay):
+		return nil
+	case <-ctx.Done():
+		return errors.Wrap(ctx.Err(), "mock orderer request aborted")
+	}
+}
+
 // Close cleans up the instance and ends goroutines
 func (o *MockOrderer) Close() {
 	close(o.BroadcastQueue)
@@ -116,6 +195,18 @@ func (o *MockOrderer) EnqueueSendBroadcastError(err error) {
 	o.BroadcastErrors <- err
 }
 
+// EnqueueSendBroadcastResponse enqueues the status returned by SendBroadcast
+func (o *MockOrderer) EnqueueSendBroadcastResponse(status *common.Status) {
+	o.BroadcastResponses <- status
+}
+
+// EnqueueDeliverResponse enqueues the blocks (and optionally the error) delivered by a single SendDeliver call.
+// For example, a config block must be enqueued twice to be retrieved by resource.LastConfigFromOrderer:
+// once as the newest block and once as the last config block.
+func (o *MockOrderer) EnqueueDeliverResponse(blocks []*common.Block, err error) {
+	o.DeliverResponses <- &MockDeliverResponse{Blocks: blocks, Err: err}
+}
+
 // EnqueueForSendDeliver enqueues a mock value (block or error) for delivery
 func (o *MockOrderer) EnqueueForSendDeliver(value interface{}) {
 	switch value.(type) {
@@ -11,9 +11,11 @@ import (
 	reqContext "context"
 	"encoding/pem"
 	"sync"
+	"time"
 
 	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
 	pb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/peer"
+	"github.com/pkg/errors"
 )
 
 // MockPeer is a mock fabricsdk.Peer.
@@ -30,6 +32,12 @@ type MockPeer struct {
 	Status               int32
 	ProcessProposalCalls int
 	Endorser             []byte
+	// ResponseDelay delays each response by the given duration (or until the context is done)
+	ResponseDelay time.Duration
+	// FailFirstN fails the first N calls to ProcessTransactionProposal with FailureErr
+	FailFirstN int
+	// FailureErr the error returned for the first FailFirstN calls (a generic error if not set)
+	FailureErr error
 }
 
 // NewMockPeer creates basic mock peer
@@ -85,12 +93,27 @@ func (p *MockPeer) URL() string {
 
 // ProcessTransactionProposal does not send anything anywhere but returns an empty mock ProposalResponse
 func (p *MockPeer) ProcessTransactionProposal(ctx reqContext.Context, tp fab.ProcessProposalRequest) (*fab.TransactionProposalResponse, error) {
+	if p.ResponseDelay > 0 {
+		select {
+		case <-time.After(p.ResponseDelay):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
 	if p.RWLock != nil {
 		p.RWLock.Lock()
 		defer p.RWLock.Unlock()
 	}
 	p.ProcessProposalCalls++
 
+	if p.ProcessProposalCalls <= p.FailFirstN {
+		if p.FailureErr != nil {
+			return nil, p.FailureErr
+		}
+		return nil, errors.Errorf("mock peer failure on call %d", p.ProcessProposalCalls)
+	}
+
 	return &fab.TransactionProposalResponse{
 		Endorser: p.MockURL,
 		Status:   p.Status,
@@ -0,0 +1,128 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package mocks
+
+import (
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+	mb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/msp"
+	pb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/peer"
+)
+
+// MockProposalResponseBuilder is used to build a mock TransactionProposalResponse, for example:
+//
+//	response := mocks.NewProposalResponse().WithStatus(200).WithPayload(payload).WithEndorser("Org1MSP", cert).Build()
+type MockProposalResponseBuilder struct {
+	endorserURL     string
+	status          int32
+	chaincodeStatus *int32
+	message         string
+	payload         []byte
+	results         []byte
+	proposalHash    []byte
+	mspID           string
+	cert            []byte
+	signature       []byte
+}
+
+// NewProposalResponse returns a builder of a successful (200) proposal response
+func NewProposalResponse() *MockProposalResponseBuilder {
+	return &MockProposalResponseBuilder{
+		endorserURL: "http://peer1.com",
+		status:      200,
+		signature:   []byte("signature"),
+	}
+}
+
+// WithEndorserURL sets the URL of the endorser which returned the response
+func (b *MockProposalResponseBuilder) WithEndorserURL(url string) *MockProposalResponseBuilder {
+	b.endorserURL = url
+	return b
+}
+
+// WithStatus sets the status of the response. The chaincode status is the same
+// unless it is set with WithChaincodeStatus.
+func (b *MockProposalResponseBuilder) WithStatus(status int32) *MockProposalResponseBuilder {
+	b.status = status
+	return b
+}
+
+// WithChaincodeStatus sets the status returned by the chaincode
+func (b *MockProposalResponseBuilder) WithChaincodeStatus(status int32) *MockProposalResponseBuilder {
+	b.chaincodeStatus = &status
+	return b
+}
+
+// WithMessage sets the message of the response
+func (b *MockProposalResponseBuilder) WithMessage(message string) *MockProposalResponseBuilder {
+	b.message = message
+	return b
+}
+
+// WithPayload sets the payload returned by the chaincode
+func (b *MockProposalResponseBuilder) WithPayload(payload []byte) *MockProposalResponseBuilder {
+	b.payload = payload
+	return b
+}
+
+// WithResults sets the (marshalled) read-write set of the simulation
+func (b *MockProposalResponseBuilder) WithResults(results []byte) *MockProposalResponseBuilder {
+	b.results = results
+	return b
+}
+
+// WithProposalHash sets the hash of the proposal for which the response was returned
+func (b *MockProposalResponseBuilder) WithProposalHash(hash []byte) *MockProposalResponseBuilder {
+	b.proposalHash = hash
+	return b
+}
+
+// WithEndorser sets the identity of the endorser, given its MSP ID and (PEM) certificate
+func (b *MockProposalResponseBuilder) WithEndorser(mspID string, cert []byte) *MockProposalResponseBuilder {
+	b.mspID = mspID
+	b.cert = cert
+	return b
+}
+
+// WithSignature sets the signature of the endorsement
+func (b *MockProposalResponseBuilder) WithSignature(signature []byte) *MockProposalResponseBuilder {
+	b.signature = signature
+	return b
+}
+
+// Build builds the TransactionProposalResponse
+func (b *MockProposalResponseBuilder) Build() *fab.TransactionProposalResponse {
+	chaincodeStatus := b.status
+	if b.chaincodeStatus != nil {
+		chaincodeStatus = *b.chaincodeStatus
+	}
+
+	action := &pb.ChaincodeAction{
+		Results:  b.results,
+		Response: &pb.Response{Status: chaincodeStatus, Message: b.message, Payload: b.payload},
+	}
+	responsePayload := &pb.ProposalResponsePayload{
+		ProposalHash: b.proposalHash,
+		Extension:    marshalOrPanic(action),
+	}
+
+	var endorser []byte
+	if b.mspID != "" || b.cert != nil {
+		endorser = marshalOrPanic(&mb.SerializedIdentity{Mspid: b.mspID, IdBytes: b.cert})
+	}
+
+	return &fab.TransactionProposalResponse{
+		Endorser:        b.endorserURL,
+		Status:          b.status,
+		ChaincodeStatus: chaincodeStatus,
+		ProposalResponse: &pb.ProposalResponse{
+			Version:     1,
+			Response:    &pb.Response{Status: b.status, Message: b.message, Payload: b.payload},
+			Payload:     marshalOrPanic(responsePayload),
+			Endorsement: &pb.Endorsement{Endorser: endorser, Signature: b.signature},
+		},
+	}
+}
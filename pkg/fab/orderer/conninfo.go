@@ -0,0 +1,50 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package orderer
+
+import (
+	reqContext "context"
+
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+)
+
+// ConnectionInfo holds the metadata of the connection over which an orderer served a request
+type ConnectionInfo struct {
+	// URL the URL of the orderer
+	URL string
+	// Address the resolved remote address of the orderer
+	Address string
+	// TLSVersion the negotiated TLS version (one of the tls.VersionXXX constants), zero if TLS is not used
+	TLSVersion uint16
+	// PeerCertSubject the subject of the orderer's TLS certificate, empty if TLS is not used
+	PeerCertSubject string
+}
+
+// newConnectionInfo returns the connection info from the peer info of the given stream context
+func newConnectionInfo(ctx reqContext.Context, url string) *ConnectionInfo {
+	info := &ConnectionInfo{URL: url}
+
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		logger.Debugf("No peer info available for orderer [%s]", url)
+		return info
+	}
+	if p.Addr != nil {
+		info.Address = p.Addr.String()
+	}
+
+	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok {
+		return info
+	}
+	info.TLSVersion = tlsInfo.State.Version
+	if len(tlsInfo.State.PeerCertificates) > 0 {
+		info.PeerCertSubject = tlsInfo.State.PeerCertificates[0].Subject.String()
+	}
+	return info
+}
@@ -0,0 +1,219 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package orderer
+
+import (
+	reqContext "context"
+	"sync/atomic"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/errors/multi"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/errors/status"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/common"
+	"github.com/pkg/errors"
+)
+
+// FailoverStrategy determines the order in which the orderers of a FailoverGroup are tried
+type FailoverStrategy int
+
+const (
+	// PriorityFailover always tries the orderers in the order in which they were provided
+	PriorityFailover FailoverStrategy = iota
+	// RoundRobinFailover starts each request at the orderer following the one at which
+	// the previous request started
+	RoundRobinFailover
+)
+
+// FailoverGroup is a fab.Orderer which sends requests to one of a group of orderers,
+// transparently failing over to the next orderer if the request fails.
+type FailoverGroup struct {
+	orderers []fab.Orderer
+	strategy FailoverStrategy
+	next     uint32
+}
+
+// FailoverInfo holds the details of how a request was served by a FailoverGroup
+type FailoverInfo struct {
+	// Orderer the orderer which served the request
+	Orderer fab.Orderer
+	// Attempts the number of orderers to which the request was sent (including the one which served it)
+	Attempts int
+}
+
+// FailoverOption describes a functional parameter for the NewFailoverGroup constructor
+type FailoverOption func(*FailoverGroup) error
+
+// NewFailoverGroup returns a FailoverGroup for the given orderers
+func NewFailoverGroup(orderers []fab.Orderer, opts ...FailoverOption) (*FailoverGroup, error) {
+	if len(orderers) == 0 {
+		return nil, errors.New("at least one orderer is required")
+	}
+
+	group := &FailoverGroup{
+		orderers: orderers,
+	}
+	for _, opt := range opts {
+		if err := opt(group); err != nil {
+			return nil, err
+		}
+	}
+	return group, nil
+}
+
+// WithFailoverStrategy is a functional option for the orderer.NewFailoverGroup constructor that configures
+// the order in which orderers are tried (defaults to PriorityFailover)
+func WithFailoverStrategy(strategy FailoverStrategy) FailoverOption {
+	return func(g *FailoverGroup) error {
+		if strategy != PriorityFailover && strategy != RoundRobinFailover {
+			return errors.Errorf("invalid failover strategy [%d]", strategy)
+		}
+		g.strategy = strategy
+
+		return nil
+	}
+}
+
+// URL returns the URL of the first orderer in the group. The orderer which served a particular
+// request is returned by SendBroadcastWithInfo and SendDeliverWithInfo.
+func (g *FailoverGroup) URL() string {
+	return g.orderers[0].URL()
+}
+
+// SendBroadcast sends the envelope to the orderers of the group until one of them succeeds
+func (g *FailoverGroup) SendBroadcast(ctx reqContext.Context, envelope *fab.SignedEnvelope) (*common.Status, error) {
+	s, _, err := g.SendBroadcastWithInfo(ctx, envelope)
+	return s, err
+}
+
+// SendBroadcastWithInfo sends the envelope to the orderers of the group like SendBroadcast and
+// additionally returns which orderer served the request. The info is nil if the request failed.
+func (g *FailoverGroup) SendBroadcastWithInfo(ctx reqContext.Context, envelope *fab.SignedEnvelope) (*common.Status, *FailoverInfo, error) {
+	var errs []error
+	for i, o := range g.ordered() {
+		s, err := o.SendBroadcast(ctx, envelope)
+		if err == nil {
+			logger.Debugf("SendBroadcast served by orderer [%s]", o.URL())
+			return s, &FailoverInfo{Orderer: o, Attempts: i + 1}, nil
+		}
+
+		errs = append(errs, errors.WithMessage(err, "orderer ["+o.URL()+"]"))
+		if !g.failover(ctx, err) {
+			break
+		}
+		logger.Debugf("SendBroadcast to orderer [%s] failed, failing over: %s", o.URL(), err)
+	}
+	return nil, nil, multi.New(errs...)
+}
+
+// SendDeliver sends the deliver request to the orderers of the group until one of them starts delivering
+// blocks. Once an orderer has delivered a block there is no further failover.
+func (g *FailoverGroup) SendDeliver(ctx reqContext.Context, envelope *fab.SignedEnvelope) (chan *common.Block, chan error) {
+	responses, errs, _ := g.SendDeliverWithInfo(ctx, envelope)
+	return responses, errs
+}
+
+// SendDeliverWithInfo sends the deliver request to the orderers of the group like SendDeliver and
+// additionally returns a channel which receives the info of the orderer which serves the request as
+// soon as it starts delivering blocks. The channel is closed without a value if no orderer served the request.
+func (g *FailoverGroup) SendDeliverWithInfo(ctx reqContext.Context, envelope *fab.SignedEnvelope) (chan *common.Block, chan error, <-chan *FailoverInfo) {
+	responses := make(chan *common.Block)
+	errs := make(chan error, 1)
+	info := make(chan *FailoverInfo, 1)
+
+	go g.deliver(ctx, envelope, responses, errs, info)
+
+	return responses, errs, info
+}
+
+func (g *FailoverGroup) deliver(ctx reqContext.Context, envelope *fab.SignedEnvelope, responses chan *common.Block, errs chan error, info chan *FailoverInfo) {
+	defer close(info)
+
+	var deliverErrs []error
+	for i, o := range g.ordered() {
+		attempts := i + 1
+		served := func() {
+			logger.Debugf("SendDeliver served by orderer [%s]", o.URL())
+			info <- &FailoverInfo{Orderer: o, Attempts: attempts}
+		}
+
+		committed, err := forwardDeliver(ctx, o, envelope, responses, served)
+		if committed {
+			if err != nil {
+				errs <- err
+				return
+			}
+			close(responses)
+			return
+		}
+
+		deliverErrs = append(deliverErrs, errors.WithMessage(err, "orderer ["+o.URL()+"]"))
+		if !g.failover(ctx, err) {
+			break
+		}
+		logger.Debugf("SendDeliver to orderer [%s] failed, failing over: %s", o.URL(), err)
+	}
+	errs <- multi.New(deliverErrs...)
+}
+
+// forwardDeliver forwards the blocks delivered by the given orderer. It returns true if the orderer
+// has either delivered a block or completed the request successfully, in which case served is invoked
+// (once, before the first block is forwarded).
+func forwardDeliver(ctx reqContext.Context, o fab.Orderer, envelope *fab.SignedEnvelope, responses chan *common.Block, served func()) (bool, error) {
+	blocks, errs := o.SendDeliver(ctx, envelope)
+
+	committed := false
+	for {
+		select {
+		case block, ok := <-blocks:
+			if !ok {
+				if !committed {
+					served()
+				}
+				return true, nil
+			}
+			if !committed {
+				committed = true
+				served()
+			}
+			select {
+			case responses <- block:
+			case <-ctx.Done():
+				return true, ctx.Err()
+			}
+		case err := <-errs:
+			return committed, err
+		case <-ctx.Done():
+			return committed, ctx.Err()
+		}
+	}
+}
+
+// ordered returns the orderers in the order in which they should be tried
+func (g *FailoverGroup) ordered() []fab.Orderer {
+	if g.strategy != RoundRobinFailover {
+		return g.orderers
+	}
+
+	start := int((atomic.AddUint32(&g.next, 1) - 1) % uint32(len(g.orderers)))
+	ordered := make([]fab.Orderer, 0, len(g.orderers))
+	ordered = append(ordered, g.orderers[start:]...)
+	return append(ordered, g.orderers[:start]...)
+}
+
+// failover determines if the next orderer should be tried after the given error.
+// Rejections by the ordering service (other than service unavailable) are not failed over
+// since the other orderers would reject the request as well.
+func (g *FailoverGroup) failover(ctx reqContext.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	s, ok := status.FromError(err)
+	if ok && s.Group == status.OrdererServerStatus {
+		return s.Code == int32(common.Status_SERVICE_UNAVAILABLE)
+	}
+	return true
+}
@@ -0,0 +1,129 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package orderer
+
+import (
+	reqContext "context"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/errors/multi"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/errors/status"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+	mocks "github.com/hyperledger/fabric-sdk-go/pkg/fab/mocks"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/common"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewFailoverGroup(t *testing.T) {
+	_, err := NewFailoverGroup(nil)
+	assert.Error(t, err, "Expected error for empty orderers")
+
+	_, err = NewFailoverGroup([]fab.Orderer{mocks.NewMockOrderer("o1", nil)}, WithFailoverStrategy(FailoverStrategy(5)))
+	assert.Error(t, err, "Expected error for invalid strategy")
+}
+
+func TestFailoverGroupSendBroadcast(t *testing.T) {
+	o1 := mocks.NewMockOrderer("o1", nil)
+	o2 := mocks.NewMockOrderer("o2", nil)
+	group, err := NewFailoverGroup([]fab.Orderer{o1, o2})
+	if err != nil {
+		t.Fatalf("Failed to create failover group: %s", err)
+	}
+	assert.Equal(t, "o1", group.URL())
+
+	o1.EnqueueSendBroadcastError(status.New(status.OrdererClientStatus, status.ConnectionFailed.ToInt32(), "connection failed", nil))
+	_, info, err := group.SendBroadcastWithInfo(reqContext.Background(), &fab.SignedEnvelope{})
+	assert.NoError(t, err)
+	assert.Equal(t, &FailoverInfo{Orderer: o2, Attempts: 2}, info)
+	assert.Equal(t, "o1", group.URL())
+
+	_, info, err = group.SendBroadcastWithInfo(reqContext.Background(), &fab.SignedEnvelope{})
+	assert.NoError(t, err)
+	assert.Equal(t, &FailoverInfo{Orderer: o1, Attempts: 1}, info, "Expecting the info of each request to be independent")
+
+	// rejections by the ordering service are not failed over
+	o1.EnqueueSendBroadcastError(status.New(status.OrdererServerStatus, int32(common.Status_BAD_REQUEST), "bad request", nil))
+	_, err = group.SendBroadcast(reqContext.Background(), &fab.SignedEnvelope{})
+	assert.Error(t, err)
+	s, ok := status.FromError(err)
+	assert.True(t, ok, "Expected status error")
+	assert.Equal(t, int32(common.Status_BAD_REQUEST), s.Code)
+
+	o1.EnqueueSendBroadcastError(errors.New("o1 failed"))
+	o2.EnqueueSendBroadcastError(errors.New("o2 failed"))
+	_, info, err = group.SendBroadcastWithInfo(reqContext.Background(), &fab.SignedEnvelope{})
+	assert.Nil(t, info)
+	errs, ok := err.(multi.Errors)
+	if !ok {
+		t.Fatalf("Expected multi errors, got %v", err)
+	}
+	assert.Len(t, errs, 2)
+}
+
+func TestFailoverGroupRoundRobin(t *testing.T) {
+	o1 := mocks.NewMockOrderer("o1", nil)
+	o2 := mocks.NewMockOrderer("o2", nil)
+	o3 := mocks.NewMockOrderer("o3", nil)
+	group, err := NewFailoverGroup([]fab.Orderer{o1, o2, o3}, WithFailoverStrategy(RoundRobinFailover))
+	if err != nil {
+		t.Fatalf("Failed to create failover group: %s", err)
+	}
+
+	for _, expected := range []string{"o1", "o2", "o3", "o1"} {
+		_, info, err := group.SendBroadcastWithInfo(reqContext.Background(), &fab.SignedEnvelope{})
+		assert.NoError(t, err)
+		assert.Equal(t, expected, info.Orderer.URL())
+	}
+}
+
+func TestFailoverGroupSendDeliver(t *testing.T) {
+	o1 := mocks.NewMockOrderer("o1", nil)
+	o2 := mocks.NewMockOrderer("o2", nil)
+	group, err := NewFailoverGroup([]fab.Orderer{o1, o2})
+	if err != nil {
+		t.Fatalf("Failed to create failover group: %s", err)
+	}
+
+	o1.EnqueueForSendDeliver(errors.New("o1 unavailable"))
+	o2.EnqueueForSendDeliver(&common.Block{Header: &common.BlockHeader{Number: 7}})
+	o2.EnqueueForSendDeliver(common.Status_SUCCESS)
+
+	ctx, cancel := reqContext.WithTimeout(reqContext.Background(), 5*time.Second)
+	defer cancel()
+
+	blocks, errs, info := group.SendDeliverWithInfo(ctx, &fab.SignedEnvelope{})
+	var received []*common.Block
+	for done := false; !done; {
+		select {
+		case block, ok := <-blocks:
+			if !ok {
+				done = true
+				continue
+			}
+			received = append(received, block)
+		case err := <-errs:
+			t.Fatalf("Unexpected deliver error: %s", err)
+		case <-ctx.Done():
+			t.Fatal("Timed out waiting for blocks")
+		}
+	}
+	if len(received) != 1 || received[0].Header.Number != 7 {
+		t.Fatalf("Expected block 7 from o2, got %v", received)
+	}
+	assert.Equal(t, &FailoverInfo{Orderer: o2, Attempts: 2}, <-info)
+	_, ok := <-info
+	assert.False(t, ok, "Expecting the info channel to be closed")
+
+	o1.EnqueueDeliverResponse(nil, errors.New("o1 unavailable"))
+	o2.EnqueueDeliverResponse(nil, errors.New("o2 unavailable"))
+	_, errs, info = group.SendDeliverWithInfo(ctx, &fab.SignedEnvelope{})
+	assert.Error(t, <-errs)
+	_, ok = <-info
+	assert.False(t, ok, "Expecting the info channel to be closed without a value if no orderer served the request")
+}
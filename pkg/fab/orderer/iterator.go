@@ -0,0 +1,171 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package orderer
+
+import (
+	reqContext "context"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+
+	ab "github.com/hyperledger/fabric-sdk-go/internal/github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+	"github.com/hyperledger/fabric-sdk-go/pkg/context"
+	"github.com/hyperledger/fabric-sdk-go/pkg/core/config/comm"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/txn"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/common"
+)
+
+// SeekRange defines the range of blocks [Start, End] of a channel to be delivered
+type SeekRange struct {
+	ChannelID string
+	Start     uint64
+	End       uint64
+}
+
+// BlockIterator iterates over the blocks delivered by the ordering service.
+// Blocks are only requested from the ordering service as they are consumed.
+type BlockIterator interface {
+	// Next advances to the next block and returns false if there are no more blocks or an error occurred
+	Next() bool
+	// Block returns the current block
+	Block() *common.Block
+	// Err returns the error which terminated the iteration, if any
+	Err() error
+	// Close tears down the underlying deliver stream. It must be called if the iteration is not
+	// completed.
+	Close()
+}
+
+// Deliver requests the given range of blocks from the orderer and returns an iterator over the blocks.
+// The request is signed with the client context of the given request context.
+func (o *Orderer) Deliver(reqCtx reqContext.Context, seekRange SeekRange) (BlockIterator, error) {
+	return deliver(reqCtx, o, seekRange)
+}
+
+func deliver(reqCtx reqContext.Context, orderer fab.Orderer, seekRange SeekRange) (BlockIterator, error) {
+	if seekRange.Start > seekRange.End {
+		return nil, errors.Errorf("invalid seek range: start [%d] is greater than end [%d]", seekRange.Start, seekRange.End)
+	}
+
+	envelope, err := newSeekEnvelope(reqCtx, seekRange)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := reqContext.WithCancel(reqCtx)
+	blocks, errs := orderer.SendDeliver(ctx, envelope)
+
+	return &blockIterator{ctx: ctx, cancel: cancel, blocks: blocks, errs: errs}, nil
+}
+
+type blockIterator struct {
+	ctx    reqContext.Context
+	cancel reqContext.CancelFunc
+	blocks chan *common.Block
+	errs   chan error
+	block  *common.Block
+	err    error
+	done   bool
+}
+
+func (it *blockIterator) Next() bool {
+	if it.done {
+		return false
+	}
+
+	select {
+	case block, ok := <-it.blocks:
+		if !ok {
+			it.finish(nil)
+			return false
+		}
+		it.block = block
+		return true
+	case err := <-it.errs:
+		it.finish(err)
+	case <-it.ctx.Done():
+		it.finish(it.ctx.Err())
+		go it.drain()
+	}
+	return false
+}
+
+func (it *blockIterator) Block() *common.Block {
+	return it.block
+}
+
+func (it *blockIterator) Err() error {
+	return it.err
+}
+
+func (it *blockIterator) Close() {
+	if it.done {
+		return
+	}
+	it.finish(nil)
+	go it.drain()
+}
+
+func (it *blockIterator) finish(err error) {
+	it.done = true
+	it.block = nil
+	it.err = err
+	it.cancel()
+}
+
+// drain consumes the deliver stream until it terminates (after the context is
+// cancelled) so that the connection is released
+func (it *blockIterator) drain() {
+	for {
+		select {
+		case _, ok := <-it.blocks:
+			if !ok {
+				return
+			}
+		case <-it.errs:
+			return
+		}
+	}
+}
+
+// newSeekEnvelope returns a signed deliver envelope for the given seek range
+func newSeekEnvelope(reqCtx reqContext.Context, seekRange SeekRange) (*fab.SignedEnvelope, error) {
+	ctx, ok := context.RequestClientContext(reqCtx)
+	if !ok {
+		return nil, errors.New("failed get client context from reqContext for signPayload")
+	}
+	th, err := txn.NewHeader(ctx, seekRange.ChannelID)
+	if err != nil {
+		return nil, errors.Wrap(err, "generating TX ID failed")
+	}
+
+	channelHeader, err := txn.CreateChannelHeader(common.HeaderType_DELIVER_SEEK_INFO, txn.ChannelHeaderOpts{
+		TxnHeader:   th,
+		TLSCertHash: comm.TLSCertHash(ctx.EndpointConfig()),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "CreateChannelHeader failed")
+	}
+
+	seekInfo := &ab.SeekInfo{
+		Start:    &ab.SeekPosition{Type: &ab.SeekPosition_Specified{Specified: &ab.SeekSpecified{Number: seekRange.Start}}},
+		Stop:     &ab.SeekPosition{Type: &ab.SeekPosition_Specified{Specified: &ab.SeekSpecified{Number: seekRange.End}}},
+		Behavior: ab.SeekInfo_BLOCK_UNTIL_READY,
+	}
+	seekInfoBytes, err := proto.Marshal(seekInfo)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal seek info failed")
+	}
+
+	payload, err := txn.CreatePayload(th, channelHeader, seekInfoBytes)
+	if err != nil {
+		return nil, errors.Wrap(err, "CreatePayload failed")
+	}
+
+	return txn.SignPayload(ctx, payload)
+}
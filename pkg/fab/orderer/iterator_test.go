@@ -0,0 +1,107 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package orderer
+
+import (
+	reqContext "context"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	ab "github.com/hyperledger/fabric-sdk-go/internal/github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+	contextImpl "github.com/hyperledger/fabric-sdk-go/pkg/context"
+	mocks "github.com/hyperledger/fabric-sdk-go/pkg/fab/mocks"
+	mspmocks "github.com/hyperledger/fabric-sdk-go/pkg/msp/test/mockmsp"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/common"
+	"github.com/stretchr/testify/assert"
+)
+
+type envelopeRecorder struct {
+	*mocks.MockOrderer
+	envelope *fab.SignedEnvelope
+	ctx      reqContext.Context
+}
+
+func (o *envelopeRecorder) SendDeliver(ctx reqContext.Context, envelope *fab.SignedEnvelope) (chan *common.Block, chan error) {
+	o.ctx = ctx
+	o.envelope = envelope
+	return o.MockOrderer.SendDeliver(ctx, envelope)
+}
+
+func newIteratorTestRequest(t *testing.T) (reqContext.Context, reqContext.CancelFunc) {
+	ctx := mocks.NewMockContext(mspmocks.NewMockSigningIdentity("test", "test"))
+	return contextImpl.NewRequest(ctx, contextImpl.WithTimeout(5*time.Second))
+}
+
+func TestDeliverIterator(t *testing.T) {
+	reqCtx, cancel := newIteratorTestRequest(t)
+	defer cancel()
+
+	o := &envelopeRecorder{MockOrderer: mocks.NewMockOrderer("o1", nil)}
+	o.EnqueueForSendDeliver(newTestBlock(2))
+	o.EnqueueForSendDeliver(newTestBlock(3))
+	o.EnqueueForSendDeliver(common.Status_SUCCESS)
+
+	it, err := deliver(reqCtx, o, SeekRange{ChannelID: "mychannel", Start: 2, End: 3})
+	if err != nil {
+		t.Fatalf("Deliver failed: %s", err)
+	}
+	defer it.Close()
+
+	payload := &common.Payload{}
+	if err := proto.Unmarshal(o.envelope.Payload, payload); err != nil {
+		t.Fatalf("Failed to unmarshal payload: %s", err)
+	}
+	seekInfo := &ab.SeekInfo{}
+	if err := proto.Unmarshal(payload.Data, seekInfo); err != nil {
+		t.Fatalf("Failed to unmarshal seek info: %s", err)
+	}
+	assert.Equal(t, uint64(2), seekInfo.Start.GetSpecified().Number)
+	assert.Equal(t, uint64(3), seekInfo.Stop.GetSpecified().Number)
+
+	var numbers []uint64
+	for it.Next() {
+		numbers = append(numbers, it.Block().Header.Number)
+	}
+	assert.NoError(t, it.Err())
+	assert.Equal(t, []uint64{2, 3}, numbers)
+	assert.False(t, it.Next(), "Expected no more blocks")
+}
+
+func TestDeliverIteratorClose(t *testing.T) {
+	reqCtx, cancel := newIteratorTestRequest(t)
+	defer cancel()
+
+	o := &envelopeRecorder{MockOrderer: mocks.NewMockOrderer("o1", nil)}
+	o.EnqueueForSendDeliver(newTestBlock(0))
+
+	it, err := deliver(reqCtx, o, SeekRange{ChannelID: "mychannel", Start: 0, End: 10})
+	if err != nil {
+		t.Fatalf("Deliver failed: %s", err)
+	}
+	if !it.Next() {
+		t.Fatalf("Expected a block, got error: %v", it.Err())
+	}
+
+	it.Close()
+	assert.Error(t, o.ctx.Err(), "Expected deliver context to be cancelled on close")
+	assert.False(t, it.Next(), "Expected no blocks after close")
+	assert.Nil(t, it.Block())
+	assert.NoError(t, it.Err())
+}
+
+func TestDeliverIteratorInvalidRange(t *testing.T) {
+	reqCtx, cancel := newIteratorTestRequest(t)
+	defer cancel()
+
+	_, err := deliver(reqCtx, mocks.NewMockOrderer("o1", nil), SeekRange{ChannelID: "mychannel", Start: 5, End: 4})
+	assert.Error(t, err, "Expected error for invalid seek range")
+
+	_, err = deliver(reqContext.Background(), mocks.NewMockOrderer("o1", nil), SeekRange{ChannelID: "mychannel"})
+	assert.Error(t, err, "Expected error for missing client context")
+}
@@ -8,7 +8,9 @@ package orderer
 
 import (
 	reqContext "context"
+	"crypto/tls"
 	"crypto/x509"
+	"fmt"
 	"time"
 
 	"github.com/pkg/errors"
@@ -23,9 +25,11 @@ import (
 	"github.com/hyperledger/fabric-sdk-go/pkg/common/errors/status"
 	"github.com/hyperledger/fabric-sdk-go/pkg/common/logging"
 	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/tracing"
 	"github.com/hyperledger/fabric-sdk-go/pkg/context"
 	"github.com/hyperledger/fabric-sdk-go/pkg/core/config/comm"
 	"github.com/hyperledger/fabric-sdk-go/pkg/core/config/endpoint"
+	fabcomm "github.com/hyperledger/fabric-sdk-go/pkg/fab/comm"
 	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/common"
 )
 
@@ -43,12 +47,20 @@ type Orderer struct {
 	url            string
 	serverName     string
 	tlsCACert      *x509.Certificate
+	tlsConfig      *tls.Config
 	grpcDialOption []grpc.DialOption
 	kap            keepalive.ClientParameters
 	dialTimeout    time.Duration
 	failFast       bool
 	allowInsecure  bool
 	commManager    fab.CommManager
+	proxyURL       string
+	dnsResolution  bool
+	balancerPolicy string
+
+	// reportDialTimeout is set if the dial timeout was configured with WithDialTimeout, in which
+	// case a DIAL_TIMEOUT (rather than CONNECTION_FAILED) status is returned when the dial times out
+	reportDialTimeout bool
 }
 
 // Option describes a functional parameter for the New constructor
@@ -75,29 +87,76 @@ func New(config fab.EndpointConfig, opts ...Option) (*Orderer, error) {
 	grpcOpts = append(grpcOpts, grpc.WithDefaultCallOptions(grpc.FailFast(orderer.failFast)))
 	if endpoint.AttemptSecured(orderer.url, orderer.allowInsecure) {
 		//tls config
-		tlsConfig, err := comm.TLSConfig(orderer.tlsCACert, orderer.serverName, config)
+		tlsConfig, err := orderer.newTLSConfig()
 		if err != nil {
 			return nil, err
 		}
-		tlsConfig.VerifyPeerCertificate = func(rawCerts [][]byte, verifiedChains [][]*x509.Certificate) error {
-			return verifier.VerifyPeerCertificate(rawCerts, verifiedChains)
-		}
 
 		grpcOpts = append(grpcOpts, grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)))
 	} else {
+		if orderer.tlsConfig != nil {
+			return nil, errors.Errorf("TLS config provided for insecure orderer [%s]", orderer.url)
+		}
 		grpcOpts = append(grpcOpts, grpc.WithInsecure())
 	}
 
 	grpcOpts = append(grpcOpts, grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(maxCallRecvMsgSize),
 		grpc.MaxCallSendMsgSize(maxCallSendMsgSize)))
 
-	orderer.dialTimeout = config.Timeout(fab.OrdererConnection)
+	grpcOpts = append(grpcOpts, fabcomm.MetadataDialOptions(endpoint.ToAddress(orderer.url))...)
+
+	proxyOpts, err := fabcomm.ProxyDialOptions(orderer.proxyURL)
+	if err != nil {
+		return nil, err
+	}
+	grpcOpts = append(grpcOpts, proxyOpts...)
+
+	balancerOpts, err := fabcomm.BalancerDialOptions(orderer.balancerPolicy)
+	if err != nil {
+		return nil, err
+	}
+	grpcOpts = append(grpcOpts, balancerOpts...)
+
+	orderer.reportDialTimeout = orderer.dialTimeout != 0
+	if orderer.dialTimeout == 0 {
+		orderer.dialTimeout = config.Timeout(fab.OrdererConnection)
+	}
 	orderer.url = endpoint.ToAddress(orderer.url)
 	orderer.grpcDialOption = grpcOpts
 
 	return orderer, nil
 }
 
+// newTLSConfig returns the TLS config provided with WithTLSConfig, or otherwise the TLS config
+// derived from the endpoint config
+func (o *Orderer) newTLSConfig() (*tls.Config, error) {
+	if o.tlsConfig != nil {
+		tlsConfig := o.tlsConfig.Clone()
+		if tlsConfig.ServerName == "" {
+			tlsConfig.ServerName = o.serverName
+		}
+		verifyPeerCertificate := tlsConfig.VerifyPeerCertificate
+		tlsConfig.VerifyPeerCertificate = func(rawCerts [][]byte, verifiedChains [][]*x509.Certificate) error {
+			if verifyPeerCertificate != nil {
+				if err := verifyPeerCertificate(rawCerts, verifiedChains); err != nil {
+					return err
+				}
+			}
+			return verifier.VerifyPeerCertificate(rawCerts, verifiedChains)
+		}
+		return tlsConfig, nil
+	}
+
+	tlsConfig, err := comm.TLSConfig(o.tlsCACert, o.serverName, o.config)
+	if err != nil {
+		return nil, err
+	}
+	tlsConfig.VerifyPeerCertificate = func(rawCerts [][]byte, verifiedChains [][]*x509.Certificate) error {
+		return verifier.VerifyPeerCertificate(rawCerts, verifiedChains)
+	}
+	return tlsConfig, nil
+}
+
 // WithURL is a functional option for the orderer.New constructor that configures the orderer's URL.
 func WithURL(url string) Option {
 	return func(o *Orderer) error {
@@ -116,6 +175,17 @@ func WithTLSCert(tlsCACert *x509.Certificate) Option {
 	}
 }
 
+// WithTLSConfig is a functional option for the orderer.New constructor that configures the TLS settings
+// used to dial the orderer, overriding the TLS settings derived from the endpoint config (e.g. to use a
+// client certificate or root CAs specific to this orderer). The orderer URL must not be insecure.
+func WithTLSConfig(tlsConfig *tls.Config) Option {
+	return func(o *Orderer) error {
+		o.tlsConfig = tlsConfig
+
+		return nil
+	}
+}
+
 // WithServerName is a functional option for the orderer.New constructor that configures the orderer's server name
 func WithServerName(serverName string) Option {
 	return func(o *Orderer) error {
@@ -134,6 +204,87 @@ func WithInsecure() Option {
 	}
 }
 
+// WithDialTimeout is a functional option for the orderer.New constructor that configures the timeout for
+// establishing a connection to the orderer (defaults to the OrdererConnection timeout from config).
+// If the connection can't be established in time then a DIAL_TIMEOUT status error is returned.
+func WithDialTimeout(timeout time.Duration) Option {
+	return func(o *Orderer) error {
+		if timeout <= 0 {
+			return errors.Errorf("invalid dial timeout [%s]", timeout)
+		}
+		o.dialTimeout = timeout
+
+		return nil
+	}
+}
+
+// WithKeepAliveTime is a functional option for the orderer.New constructor that configures the interval
+// after which the client pings the orderer if there is no activity. Keepalive is only enabled if this is set.
+func WithKeepAliveTime(kaTime time.Duration) Option {
+	return func(o *Orderer) error {
+		o.kap.Time = kaTime
+
+		return nil
+	}
+}
+
+// WithKeepAliveTimeout is a functional option for the orderer.New constructor that configures the time
+// the client waits for a keepalive ping ack before closing the connection
+func WithKeepAliveTimeout(kaTimeout time.Duration) Option {
+	return func(o *Orderer) error {
+		o.kap.Timeout = kaTimeout
+
+		return nil
+	}
+}
+
+// WithKeepAlivePermitWithoutStream is a functional option for the orderer.New constructor that configures
+// whether keepalive pings are sent when there are no active streams
+func WithKeepAlivePermitWithoutStream(permit bool) Option {
+	return func(o *Orderer) error {
+		o.kap.PermitWithoutStream = permit
+
+		return nil
+	}
+}
+
+// WithProxy is a functional option for the orderer.New constructor that routes the connection to the orderer
+// through the SOCKS5 (socks5://host:port) or HTTP CONNECT (http://host:port) proxy with the given URL.
+// TLS is still negotiated with (and verified against) the orderer itself.
+func WithProxy(proxyURL string) Option {
+	return func(o *Orderer) error {
+		o.proxyURL = proxyURL
+
+		return nil
+	}
+}
+
+// WithDNSResolution is a functional option for the orderer.New constructor that resolves the host of the
+// orderer's URL with gRPC's DNS resolver, so that a single logical endpoint (e.g. the DNS name of an
+// orderer cluster) maps to the dynamically changing set of nodes behind the name. The name is re-resolved
+// when connections to the nodes fail.
+func WithDNSResolution() Option {
+	return func(o *Orderer) error {
+		o.dnsResolution = true
+
+		return nil
+	}
+}
+
+// WithBalancerPolicy is a functional option for the orderer.New constructor that configures the gRPC
+// load-balancing policy (fabcomm.PickFirstBalancer or fabcomm.RoundRobinBalancer) used when the orderer's
+// URL resolves to multiple addresses (see WithDNSResolution). Defaults to pick first.
+func WithBalancerPolicy(policy string) Option {
+	return func(o *Orderer) error {
+		if err := fabcomm.ValidateBalancerPolicy(policy); err != nil {
+			return err
+		}
+		o.balancerPolicy = policy
+
+		return nil
+	}
+}
+
 // FromOrdererConfig is a functional option for the orderer.New constructor that configures a new orderer
 // from a apiconfig.OrdererConfig struct
 func FromOrdererConfig(ordererCfg *fab.OrdererConfig) Option {
@@ -165,6 +316,9 @@ func FromOrdererConfig(ordererCfg *fab.OrdererConfig) Option {
 		o.kap = getKeepAliveOptions(ordererCfg)
 		o.failFast = getFailFast(ordererCfg)
 		o.allowInsecure = isInsecureConnectionAllowed(ordererCfg)
+		o.proxyURL = getProxyURL(ordererCfg)
+		o.dnsResolution = isDNSResolutionEnabled(ordererCfg)
+		o.balancerPolicy = getBalancerPolicy(ordererCfg)
 
 		return nil
 	}
@@ -191,6 +345,13 @@ func getServerNameOverride(ordererCfg *fab.OrdererConfig) string {
 	return serverNameOverride
 }
 
+func getProxyURL(ordererCfg *fab.OrdererConfig) string {
+	if str, ok := ordererCfg.GRPCOptions["proxy-url"].(string); ok {
+		return str
+	}
+	return ""
+}
+
 func getFailFast(ordererCfg *fab.OrdererConfig) bool {
 
 	var failFast = true
@@ -215,6 +376,20 @@ func getKeepAliveOptions(ordererCfg *fab.OrdererConfig) keepalive.ClientParamete
 	return kap
 }
 
+func getBalancerPolicy(ordererCfg *fab.OrdererConfig) string {
+	if str, ok := ordererCfg.GRPCOptions["balancer-policy"].(string); ok {
+		return str
+	}
+	return ""
+}
+
+func isDNSResolutionEnabled(ordererCfg *fab.OrdererConfig) bool {
+	if dns, ok := ordererCfg.GRPCOptions["dns-resolution"]; ok {
+		return cast.ToBool(dns)
+	}
+	return false
+}
+
 func isInsecureConnectionAllowed(ordererCfg *fab.OrdererConfig) bool {
 	allowInsecure, ok := ordererCfg.GRPCOptions["allow-insecure"].(bool)
 	if ok {
@@ -225,7 +400,7 @@ func isInsecureConnectionAllowed(ordererCfg *fab.OrdererConfig) bool {
 
 func (o *Orderer) conn(ctx reqContext.Context) (*grpc.ClientConn, error) {
 	// Establish connection to Ordering Service
-	ctx, cancel := reqContext.WithTimeout(ctx, o.dialTimeout)
+	dialCtx, cancel := reqContext.WithTimeout(ctx, o.dialTimeout)
 	defer cancel()
 
 	commManager, ok := context.RequestCommManager(ctx)
@@ -233,7 +408,18 @@ func (o *Orderer) conn(ctx reqContext.Context) (*grpc.ClientConn, error) {
 		commManager = o.commManager
 	}
 
-	return commManager.DialContext(ctx, o.url, o.grpcDialOption...)
+	conn, err := commManager.DialContext(dialCtx, fabcomm.DialTarget(o.url, o.dnsResolution), o.grpcDialOption...)
+	if err != nil && o.reportDialTimeout && isDialTimeout(ctx, dialCtx) {
+		return nil, status.New(status.OrdererClientStatus, status.DialTimeout.ToInt32(),
+			fmt.Sprintf("dial timed out after %s: %s", o.dialTimeout, err), []interface{}{o.url})
+	}
+	return conn, err
+}
+
+// isDialTimeout returns true if the dial timed out before the request context was done, i.e. the
+// connection could not be established within the dial timeout (as opposed to the request timing out)
+func isDialTimeout(ctx, dialCtx reqContext.Context) bool {
+	return dialCtx.Err() == reqContext.DeadlineExceeded && ctx.Err() == nil
 }
 
 func (o *Orderer) releaseConn(ctx reqContext.Context, conn *grpc.ClientConn) {
@@ -251,10 +437,33 @@ func (o *Orderer) URL() string {
 	return o.url
 }
 
+// Health checks whether a connection to the orderer can be established
+func (o *Orderer) Health(ctx reqContext.Context) error {
+	conn, err := o.conn(ctx)
+	if err != nil {
+		if s, ok := err.(*status.Status); ok {
+			return s
+		}
+		rpcStatus, ok := grpcstatus.FromError(err)
+		if ok {
+			return errors.WithMessage(status.NewFromGRPCStatus(rpcStatus), "connection failed")
+		}
+		return status.New(status.OrdererClientStatus, status.ConnectionFailed.ToInt32(), err.Error(), []interface{}{o.url})
+	}
+	o.releaseConn(ctx, conn)
+
+	return nil
+}
+
 // SendBroadcast Send the created transaction to Orderer.
 func (o *Orderer) SendBroadcast(ctx reqContext.Context, envelope *fab.SignedEnvelope) (*common.Status, error) {
+	logger.Debugw("Broadcasting envelope", context.LogFields(ctx, logging.EndpointURL(o.url))...)
+
 	conn, err := o.conn(ctx)
 	if err != nil {
+		if s, ok := err.(*status.Status); ok {
+			return nil, s
+		}
 		rpcStatus, ok := grpcstatus.FromError(err)
 		if ok {
 			return nil, errors.WithMessage(status.NewFromGRPCStatus(rpcStatus), "connection failed")
@@ -321,40 +530,60 @@ func broadcastStream(broadcastClient ab.AtomicBroadcast_BroadcastClient, respons
 // blocks requested
 // envelope: contains the seek request for blocks
 func (o *Orderer) SendDeliver(ctx reqContext.Context, envelope *fab.SignedEnvelope) (chan *common.Block, chan error) {
+	responses, errs, _ := o.SendDeliverWithConnectionInfo(ctx, envelope)
+	return responses, errs
+}
+
+// SendDeliverWithConnectionInfo sends a deliver request to the ordering service like SendDeliver
+// and additionally returns the metadata of the connection over which the blocks are delivered.
+// The connection info is nil if the deliver stream could not be established.
+func (o *Orderer) SendDeliverWithConnectionInfo(ctx reqContext.Context, envelope *fab.SignedEnvelope) (chan *common.Block, chan error, *ConnectionInfo) {
 
 	responses := make(chan *common.Block)
 	errs := make(chan error, 1)
 
+	ctx, span := tracing.StartSpan(ctx, "orderer.SendDeliver")
+	span.SetAttribute(tracing.TargetsKey, o.url)
+
 	conn, err := o.conn(ctx)
 	if err != nil {
-		rpcStatus, ok := grpcstatus.FromError(err)
-		if ok {
-			errs <- errors.WithMessage(status.NewFromGRPCStatus(rpcStatus), "connection failed")
-			return responses, errs
+		if s, ok := err.(*status.Status); ok {
+			err = s
+		} else if rpcStatus, ok := grpcstatus.FromError(err); ok {
+			err = errors.WithMessage(status.NewFromGRPCStatus(rpcStatus), "connection failed")
+		} else {
+			err = status.New(status.OrdererClientStatus, status.ConnectionFailed.ToInt32(), err.Error(), nil)
 		}
-
-		errs <- status.New(status.OrdererClientStatus, status.ConnectionFailed.ToInt32(), err.Error(), nil)
-		return responses, errs
+		tracing.EndSpan(span, err)
+		errs <- err
+		return responses, errs, nil
 	}
 
 	// Create atomic broadcast client
 	broadcastClient, err := ab.NewAtomicBroadcastClient(conn).Deliver(ctx)
 	if err != nil {
-		logger.Errorf("deliver failed [%s]", err)
+		logger.Errorw("Deliver failed", context.LogFields(ctx, logging.EndpointURL(o.url), logging.NewField("error", err))...)
 		o.releaseConn(ctx, conn)
 
-		errs <- errors.Wrap(err, "deliver failed")
-		return responses, errs
+		err = errors.Wrap(err, "deliver failed")
+		tracing.EndSpan(span, err)
+		errs <- err
+		return responses, errs, nil
 	}
+	connInfo := newConnectionInfo(broadcastClient.Context(), o.url)
 
 	// Receive blocks from the GRPC stream and put them on the channel
 	go func() {
-		blockStream(broadcastClient, responses, errs)
+		err := blockStream(broadcastClient, responses)
+		if err != nil {
+			errs <- err
+		}
 		o.releaseConn(ctx, conn)
+		tracing.EndSpan(span, err)
 	}()
 
 	// Send block request envelope
-	logger.Debugf("Requesting blocks from ordering service")
+	logger.Debugw("Requesting blocks from ordering service", context.LogFields(ctx, logging.EndpointURL(o.url))...)
 	err = broadcastClient.Send(&common.Envelope{
 		Payload:   envelope.Payload,
 		Signature: envelope.Signature,
@@ -363,22 +592,23 @@ func (o *Orderer) SendDeliver(ctx reqContext.Context, envelope *fab.SignedEnvelo
 		o.releaseConn(ctx, conn)
 
 		errs <- errors.Wrap(err, "failed to send block request to orderer")
-		return responses, errs
+		return responses, errs, connInfo
 	}
 
 	if err = broadcastClient.CloseSend(); err != nil {
 		logger.Debugf("unable to close deliver client [%s]", err)
 	}
 
-	return responses, errs
+	return responses, errs, connInfo
 }
 
-func blockStream(deliverClient ab.AtomicBroadcast_DeliverClient, responses chan *common.Block, errs chan error) {
+// blockStream puts the blocks received from the ordering service on the responses channel until
+// the stream completes (in which case the channel is closed) or fails (in which case the error is returned)
+func blockStream(deliverClient ab.AtomicBroadcast_DeliverClient, responses chan *common.Block) error {
 	for {
 		response, err := deliverClient.Recv()
 		if err != nil {
-			errs <- errors.Wrap(err, "recv from ordering service failed")
-			return
+			return errors.Wrap(err, "recv from ordering service failed")
 		}
 		// Assert response type
 		switch t := response.Type.(type) {
@@ -386,11 +616,10 @@ func blockStream(deliverClient ab.AtomicBroadcast_DeliverClient, responses chan
 		case *ab.DeliverResponse_Status:
 			logger.Debugf("Received deliver response status from ordering service: %s", t.Status)
 			if t.Status != common.Status_SUCCESS {
-				errs <- status.New(status.OrdererServerStatus, int32(t.Status), "error status from ordering service", []interface{}{})
-				return
+				return status.New(status.OrdererServerStatus, int32(t.Status), "error status from ordering service", []interface{}{})
 			}
 			close(responses)
-			return
+			return nil
 
 		// Response is a requested block
 		case *ab.DeliverResponse_Block:
@@ -398,8 +627,7 @@ func blockStream(deliverClient ab.AtomicBroadcast_DeliverClient, responses chan
 			responses <- response.GetBlock()
 		// Unknown response
 		default:
-			errs <- errors.Errorf("unknown response type from ordering service %T", t)
-			return
+			return errors.Errorf("unknown response type from ordering service %T", t)
 		}
 	}
 }
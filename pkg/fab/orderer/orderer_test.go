@@ -8,6 +8,7 @@ package orderer
 
 import (
 	reqContext "context"
+	"crypto/tls"
 	"crypto/x509"
 	"fmt"
 	"net"
@@ -159,6 +160,23 @@ func TestNewOrdererWithTLS(t *testing.T) {
 	}
 }
 
+func TestNewOrdererWithTLSConfig(t *testing.T) {
+	// TLS config from the endpoint config would fail, the provided TLS config is used instead
+	orderer, err := New(mocks.NewMockEndpointConfigCustomized(true, false, true), WithURL("grpcs://"),
+		WithTLSConfig(&tls.Config{RootCAs: x509.NewCertPool()}))
+
+	if orderer == nil || err != nil {
+		t.Fatalf("Testing New with TLS config failed, cause [%s]", err)
+	}
+
+	//Negative Test case
+	orderer, err = New(mocks.NewMockEndpointConfig(), WithURL("grpc://"+testOrdererURL), WithTLSConfig(&tls.Config{}))
+
+	if orderer != nil || err == nil {
+		t.Fatalf("Testing New with TLS config and insecure URL was supposed to fail")
+	}
+}
+
 func TestNewOrdererWithMutualTLS(t *testing.T) {
 	//Positive Test case
 	tlsConfig := endpoint.TLSConfig{Path: "../../../test/fixtures/fabricca/tls/ca/ca_root.pem"}
@@ -191,6 +209,77 @@ func TestSendBroadcastHappy(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+func TestSendBroadcastWithDNSResolution(t *testing.T) {
+	_, port, err := net.SplitHostPort(ordererAddr)
+	assert.Nil(t, err)
+
+	ordererConfig := getGRPCOpts(net.JoinHostPort("localhost", port), true, false, true)
+	ordererConfig.GRPCOptions["dns-resolution"] = true
+	orderer, err := New(mocks.NewMockEndpointConfig(), FromOrdererConfig(ordererConfig))
+	assert.Nil(t, err)
+	assert.True(t, orderer.dnsResolution)
+
+	_, err = orderer.SendBroadcast(reqContext.Background(), &fab.SignedEnvelope{})
+	assert.Nil(t, err)
+}
+
+func TestOrdererBalancerPolicy(t *testing.T) {
+	_, err := New(mocks.NewMockEndpointConfig(), WithURL("grpc://"+ordererAddr), WithBalancerPolicy("random"))
+	assert.Error(t, err, "expected error for unsupported balancer policy")
+
+	ordererConfig := getGRPCOpts(ordererAddr, true, false, true)
+	ordererConfig.GRPCOptions["balancer-policy"] = "random"
+	_, err = New(mocks.NewMockEndpointConfig(), FromOrdererConfig(ordererConfig))
+	assert.Error(t, err, "expected error for unsupported balancer policy in config")
+
+	ordererConfig.GRPCOptions["balancer-policy"] = "round_robin"
+	ordererConfig.GRPCOptions["dns-resolution"] = true
+	orderer, err := New(mocks.NewMockEndpointConfig(), FromOrdererConfig(ordererConfig))
+	assert.Nil(t, err)
+	assert.Equal(t, "round_robin", orderer.balancerPolicy)
+
+	_, err = orderer.SendBroadcast(reqContext.Background(), &fab.SignedEnvelope{})
+	assert.Nil(t, err)
+}
+
+func TestOrdererDialTimeout(t *testing.T) {
+	_, err := New(mocks.NewMockEndpointConfig(), WithURL("grpc://"+testOrdererURL), WithDialTimeout(-1))
+	assert.Error(t, err, "expected error for invalid dial timeout")
+
+	orderer, err := New(mocks.NewMockEndpointConfig(), WithURL("grpc://"+testOrdererURL+"Test"), WithInsecure(), WithDialTimeout(100*time.Millisecond))
+	assert.Nil(t, err)
+	assert.Equal(t, 100*time.Millisecond, orderer.dialTimeout)
+
+	ctx, cancel := reqContext.WithTimeout(reqContext.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err = orderer.SendBroadcast(ctx, &fab.SignedEnvelope{})
+	statusError, ok := status.FromError(err)
+	assert.True(t, ok, "Expected status error")
+	assert.Equal(t, status.OrdererClientStatus, statusError.Group)
+	assert.EqualValues(t, status.DialTimeout.ToInt32(), statusError.Code)
+}
+
+func TestOrdererHealth(t *testing.T) {
+	ordererConfig := getGRPCOpts(ordererAddr, true, false, true)
+	orderer, err := New(mocks.NewMockEndpointConfig(), FromOrdererConfig(ordererConfig))
+	assert.Nil(t, err)
+
+	var hc fab.HealthChecker = orderer
+	assert.Nil(t, hc.Health(reqContext.Background()), "expected orderer to be healthy")
+
+	ordererConfig = getGRPCOpts(testOrdererURL+"Test", true, false, true)
+	orderer, err = New(mocks.NewMockEndpointConfig(), FromOrdererConfig(ordererConfig))
+	assert.Nil(t, err)
+	orderer.dialTimeout = 15
+
+	err = orderer.Health(reqContext.Background())
+	statusError, ok := status.FromError(err)
+	assert.True(t, ok, "Expected status error")
+	assert.Equal(t, status.OrdererClientStatus, statusError.Group)
+	assert.EqualValues(t, status.ConnectionFailed.ToInt32(), statusError.Code)
+}
+
 func TestSendBroadcastTimeout(t *testing.T) {
 
 	ordererConfig := getGRPCOpts(testOrdererURL+"Test", true, false, true)
@@ -270,6 +359,48 @@ func TestSendDeliverServerSuccessResponse(t *testing.T) {
 	}
 }
 
+func TestSendDeliverWithConnectionInfo(t *testing.T) {
+
+	broadcastServer := mocks.MockBroadcastServer{
+		DeliverResponse: &ab.DeliverResponse{
+			Type: &ab.DeliverResponse_Status{
+				Status: common.Status_SUCCESS,
+			},
+		},
+	}
+
+	grpcServer := grpc.NewServer()
+	defer grpcServer.Stop()
+	addr := startCustomizedMockServer(t, testOrdererURL, grpcServer, &broadcastServer)
+
+	orderer, _ := New(mocks.NewMockEndpointConfig(), WithURL("grpc://"+addr), WithInsecure())
+
+	ctx, cancel := reqContext.WithTimeout(reqContext.Background(), 5*time.Second)
+	defer cancel()
+	blocks, errors, connInfo := orderer.SendDeliverWithConnectionInfo(ctx, &fab.SignedEnvelope{})
+
+	if connInfo == nil {
+		t.Fatalf("Expected connection info")
+	}
+	assert.Equal(t, addr, connInfo.URL)
+	assert.Equal(t, addr, connInfo.Address)
+	assert.Zero(t, connInfo.TLSVersion, "Expected no TLS version for insecure connection")
+	assert.Empty(t, connInfo.PeerCertSubject)
+
+	select {
+	case <-blocks:
+	case err := <-errors:
+		t.Fatalf("This usecase was not supposed to get error : %s ", err.Error())
+	case <-time.After(time.Second * 5):
+		t.Fatalf("Did not receive response from SendDeliver")
+	}
+
+	// no connection info if the orderer can't be reached
+	orderer, _ = New(mocks.NewMockEndpointConfig(), WithURL("grpc://"+testOrdererURL+"Test"), WithInsecure())
+	_, _, connInfo = orderer.SendDeliverWithConnectionInfo(ctx, &fab.SignedEnvelope{})
+	assert.Nil(t, connInfo)
+}
+
 func TestSendDeliverFailure(t *testing.T) {
 
 	broadcastServer := mocks.MockBroadcastServer{
@@ -385,6 +516,24 @@ func TestGetKeepAliveOptions(t *testing.T) {
 
 }
 
+func TestKeepAliveOptions(t *testing.T) {
+	orderer, err := New(mocks.NewMockEndpointConfig(), WithURL("grpc://"+testOrdererURL), WithInsecure(),
+		WithKeepAliveTime(10*time.Second), WithKeepAliveTimeout(3*time.Second), WithKeepAlivePermitWithoutStream(true))
+	if err != nil {
+		t.Fatalf("Failed to create orderer: %s", err)
+	}
+	assert.Equal(t, 10*time.Second, orderer.kap.Time)
+	assert.Equal(t, 3*time.Second, orderer.kap.Timeout)
+	assert.True(t, orderer.kap.PermitWithoutStream)
+
+	// keepalive dial option is added in addition to fail-fast, transport and message size options
+	orderer2, err := New(mocks.NewMockEndpointConfig(), WithURL("grpc://"+testOrdererURL), WithInsecure())
+	if err != nil {
+		t.Fatalf("Failed to create orderer: %s", err)
+	}
+	assert.Equal(t, len(orderer2.grpcDialOption)+1, len(orderer.grpcDialOption))
+}
+
 func TestFailFast(t *testing.T) {
 	grpcOpts := make(map[string]interface{})
 	ordererConfig := &fab.OrdererConfig{
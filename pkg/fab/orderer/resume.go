@@ -0,0 +1,151 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package orderer
+
+import (
+	reqContext "context"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/errors/status"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/common"
+	"github.com/pkg/errors"
+)
+
+// SeekEnvelopeFunc returns a signed deliver envelope which seeks blocks starting at the given block number
+type SeekEnvelopeFunc func(startBlock uint64) (*fab.SignedEnvelope, error)
+
+type deliverOpts struct {
+	seekEnvelope  SeekEnvelopeFunc
+	maxReconnects int
+	lastBlock     *uint64
+}
+
+// DeliverOption describes a functional parameter for SendDeliverWithResume
+type DeliverOption func(*deliverOpts)
+
+// WithReconnect reconnects up to maxReconnects times if the deliver stream breaks. The seek envelope
+// for the reconnect is obtained from the given function, starting at the block following the last
+// block received. If the stream breaks before any block was received, the original envelope is sent again.
+func WithReconnect(maxReconnects int, seekEnvelope SeekEnvelopeFunc) DeliverOption {
+	return func(opts *deliverOpts) {
+		opts.maxReconnects = maxReconnects
+		opts.seekEnvelope = seekEnvelope
+	}
+}
+
+// WithLastBlock specifies the number of the last block already received by the caller. Blocks up to and
+// including this block are not delivered again and a reconnect resumes at the following block.
+func WithLastBlock(blockNumber uint64) DeliverOption {
+	return func(opts *deliverOpts) {
+		opts.lastBlock = &blockNumber
+	}
+}
+
+// SendDeliverWithResume sends a deliver request to the ordering service like SendDeliver. If the
+// stream breaks it reconnects, according to the given options, resuming at the block following the last
+// block received so that no block is delivered twice.
+func (o *Orderer) SendDeliverWithResume(ctx reqContext.Context, envelope *fab.SignedEnvelope, opts ...DeliverOption) (chan *common.Block, chan error) {
+	return sendDeliverWithResume(ctx, o, envelope, opts...)
+}
+
+func sendDeliverWithResume(ctx reqContext.Context, orderer fab.Orderer, envelope *fab.SignedEnvelope, opts ...DeliverOption) (chan *common.Block, chan error) {
+	dopts := deliverOpts{}
+	for _, opt := range opts {
+		opt(&dopts)
+	}
+
+	responses := make(chan *common.Block)
+	errs := make(chan error, 1)
+
+	go func() {
+		resumer := &deliverResumer{orderer: orderer, opts: dopts, responses: responses}
+		if err := resumer.deliver(ctx, envelope); err != nil {
+			errs <- err
+			return
+		}
+		close(responses)
+	}()
+
+	return responses, errs
+}
+
+type deliverResumer struct {
+	orderer   fab.Orderer
+	opts      deliverOpts
+	responses chan *common.Block
+	// highWater the number of the last block delivered (if hasHighWater is set)
+	highWater    uint64
+	hasHighWater bool
+}
+
+func (r *deliverResumer) deliver(ctx reqContext.Context, envelope *fab.SignedEnvelope) error {
+	if r.opts.lastBlock != nil {
+		r.highWater, r.hasHighWater = *r.opts.lastBlock, true
+	}
+
+	for reconnects := 0; ; reconnects++ {
+		err := r.forward(ctx, envelope)
+		if err == nil {
+			return nil
+		}
+		if !r.resumable(ctx, err, reconnects) {
+			return err
+		}
+
+		if !r.hasHighWater {
+			// Nothing was received yet so the original seek position still applies
+			logger.Debugf("Deliver stream from orderer [%s] broke before any block was received, resending the original request: %s", r.orderer.URL(), err)
+			continue
+		}
+
+		start := r.highWater + 1
+		logger.Debugf("Deliver stream from orderer [%s] broke, resuming at block [%d]: %s", r.orderer.URL(), start, err)
+
+		envelope, err = r.opts.seekEnvelope(start)
+		if err != nil {
+			return errors.WithMessage(err, "creating seek envelope for resume failed")
+		}
+	}
+}
+
+// forward forwards the blocks of a single deliver stream, skipping blocks that have already been delivered
+func (r *deliverResumer) forward(ctx reqContext.Context, envelope *fab.SignedEnvelope) error {
+	blocks, errs := r.orderer.SendDeliver(ctx, envelope)
+	for {
+		select {
+		case block, ok := <-blocks:
+			if !ok {
+				return nil
+			}
+			number := block.GetHeader().GetNumber()
+			if r.hasHighWater && number <= r.highWater {
+				logger.Debugf("Skipping block [%d] which has already been delivered", number)
+				continue
+			}
+			select {
+			case r.responses <- block:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+			r.highWater, r.hasHighWater = number, true
+		case err := <-errs:
+			return err
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// resumable determines if the deliver should be resumed after the given error. Errors returned
+// by the ordering service itself are not resumed since the orderer would return them again.
+func (r *deliverResumer) resumable(ctx reqContext.Context, err error, reconnects int) bool {
+	if r.opts.seekEnvelope == nil || reconnects >= r.opts.maxReconnects || ctx.Err() != nil {
+		return false
+	}
+	s, ok := status.FromError(err)
+	return !ok || s.Group != status.OrdererServerStatus
+}
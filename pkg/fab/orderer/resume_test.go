@@ -0,0 +1,138 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package orderer
+
+import (
+	reqContext "context"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+	mocks "github.com/hyperledger/fabric-sdk-go/pkg/fab/mocks"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/common"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestBlock(number uint64) *common.Block {
+	return &common.Block{Header: &common.BlockHeader{Number: number}}
+}
+
+func receiveBlocks(t *testing.T, ctx reqContext.Context, blocks chan *common.Block, errs chan error) ([]uint64, error) {
+	var numbers []uint64
+	for {
+		select {
+		case block, ok := <-blocks:
+			if !ok {
+				return numbers, nil
+			}
+			numbers = append(numbers, block.Header.Number)
+		case err := <-errs:
+			return numbers, err
+		case <-ctx.Done():
+			t.Fatal("Timed out waiting for blocks")
+		}
+	}
+}
+
+func TestSendDeliverWithResume(t *testing.T) {
+	o := mocks.NewMockOrderer("o1", nil)
+	o.EnqueueForSendDeliver(newTestBlock(1))
+	o.EnqueueForSendDeliver(newTestBlock(2))
+	o.EnqueueForSendDeliver(errors.New("stream broken"))
+	// the orderer re-streams block 2 which must not be delivered again
+	o.EnqueueForSendDeliver(newTestBlock(2))
+	o.EnqueueForSendDeliver(newTestBlock(3))
+	o.EnqueueForSendDeliver(common.Status_SUCCESS)
+
+	var starts []uint64
+	seek := func(start uint64) (*fab.SignedEnvelope, error) {
+		starts = append(starts, start)
+		return &fab.SignedEnvelope{}, nil
+	}
+
+	ctx, cancel := reqContext.WithTimeout(reqContext.Background(), 5*time.Second)
+	defer cancel()
+
+	blocks, errs := sendDeliverWithResume(ctx, o, &fab.SignedEnvelope{}, WithReconnect(1, seek))
+	numbers, err := receiveBlocks(t, ctx, blocks, errs)
+	assert.NoError(t, err)
+	assert.Equal(t, []uint64{1, 2, 3}, numbers)
+	// the mock orderer doesn't break the stream so the exact position depends on scheduling
+	if len(starts) != 1 || starts[0] < 2 {
+		t.Fatalf("Expected a single resume after the last received block, got %v", starts)
+	}
+}
+
+func TestSendDeliverWithResumeLastBlock(t *testing.T) {
+	o := mocks.NewMockOrderer("o1", nil)
+	o.EnqueueForSendDeliver(newTestBlock(4))
+	o.EnqueueForSendDeliver(newTestBlock(5))
+	o.EnqueueForSendDeliver(newTestBlock(6))
+	o.EnqueueForSendDeliver(common.Status_SUCCESS)
+
+	ctx, cancel := reqContext.WithTimeout(reqContext.Background(), 5*time.Second)
+	defer cancel()
+
+	blocks, errs := sendDeliverWithResume(ctx, o, &fab.SignedEnvelope{}, WithLastBlock(5))
+	numbers, err := receiveBlocks(t, ctx, blocks, errs)
+	assert.NoError(t, err)
+	assert.Equal(t, []uint64{6}, numbers)
+}
+
+func TestSendDeliverWithResumeExhausted(t *testing.T) {
+	o := mocks.NewMockOrderer("o1", nil)
+	o.EnqueueForSendDeliver(errors.New("stream broken"))
+	o.EnqueueForSendDeliver(errors.New("stream broken again"))
+
+	seek := func(start uint64) (*fab.SignedEnvelope, error) {
+		t.Errorf("Unexpected seek envelope for block %d when no block was received", start)
+		return nil, errors.New("unexpected seek")
+	}
+
+	ctx, cancel := reqContext.WithTimeout(reqContext.Background(), 5*time.Second)
+	defer cancel()
+
+	blocks, errs := sendDeliverWithResume(ctx, o, &fab.SignedEnvelope{}, WithReconnect(1, seek))
+	_, err := receiveBlocks(t, ctx, blocks, errs)
+	if err == nil || err.Error() != "stream broken again" {
+		t.Fatalf("Expected error after reconnects were exhausted, got %v", err)
+	}
+}
+
+// deliverRecorder records all of the envelopes sent to SendDeliver
+type deliverRecorder struct {
+	*mocks.MockOrderer
+	envelopes []*fab.SignedEnvelope
+}
+
+func (o *deliverRecorder) SendDeliver(ctx reqContext.Context, envelope *fab.SignedEnvelope) (chan *common.Block, chan error) {
+	o.envelopes = append(o.envelopes, envelope)
+	return o.MockOrderer.SendDeliver(ctx, envelope)
+}
+
+func TestSendDeliverWithResumeBeforeFirstBlock(t *testing.T) {
+	o := &deliverRecorder{MockOrderer: mocks.NewMockOrderer("o1", nil)}
+	o.EnqueueForSendDeliver(errors.New("stream broken"))
+	o.EnqueueForSendDeliver(newTestBlock(7))
+	o.EnqueueForSendDeliver(common.Status_SUCCESS)
+
+	seek := func(start uint64) (*fab.SignedEnvelope, error) {
+		t.Errorf("Unexpected seek envelope for block %d when no block was received", start)
+		return nil, errors.New("unexpected seek")
+	}
+
+	ctx, cancel := reqContext.WithTimeout(reqContext.Background(), 5*time.Second)
+	defer cancel()
+
+	envelope := &fab.SignedEnvelope{Payload: []byte("seek newest")}
+	blocks, errs := sendDeliverWithResume(ctx, o, envelope, WithReconnect(1, seek))
+	numbers, err := receiveBlocks(t, ctx, blocks, errs)
+	assert.NoError(t, err)
+	assert.Equal(t, []uint64{7}, numbers)
+	assert.Equal(t, []*fab.SignedEnvelope{envelope, envelope}, o.envelopes, "Expected the original envelope to be sent again")
+}
@@ -0,0 +1,262 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package peer
+
+import (
+	reqContext "context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"hash"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+)
+
+// ConnectionPool shares gRPC connections between peers which target the same URL with
+// the same TLS settings and proxy. Connections are reference counted and are kept open while idle
+// so that they can be reused by subsequent requests, until the pool is closed or, if an idle
+// timeout is configured, until they have been unused for longer than the idle timeout.
+type ConnectionPool struct {
+	mutex       sync.Mutex
+	conns       map[string]*pooledConn
+	index       map[*grpc.ClientConn]*pooledConn
+	closed      bool
+	idleTimeout time.Duration
+}
+
+type pooledConn struct {
+	key      string
+	conn     *grpc.ClientConn
+	refCount int
+	// idleSince is the time at which the last usage of the connection was released
+	idleSince time.Time
+	// idleTimer closes the connection once it has been idle for the pool's idle timeout
+	idleTimer *time.Timer
+}
+
+// PoolOption describes a functional parameter for the NewConnectionPool constructor
+type PoolOption func(*ConnectionPool)
+
+// NewConnectionPool returns a new ConnectionPool
+func NewConnectionPool(opts ...PoolOption) *ConnectionPool {
+	cp := &ConnectionPool{
+		conns: make(map[string]*pooledConn),
+		index: make(map[*grpc.ClientConn]*pooledConn),
+	}
+	for _, opt := range opts {
+		opt(cp)
+	}
+	return cp
+}
+
+// WithIdleTimeout closes pooled connections which have not been in use (i.e. all of their usages
+// have been released) for the given duration. Obtaining the connection again resets the idle timer.
+// By default, idle connections are kept open until the pool is closed.
+func WithIdleTimeout(idleTimeout time.Duration) PoolOption {
+	return func(cp *ConnectionPool) {
+		cp.idleTimeout = idleTimeout
+	}
+}
+
+// WithConnectionPool is a functional option for the peer.New constructor that configures the peer
+// to obtain its connections from the given pool. Note that a comm manager set on the request
+// context takes precedence over the pool.
+func WithConnectionPool(pool *ConnectionPool) Option {
+	return func(p *Peer) error {
+		p.connPool = pool
+
+		return nil
+	}
+}
+
+// ClosePool closes all connections of the pool. Connections can no longer be obtained from the pool once closed.
+func (cp *ConnectionPool) ClosePool() {
+	cp.mutex.Lock()
+	defer cp.mutex.Unlock()
+
+	if cp.closed {
+		return
+	}
+	cp.closed = true
+
+	for _, c := range cp.conns {
+		if c.refCount > 0 {
+			logger.Debugf("Closing pooled connection [%s] which is still in use", c.key)
+		}
+		c.stopIdleTimer()
+		closePooledConn(c.conn)
+	}
+	cp.conns = make(map[string]*pooledConn)
+	cp.index = make(map[*grpc.ClientConn]*pooledConn)
+}
+
+// acquire returns the pooled connection for the given key, dialing a new connection if required
+func (cp *ConnectionPool) acquire(ctx reqContext.Context, key string, target string, opts ...grpc.DialOption) (*grpc.ClientConn, error) {
+	if conn, ok, err := cp.load(key); ok || err != nil {
+		return conn, err
+	}
+
+	logger.Debugf("Dialing pooled connection [%s]", target)
+	conn, err := grpc.DialContext(ctx, target, append(opts, grpc.WithBlock())...)
+	if err != nil {
+		return nil, err
+	}
+
+	cp.mutex.Lock()
+	defer cp.mutex.Unlock()
+
+	if cp.closed {
+		closePooledConn(conn)
+		return nil, errors.New("connection pool is closed")
+	}
+
+	// Another request may have dialed the same connection concurrently
+	if c, ok := cp.conns[key]; ok {
+		if c.conn.GetState() != connectivity.Shutdown {
+			closePooledConn(conn)
+			c.stopIdleTimer()
+			c.refCount++
+			return c.conn, nil
+		}
+		delete(cp.index, c.conn)
+	}
+
+	c := &pooledConn{key: key, conn: conn, refCount: 1}
+	cp.conns[key] = c
+	cp.index[conn] = c
+	return conn, nil
+}
+
+func (cp *ConnectionPool) load(key string) (*grpc.ClientConn, bool, error) {
+	cp.mutex.Lock()
+	defer cp.mutex.Unlock()
+
+	if cp.closed {
+		return nil, false, errors.New("connection pool is closed")
+	}
+
+	c, ok := cp.conns[key]
+	if !ok {
+		return nil, false, nil
+	}
+	if c.conn.GetState() == connectivity.Shutdown {
+		logger.Debugf("Removing shut down pooled connection [%s]", key)
+		c.stopIdleTimer()
+		delete(cp.conns, key)
+		delete(cp.index, c.conn)
+		return nil, false, nil
+	}
+
+	c.stopIdleTimer()
+	c.refCount++
+	return c.conn, true, nil
+}
+
+// release decrements the reference count of the given pooled connection
+func (cp *ConnectionPool) release(conn *grpc.ClientConn) {
+	cp.mutex.Lock()
+	defer cp.mutex.Unlock()
+
+	c, ok := cp.index[conn]
+	if !ok {
+		// The pool has been closed or the connection was replaced
+		closePooledConn(conn)
+		return
+	}
+	if c.refCount > 0 {
+		c.refCount--
+	}
+	if c.refCount == 0 && cp.idleTimeout > 0 {
+		c.idleSince = time.Now()
+		c.stopIdleTimer()
+		c.idleTimer = time.AfterFunc(cp.idleTimeout, func() { cp.evict(c) })
+	}
+}
+
+// evict closes and removes the given connection if it is still idle
+func (cp *ConnectionPool) evict(c *pooledConn) {
+	cp.mutex.Lock()
+	defer cp.mutex.Unlock()
+
+	if cp.index[c.conn] != c || c.refCount > 0 || time.Since(c.idleSince) < cp.idleTimeout {
+		// The connection was closed, replaced or used again in the meantime
+		return
+	}
+
+	logger.Debugf("Closing pooled connection [%s] which has been idle for %s", c.key, cp.idleTimeout)
+	delete(cp.conns, c.key)
+	delete(cp.index, c.conn)
+	closePooledConn(c.conn)
+}
+
+func (c *pooledConn) stopIdleTimer() {
+	if c.idleTimer != nil {
+		c.idleTimer.Stop()
+		c.idleTimer = nil
+	}
+}
+
+func closePooledConn(conn *grpc.ClientConn) {
+	if conn.GetState() == connectivity.Shutdown {
+		return
+	}
+	if err := conn.Close(); err != nil {
+		logger.Debugf("unable to close pooled connection [%s]", err)
+	}
+}
+
+// pooledCommManager is a fab.CommManager which obtains connections from a ConnectionPool
+type pooledCommManager struct {
+	pool *ConnectionPool
+	key  string
+}
+
+func (m *pooledCommManager) DialContext(ctx reqContext.Context, target string, opts ...grpc.DialOption) (*grpc.ClientConn, error) {
+	return m.pool.acquire(ctx, m.key, target, opts...)
+}
+
+func (m *pooledCommManager) ReleaseConn(conn *grpc.ClientConn) {
+	m.pool.release(conn)
+}
+
+// connectionKey returns the pool key for the given peer. Peers share a connection only if they have the same
+// URL and the same dial settings (TLS, proxy, name resolution, balancer, message sizes, keepalive and fail fast).
+func connectionKey(p *Peer) string {
+	h := sha256.New()
+	writeKeyField(h, []byte(p.url))
+	var raw []byte
+	if p.certificate != nil {
+		raw = p.certificate.Raw
+	}
+	writeKeyField(h, raw)
+	writeKeyField(h, []byte(p.serverName))
+	writeKeyField(h, []byte(strconv.FormatBool(p.inSecure)))
+	writeKeyField(h, []byte(p.proxyURL))
+	writeKeyField(h, []byte(strconv.FormatBool(p.dnsResolution)))
+	writeKeyField(h, []byte(p.balancerPolicy))
+	writeKeyField(h, []byte(strconv.Itoa(p.maxRecvMsgSize)))
+	writeKeyField(h, []byte(strconv.Itoa(p.maxSendMsgSize)))
+	writeKeyField(h, []byte(p.kap.Time.String()))
+	writeKeyField(h, []byte(p.kap.Timeout.String()))
+	writeKeyField(h, []byte(strconv.FormatBool(p.kap.PermitWithoutStream)))
+	writeKeyField(h, []byte(strconv.FormatBool(p.failFast)))
+	// the URL is only prepended to make the key readable in logs; the hash alone identifies the connection
+	return p.url + "#" + hex.EncodeToString(h.Sum(nil))
+}
+
+// writeKeyField writes the length-prefixed field so that adjacent fields cannot run into each other
+func writeKeyField(h hash.Hash, field []byte) {
+	var l [8]byte
+	binary.BigEndian.PutUint64(l[:], uint64(len(field)))
+	h.Write(l[:])
+	h.Write(field)
+}
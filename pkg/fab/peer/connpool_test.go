@@ -0,0 +1,167 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package peer
+
+import (
+	reqContext "context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	mockfab "github.com/hyperledger/fabric-sdk-go/pkg/common/providers/test/mockfab"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/keepalive"
+)
+
+func TestConnectionPool(t *testing.T) {
+	grpcServer := grpc.NewServer()
+	defer grpcServer.Stop()
+	_, addr := startEndorserServer(t, grpcServer)
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	config := mockfab.DefaultMockConfig(mockCtrl)
+	config.EXPECT().Timeout(gomock.Any()).Return(time.Second * 1).AnyTimes()
+
+	pool := NewConnectionPool()
+	peer1, err := New(config, WithURL("grpc://"+addr), WithInsecure(), WithConnectionPool(pool))
+	if err != nil {
+		t.Fatalf("Failed to create peer: %s", err)
+	}
+	peer2, err := New(config, WithURL("grpc://"+addr), WithInsecure(), WithConnectionPool(pool))
+	if err != nil {
+		t.Fatalf("Failed to create peer: %s", err)
+	}
+	peer3, err := New(config, WithURL("grpc://"+addr), WithInsecure(), WithServerName("other"), WithConnectionPool(pool))
+	if err != nil {
+		t.Fatalf("Failed to create peer: %s", err)
+	}
+
+	ctx, cancel := reqContext.WithTimeout(reqContext.Background(), normalTimeout)
+	defer cancel()
+
+	for _, p := range []*Peer{peer1, peer2, peer3} {
+		_, err = p.ProcessTransactionProposal(ctx, mockProcessProposalRequest())
+		if err != nil {
+			t.Fatalf("Process proposal failed: %s", err)
+		}
+	}
+
+	assert.Len(t, pool.conns, 2, "Expected peers with the same URL and TLS settings to share a connection")
+	for _, c := range pool.conns {
+		assert.Equal(t, 0, c.refCount, "Expected connections to be released")
+	}
+
+	m1 := peer1.commManager.(*pooledCommManager)
+	conn1, err := m1.DialContext(ctx, addr)
+	if err != nil {
+		t.Fatalf("Failed to obtain pooled connection: %s", err)
+	}
+	conn2, err := peer2.commManager.DialContext(ctx, addr)
+	if err != nil {
+		t.Fatalf("Failed to obtain pooled connection: %s", err)
+	}
+	assert.True(t, conn1 == conn2, "Expected the same pooled connection")
+	assert.Equal(t, 2, pool.conns[m1.key].refCount)
+	m1.ReleaseConn(conn1)
+	assert.Equal(t, 1, pool.conns[m1.key].refCount)
+
+	pool.ClosePool()
+	assert.Len(t, pool.conns, 0)
+	_, err = peer1.ProcessTransactionProposal(ctx, mockProcessProposalRequest())
+	assert.Error(t, err, "Expected error after pool is closed")
+
+	// releasing a connection after the pool has been closed is harmless
+	peer2.commManager.ReleaseConn(conn2)
+}
+
+func TestConnectionKey(t *testing.T) {
+	key := connectionKey(&Peer{url: "grpc://localhost:7051"})
+	assert.Equal(t, key, connectionKey(&Peer{url: "grpc://localhost:7051"}))
+
+	for name, p := range map[string]*Peer{
+		"maxRecvMsgSize": {url: "grpc://localhost:7051", maxRecvMsgSize: 1024},
+		"maxSendMsgSize": {url: "grpc://localhost:7051", maxSendMsgSize: 1024},
+		"keepalive":      {url: "grpc://localhost:7051", kap: keepalive.ClientParameters{Time: time.Minute}},
+		"failFast":       {url: "grpc://localhost:7051", failFast: true},
+	} {
+		assert.NotEqual(t, key, connectionKey(p), "expecting %s to be part of the connection key", name)
+	}
+
+	assert.NotEqual(t, connectionKey(&Peer{url: "grpc://localhost:7051", serverName: "ab", proxyURL: "c"}),
+		connectionKey(&Peer{url: "grpc://localhost:7051", serverName: "a", proxyURL: "bc"}),
+		"expecting adjacent fields not to run into each other")
+	assert.NotEqual(t, connectionKey(&Peer{url: "grpc://localhost:7051#", serverName: "a"}),
+		connectionKey(&Peer{url: "grpc://localhost:7051", serverName: "#a"}),
+		"expecting the URL not to run into the other fields")
+}
+
+func TestConnectionPoolIdleTimeout(t *testing.T) {
+	grpcServer := grpc.NewServer()
+	defer grpcServer.Stop()
+	_, addr := startEndorserServer(t, grpcServer)
+
+	idleTimeout := 200 * time.Millisecond
+	pool := NewConnectionPool(WithIdleTimeout(idleTimeout))
+	defer pool.ClosePool()
+
+	ctx, cancel := reqContext.WithTimeout(reqContext.Background(), normalTimeout)
+	defer cancel()
+
+	conn, err := pool.acquire(ctx, "key1", addr, grpc.WithInsecure())
+	if err != nil {
+		t.Fatalf("Failed to obtain pooled connection: %s", err)
+	}
+
+	// a connection which is in use is not closed
+	time.Sleep(idleTimeout * 2)
+	assert.NotEqual(t, connectivity.Shutdown, conn.GetState(), "Expected connection in use to remain open")
+
+	pool.release(conn)
+	time.Sleep(idleTimeout * 3)
+	assert.Equal(t, connectivity.Shutdown, conn.GetState(), "Expected idle connection to be closed")
+
+	pool.mutex.Lock()
+	assert.Len(t, pool.conns, 0, "Expected idle connection to be removed from the pool")
+	pool.mutex.Unlock()
+}
+
+func TestConnectionPoolIdleTimeoutReset(t *testing.T) {
+	grpcServer := grpc.NewServer()
+	defer grpcServer.Stop()
+	_, addr := startEndorserServer(t, grpcServer)
+
+	idleTimeout := 300 * time.Millisecond
+	pool := NewConnectionPool(WithIdleTimeout(idleTimeout))
+	defer pool.ClosePool()
+
+	ctx, cancel := reqContext.WithTimeout(reqContext.Background(), normalTimeout)
+	defer cancel()
+
+	conn, err := pool.acquire(ctx, "key1", addr, grpc.WithInsecure())
+	if err != nil {
+		t.Fatalf("Failed to obtain pooled connection: %s", err)
+	}
+	pool.release(conn)
+
+	// using the connection again before the idle timeout expires resets the idle timer
+	for i := 0; i < 3; i++ {
+		time.Sleep(idleTimeout / 2)
+		conn2, err := pool.acquire(ctx, "key1", addr, grpc.WithInsecure())
+		if err != nil {
+			t.Fatalf("Failed to obtain pooled connection: %s", err)
+		}
+		assert.True(t, conn == conn2, "Expected the same pooled connection")
+		pool.release(conn2)
+	}
+	assert.NotEqual(t, connectivity.Shutdown, conn.GetState(), "Expected connection which was used again to remain open")
+
+	time.Sleep(idleTimeout * 3)
+	assert.Equal(t, connectivity.Shutdown, conn.GetState(), "Expected idle connection to be closed")
+}
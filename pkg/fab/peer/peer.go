@@ -10,7 +10,9 @@ import (
 	reqContext "context"
 
 	"crypto/x509"
+	"time"
 
+	"github.com/pkg/errors"
 	"github.com/spf13/cast"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/keepalive"
@@ -19,6 +21,7 @@ import (
 	"github.com/hyperledger/fabric-sdk-go/pkg/common/errors/status"
 	"github.com/hyperledger/fabric-sdk-go/pkg/common/logging"
 	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+	fabcomm "github.com/hyperledger/fabric-sdk-go/pkg/fab/comm"
 )
 
 var logger = logging.NewLogger("fabsdk/fab")
@@ -26,16 +29,23 @@ var logger = logging.NewLogger("fabsdk/fab")
 // Peer represents a node in the target blockchain network to which
 // HFC sends endorsement proposals, transaction ordering or query requests.
 type Peer struct {
-	config      fab.EndpointConfig
-	certificate *x509.Certificate
-	serverName  string
-	processor   fab.ProposalProcessor
-	mspID       string
-	url         string
-	kap         keepalive.ClientParameters
-	failFast    bool
-	inSecure    bool
-	commManager fab.CommManager
+	config         fab.EndpointConfig
+	certificate    *x509.Certificate
+	serverName     string
+	processor      fab.ProposalProcessor
+	mspID          string
+	url            string
+	kap            keepalive.ClientParameters
+	failFast       bool
+	inSecure       bool
+	commManager    fab.CommManager
+	connPool       *ConnectionPool
+	maxRecvMsgSize int
+	maxSendMsgSize int
+	dialTimeout    time.Duration
+	proxyURL       string
+	dnsResolution  bool
+	balancerPolicy string
 }
 
 // Option describes a functional parameter for the New constructor
@@ -56,6 +66,13 @@ func New(config fab.EndpointConfig, opts ...Option) (*Peer, error) {
 		}
 	}
 
+	if peer.connPool != nil {
+		peer.commManager = &pooledCommManager{
+			pool: peer.connPool,
+			key:  connectionKey(peer),
+		}
+	}
+
 	if peer.processor == nil {
 		// TODO: config is declaring TLS but cert & serverHostOverride is being passed-in...
 		endorseRequest := peerEndorserRequest{
@@ -67,6 +84,12 @@ func New(config fab.EndpointConfig, opts ...Option) (*Peer, error) {
 			failFast:           peer.failFast,
 			allowInsecure:      peer.inSecure,
 			commManager:        peer.commManager,
+			maxRecvMsgSize:     peer.maxRecvMsgSize,
+			maxSendMsgSize:     peer.maxSendMsgSize,
+			dialTimeout:        peer.dialTimeout,
+			proxyURL:           peer.proxyURL,
+			dnsResolution:      peer.dnsResolution,
+			balancerPolicy:     peer.balancerPolicy,
 		}
 		processor, err := newPeerEndorser(&endorseRequest)
 
@@ -124,6 +147,84 @@ func WithMSPID(mspID string) Option {
 	}
 }
 
+// WithMaxRecvMsgSize is a functional option for the peer.New constructor that configures the maximum
+// message size in bytes the peer's client can receive (defaults to 100MB)
+func WithMaxRecvMsgSize(size int) Option {
+	return func(p *Peer) error {
+		if size <= 0 {
+			return errors.Errorf("invalid max receive message size [%d]", size)
+		}
+		p.maxRecvMsgSize = size
+
+		return nil
+	}
+}
+
+// WithMaxSendMsgSize is a functional option for the peer.New constructor that configures the maximum
+// message size in bytes the peer's client can send (defaults to 100MB)
+func WithMaxSendMsgSize(size int) Option {
+	return func(p *Peer) error {
+		if size <= 0 {
+			return errors.Errorf("invalid max send message size [%d]", size)
+		}
+		p.maxSendMsgSize = size
+
+		return nil
+	}
+}
+
+// WithDialTimeout is a functional option for the peer.New constructor that configures the timeout for
+// establishing a connection to the peer (defaults to the EndorserConnection timeout from config).
+// If the connection can't be established in time then a DIAL_TIMEOUT status error is returned.
+func WithDialTimeout(timeout time.Duration) Option {
+	return func(p *Peer) error {
+		if timeout <= 0 {
+			return errors.Errorf("invalid dial timeout [%s]", timeout)
+		}
+		p.dialTimeout = timeout
+
+		return nil
+	}
+}
+
+// WithProxy is a functional option for the peer.New constructor that routes the connection to the peer
+// through the SOCKS5 (socks5://host:port) or HTTP CONNECT (http://host:port) proxy with the given URL.
+// TLS is still negotiated with (and verified against) the peer itself. When a connection pool is used,
+// connections are only shared between peers which use the same proxy.
+func WithProxy(proxyURL string) Option {
+	return func(p *Peer) error {
+		p.proxyURL = proxyURL
+
+		return nil
+	}
+}
+
+// WithDNSResolution is a functional option for the peer.New constructor that resolves the host of the
+// peer's URL with gRPC's DNS resolver, so that a single logical endpoint (e.g. a Kubernetes headless
+// service) maps to the dynamically changing set of nodes behind the name. The name is re-resolved when
+// connections to the nodes fail.
+func WithDNSResolution() Option {
+	return func(p *Peer) error {
+		p.dnsResolution = true
+
+		return nil
+	}
+}
+
+// WithBalancerPolicy is a functional option for the peer.New constructor that configures the gRPC
+// load-balancing policy (fabcomm.PickFirstBalancer or fabcomm.RoundRobinBalancer) used when the peer's
+// URL resolves to multiple addresses (see WithDNSResolution). Defaults to pick first.
+func WithBalancerPolicy(policy string) Option {
+	return func(p *Peer) error {
+		if err := fabcomm.ValidateBalancerPolicy(policy); err != nil {
+			return err
+		}
+		p.balancerPolicy = policy
+
+		return nil
+	}
+}
+
 // FromPeerConfig is a functional option for the peer.New constructor that configures a new peer
 // from a apiconfig.NetworkPeer struct
 func FromPeerConfig(peerCfg *fab.NetworkPeer) Option {
@@ -155,6 +256,9 @@ func FromPeerConfig(peerCfg *fab.NetworkPeer) Option {
 		p.mspID = peerCfg.MSPID
 		p.kap = getKeepAliveOptions(peerCfg)
 		p.failFast = getFailFast(peerCfg)
+		p.proxyURL = getProxyURL(peerCfg)
+		p.dnsResolution = isDNSResolutionEnabled(peerCfg)
+		p.balancerPolicy = getBalancerPolicy(peerCfg)
 		return nil
 	}
 }
@@ -168,6 +272,13 @@ func getServerNameOverride(peerCfg *fab.NetworkPeer) string {
 	return serverHostOverride
 }
 
+func getProxyURL(peerCfg *fab.NetworkPeer) string {
+	if str, ok := peerCfg.GRPCOptions["proxy-url"].(string); ok {
+		return str
+	}
+	return ""
+}
+
 func getFailFast(peerCfg *fab.NetworkPeer) bool {
 	var failFast = true
 	if ff, ok := peerCfg.GRPCOptions["fail-fast"].(bool); ok {
@@ -192,6 +303,20 @@ func getKeepAliveOptions(peerCfg *fab.NetworkPeer) keepalive.ClientParameters {
 	return kap
 }
 
+func getBalancerPolicy(peerCfg *fab.NetworkPeer) string {
+	if str, ok := peerCfg.GRPCOptions["balancer-policy"].(string); ok {
+		return str
+	}
+	return ""
+}
+
+func isDNSResolutionEnabled(peerCfg *fab.NetworkPeer) bool {
+	if dns, ok := peerCfg.GRPCOptions["dns-resolution"]; ok {
+		return cast.ToBool(dns)
+	}
+	return false
+}
+
 func isInsecureConnectionAllowed(peerCfg *fab.NetworkPeer) bool {
 	allowInsecure, ok := peerCfg.GRPCOptions["allow-insecure"].(bool)
 	if ok {
@@ -225,6 +350,15 @@ func (p *Peer) ProcessTransactionProposal(ctx reqContext.Context, proposal fab.P
 	return p.processor.ProcessTransactionProposal(ctx, proposal)
 }
 
+// Health checks whether the peer is reachable. Peers with a custom proposal processor which
+// doesn't support health checks are assumed to be healthy.
+func (p *Peer) Health(ctx reqContext.Context) error {
+	if hc, ok := p.processor.(fab.HealthChecker); ok {
+		return hc.Health(ctx)
+	}
+	return nil
+}
+
 func (p *Peer) String() string {
 	return p.url
 }
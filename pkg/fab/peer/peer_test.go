@@ -13,6 +13,7 @@ import (
 	"time"
 
 	"github.com/golang/mock/gomock"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/errors/status"
 	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
 	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/test/mockfab"
 	"github.com/hyperledger/fabric-sdk-go/pkg/core/config/endpoint"
@@ -218,6 +219,31 @@ func TestPeerOptions(t *testing.T) {
 	}
 }
 
+func TestPeerMsgSizeOptions(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	config := mockfab.DefaultMockConfig(mockCtrl)
+	config.EXPECT().Timeout(gomock.Any()).Return(time.Second * 1).AnyTimes()
+
+	p, err := New(config, WithURL("grpc://"+testAddress), WithInsecure(), WithMaxRecvMsgSize(200*1024*1024), WithMaxSendMsgSize(1024))
+	if err != nil {
+		t.Fatalf("Failed to create new peer with message size options (%v)", err)
+	}
+	if p.maxRecvMsgSize != 200*1024*1024 || p.maxSendMsgSize != 1024 {
+		t.Fatalf("Unexpected message sizes [%d, %d]", p.maxRecvMsgSize, p.maxSendMsgSize)
+	}
+
+	_, err = New(config, WithURL("grpc://"+testAddress), WithInsecure(), WithMaxRecvMsgSize(0))
+	if err == nil {
+		t.Fatalf("Expected error for invalid max receive message size")
+	}
+
+	_, err = New(config, WithURL("grpc://"+testAddress), WithInsecure(), WithMaxSendMsgSize(-1))
+	if err == nil {
+		t.Fatalf("Expected error for invalid max send message size")
+	}
+}
+
 // TestNewPeerSecured validates that insecure option
 func TestNewPeerSecured(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
@@ -237,3 +263,41 @@ func TestNewPeerSecured(t *testing.T) {
 	}
 
 }
+
+func TestPeerDialTimeout(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	config := mockfab.DefaultMockConfig(mockCtrl)
+
+	_, err := New(config, WithURL("grpc://"+testAddress), WithInsecure(), WithDialTimeout(0))
+	if err == nil {
+		t.Fatalf("Expected error for invalid dial timeout")
+	}
+
+	p, err := New(config, WithURL("grpc://"+testAddress), WithInsecure(), WithDialTimeout(100*time.Millisecond))
+	if err != nil {
+		t.Fatalf("Peer construction error (%v)", err)
+	}
+	if p.processor.(*peerEndorser).dialTimeout != 100*time.Millisecond {
+		t.Fatalf("Expected dial timeout to be set")
+	}
+
+	ctx, cancel := reqContext.WithTimeout(reqContext.Background(), normalTimeout)
+	defer cancel()
+
+	_, err = p.ProcessTransactionProposal(ctx, fab.ProcessProposalRequest{})
+	s, ok := status.FromError(err)
+	if !ok || s.Group != status.EndorserClientStatus || s.Code != status.DialTimeout.ToInt32() {
+		t.Fatalf("Expected dial timeout status, got %v", err)
+	}
+
+	// The request timing out while dialing is not a dial timeout
+	ctx, cancel = reqContext.WithTimeout(reqContext.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err = p.ProcessTransactionProposal(ctx, fab.ProcessProposalRequest{})
+	s, ok = status.FromError(err)
+	if !ok || s.Code != status.ConnectionFailed.ToInt32() {
+		t.Fatalf("Expected connection failed status, got %v", err)
+	}
+}
@@ -9,6 +9,7 @@ package peer
 import (
 	reqContext "context"
 	"crypto/x509"
+	"fmt"
 	"strconv"
 	"strings"
 	"time"
@@ -22,10 +23,13 @@ import (
 
 	"github.com/hyperledger/fabric-sdk-go/pkg/client/common/verifier"
 	"github.com/hyperledger/fabric-sdk-go/pkg/common/errors/status"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/logging"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/metrics"
 	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
 	"github.com/hyperledger/fabric-sdk-go/pkg/context"
 	"github.com/hyperledger/fabric-sdk-go/pkg/core/config/comm"
 	"github.com/hyperledger/fabric-sdk-go/pkg/core/config/endpoint"
+	fabcomm "github.com/hyperledger/fabric-sdk-go/pkg/fab/comm"
 	pb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/peer"
 	protos_utils "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/utils"
 )
@@ -40,8 +44,13 @@ const (
 type peerEndorser struct {
 	grpcDialOption []grpc.DialOption
 	target         string
+	dialTarget     string
 	dialTimeout    time.Duration
 	commManager    fab.CommManager
+
+	// reportDialTimeout is set if the dial timeout was configured with WithDialTimeout, in which
+	// case a DIAL_TIMEOUT (rather than CONNECTION_FAILED) status is returned when the dial times out
+	reportDialTimeout bool
 }
 
 type peerEndorserRequest struct {
@@ -53,6 +62,12 @@ type peerEndorserRequest struct {
 	failFast           bool
 	allowInsecure      bool
 	commManager        fab.CommManager
+	maxRecvMsgSize     int
+	maxSendMsgSize     int
+	dialTimeout        time.Duration
+	proxyURL           string
+	dnsResolution      bool
+	balancerPolicy     string
 }
 
 func newPeerEndorser(endorseReq *peerEndorserRequest) (*peerEndorser, error) {
@@ -81,16 +96,42 @@ func newPeerEndorser(endorseReq *peerEndorserRequest) (*peerEndorser, error) {
 		grpcOpts = append(grpcOpts, grpc.WithInsecure())
 	}
 
-	grpcOpts = append(grpcOpts, grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(maxCallRecvMsgSize),
-		grpc.MaxCallSendMsgSize(maxCallSendMsgSize)))
+	recvMsgSize, sendMsgSize := maxCallRecvMsgSize, maxCallSendMsgSize
+	if endorseReq.maxRecvMsgSize > 0 {
+		recvMsgSize = endorseReq.maxRecvMsgSize
+	}
+	if endorseReq.maxSendMsgSize > 0 {
+		sendMsgSize = endorseReq.maxSendMsgSize
+	}
+	grpcOpts = append(grpcOpts, grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(recvMsgSize),
+		grpc.MaxCallSendMsgSize(sendMsgSize)))
 
-	timeout := endorseReq.config.Timeout(fab.EndorserConnection)
+	grpcOpts = append(grpcOpts, fabcomm.MetadataDialOptions(endpoint.ToAddress(endorseReq.target))...)
+
+	proxyOpts, err := fabcomm.ProxyDialOptions(endorseReq.proxyURL)
+	if err != nil {
+		return nil, err
+	}
+	grpcOpts = append(grpcOpts, proxyOpts...)
+
+	balancerOpts, err := fabcomm.BalancerDialOptions(endorseReq.balancerPolicy)
+	if err != nil {
+		return nil, err
+	}
+	grpcOpts = append(grpcOpts, balancerOpts...)
+
+	timeout := endorseReq.dialTimeout
+	if timeout == 0 {
+		timeout = endorseReq.config.Timeout(fab.EndorserConnection)
+	}
 
 	pc := &peerEndorser{
-		grpcDialOption: grpcOpts,
-		target:         endpoint.ToAddress(endorseReq.target),
-		dialTimeout:    timeout,
-		commManager:    endorseReq.commManager,
+		grpcDialOption:    grpcOpts,
+		target:            endpoint.ToAddress(endorseReq.target),
+		dialTarget:        fabcomm.DialTarget(endpoint.ToAddress(endorseReq.target), endorseReq.dnsResolution),
+		dialTimeout:       timeout,
+		commManager:       endorseReq.commManager,
+		reportDialTimeout: endorseReq.dialTimeout != 0,
 	}
 
 	return pc, nil
@@ -98,9 +139,11 @@ func newPeerEndorser(endorseReq *peerEndorserRequest) (*peerEndorser, error) {
 
 // ProcessTransactionProposal sends the transaction proposal to a peer and returns the response.
 func (p *peerEndorser) ProcessTransactionProposal(ctx reqContext.Context, request fab.ProcessProposalRequest) (*fab.TransactionProposalResponse, error) {
-	logger.Debugf("Processing proposal using endorser: %s", p.target)
+	logger.Debugw("Processing proposal", context.LogFields(ctx, logging.EndpointURL(p.target))...)
 
+	start := time.Now()
 	proposalResponse, err := p.sendProposal(ctx, request)
+	metrics.Get().ObserveProposalLatency(p.target, time.Since(start), err)
 	if err != nil {
 		tpr := fab.TransactionProposalResponse{Endorser: p.target}
 		return &tpr, errors.Wrapf(err, "Transaction processing for endorser [%s]", p.target)
@@ -121,10 +164,21 @@ func (p *peerEndorser) conn(ctx reqContext.Context) (*grpc.ClientConn, error) {
 		commManager = p.commManager
 	}
 
-	ctx, cancel := reqContext.WithTimeout(ctx, p.dialTimeout)
+	dialCtx, cancel := reqContext.WithTimeout(ctx, p.dialTimeout)
 	defer cancel()
 
-	return commManager.DialContext(ctx, p.target, p.grpcDialOption...)
+	conn, err := commManager.DialContext(dialCtx, p.dialTarget, p.grpcDialOption...)
+	if err != nil && p.reportDialTimeout && isDialTimeout(ctx, dialCtx) {
+		return nil, status.New(status.EndorserClientStatus, status.DialTimeout.ToInt32(),
+			fmt.Sprintf("dial timed out after %s: %s", p.dialTimeout, err), []interface{}{p.target})
+	}
+	return conn, err
+}
+
+// isDialTimeout returns true if the dial timed out before the request context was done, i.e. the
+// connection could not be established within the dial timeout (as opposed to the request timing out)
+func isDialTimeout(ctx, dialCtx reqContext.Context) bool {
+	return dialCtx.Err() == reqContext.DeadlineExceeded && ctx.Err() == nil
 }
 
 func (p *peerEndorser) releaseConn(ctx reqContext.Context, conn *grpc.ClientConn) {
@@ -136,9 +190,30 @@ func (p *peerEndorser) releaseConn(ctx reqContext.Context, conn *grpc.ClientConn
 	commManager.ReleaseConn(conn)
 }
 
+// Health checks whether a connection to the endorser can be established
+func (p *peerEndorser) Health(ctx reqContext.Context) error {
+	conn, err := p.conn(ctx)
+	if err != nil {
+		if s, ok := err.(*status.Status); ok {
+			return s
+		}
+		rpcStatus, ok := grpcstatus.FromError(err)
+		if ok {
+			return errors.WithMessage(status.NewFromGRPCStatus(rpcStatus), "connection failed")
+		}
+		return status.New(status.EndorserClientStatus, status.ConnectionFailed.ToInt32(), err.Error(), []interface{}{p.target})
+	}
+	p.releaseConn(ctx, conn)
+
+	return nil
+}
+
 func (p *peerEndorser) sendProposal(ctx reqContext.Context, proposal fab.ProcessProposalRequest) (*pb.ProposalResponse, error) {
 	conn, err := p.conn(ctx)
 	if err != nil {
+		if s, ok := err.(*status.Status); ok {
+			return nil, s
+		}
 		rpcStatus, ok := grpcstatus.FromError(err)
 		if ok {
 			return nil, errors.WithMessage(status.NewFromGRPCStatus(rpcStatus), "connection failed")
@@ -151,7 +226,7 @@ func (p *peerEndorser) sendProposal(ctx reqContext.Context, proposal fab.Process
 	resp, err := endorserClient.ProcessProposal(ctx, proposal.SignedProposal)
 
 	if err != nil {
-		logger.Errorf("process proposal failed [%s]", err)
+		logger.Errorw("Process proposal failed", context.LogFields(ctx, logging.EndpointURL(p.target), logging.NewField("error", err))...)
 		rpcStatus, ok := grpcstatus.FromError(err)
 
 		if ok {
@@ -10,7 +10,9 @@ import (
 	reqContext "context"
 	"crypto/x509"
 	"fmt"
+	"io"
 	"net"
+	"net/http"
 	"reflect"
 	"testing"
 	"time"
@@ -20,6 +22,7 @@ import (
 	"google.golang.org/grpc"
 	grpcCodes "google.golang.org/grpc/codes"
 	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/metadata"
 	grpcstatus "google.golang.org/grpc/status"
 
 	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
@@ -27,6 +30,8 @@ import (
 
 	"github.com/hyperledger/fabric-sdk-go/pkg/common/errors/status"
 	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/test/mockfab"
+	contextImpl "github.com/hyperledger/fabric-sdk-go/pkg/context"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/comm"
 	"github.com/hyperledger/fabric-sdk-go/pkg/fab/mocks"
 )
 
@@ -202,6 +207,226 @@ func TestProcessProposalGoodDial(t *testing.T) {
 	}
 }
 
+func TestProcessProposalWithDNSResolution(t *testing.T) {
+	grpcServer := grpc.NewServer()
+	defer grpcServer.Stop()
+	_, addr := startEndorserServer(t, grpcServer)
+	_, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		t.Fatalf("Invalid address %s", addr)
+	}
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	config := mockfab.DefaultMockConfig(mockCtrl)
+	config.EXPECT().Timeout(gomock.Any()).Return(time.Second * 1).AnyTimes()
+
+	peer, err := New(config, WithURL("grpc://"+net.JoinHostPort("localhost", port)), WithInsecure(), WithDNSResolution())
+	if err != nil {
+		t.Fatalf("Failed to create peer: %s", err)
+	}
+	assert.Equal(t, "dns:///localhost:"+port, peer.processor.(*peerEndorser).dialTarget)
+
+	ctx, cancel := reqContext.WithTimeout(reqContext.Background(), normalTimeout)
+	defer cancel()
+	if _, err = peer.ProcessTransactionProposal(ctx, mockProcessProposalRequest()); err != nil {
+		t.Fatalf("Process proposal with DNS resolution failed (%v)", err)
+	}
+}
+
+func TestProcessProposalWithBalancerPolicy(t *testing.T) {
+	grpcServer := grpc.NewServer()
+	defer grpcServer.Stop()
+	_, addr := startEndorserServer(t, grpcServer)
+	_, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		t.Fatalf("Invalid address %s", addr)
+	}
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	config := mockfab.DefaultMockConfig(mockCtrl)
+	config.EXPECT().Timeout(gomock.Any()).Return(time.Second * 1).AnyTimes()
+
+	_, err = New(config, WithURL("grpc://"+addr), WithInsecure(), WithBalancerPolicy("random"))
+	assert.Error(t, err, "expecting error for unsupported balancer policy")
+
+	peer, err := New(config, WithURL("grpc://"+net.JoinHostPort("localhost", port)), WithInsecure(), WithDNSResolution(), WithBalancerPolicy(comm.RoundRobinBalancer))
+	if err != nil {
+		t.Fatalf("Failed to create peer: %s", err)
+	}
+
+	ctx, cancel := reqContext.WithTimeout(reqContext.Background(), normalTimeout)
+	defer cancel()
+	if _, err = peer.ProcessTransactionProposal(ctx, mockProcessProposalRequest()); err != nil {
+		t.Fatalf("Process proposal with round robin balancer failed (%v)", err)
+	}
+}
+
+func TestPeerHealth(t *testing.T) {
+	grpcServer := grpc.NewServer()
+	defer grpcServer.Stop()
+	_, addr := startEndorserServer(t, grpcServer)
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	config := mockfab.DefaultMockConfig(mockCtrl)
+	config.EXPECT().Timeout(gomock.Any()).Return(time.Second * 1).AnyTimes()
+
+	ctx, cancel := reqContext.WithTimeout(reqContext.Background(), normalTimeout)
+	defer cancel()
+
+	var hc fab.HealthChecker
+	p, err := New(config, WithURL("grpc://"+addr), WithInsecure())
+	if err != nil {
+		t.Fatalf("Peer construction error (%v)", err)
+	}
+	hc = p
+	if err := hc.Health(ctx); err != nil {
+		t.Fatalf("Expected peer to be healthy: %s", err)
+	}
+
+	p, err = New(config, WithURL("grpc://"+testAddress), WithInsecure())
+	if err != nil {
+		t.Fatalf("Peer construction error (%v)", err)
+	}
+	err = p.Health(ctx)
+	if err == nil {
+		t.Fatalf("Expected health check of unreachable peer to fail")
+	}
+	s, ok := status.FromError(err)
+	if !ok || s.Code != status.ConnectionFailed.ToInt32() {
+		t.Fatalf("Expected connection failed status, got %v", err)
+	}
+}
+
+func TestProcessProposalRequestID(t *testing.T) {
+	grpcServer := grpc.NewServer()
+	defer grpcServer.Stop()
+	endorserServer, addr := startEndorserServer(t, grpcServer)
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	config := mockfab.DefaultMockConfig(mockCtrl)
+	config.EXPECT().Timeout(gomock.Any()).Return(time.Second * 1).AnyTimes()
+
+	conn, err := newPeerEndorser(getPeerEndorserRequest("grpc://"+addr, nil, "", config, kap, false, true))
+	if err != nil {
+		t.Fatalf("Peer conn construction error (%v)", err)
+	}
+
+	ctx, cancel := reqContext.WithTimeout(contextImpl.WithRequestID(reqContext.Background(), "req1"), normalTimeout)
+	defer cancel()
+	if _, err = conn.ProcessTransactionProposal(ctx, mockProcessProposalRequest()); err != nil {
+		t.Fatalf("Process proposal failed (%v)", err)
+	}
+	if ids := endorserServer.LastRequestMetadata()[contextImpl.RequestIDMetadataKey]; len(ids) != 0 {
+		t.Fatalf("Expected request ID not to be sent as metadata, got %v", ids)
+	}
+
+	ctx, cancel = reqContext.WithTimeout(contextImpl.WithRequestID(reqContext.Background(), "req2", contextImpl.WithRequestIDMetadata()), normalTimeout)
+	defer cancel()
+	if _, err = conn.ProcessTransactionProposal(ctx, mockProcessProposalRequest()); err != nil {
+		t.Fatalf("Process proposal failed (%v)", err)
+	}
+	ids := endorserServer.LastRequestMetadata()[contextImpl.RequestIDMetadataKey]
+	if len(ids) != 1 || ids[0] != "req2" {
+		t.Fatalf("Expected request ID to be sent as metadata, got %v", ids)
+	}
+}
+
+func TestProcessProposalMetadataMutator(t *testing.T) {
+	grpcServer := grpc.NewServer()
+	defer grpcServer.Stop()
+	endorserServer, addr := startEndorserServer(t, grpcServer)
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	config := mockfab.DefaultMockConfig(mockCtrl)
+	config.EXPECT().Timeout(gomock.Any()).Return(time.Second * 1).AnyTimes()
+
+	conn, err := newPeerEndorser(getPeerEndorserRequest("grpc://"+addr, nil, "", config, kap, false, true))
+	if err != nil {
+		t.Fatalf("Peer conn construction error (%v)", err)
+	}
+
+	var target, method string
+	comm.SetMetadataMutator(func(ctx reqContext.Context, tgt, m string, md metadata.MD) error {
+		target, method = tgt, m
+		md["authorization"] = []string{"Bearer token"}
+		return nil
+	})
+	defer comm.SetMetadataMutator(nil)
+
+	ctx, cancel := reqContext.WithTimeout(contextImpl.WithRequestID(reqContext.Background(), "req1", contextImpl.WithRequestIDMetadata()), normalTimeout)
+	defer cancel()
+	if _, err = conn.ProcessTransactionProposal(ctx, mockProcessProposalRequest()); err != nil {
+		t.Fatalf("Process proposal failed (%v)", err)
+	}
+	md := endorserServer.LastRequestMetadata()
+	assert.Equal(t, []string{"Bearer token"}, md["authorization"], "expecting metadata added by the mutator")
+	assert.Equal(t, []string{"req1"}, md[contextImpl.RequestIDMetadataKey], "expecting existing metadata to be preserved")
+	assert.Equal(t, addr, target)
+	assert.Equal(t, "/protos.Endorser/ProcessProposal", method)
+
+	comm.SetMetadataMutator(func(ctx reqContext.Context, tgt, m string, md metadata.MD) error {
+		return fmt.Errorf("no token")
+	})
+	_, err = conn.ProcessTransactionProposal(ctx, mockProcessProposalRequest())
+	assert.Error(t, err, "expecting the call to fail when the mutator fails")
+}
+
+func TestProcessProposalThroughProxy(t *testing.T) {
+	grpcServer := grpc.NewServer()
+	defer grpcServer.Stop()
+	_, addr := startEndorserServer(t, grpcServer)
+
+	proxied := make(chan string, 1)
+	lis, err := net.Listen("tcp", testAddress)
+	if err != nil {
+		t.Fatalf("Error starting proxy %s", err)
+	}
+	defer lis.Close()
+	go http.Serve(lis, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		target, err := net.Dial("tcp", r.Host)
+		if r.Method != http.MethodConnect || err != nil {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		proxied <- r.Host
+		w.WriteHeader(http.StatusOK)
+		conn, _, err := w.(http.Hijacker).Hijack()
+		if err != nil {
+			return
+		}
+		go io.Copy(target, conn)
+		io.Copy(conn, target)
+	}))
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	config := mockfab.DefaultMockConfig(mockCtrl)
+	config.EXPECT().Timeout(gomock.Any()).Return(time.Second * 1).AnyTimes()
+
+	request := getPeerEndorserRequest("grpc://"+addr, nil, "", config, kap, false, true)
+	request.proxyURL = "http://" + lis.Addr().String()
+	conn, err := newPeerEndorser(request)
+	if err != nil {
+		t.Fatalf("Peer conn construction error (%v)", err)
+	}
+
+	ctx, cancel := reqContext.WithTimeout(reqContext.Background(), normalTimeout)
+	defer cancel()
+	if _, err = conn.ProcessTransactionProposal(ctx, mockProcessProposalRequest()); err != nil {
+		t.Fatalf("Process proposal through proxy failed (%v)", err)
+	}
+	assert.Equal(t, addr, <-proxied, "expecting the connection to be tunnelled to the peer")
+
+	request.proxyURL = "ftp://" + lis.Addr().String()
+	_, err = newPeerEndorser(request)
+	assert.Error(t, err, "expecting error for unsupported proxy")
+}
+
 func testProcessProposal(t *testing.T, url string) (*fab.TransactionProposalResponse, error) {
 	mockCtrl := gomock.NewController(t)
 	defer mockCtrl.Finish()
@@ -0,0 +1,290 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fab
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/core"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+	"github.com/pkg/errors"
+)
+
+const defaultWatchInterval = time.Second * 5
+
+// ReloadCallback is invoked with the new endpoint config after each successful reload
+type ReloadCallback func(config fab.EndpointConfig)
+
+// ReloadOpt is an option for the reloadable endpoint config
+type ReloadOpt func(c *ReloadableEndpointConfig) error
+
+// WithReloadCallback registers a callback which is invoked after each successful reload
+func WithReloadCallback(cb ReloadCallback) ReloadOpt {
+	return func(c *ReloadableEndpointConfig) error {
+		c.callbacks = append(c.callbacks, cb)
+		return nil
+	}
+}
+
+// WithFileWatch checks the modification time of the given config file at the given
+// interval and reloads the config from the config provider when the file changes.
+// The interval must be greater than 0.
+func WithFileWatch(path string, interval time.Duration) ReloadOpt {
+	return func(c *ReloadableEndpointConfig) error {
+		if interval <= 0 {
+			return errors.Errorf("invalid file watch interval [%s]", interval)
+		}
+		c.watchPath = path
+		c.watchInterval = interval
+		return nil
+	}
+}
+
+// ReloadableEndpointConfig is an EndpointConfig whose peer, orderer and channel definitions
+// may be reloaded without restarting the process. The current config is swapped atomically,
+// so readers always observe either the old or the new config in its entirety.
+type ReloadableEndpointConfig struct {
+	provider      core.ConfigProvider
+	current       atomic.Value
+	lock          sync.Mutex
+	callbacks     []ReloadCallback
+	watchPath     string
+	watchInterval time.Duration
+	done          chan struct{}
+	closeOnce     sync.Once
+}
+
+// configHolder allows any EndpointConfig implementation to be stored in an atomic.Value
+type configHolder struct {
+	config fab.EndpointConfig
+}
+
+// NewReloadableEndpointConfig returns a new reloadable endpoint config which is initially
+// loaded from the given config provider
+func NewReloadableEndpointConfig(provider core.ConfigProvider, opts ...ReloadOpt) (*ReloadableEndpointConfig, error) {
+	if provider == nil {
+		return nil, errors.New("config provider is required")
+	}
+
+	c := &ReloadableEndpointConfig{
+		provider:      provider,
+		watchInterval: defaultWatchInterval,
+		done:          make(chan struct{}),
+	}
+
+	for _, opt := range opts {
+		if err := opt(c); err != nil {
+			return nil, err
+		}
+	}
+
+	config, err := c.load()
+	if err != nil {
+		return nil, err
+	}
+	c.current.Store(&configHolder{config: config})
+
+	if c.watchPath != "" {
+		modTime, err := fileModTime(c.watchPath)
+		if err != nil {
+			return nil, errors.WithMessage(err, "unable to watch config file")
+		}
+		go c.watch(modTime)
+	}
+
+	return c, nil
+}
+
+// OnReload registers a callback which is invoked after each successful reload
+func (c *ReloadableEndpointConfig) OnReload(cb ReloadCallback) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.callbacks = append(c.callbacks, cb)
+}
+
+// Reload reloads the endpoint config from the config provider. If the new config cannot be
+// loaded then an error is returned and the current config is retained.
+func (c *ReloadableEndpointConfig) Reload() error {
+	config, err := c.load()
+	if err != nil {
+		return err
+	}
+	c.swap(config)
+	return nil
+}
+
+// Update replaces the endpoint config with the config from the given backends. If the new
+// config cannot be loaded then an error is returned and the current config is retained.
+func (c *ReloadableEndpointConfig) Update(backends ...core.ConfigBackend) error {
+	config, err := ConfigFromBackend(backends...)
+	if err != nil {
+		return errors.WithMessage(err, "unable to load endpoint config")
+	}
+	c.swap(config)
+	return nil
+}
+
+// Close stops watching the config file
+func (c *ReloadableEndpointConfig) Close() {
+	c.closeOnce.Do(func() {
+		close(c.done)
+	})
+}
+
+// Timeout returns the timeout for the given timeout type
+func (c *ReloadableEndpointConfig) Timeout(tType fab.TimeoutType) time.Duration {
+	return c.config().Timeout(tType)
+}
+
+// MSPID returns the MSP ID for the given org
+func (c *ReloadableEndpointConfig) MSPID(org string) (string, error) {
+	return c.config().MSPID(org)
+}
+
+// PeerMSPID returns the MSP ID for the given peer
+func (c *ReloadableEndpointConfig) PeerMSPID(name string) (string, error) {
+	return c.config().PeerMSPID(name)
+}
+
+// OrderersConfig returns all of the configured orderers
+func (c *ReloadableEndpointConfig) OrderersConfig() ([]fab.OrdererConfig, error) {
+	return c.config().OrderersConfig()
+}
+
+// OrdererConfig returns the config of the given orderer
+func (c *ReloadableEndpointConfig) OrdererConfig(nameOrURL string) (*fab.OrdererConfig, error) {
+	return c.config().OrdererConfig(nameOrURL)
+}
+
+// PeersConfig returns the configs of the peers of the given org
+func (c *ReloadableEndpointConfig) PeersConfig(org string) ([]fab.PeerConfig, error) {
+	return c.config().PeersConfig(org)
+}
+
+// PeerConfig returns the config of the given peer
+func (c *ReloadableEndpointConfig) PeerConfig(nameOrURL string) (*fab.PeerConfig, error) {
+	return c.config().PeerConfig(nameOrURL)
+}
+
+// NetworkConfig returns the network configuration
+func (c *ReloadableEndpointConfig) NetworkConfig() (*fab.NetworkConfig, error) {
+	return c.config().NetworkConfig()
+}
+
+// NetworkPeers returns all of the configured peers
+func (c *ReloadableEndpointConfig) NetworkPeers() ([]fab.NetworkPeer, error) {
+	return c.config().NetworkPeers()
+}
+
+// ChannelConfig returns the config of the given channel
+func (c *ReloadableEndpointConfig) ChannelConfig(name string) (*fab.ChannelNetworkConfig, error) {
+	return c.config().ChannelConfig(name)
+}
+
+// ChannelPeers returns the peers of the given channel
+func (c *ReloadableEndpointConfig) ChannelPeers(name string) ([]fab.ChannelPeer, error) {
+	return c.config().ChannelPeers(name)
+}
+
+// ChannelOrderers returns the orderers of the given channel
+func (c *ReloadableEndpointConfig) ChannelOrderers(name string) ([]fab.OrdererConfig, error) {
+	return c.config().ChannelOrderers(name)
+}
+
+// TLSCACertPool returns the TLS CA cert pool including the given certs
+func (c *ReloadableEndpointConfig) TLSCACertPool(certs ...*x509.Certificate) (*x509.CertPool, error) {
+	return c.config().TLSCACertPool(certs...)
+}
+
+// EventServiceType returns the event service type
+func (c *ReloadableEndpointConfig) EventServiceType() fab.EventServiceType {
+	return c.config().EventServiceType()
+}
+
+// TLSClientCerts returns the TLS client certs
+func (c *ReloadableEndpointConfig) TLSClientCerts() ([]tls.Certificate, error) {
+	return c.config().TLSClientCerts()
+}
+
+// CryptoConfigPath returns the crypto config path
+func (c *ReloadableEndpointConfig) CryptoConfigPath() string {
+	return c.config().CryptoConfigPath()
+}
+
+func (c *ReloadableEndpointConfig) config() fab.EndpointConfig {
+	return c.current.Load().(*configHolder).config
+}
+
+func (c *ReloadableEndpointConfig) load() (fab.EndpointConfig, error) {
+	backends, err := c.provider()
+	if err != nil {
+		return nil, errors.WithMessage(err, "unable to load config backend")
+	}
+
+	config, err := ConfigFromBackend(backends...)
+	if err != nil {
+		return nil, errors.WithMessage(err, "unable to load endpoint config")
+	}
+	return config, nil
+}
+
+func (c *ReloadableEndpointConfig) swap(config fab.EndpointConfig) {
+	c.lock.Lock()
+	c.current.Store(&configHolder{config: config})
+	callbacks := make([]ReloadCallback, len(c.callbacks))
+	copy(callbacks, c.callbacks)
+	c.lock.Unlock()
+
+	logger.Debugf("Endpoint config reloaded - notifying %d subscriber(s)", len(callbacks))
+
+	for _, cb := range callbacks {
+		cb(config)
+	}
+}
+
+func (c *ReloadableEndpointConfig) watch(modTime time.Time) {
+	ticker := time.NewTicker(c.watchInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.done:
+			logger.Debugf("Stopped watching config file [%s]", c.watchPath)
+			return
+		case <-ticker.C:
+			current, err := fileModTime(c.watchPath)
+			if err != nil {
+				logger.Warnf("Unable to check config file [%s]: %s", c.watchPath, err)
+				continue
+			}
+			if current.Equal(modTime) {
+				continue
+			}
+
+			logger.Infof("Config file [%s] was modified - reloading endpoint config", c.watchPath)
+			if err := c.Reload(); err != nil {
+				logger.Warnf("Unable to reload endpoint config from [%s]: %s", c.watchPath, err)
+				continue
+			}
+			modTime = current
+		}
+	}
+}
+
+func fileModTime(path string) (time.Time, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return info.ModTime(), nil
+}
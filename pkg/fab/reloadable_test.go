@@ -0,0 +1,114 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fab
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/core"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+	"github.com/hyperledger/fabric-sdk-go/pkg/core/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReloadableEndpointConfigUpdate(t *testing.T) {
+	var reloaded []fab.EndpointConfig
+	cfg, err := NewReloadableEndpointConfig(
+		func() ([]core.ConfigBackend, error) { return []core.ConfigBackend{getCustomBackend()}, nil },
+		WithReloadCallback(func(config fab.EndpointConfig) { reloaded = append(reloaded, config) }),
+	)
+	require.NoError(t, err)
+	defer cfg.Close()
+
+	peerConfig, err := cfg.PeerConfig("peer0.org1.example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "peer0.org1.example.com:7051", peerConfig.URL)
+
+	backend := getCustomBackend()
+	backend.KeyValueMap["peers"] = map[string]interface{}{
+		"peer0.org1.example.com": map[string]interface{}{"url": "peer0.org1.example.com:8051"},
+	}
+	require.NoError(t, cfg.Update(backend))
+
+	peerConfig, err = cfg.PeerConfig("peer0.org1.example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "peer0.org1.example.com:8051", peerConfig.URL)
+	require.Len(t, reloaded, 1)
+
+	networkConfig, err := reloaded[0].NetworkConfig()
+	require.NoError(t, err)
+	assert.Len(t, networkConfig.Peers, 1)
+}
+
+func TestReloadableEndpointConfigInvalidUpdate(t *testing.T) {
+	cfg, err := NewReloadableEndpointConfig(func() ([]core.ConfigBackend, error) { return []core.ConfigBackend{getCustomBackend()}, nil })
+	require.NoError(t, err)
+	defer cfg.Close()
+
+	backend := getCustomBackend()
+	backend.KeyValueMap["entityMatchers"] = map[string]interface{}{
+		"peer": []interface{}{map[string]interface{}{"pattern": "(", "mappedHost": "peer0.org1.example.com"}},
+	}
+	assert.Error(t, cfg.Update(backend))
+
+	peerConfig, err := cfg.PeerConfig("peer0.org1.example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "peer0.org1.example.com:7051", peerConfig.URL, "expecting the previous config to be retained")
+}
+
+func TestReloadableEndpointConfigFileWatch(t *testing.T) {
+	content, err := ioutil.ReadFile(configTestFilePath)
+	require.NoError(t, err)
+
+	dir, err := ioutil.TempDir("", "reloadable")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "config.yaml")
+	require.NoError(t, ioutil.WriteFile(path, content, 0600))
+
+	reloaded := make(chan fab.EndpointConfig, 1)
+	cfg, err := NewReloadableEndpointConfig(
+		config.FromFile(path),
+		WithFileWatch(path, 10*time.Millisecond),
+		WithReloadCallback(func(config fab.EndpointConfig) { reloaded <- config }),
+	)
+	require.NoError(t, err)
+	defer cfg.Close()
+
+	_, err = NewReloadableEndpointConfig(config.FromFile(path), WithFileWatch(path, 0))
+	assert.Error(t, err, "expecting error for invalid watch interval")
+
+	ordererConfig, err := cfg.OrdererConfig("orderer.example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "orderer.example.com:7050", ordererConfig.URL)
+
+	content = []byte(strings.Replace(string(content), "url: orderer.example.com:7050", "url: orderer.example.com:8050", 1))
+	require.NoError(t, ioutil.WriteFile(path, content, 0600))
+	require.NoError(t, os.Chtimes(path, time.Now(), time.Now().Add(time.Minute)))
+
+	select {
+	case <-reloaded:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the config to be reloaded")
+	}
+
+	ordererConfig, err = cfg.OrdererConfig("orderer.example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "orderer.example.com:8050", ordererConfig.URL)
+}
+
+func TestReloadableEndpointConfigNoProvider(t *testing.T) {
+	_, err := NewReloadableEndpointConfig(nil)
+	assert.Error(t, err)
+}
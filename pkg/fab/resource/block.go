@@ -77,7 +77,7 @@ func retrieveBlock(reqCtx reqContext.Context, orderers []fab.Orderer, channel st
 		Data:   seekInfoBytes,
 	}
 
-	resp, err := retry.NewInvoker(retry.New(opts.retry)).Invoke(
+	resp, err := retry.NewInvoker(retry.New(opts.retry), retry.WithBeforeRetry(opts.beforeRetry)).InvokeWithContext(reqCtx,
 		func() (interface{}, error) {
 			return txn.SendPayload(reqCtx, &payload, orderers)
 		},
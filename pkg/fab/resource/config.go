@@ -7,12 +7,14 @@ SPDX-License-Identifier: Apache-2.0
 package resource
 
 import (
+	"fmt"
+
 	"github.com/golang/protobuf/proto"
 	"github.com/pkg/errors"
 
-	"github.com/hyperledger/fabric-sdk-go/internal/github.com/hyperledger/fabric/common/crypto"
 	fcutils "github.com/hyperledger/fabric-sdk-go/internal/github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/context"
+	"github.com/hyperledger/fabric-sdk-go/pkg/core/cryptosuite"
 	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/common"
 )
 
@@ -24,8 +26,8 @@ func CreateConfigSignature(ctx context.Client, config []byte) (*common.ConfigSig
 		return nil, errors.WithMessage(err, "failed to get user context's identity")
 	}
 
-	// generate a random nonce
-	nonce, err := crypto.GetRandomNonce()
+	// generate a nonce (random unless the crypto suite supplies it)
+	nonce, err := cryptosuite.GetNonce(ctx.CryptoSuite())
 	if err != nil {
 		return nil, errors.WithMessage(err, "nonce creation failed")
 	}
@@ -106,6 +108,19 @@ func CreateConfigEnvelope(data []byte) (*common.ConfigEnvelope, error) {
 	return configEnvelope, nil
 }
 
+// VerifyConfigBlock checks that the block retrieved for the given block number is a config block
+func VerifyConfigBlock(block *common.Block, blockNumber uint64) error {
+	if block.Data == nil || len(block.Data.Data) != 1 {
+		return errors.Errorf("block %d is not a config block: config block must contain one transaction", blockNumber)
+	}
+
+	if _, err := CreateConfigEnvelope(block.Data.Data[0]); err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("block %d is not a config block", blockNumber))
+	}
+
+	return nil
+}
+
 // GetLastConfigFromBlock returns the LastConfig data from the given block
 func GetLastConfigFromBlock(block *common.Block) (*common.LastConfig, error) {
 	if block.Metadata == nil {
@@ -9,8 +9,10 @@ package resource
 import (
 	"io/ioutil"
 	"path"
+	"strings"
 	"testing"
 
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/mocks"
 	"github.com/hyperledger/fabric-sdk-go/test/metadata"
 )
 
@@ -39,3 +41,23 @@ func TestCreateConfigSignature(t *testing.T) {
 		t.Fatalf("Expected 'channel configuration required %v", err)
 	}
 }
+
+func TestVerifyConfigBlock(t *testing.T) {
+	builder := &mocks.MockConfigBlockBuilder{
+		MockConfigGroupBuilder: mocks.MockConfigGroupBuilder{
+			ModPolicy:      "Admins",
+			MSPNames:       []string{"Org1MSP"},
+			OrdererAddress: "localhost:7054",
+		},
+		Index: 2,
+	}
+
+	if err := VerifyConfigBlock(builder.Build(), 2); err != nil {
+		t.Fatalf("Expected config block to be verified: %s", err)
+	}
+
+	err := VerifyConfigBlock(mocks.NewSimpleMockBlock(), 2)
+	if err == nil || !strings.Contains(err.Error(), "block 2 is not a config block") {
+		t.Fatalf("Expected error for block which is not a config block, got: %v", err)
+	}
+}
@@ -37,7 +37,8 @@ type fabCtx struct {
 }
 
 type options struct {
-	retry retry.Opts
+	retry       retry.Opts
+	beforeRetry retry.BeforeRetryHandler
 }
 
 // Opt is a resource option
@@ -50,6 +51,13 @@ func WithRetry(retry retry.Opts) Opt {
 	}
 }
 
+// WithBeforeRetry supplies a function to call before each retry attempt
+func WithBeforeRetry(beforeRetry retry.BeforeRetryHandler) Opt {
+	return func(options *options) {
+		options.beforeRetry = beforeRetry
+	}
+}
+
 // SignChannelConfig signs a configuration.
 func SignChannelConfig(ctx context.Client, config []byte, signer msp.SigningIdentity) (*common.ConfigSignature, error) {
 	logger.Debug("SignChannelConfig - start")
@@ -109,7 +117,7 @@ func CreateChannel(reqCtx reqContext.Context, request api.CreateChannelRequest,
 
 	optionsValue := getOpts(opts...)
 
-	_, err = retry.NewInvoker(retry.New(optionsValue.retry)).Invoke(
+	_, err = retry.NewInvoker(retry.New(optionsValue.retry)).InvokeWithContext(reqCtx,
 		func() (interface{}, error) {
 			return nil, createOrUpdateChannel(reqCtx, txh, request)
 		},
@@ -173,6 +181,26 @@ func LastConfigFromOrderer(reqCtx reqContext.Context, channelName string, ordere
 	return block, nil
 }
 
+// ConfigBlockFromOrderer fetches the configuration block at the given block number for
+// the specified channel from the given orderer. An error is returned if the block at the
+// given number is not a config block.
+func ConfigBlockFromOrderer(reqCtx reqContext.Context, channelName string, orderer fab.Orderer, blockNumber uint64, opts ...Opt) (*common.Block, error) {
+	logger.Debugf("channelConfig - start for channel %s, block %d", channelName, blockNumber)
+
+	optionsValue := getOpts(opts...)
+
+	block, err := retrieveBlock(reqCtx, []fab.Orderer{orderer}, channelName, newSpecificSeekPosition(blockNumber), optionsValue)
+	if err != nil {
+		return nil, errors.WithMessage(err, "retrieve block failed")
+	}
+
+	if err := VerifyConfigBlock(block, blockNumber); err != nil {
+		return nil, err
+	}
+
+	return block, nil
+}
+
 // JoinChannel sends a join channel proposal to the target peer.
 //
 // TODO extract targets from request into parameter.
@@ -225,41 +253,84 @@ func extractSignedEnvelope(reqEnvelope []byte) (*fab.SignedEnvelope, error) {
 	return &se, nil
 }
 
+// CreateChannelEnvelope builds and signs the envelope of a channel create/update request without
+// sending it to the orderer. The orderer of the request is not required.
+func CreateChannelEnvelope(ctx context.Client, request api.CreateChannelRequest) (*fab.SignedEnvelope, fab.TransactionID, error) {
+	if request.Name == "" {
+		return nil, fab.EmptyTransactionID, errors.New("missing name request parameter for the new channel")
+	}
+
+	if request.Config == nil {
+		return nil, fab.EmptyTransactionID, errors.New("missing envelope request parameter containing the configuration of the new channel")
+	}
+
+	if request.Signatures == nil {
+		return nil, fab.EmptyTransactionID, errors.New("missing signatures request parameter for the new channel")
+	}
+
+	txh, err := txn.NewHeader(ctx, request.Name)
+	if err != nil {
+		return nil, fab.EmptyTransactionID, errors.WithMessage(err, "creation of transaction header failed")
+	}
+
+	payload, err := createChannelPayload(ctx, txh, request)
+	if err != nil {
+		return nil, fab.EmptyTransactionID, err
+	}
+
+	envelope, err := txn.SignPayload(ctx, payload)
+	if err != nil {
+		return nil, fab.EmptyTransactionID, err
+	}
+	return envelope, txh.TransactionID(), nil
+}
+
 // createOrUpdateChannel creates a new channel or updates an existing channel.
 func createOrUpdateChannel(reqCtx reqContext.Context, txh *txn.TransactionHeader, request api.CreateChannelRequest) error {
 
+	ctx, ok := contextImpl.RequestClientContext(reqCtx)
+	if !ok {
+		return errors.New("failed get client context from reqContext for Creating ChannelHeader")
+	}
+
+	payload, err := createChannelPayload(ctx, txh, request)
+	if err != nil {
+		return err
+	}
+
+	_, err = txn.BroadcastPayload(reqCtx, payload, []fab.Orderer{request.Orderer})
+	if err != nil {
+		return errors.WithMessage(err, "SendEnvelope failed")
+	}
+	return nil
+}
+
+// createChannelPayload creates the CONFIG_UPDATE payload of a channel create/update request.
+func createChannelPayload(ctx context.Client, txh *txn.TransactionHeader, request api.CreateChannelRequest) (*common.Payload, error) {
+
 	configUpdateEnvelope := &common.ConfigUpdateEnvelope{
 		ConfigUpdate: request.Config,
 		Signatures:   request.Signatures,
 	}
 	configUpdateEnvelopeBytes, err := proto.Marshal(configUpdateEnvelope)
 	if err != nil {
-		return errors.Wrap(err, "marshal configUpdateEnvelope failed")
+		return nil, errors.Wrap(err, "marshal configUpdateEnvelope failed")
 	}
 
-	ctx, ok := contextImpl.RequestClientContext(reqCtx)
-	if !ok {
-		return errors.New("failed get client context from reqContext for Creating ChannelHeader")
-	}
 	channelHeaderOpts := txn.ChannelHeaderOpts{
 		TxnHeader:   txh,
 		TLSCertHash: ccomm.TLSCertHash(ctx.EndpointConfig()),
 	}
 	channelHeader, err := txn.CreateChannelHeader(common.HeaderType_CONFIG_UPDATE, channelHeaderOpts)
 	if err != nil {
-		return errors.WithMessage(err, "CreateChannelHeader failed")
+		return nil, errors.WithMessage(err, "CreateChannelHeader failed")
 	}
 
 	payload, err := txn.CreatePayload(txh, channelHeader, configUpdateEnvelopeBytes)
 	if err != nil {
-		return errors.WithMessage(err, "CreatePayload failed")
-	}
-
-	_, err = txn.BroadcastPayload(reqCtx, payload, []fab.Orderer{request.Orderer})
-	if err != nil {
-		return errors.WithMessage(err, "SendEnvelope failed")
+		return nil, errors.WithMessage(err, "CreatePayload failed")
 	}
-	return nil
+	return payload, nil
 }
 
 // QueryChannels queries the names of all the channels that a peer has joined.
@@ -353,7 +424,7 @@ func InstallChaincode(reqCtx reqContext.Context, req api.InstallChaincodeRequest
 
 	optionsValue := getOpts(opts...)
 
-	resp, err := retry.NewInvoker(retry.New(optionsValue.retry)).Invoke(
+	resp, err := retry.NewInvoker(retry.New(optionsValue.retry)).InvokeWithContext(reqCtx,
 		func() (interface{}, error) {
 			return txn.SendProposal(reqCtx, prop, targets)
 		},
@@ -384,7 +455,7 @@ func queryChaincodeWithTarget(reqCtx reqContext.Context, request fab.ChaincodeIn
 		return nil, errors.WithMessage(err, "NewProposal failed")
 	}
 
-	resp, err := retry.NewInvoker(retry.New(opts.retry)).Invoke(
+	resp, err := retry.NewInvoker(retry.New(opts.retry), retry.WithBeforeRetry(opts.beforeRetry)).InvokeWithContext(reqCtx,
 		func() (interface{}, error) {
 			return txn.SendProposal(reqCtx, tp, targets)
 		},
@@ -15,6 +15,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric-sdk-go/pkg/common/errors/retry"
 
 	"github.com/hyperledger/fabric-sdk-go/pkg/common/errors/status"
@@ -110,6 +111,60 @@ func TestCreateChannel(t *testing.T) {
 	}
 }
 
+func TestCreateChannelEnvelope(t *testing.T) {
+	ctx := setupContext()
+
+	configTx, err := ioutil.ReadFile(path.Join("../../../", metadata.ChannelConfigPath, "mychannel.tx"))
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	config, err := ExtractChannelConfig(configTx)
+	if err != nil {
+		t.Fatalf("Failed to extract channel config: %s", err)
+	}
+	signature, err := SignChannelConfig(ctx, config, nil)
+	if err != nil {
+		t.Fatalf("Failed to sign channel config: %s", err)
+	}
+
+	_, _, err = CreateChannelEnvelope(ctx, api.CreateChannelRequest{Config: config, Signatures: []*common.ConfigSignature{signature}})
+	if err == nil {
+		t.Fatalf("Expected error creating envelope without name")
+	}
+
+	_, _, err = CreateChannelEnvelope(ctx, api.CreateChannelRequest{Name: "mychannel", Signatures: []*common.ConfigSignature{signature}})
+	if err == nil {
+		t.Fatalf("Expected error creating envelope without config")
+	}
+
+	envelope, txID, err := CreateChannelEnvelope(ctx, api.CreateChannelRequest{
+		Name:       "mychannel",
+		Config:     config,
+		Signatures: []*common.ConfigSignature{signature},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create channel envelope: %s", err)
+	}
+	if txID == fab.EmptyTransactionID {
+		t.Fatalf("Expected transaction ID")
+	}
+
+	payload := &common.Payload{}
+	if err := proto.Unmarshal(envelope.Payload, payload); err != nil {
+		t.Fatalf("Failed to unmarshal payload: %s", err)
+	}
+	configUpdateEnvelope := &common.ConfigUpdateEnvelope{}
+	if err := proto.Unmarshal(payload.Data, configUpdateEnvelope); err != nil {
+		t.Fatalf("Failed to unmarshal config update envelope: %s", err)
+	}
+	if !bytes.Equal(config, configUpdateEnvelope.ConfigUpdate) {
+		t.Fatalf("Expected config update to be included in envelope")
+	}
+	if len(configUpdateEnvelope.Signatures) != 1 {
+		t.Fatalf("Expected one config signature but got %d", len(configUpdateEnvelope.Signatures))
+	}
+}
+
 func TestJoinChannel(t *testing.T) {
 	var peers []fab.ProposalProcessor
 
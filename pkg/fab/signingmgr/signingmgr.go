@@ -39,6 +39,11 @@ func (mgr *SigningManager) Sign(object []byte, key core.Key) ([]byte, error) {
 		return nil, errors.New("key (for signing) required")
 	}
 
+	if cryptosuite.IsEd25519Key(key) {
+		// Ed25519 signs the object itself rather than a digest of it
+		return mgr.cryptoProvider.Sign(key, object, mgr.signerOpts)
+	}
+
 	digest, err := mgr.cryptoProvider.Hash(object, mgr.hashOpts)
 	if err != nil {
 		return nil, err
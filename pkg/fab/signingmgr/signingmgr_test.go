@@ -10,6 +10,7 @@ import (
 	"bytes"
 	"testing"
 
+	"github.com/hyperledger/fabric-sdk-go/pkg/core/cryptosuite"
 	bccspwrapper "github.com/hyperledger/fabric-sdk-go/pkg/core/cryptosuite/bccsp/wrapper"
 	fcmocks "github.com/hyperledger/fabric-sdk-go/pkg/fab/mocks"
 	"github.com/hyperledger/fabric-sdk-go/pkg/msp/test/mockmsp"
@@ -48,3 +49,33 @@ func TestSigningManager(t *testing.T) {
 	}
 
 }
+
+func TestSigningManagerEd25519(t *testing.T) {
+
+	cs := cryptosuite.GetDefault()
+	signingMgr, err := New(cs)
+	if err != nil {
+		t.Fatalf("Failed to  setup signing manager: %s", err)
+	}
+
+	key, err := cs.KeyGen(cryptosuite.GetED25519KeyGenOpts(true))
+	if err != nil {
+		t.Fatalf("Failed to generate key: %s", err)
+	}
+
+	object := []byte("Hello")
+	signedObj, err := signingMgr.Sign(object, key)
+	if err != nil {
+		t.Fatalf("Failed to sign object: %s", err)
+	}
+
+	// The object itself (rather than its hash) is signed
+	pubKey, err := key.PublicKey()
+	if err != nil {
+		t.Fatalf("Failed to get public key: %s", err)
+	}
+	valid, err := cs.Verify(pubKey, signedObj, object, nil)
+	if err != nil || !valid {
+		t.Fatalf("Failed to verify signature: %v", err)
+	}
+}
@@ -15,7 +15,7 @@ import (
 	"github.com/golang/protobuf/ptypes"
 	"github.com/pkg/errors"
 
-	"github.com/hyperledger/fabric-sdk-go/internal/github.com/hyperledger/fabric/common/crypto"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/logging"
 	contextApi "github.com/hyperledger/fabric-sdk-go/pkg/common/providers/context"
 	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
 	"github.com/hyperledger/fabric-sdk-go/pkg/core/cryptosuite"
@@ -54,8 +54,8 @@ func (th *TransactionHeader) ChannelID() string {
 // NewHeader computes a TransactionID from the current user context and holds
 // metadata to create transaction proposals.
 func NewHeader(ctx contextApi.Client, channelID string) (*TransactionHeader, error) {
-	// generate a random nonce
-	nonce, err := crypto.GetRandomNonce()
+	// generate a nonce (random unless the crypto suite supplies it)
+	nonce, err := cryptosuite.GetNonce(ctx.CryptoSuite())
 	if err != nil {
 		return nil, errors.WithMessage(err, "nonce creation failed")
 	}
@@ -99,8 +99,8 @@ func computeTxnID(nonce, creator []byte, h hash.Hash) (string, error) {
 	return id, nil
 }
 
-// signPayload signs payload
-func signPayload(ctx contextApi.Client, payload *common.Payload) (*fab.SignedEnvelope, error) {
+// SignPayload signs the payload with the signing identity of the given client context
+func SignPayload(ctx contextApi.Client, payload *common.Payload) (*fab.SignedEnvelope, error) {
 	payloadBytes, err := proto.Marshal(payload)
 	if err != nil {
 		return nil, errors.WithMessage(err, "marshaling of payload failed")
@@ -127,7 +127,9 @@ type ChannelHeaderOpts struct {
 //
 // TODO: Determine if this function should be exported after refactoring is completed.
 func CreateChannelHeader(headerType common.HeaderType, opts ChannelHeaderOpts) (*common.ChannelHeader, error) {
-	logger.Debugf("buildChannelHeader - headerType: %s channelID: %s txID: %d epoch: % chaincodeID: %s timestamp: %v", headerType, opts.TxnHeader.channelID, opts.TxnHeader.id, opts.Epoch, opts.ChaincodeID, opts.Timestamp)
+	logger.Debugw("buildChannelHeader", logging.NewField("headerType", headerType), logging.ChannelID(opts.TxnHeader.channelID),
+		logging.TxID(string(opts.TxnHeader.id)), logging.NewField("epoch", opts.Epoch), logging.NewField("chaincodeID", opts.ChaincodeID),
+		logging.NewField("timestamp", opts.Timestamp))
 	channelHeader := &common.ChannelHeader{
 		Type:        int32(headerType),
 		ChannelId:   opts.TxnHeader.channelID,
@@ -57,8 +57,8 @@ func CreateChaincodeInvokeProposal(txh fab.TransactionHeader, request fab.Chainc
 	return &tp, nil
 }
 
-// signProposal creates a SignedProposal based on the current context.
-func signProposal(ctx contextApi.Client, proposal *pb.Proposal) (*pb.SignedProposal, error) {
+// SignProposal creates a SignedProposal based on the current context.
+func SignProposal(ctx contextApi.Client, proposal *pb.Proposal) (*pb.SignedProposal, error) {
 	proposalBytes, err := proto.Marshal(proposal)
 	if err != nil {
 		return nil, errors.Wrap(err, "mashal proposal failed")
@@ -98,7 +98,7 @@ func SendProposal(reqCtx reqContext.Context, proposal *fab.TransactionProposal,
 	if !ok {
 		return nil, errors.New("failed get client context from reqContext for signProposal")
 	}
-	signedProposal, err := signProposal(ctx, proposal.Proposal)
+	signedProposal, err := SignProposal(ctx, proposal.Proposal)
 	if err != nil {
 		return nil, errors.WithMessage(err, "sign proposal failed")
 	}
@@ -49,9 +49,9 @@ func TestNewTransactionProposal(t *testing.T) {
 		t.Fatalf("Create Transaction Proposal Failed: %s", err)
 	}
 
-	signedProposal, err := signProposal(ctx, tp.Proposal)
+	signedProposal, err := SignProposal(ctx, tp.Proposal)
 	if err != nil {
-		t.Fatalf("signProposal failed: %s", err)
+		t.Fatalf("SignProposal failed: %s", err)
 	}
 
 	_, err = proto.Marshal(signedProposal)
@@ -191,9 +191,9 @@ func TestSendTransactionProposalToProcessors(t *testing.T) {
 	defer mockCtrl.Finish()
 	proc := mock_context.NewMockProposalProcessor(mockCtrl)
 
-	stp, err := signProposal(ctx, &pb.Proposal{})
+	stp, err := SignProposal(ctx, &pb.Proposal{})
 	if err != nil {
-		t.Fatalf("signProposal returned error: %s", err)
+		t.Fatalf("SignProposal returned error: %s", err)
 	}
 	tp := fab.ProcessProposalRequest{
 		SignedProposal: stp,
@@ -241,9 +241,9 @@ func TestProposalResponseError(t *testing.T) {
 	proc := mock_context.NewMockProposalProcessor(mockCtrl)
 	proc2 := mock_context.NewMockProposalProcessor(mockCtrl)
 
-	stp, err := signProposal(ctx, &pb.Proposal{})
+	stp, err := SignProposal(ctx, &pb.Proposal{})
 	if err != nil {
-		t.Fatalf("signProposal returned error: %s", err)
+		t.Fatalf("SignProposal returned error: %s", err)
 	}
 	tp := fab.ProcessProposalRequest{
 		SignedProposal: stp,
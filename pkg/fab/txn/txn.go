@@ -144,7 +144,7 @@ func BroadcastPayload(reqCtx reqContext.Context, payload *common.Payload, ordere
 	if !ok {
 		return nil, errors.New("failed get client context from reqContext for signPayload")
 	}
-	envelope, err := signPayload(ctx, payload)
+	envelope, err := SignPayload(ctx, payload)
 	if err != nil {
 		return nil, err
 	}
@@ -178,14 +178,14 @@ func broadcastEnvelope(reqCtx reqContext.Context, envelope *fab.SignedEnvelope,
 }
 
 func sendBroadcast(reqCtx reqContext.Context, envelope *fab.SignedEnvelope, orderer fab.Orderer) (*fab.TransactionResponse, error) {
-	logger.Debugf("Broadcasting envelope to orderer :%s\n", orderer.URL())
+	logger.Debugw("Broadcasting envelope to orderer", context.LogFields(reqCtx, logging.EndpointURL(orderer.URL()))...)
 	// Send request
 	if _, err := orderer.SendBroadcast(reqCtx, envelope); err != nil {
-		logger.Debugf("Receive Error Response from orderer :%v\n", err)
+		logger.Debugw("Received error response from orderer", context.LogFields(reqCtx, logging.EndpointURL(orderer.URL()), logging.NewField("error", err))...)
 		return nil, errors.Wrapf(err, "calling orderer '%s' failed", orderer.URL())
 	}
 
-	logger.Debugf("Receive Success Response from orderer\n")
+	logger.Debugw("Received success response from orderer", context.LogFields(reqCtx, logging.EndpointURL(orderer.URL()))...)
 	return &fab.TransactionResponse{Orderer: orderer.URL()}, nil
 }
 
@@ -199,7 +199,7 @@ func SendPayload(reqCtx reqContext.Context, payload *common.Payload, orderers []
 	if !ok {
 		return nil, errors.New("failed get client context from reqContext for signPayload")
 	}
-	envelope, err := signPayload(ctx, payload)
+	envelope, err := SignPayload(ctx, payload)
 	if err != nil {
 		return nil, err
 	}
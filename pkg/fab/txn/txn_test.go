@@ -7,8 +7,10 @@ SPDX-License-Identifier: Apache-2.0
 package txn
 
 import (
+	"bytes"
 	reqContext "context"
 	"fmt"
+	"math/rand"
 	"os"
 	"strconv"
 	"strings"
@@ -20,6 +22,7 @@ import (
 
 	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
 	"github.com/hyperledger/fabric-sdk-go/pkg/context"
+	"github.com/hyperledger/fabric-sdk-go/pkg/core/cryptosuite"
 	"github.com/hyperledger/fabric-sdk-go/pkg/fab/mocks"
 	mspmocks "github.com/hyperledger/fabric-sdk-go/pkg/msp/test/mockmsp"
 	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/common"
@@ -305,7 +308,7 @@ func TestSignPayload(t *testing.T) {
 
 	payload := common.Payload{}
 
-	signedEnv, err := signPayload(ctx, &payload)
+	signedEnv, err := SignPayload(ctx, &payload)
 
 	if err != nil || signedEnv == nil {
 		t.Fatal("Test Sign Payload Failed")
@@ -352,3 +355,31 @@ func setupMassiveTestOrderer(numberOfOrderers int) []fab.Orderer {
 
 	return orderers
 }
+
+func TestNewHeaderWithNonceSource(t *testing.T) {
+	user := mspmocks.NewMockSigningIdentity("test", "1234")
+	ctx := mocks.NewMockContext(user)
+
+	// Random nonces by default
+	txh1, err := NewHeader(ctx, "test")
+	assert.Nil(t, err, "NewHeader failed")
+	txh2, err := NewHeader(ctx, "test")
+	assert.Nil(t, err, "NewHeader failed")
+	assert.NotEqual(t, txh1.TransactionID(), txh2.TransactionID())
+
+	// The same nonce source produces the same transaction IDs
+	ctx.SetCryptoSuite(cryptosuite.WithNonceSource(&mocks.MockCryptoSuite{}, rand.New(rand.NewSource(1))))
+	txh1, err = NewHeader(ctx, "test")
+	assert.Nil(t, err, "NewHeader failed")
+
+	ctx.SetCryptoSuite(cryptosuite.WithNonceSource(&mocks.MockCryptoSuite{}, rand.New(rand.NewSource(1))))
+	txh2, err = NewHeader(ctx, "test")
+	assert.Nil(t, err, "NewHeader failed")
+	assert.Equal(t, txh1.TransactionID(), txh2.TransactionID())
+	assert.Equal(t, txh1.Nonce(), txh2.Nonce())
+
+	// The nonce source is exhausted
+	ctx.SetCryptoSuite(cryptosuite.WithNonceSource(&mocks.MockCryptoSuite{}, bytes.NewReader([]byte("short"))))
+	_, err = NewHeader(ctx, "test")
+	assert.NotNil(t, err, "expected error for exhausted nonce source")
+}
@@ -9,9 +9,14 @@ package fabpvdr
 import (
 	"crypto/sha256"
 	"strconv"
+	"sync/atomic"
+	"time"
 
 	"github.com/hyperledger/fabric-sdk-go/pkg/common/options"
 	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/events/client"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/events/deliverclient/seek"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/events/service/dispatcher"
 )
 
 // CacheKey holds a key for the provider cache
@@ -23,9 +28,23 @@ type CacheKey struct {
 }
 
 type params struct {
-	permitBlockEvents bool
+	permitBlockEvents       bool
+	seekType                string
+	fromBlock               uint64
+	reconn                  *bool
+	maxReconnAttempts       uint
+	timeBetweenConnAttempts time.Duration
+	consumerBufferSize      uint
+	consumerTimeout         *time.Duration
+	connStateHandlerID      uint64
+	dispatchWorkers         int
+	dispatchPolicy          dispatcher.DispatchPolicy
 }
 
+// connStateHandlerCount is used to generate a unique cache key for event services which
+// have a connection state handler since handlers (functions) cannot be compared
+var connStateHandlerCount uint64
+
 func defaultParams() *params {
 	return &params{}
 }
@@ -34,9 +53,71 @@ func (p *params) PermitBlockEvents() {
 	p.permitBlockEvents = true
 }
 
+func (p *params) SetSeekType(value seek.Type) {
+	p.seekType = string(value)
+}
+
+func (p *params) SetFromBlock(value uint64) {
+	p.fromBlock = value
+}
+
+func (p *params) SetReconnect(value bool) {
+	p.reconn = &value
+}
+
+func (p *params) SetMaxReconnectAttempts(value uint) {
+	p.maxReconnAttempts = value
+}
+
+func (p *params) SetTimeBetweenConnectAttempts(value time.Duration) {
+	p.timeBetweenConnAttempts = value
+}
+
+func (p *params) SetEventConsumerBufferSize(value uint) {
+	p.consumerBufferSize = value
+}
+
+func (p *params) SetEventConsumerTimeout(value time.Duration) {
+	p.consumerTimeout = &value
+}
+
+func (p *params) SetConnectionStateHandler(value client.ConnectionStateHandler) {
+	if value != nil {
+		p.connStateHandlerID = atomic.AddUint64(&connStateHandlerCount, 1)
+	}
+}
+
+func (p *params) SetDispatchWorkers(value int) {
+	p.dispatchWorkers = value
+}
+
+func (p *params) SetDispatchPolicy(value dispatcher.DispatchPolicy) {
+	p.dispatchPolicy = value
+}
+
 func (p *params) getOptKey() string {
 	//	Construct opts portion
 	optKey := "blockEvents:" + strconv.FormatBool(p.permitBlockEvents)
+	if p.seekType != "" {
+		optKey += ",seekType:" + p.seekType + ",fromBlock:" + strconv.FormatUint(p.fromBlock, 10)
+	}
+	if p.reconn != nil {
+		optKey += ",reconnect:" + strconv.FormatBool(*p.reconn) +
+			",maxReconnectAttempts:" + strconv.FormatUint(uint64(p.maxReconnAttempts), 10) +
+			",timeBetweenConnectAttempts:" + p.timeBetweenConnAttempts.String()
+	}
+	if p.connStateHandlerID > 0 {
+		optKey += ",connStateHandler:" + strconv.FormatUint(p.connStateHandlerID, 10)
+	}
+	if p.dispatchWorkers > 0 {
+		optKey += ",dispatchWorkers:" + strconv.Itoa(p.dispatchWorkers) + ",dispatchPolicy:" + p.dispatchPolicy.String()
+	}
+	if p.consumerBufferSize > 0 {
+		optKey += ",consumerBufferSize:" + strconv.FormatUint(uint64(p.consumerBufferSize), 10)
+	}
+	if p.consumerTimeout != nil {
+		optKey += ",consumerTimeout:" + p.consumerTimeout.String()
+	}
 	return optKey
 }
 
@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package fabpvdr
 
 import (
+	"regexp"
 	"sync/atomic"
 	"time"
 
@@ -95,6 +96,34 @@ func (ref *EventClientRef) RegisterChaincodeEvent(ccID, eventFilter string) (fab
 	return service.RegisterChaincodeEvent(ccID, eventFilter)
 }
 
+// RegisterChaincodeEventByPattern registers for chaincode events whose name matches the given pattern.
+func (ref *EventClientRef) RegisterChaincodeEventByPattern(ccID string, pattern *regexp.Regexp) (fab.Registration, <-chan *fab.CCEvent, error) {
+	service, err := ref.get()
+	if err != nil {
+		return nil, nil, err
+	}
+	registrar, ok := service.(fab.CCEventPatternRegistrar)
+	if !ok {
+		return nil, nil, errors.New("event service does not support registering for chaincode events by pattern")
+	}
+	return registrar.RegisterChaincodeEventByPattern(ccID, pattern)
+}
+
+// StartBlockNum returns the number of the first block for which an event was received.
+// False is returned if no block has been received yet or if the event service doesn't report it.
+func (ref *EventClientRef) StartBlockNum() (uint64, bool) {
+	service, err := ref.get()
+	if err != nil {
+		logger.Debugf("Error getting event service: %s", err)
+		return 0, false
+	}
+	reporter, ok := service.(fab.StartBlockReporter)
+	if !ok {
+		return 0, false
+	}
+	return reporter.StartBlockNum()
+}
+
 // RegisterTxStatusEvent registers for transaction status events.
 func (ref *EventClientRef) RegisterTxStatusEvent(txID string) (fab.Registration, <-chan *fab.TxStatusEvent, error) {
 	service, err := ref.get()
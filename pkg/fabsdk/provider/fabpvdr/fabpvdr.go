@@ -48,8 +48,28 @@ type InfraProvider struct {
 	membershipCache   cache
 }
 
+// Option configures the InfraProvider
+type Option func(opts *infraOptions)
+
+type infraOptions struct {
+	membershipOpts []membership.Option
+}
+
+// WithMembershipOptions sets the options which are applied to channel memberships,
+// e.g. membership.WithCRLCheck to check identities against a CRL
+func WithMembershipOptions(opts ...membership.Option) Option {
+	return func(o *infraOptions) {
+		o.membershipOpts = append(o.membershipOpts, opts...)
+	}
+}
+
 // New creates a InfraProvider enabling access to core Fabric objects and functionality.
-func New(config fab.EndpointConfig) *InfraProvider {
+func New(config fab.EndpointConfig, opts ...Option) *InfraProvider {
+	o := infraOptions{}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
 	idleTime := config.Timeout(fab.ConnectionIdle)
 	sweepTime := config.Timeout(fab.CacheSweepInterval)
 	eventIdleTime := config.Timeout(fab.EventServiceIdle)
@@ -73,7 +93,7 @@ func New(config fab.EndpointConfig) *InfraProvider {
 		commManager:       comm.NewCachingConnector(sweepTime, idleTime),
 		eventServiceCache: eventServiceCache,
 		chCfgCache:        chconfig.NewRefCache(chConfigRefresh),
-		membershipCache:   membership.NewRefCache(membershipRefresh),
+		membershipCache:   membership.NewRefCache(membershipRefresh, o.membershipOpts...),
 	}
 }
 
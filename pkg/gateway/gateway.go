@@ -0,0 +1,232 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package gateway provides a simplified, high-level API for submitting and evaluating transactions,
+// modelled on the Fabric Gateway programming model. It is a thin facade over the SDK context, the
+// channel client and the channel's event service; applications which require more control may use
+// the low-level channel client (see Network.Client) or the other clients in pkg/client directly.
+//
+//  Basic Flow:
+//  1) Connect to the gateway
+//  2) Get the network (channel)
+//  3) Get the contract (chaincode)
+//  4) Submit or evaluate transactions
+//
+//  gw, err := gateway.Connect(config.FromFile("config.yaml"), gateway.WithUser("User1"))
+//  if err != nil {
+//  	return err
+//  }
+//  defer gw.Close()
+//
+//  network, err := gw.Network("mychannel")
+//  if err != nil {
+//  	return err
+//  }
+//
+//  contract := network.Contract("mycc")
+//  result, err := contract.Submit("move", "a", "b", "10")
+package gateway
+
+import (
+	"sync"
+	"time"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/client/channel"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/errors/retry"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/logging"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/context"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/core"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/msp"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fabsdk"
+	"github.com/pkg/errors"
+)
+
+var logger = logging.NewLogger("fabsdk/gateway")
+
+// Gateway is the entry point to a Fabric network. It holds the identity with which transactions
+// are submitted and provides access to the networks (channels) available to that identity.
+type Gateway struct {
+	sdk             *fabsdk.FabricSDK
+	closeSDK        bool
+	channelProvider func(channelID string) context.ChannelProvider
+	ctxOptions      []fabsdk.ContextOption
+	retry           *retry.Opts
+	commitTimeout   time.Duration
+	mutex           sync.Mutex
+	networks        map[string]*Network
+}
+
+// Option describes a functional parameter for Connect
+type Option func(gw *Gateway) error
+
+// WithUser uses the named user (loaded from the SDK configuration) to submit transactions
+func WithUser(username string) Option {
+	return func(gw *Gateway) error {
+		if username == "" {
+			return errors.New("username is empty")
+		}
+		gw.ctxOptions = append(gw.ctxOptions, fabsdk.WithUser(username))
+		return nil
+	}
+}
+
+// WithIdentity uses a pre-constructed identity to submit transactions
+func WithIdentity(signingIdentity msp.SigningIdentity) Option {
+	return func(gw *Gateway) error {
+		if signingIdentity == nil {
+			return errors.New("signing identity is nil")
+		}
+		gw.ctxOptions = append(gw.ctxOptions, fabsdk.WithIdentity(signingIdentity))
+		return nil
+	}
+}
+
+// WithOrg uses the named organization. If not provided then the organization of the client
+// in the SDK configuration is used.
+func WithOrg(org string) Option {
+	return func(gw *Gateway) error {
+		if org == "" {
+			return errors.New("org is empty")
+		}
+		gw.ctxOptions = append(gw.ctxOptions, fabsdk.WithOrg(org))
+		return nil
+	}
+}
+
+// WithRetry sets the retry options used when submitting and evaluating transactions (see
+// retry.DefaultChannelOpts). By default transactions are not retried.
+func WithRetry(retryOpts retry.Opts) Option {
+	return func(gw *Gateway) error {
+		gw.retry = &retryOpts
+		return nil
+	}
+}
+
+// WithCommitTimeout sets the maximum time that Submit waits for the transaction to be endorsed,
+// ordered and committed. If not provided then the execute timeout of the SDK configuration is used.
+func WithCommitTimeout(timeout time.Duration) Option {
+	return func(gw *Gateway) error {
+		if timeout <= 0 {
+			return errors.Errorf("invalid commit timeout [%s]", timeout)
+		}
+		gw.commitTimeout = timeout
+		return nil
+	}
+}
+
+// Connect creates an SDK instance from the given configuration and connects to the gateway.
+// The SDK instance is closed when the gateway is closed.
+//  Parameters:
+//  configProvider provides the SDK configuration
+//  options hold the identity and other optional gateway options
+//
+//  Returns:
+//  the gateway
+func Connect(configProvider core.ConfigProvider, options ...Option) (*Gateway, error) {
+	sdk, err := fabsdk.New(configProvider)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to create SDK")
+	}
+
+	gw, err := ConnectWithSDK(sdk, options...)
+	if err != nil {
+		sdk.Close()
+		return nil, err
+	}
+	gw.closeSDK = true
+	return gw, nil
+}
+
+// ConnectWithSDK connects to the gateway using an existing SDK instance. The SDK instance
+// is not closed when the gateway is closed.
+//  Parameters:
+//  sdk is the SDK instance
+//  options hold the identity and other optional gateway options
+//
+//  Returns:
+//  the gateway
+func ConnectWithSDK(sdk *fabsdk.FabricSDK, options ...Option) (*Gateway, error) {
+	if sdk == nil {
+		return nil, errors.New("SDK is nil")
+	}
+
+	gw := &Gateway{sdk: sdk}
+	gw.channelProvider = func(channelID string) context.ChannelProvider {
+		return sdk.ChannelContext(channelID, gw.ctxOptions...)
+	}
+
+	if err := gw.applyOptions(options...); err != nil {
+		return nil, err
+	}
+	return gw, nil
+}
+
+func newGateway(channelProvider func(channelID string) context.ChannelProvider, options ...Option) (*Gateway, error) {
+	gw := &Gateway{channelProvider: channelProvider}
+	if err := gw.applyOptions(options...); err != nil {
+		return nil, err
+	}
+	return gw, nil
+}
+
+func (gw *Gateway) applyOptions(options ...Option) error {
+	gw.networks = make(map[string]*Network)
+	for _, option := range options {
+		if err := option(gw); err != nil {
+			return errors.WithMessage(err, "failed to apply gateway option")
+		}
+	}
+	return nil
+}
+
+// Network returns the network (channel) with the given name. Networks are cached
+// so the same instance is returned for subsequent calls.
+func (gw *Gateway) Network(channelID string) (*Network, error) {
+	if channelID == "" {
+		return nil, errors.New("channel ID is empty")
+	}
+
+	gw.mutex.Lock()
+	defer gw.mutex.Unlock()
+
+	if network, ok := gw.networks[channelID]; ok {
+		return network, nil
+	}
+
+	var clientOpts []channel.ClientOption
+	if gw.retry != nil {
+		clientOpts = append(clientOpts, channel.WithDefaultRetry(*gw.retry), channel.WithDefaultExecuteRetry(*gw.retry))
+	}
+
+	client, err := channel.New(gw.channelProvider(channelID), clientOpts...)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to create channel client")
+	}
+
+	logger.Debugf("Created network for channel [%s]", channelID)
+
+	network := &Network{name: channelID, client: client, gateway: gw}
+	gw.networks[channelID] = network
+	return network, nil
+}
+
+// Close closes the networks of the gateway (cancelling their in-flight transactions) and,
+// if the gateway was created with Connect, closes the SDK instance.
+func (gw *Gateway) Close() {
+	gw.mutex.Lock()
+	defer gw.mutex.Unlock()
+
+	for channelID, network := range gw.networks {
+		if err := network.client.Close(); err != nil {
+			logger.Warnf("Failed to close network [%s]: %s", channelID, err)
+		}
+	}
+	gw.networks = make(map[string]*Network)
+	if gw.closeSDK {
+		gw.sdk.Close()
+		gw.closeSDK = false
+	}
+}
@@ -0,0 +1,169 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package gateway
+
+import (
+	"testing"
+	"time"
+
+	txnmocks "github.com/hyperledger/fabric-sdk-go/pkg/client/common/mocks"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/errors/retry"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/errors/status"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/context"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+	contextImpl "github.com/hyperledger/fabric-sdk-go/pkg/context"
+	configImpl "github.com/hyperledger/fabric-sdk-go/pkg/core/config"
+	fcmocks "github.com/hyperledger/fabric-sdk-go/pkg/fab/mocks"
+	mspmocks "github.com/hyperledger/fabric-sdk-go/pkg/msp/test/mockmsp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	channelID     = "mychannel"
+	sdkConfigFile = "../../test/fixtures/config/config_test.yaml"
+)
+
+func TestConnect(t *testing.T) {
+	gw, err := Connect(configImpl.FromFile(sdkConfigFile), WithUser("User1"), WithOrg("org1"))
+	require.NoError(t, err)
+	assert.True(t, gw.closeSDK, "expecting gateway to own the SDK")
+	gw.Close()
+	gw.Close()
+
+	_, err = Connect(configImpl.FromFile(sdkConfigFile), WithUser(""))
+	assert.Error(t, err, "expecting error for empty username")
+
+	_, err = ConnectWithSDK(nil)
+	assert.Error(t, err, "expecting error for nil SDK")
+}
+
+func TestOptions(t *testing.T) {
+	_, err := newGateway(nil, WithOrg(""))
+	assert.Error(t, err)
+
+	_, err = newGateway(nil, WithIdentity(nil))
+	assert.Error(t, err)
+
+	_, err = newGateway(nil, WithCommitTimeout(0))
+	assert.Error(t, err)
+
+	gw, err := newGateway(nil, WithRetry(retry.DefaultChannelOpts), WithCommitTimeout(time.Second),
+		WithIdentity(mspmocks.NewMockSigningIdentity("test", "test")))
+	require.NoError(t, err)
+	assert.Equal(t, retry.DefaultChannelOpts.Attempts, gw.retry.Attempts)
+	assert.Equal(t, time.Second, gw.commitTimeout)
+	assert.Len(t, gw.ctxOptions, 1)
+}
+
+func TestNetwork(t *testing.T) {
+	peer := fcmocks.NewMockPeer("Peer1", "http://peer1.com")
+	gw, err := newGateway(newMockChannelProvider(t, peer), WithRetry(retry.DefaultChannelOpts))
+	require.NoError(t, err)
+
+	_, err = gw.Network("")
+	assert.Error(t, err, "expecting error for empty channel ID")
+
+	network, err := gw.Network(channelID)
+	require.NoError(t, err)
+	assert.Equal(t, channelID, network.Name())
+	assert.NotNil(t, network.Client())
+
+	cached, err := gw.Network(channelID)
+	require.NoError(t, err)
+	assert.True(t, network == cached, "expecting network to be cached")
+
+	gw.Close()
+	network2, err := gw.Network(channelID)
+	require.NoError(t, err)
+	assert.False(t, network == network2, "expecting new network after close")
+}
+
+func TestSubmitAndEvaluate(t *testing.T) {
+	peer := fcmocks.NewMockPeer("Peer1", "http://peer1.com")
+	peer.Payload = []byte("result")
+
+	gw, err := newGateway(newMockChannelProvider(t, peer), WithCommitTimeout(5*time.Second))
+	require.NoError(t, err)
+	defer gw.Close()
+
+	network, err := gw.Network(channelID)
+	require.NoError(t, err)
+
+	contract := network.Contract("mycc")
+	assert.Equal(t, "mycc", contract.Name())
+
+	result, err := contract.Evaluate("query", "a")
+	require.NoError(t, err)
+	assert.Equal(t, "result", string(result))
+
+	result, err = contract.Submit("move", "a", "b", "10")
+	require.NoError(t, err)
+	assert.Equal(t, "result", string(result))
+
+	peer.Error = status.New(status.EndorserServerStatus, int32(500), "internal error", nil)
+
+	_, err = contract.Evaluate("query", "a")
+	assert.Error(t, err)
+
+	_, err = contract.Submit("move", "a", "b", "10")
+	assert.Error(t, err)
+	s, ok := status.FromError(err)
+	assert.True(t, ok, "expecting status error but got %+v", err)
+	assert.Equal(t, status.EndorserServerStatus, s.Group)
+}
+
+func TestContractEvents(t *testing.T) {
+	gw, err := newGateway(newMockChannelProvider(t))
+	require.NoError(t, err)
+
+	network, err := gw.Network(channelID)
+	require.NoError(t, err)
+
+	contract := network.Contract("mycc")
+	reg, events, err := contract.RegisterEvent("event.*")
+	require.NoError(t, err)
+	assert.NotNil(t, events)
+	contract.Unregister(reg)
+}
+
+func newMockChannelProvider(t *testing.T, peers ...fab.Peer) func(string) context.ChannelProvider {
+	user := mspmocks.NewMockSigningIdentity("test", "test")
+	ctx := fcmocks.NewMockContext(user)
+
+	orderer := fcmocks.NewMockOrderer("", nil)
+	transactor := txnmocks.MockTransactor{
+		Ctx:       ctx,
+		ChannelID: channelID,
+		Orderers:  []fab.Orderer{orderer},
+	}
+	ctx.InfraProvider().(*fcmocks.MockInfraProvider).SetCustomTransactor(&transactor)
+
+	chProvider, err := fcmocks.NewMockChannelProvider(ctx)
+	require.NoError(t, err)
+	chService, err := chProvider.ChannelService(ctx, channelID)
+	require.NoError(t, err)
+	ctx.MockProviderContext.ChannelProvider().(*fcmocks.MockChannelProvider).SetCustomChannelService(chService)
+
+	selectionProvider, err := txnmocks.NewMockSelectionProvider(nil, peers)
+	require.NoError(t, err)
+	selection, err := selectionProvider.CreateSelectionService(channelID)
+	require.NoError(t, err)
+	ctx.MockProviderContext.SelectionProvider().(*fcmocks.MockSelectionProvider).SetCustomSelectionService(selection)
+
+	discoveryProvider, err := txnmocks.NewMockDiscoveryProvider(nil, nil)
+	require.NoError(t, err)
+	discovery, err := discoveryProvider.CreateDiscoveryService(channelID)
+	require.NoError(t, err)
+	ctx.MockProviderContext.DiscoveryProvider().(*fcmocks.MockStaticDiscoveryProvider).SetCustomDiscoveryService(discovery)
+
+	return func(channelID string) context.ChannelProvider {
+		return func() (context.Channel, error) {
+			return contextImpl.NewChannel(func() (context.Client, error) { return ctx, nil }, channelID)
+		}
+	}
+}
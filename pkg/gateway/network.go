@@ -0,0 +1,107 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package gateway
+
+import (
+	"github.com/hyperledger/fabric-sdk-go/pkg/client/channel"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+	"github.com/pkg/errors"
+)
+
+// Network is a channel on which transactions are submitted
+type Network struct {
+	name    string
+	client  *channel.Client
+	gateway *Gateway
+}
+
+// Name returns the name of the network (the channel ID)
+func (n *Network) Name() string {
+	return n.name
+}
+
+// Client returns the underlying channel client, which may be used for operations that
+// are not supported by the gateway (e.g. custom handlers or targets)
+func (n *Network) Client() *channel.Client {
+	return n.client
+}
+
+// Contract returns the contract (chaincode) with the given name
+func (n *Network) Contract(chaincodeID string) *Contract {
+	return &Contract{name: chaincodeID, network: n}
+}
+
+// Contract is a chaincode which is invoked on a network
+type Contract struct {
+	name    string
+	network *Network
+}
+
+// Name returns the name of the contract (the chaincode ID)
+func (c *Contract) Name() string {
+	return c.name
+}
+
+// Evaluate invokes the given chaincode function on the endorsing peers and returns the result
+// without submitting the transaction to the orderer. It is used to query the ledger.
+//  Parameters:
+//  fcn is the chaincode function
+//  args are the arguments of the chaincode function
+//
+//  Returns:
+//  the payload returned by the chaincode
+func (c *Contract) Evaluate(fcn string, args ...string) ([]byte, error) {
+	response, err := c.network.client.Query(c.request(fcn, args))
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to evaluate transaction")
+	}
+	return response.Payload, nil
+}
+
+// Submit invokes the given chaincode function on the endorsing peers, submits the endorsed
+// transaction to the orderer and waits for the transaction to be committed. A nil error is
+// returned only if the transaction was committed as valid.
+//  Parameters:
+//  fcn is the chaincode function
+//  args are the arguments of the chaincode function
+//
+//  Returns:
+//  the payload returned by the chaincode
+func (c *Contract) Submit(fcn string, args ...string) ([]byte, error) {
+	var options []channel.RequestOption
+	if timeout := c.network.gateway.commitTimeout; timeout > 0 {
+		options = append(options, channel.WithTimeout(fab.Execute, timeout))
+	}
+
+	response, err := c.network.client.Execute(c.request(fcn, args), options...)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to submit transaction")
+	}
+
+	logger.Debugf("Transaction [%s] committed with code [%s]", response.TransactionID, response.TxValidationCode)
+
+	return response.Payload, nil
+}
+
+// RegisterEvent registers for chaincode events of the contract matching the given filter.
+// Call Unregister with the returned registration when the events are no longer required.
+func (c *Contract) RegisterEvent(eventFilter string) (fab.Registration, <-chan *fab.CCEvent, error) {
+	return c.network.client.RegisterChaincodeEvent(c.name, eventFilter)
+}
+
+// Unregister removes the given event registration and closes the event channel
+func (c *Contract) Unregister(registration fab.Registration) {
+	c.network.client.UnregisterChaincodeEvent(registration)
+}
+
+func (c *Contract) request(fcn string, args []string) channel.Request {
+	request := channel.Request{ChaincodeID: c.name, Fcn: fcn}
+	for _, arg := range args {
+		request.Args = append(request.Args, []byte(arg))
+	}
+	return request
+}
@@ -8,6 +8,8 @@ package api
 
 import (
 	"errors"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/core"
 )
 
 var (
@@ -18,6 +20,7 @@ var (
 // CAClient provides management of identities in a Fabric network
 type CAClient interface {
 	Enroll(enrollmentID string, enrollmentSecret string) error
+	EnrollWithCSR(enrollmentID string, enrollmentSecret string, csr []byte, key core.Key) error
 	Reenroll(enrollmentID string) error
 	Register(request *RegistrationRequest) (string, error)
 	Revoke(request *RevocationRequest) (*RevocationResponse, error)
@@ -0,0 +1,53 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package msp
+
+import (
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/json"
+	"encoding/pem"
+
+	"github.com/pkg/errors"
+)
+
+// AttributesOID is the ASN.1 object identifier (1.2.3.4.5.6.7.8.1) of the X.509 extension
+// in which the Fabric CA stores the attributes of an identity. The value of the extension
+// is a JSON document of the form {"attrs":{"name":"value",...}}.
+var AttributesOID = asn1.ObjectIdentifier{1, 2, 3, 4, 5, 6, 7, 8, 1}
+
+type attributes struct {
+	Attrs map[string]string `json:"attrs"`
+}
+
+// AttributesFromCert returns the Fabric CA attributes embedded in the given (PEM encoded) certificate.
+// An empty map is returned if the certificate doesn't contain the attribute extension.
+func AttributesFromCert(certPEM []byte) (map[string]string, error) {
+	block, _ := pem.Decode(certPEM)
+	if block == nil {
+		return nil, errors.New("PEM encoded certificate is required")
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, errors.Wrap(err, "certificate parsing failed")
+	}
+
+	for _, ext := range cert.Extensions {
+		if !ext.Id.Equal(AttributesOID) {
+			continue
+		}
+		attrs := attributes{}
+		if err := json.Unmarshal(ext.Value, &attrs); err != nil {
+			return nil, errors.Wrap(err, "unmarshal of attribute extension failed")
+		}
+		if attrs.Attrs == nil {
+			return map[string]string{}, nil
+		}
+		return attrs.Attrs, nil
+	}
+	return map[string]string{}, nil
+}
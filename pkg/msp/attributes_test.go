@@ -0,0 +1,77 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package msp
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+)
+
+func TestAttributesFromCert(t *testing.T) {
+	cert := createTestCertWithExtension(t, []byte(`{"attrs":{"hf.EnrollmentID":"user1","role":"auditor"}}`))
+	attrs, err := AttributesFromCert(cert)
+	if err != nil {
+		t.Fatalf("Failed to get attributes: %s", err)
+	}
+	if len(attrs) != 2 || attrs["hf.EnrollmentID"] != "user1" || attrs["role"] != "auditor" {
+		t.Fatalf("Unexpected attributes: %v", attrs)
+	}
+
+	user := &User{id: "user1", mspID: "Org1MSP", enrollmentCertificate: cert}
+	attrs, err = user.Attributes()
+	if err != nil {
+		t.Fatalf("Failed to get user attributes: %s", err)
+	}
+	if attrs["role"] != "auditor" {
+		t.Fatalf("Unexpected user attributes: %v", attrs)
+	}
+
+	// Certificate without the attribute extension
+	attrs, err = AttributesFromCert([]byte(testCert))
+	if err != nil {
+		t.Fatalf("Failed to get attributes: %s", err)
+	}
+	if attrs == nil || len(attrs) != 0 {
+		t.Fatalf("Expected empty attributes but got: %v", attrs)
+	}
+
+	_, err = AttributesFromCert(createTestCertWithExtension(t, []byte("not json")))
+	if err == nil {
+		t.Fatalf("Expected error for malformed attribute extension")
+	}
+
+	_, err = AttributesFromCert([]byte("not a cert"))
+	if err == nil {
+		t.Fatalf("Expected error for invalid certificate")
+	}
+}
+
+func createTestCertWithExtension(t *testing.T, value []byte) []byte {
+	privKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %s", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:    big.NewInt(1),
+		Subject:         pkix.Name{CommonName: "user1"},
+		NotBefore:       time.Now(),
+		NotAfter:        time.Now().Add(time.Hour),
+		ExtraExtensions: []pkix.Extension{{Id: AttributesOID, Value: value}},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &privKey.PublicKey, privKey)
+	if err != nil {
+		t.Fatalf("Failed to create certificate: %s", err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
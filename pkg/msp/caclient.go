@@ -7,6 +7,9 @@ SPDX-License-Identifier: Apache-2.0
 package msp
 
 import (
+	"bytes"
+	"crypto/x509"
+	"encoding/pem"
 	"fmt"
 
 	"strings"
@@ -132,6 +135,74 @@ func (c *CAClientImpl) Enroll(enrollmentID string, enrollmentSecret string) erro
 	return nil
 }
 
+// EnrollWithCSR enrolls a registered user using a pre-built (PEM encoded) CSR. The private key of the CSR
+// is never held by the SDK (e.g. it is held in an HSM), only the certificate issued by the CA is stored.
+// If key is not nil then the public key of the CSR must match it.
+func (c *CAClientImpl) EnrollWithCSR(enrollmentID string, enrollmentSecret string, csr []byte, key core.Key) error {
+
+	if c.adapter == nil {
+		return fmt.Errorf("no CAs configured for organization: %s", c.orgName)
+	}
+	if enrollmentID == "" {
+		return errors.New("enrollmentID is required")
+	}
+	if enrollmentSecret == "" {
+		return errors.New("enrollmentSecret is required")
+	}
+	if err := c.verifyCSR(csr, key); err != nil {
+		return errors.WithMessage(err, "invalid CSR")
+	}
+	cert, err := c.adapter.EnrollWithCSR(enrollmentID, enrollmentSecret, csr)
+	if err != nil {
+		return errors.Wrap(err, "enroll failed")
+	}
+	userData := &msp.UserData{
+		MSPID: c.orgMSPID,
+		ID:    enrollmentID,
+		EnrollmentCertificate: cert,
+	}
+	err = c.userStore.Store(userData)
+	if err != nil {
+		return errors.Wrap(err, "enroll failed")
+	}
+	return nil
+}
+
+// verifyCSR checks the signature of the given CSR and, if a key is given, that the public key of the CSR matches it
+func (c *CAClientImpl) verifyCSR(csrPEM []byte, key core.Key) error {
+	block, _ := pem.Decode(csrPEM)
+	if block == nil || block.Type != "CERTIFICATE REQUEST" {
+		return errors.New("PEM encoded certificate request is required")
+	}
+	csr, err := x509.ParseCertificateRequest(block.Bytes)
+	if err != nil {
+		return errors.Wrap(err, "parsing certificate request failed")
+	}
+	if err = csr.CheckSignature(); err != nil {
+		return errors.Wrap(err, "certificate request signature is not valid")
+	}
+	if key == nil {
+		return nil
+	}
+
+	csrPubKey, err := x509.MarshalPKIXPublicKey(csr.PublicKey)
+	if err != nil {
+		return errors.Wrap(err, "marshalling public key of certificate request failed")
+	}
+	pubKey, err := key.PublicKey()
+	if err != nil {
+		return errors.WithMessage(err, "fetching public key of key failed")
+	}
+	keyPubKey, err := pubKey.Bytes()
+	if err != nil {
+		return errors.WithMessage(err, "marshalling public key of key failed")
+	}
+	if !bytes.Equal(csrPubKey, keyPubKey) {
+		return errors.Errorf("public key of certificate request doesn't match key [%x]", key.SKI())
+	}
+	return nil
+}
+
 // Reenroll an enrolled user in order to obtain a new signed X509 certificate
 func (c *CAClientImpl) Reenroll(enrollmentID string) error {
 
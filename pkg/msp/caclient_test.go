@@ -7,6 +7,10 @@ SPDX-License-Identifier: Apache-2.0
 package msp
 
 import (
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
 	"testing"
 	"time"
 
@@ -14,6 +18,7 @@ import (
 	"strings"
 
 	"github.com/golang/mock/gomock"
+	fabricCaUtil "github.com/hyperledger/fabric-sdk-go/internal/github.com/hyperledger/fabric-ca/util"
 	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/core"
 	fabApi "github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
 	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/msp"
@@ -79,6 +84,64 @@ func TestEnrollAndReenroll(t *testing.T) {
 	reenrollWithAppropriateUser(f, t, enrolledUserData)
 }
 
+// TestEnrollWithCSR tests enrollment with a pre-built certificate request
+func TestEnrollWithCSR(t *testing.T) {
+
+	f := textFixture{}
+	f.setup()
+	defer f.close()
+
+	orgMSPID := mspIDByOrgName(t, f.endpointConfig, org1)
+
+	key, err := fabricCaUtil.ImportBCCSPKeyFromPEMBytes([]byte(testPrivKey), f.cryptoSuite, false)
+	if err != nil {
+		t.Fatalf("Failed to import private key: %s", err)
+	}
+	otherKey, err := fabricCaUtil.ImportBCCSPKeyFromPEMBytes(generatedKeyBytes, f.cryptoSuite, true)
+	if err != nil {
+		t.Fatalf("Failed to import private key: %s", err)
+	}
+	csr := createTestCSR(t, testPrivKey, "csrUser")
+
+	// Invalid CSR
+	err = f.caClient.EnrollWithCSR("csrUser", "enrollmentSecret", []byte(testCert), nil)
+	if err == nil || !strings.Contains(err.Error(), "certificate request is required") {
+		t.Fatalf("Expected invalid CSR error, got: %v", err)
+	}
+
+	// CSR doesn't match the expected key
+	err = f.caClient.EnrollWithCSR("csrUser", "enrollmentSecret", csr, otherKey)
+	if err == nil || !strings.Contains(err.Error(), "doesn't match key") {
+		t.Fatalf("Expected key mismatch error, got: %v", err)
+	}
+
+	enrollUsername := createRandomName()
+	err = f.caClient.EnrollWithCSR(enrollUsername, "enrollmentSecret", csr, key)
+	if err != nil {
+		t.Fatalf("EnrollWithCSR return error %v", err)
+	}
+	userData, err := f.userStore.Load(msp.IdentityIdentifier{MSPID: orgMSPID, ID: enrollUsername})
+	if err != nil {
+		t.Fatalf("Expected to load user from user store")
+	}
+	if string(userData.EnrollmentCertificate) != testCert {
+		t.Fatalf("Expected the certificate issued by the CA to be stored")
+	}
+}
+
+func createTestCSR(t *testing.T, keyPEM string, commonName string) []byte {
+	block, _ := pem.Decode([]byte(keyPEM))
+	privKey, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		t.Fatalf("Failed to parse private key: %s", err)
+	}
+	csr, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{Subject: pkix.Name{CommonName: commonName}}, privKey)
+	if err != nil {
+		t.Fatalf("Failed to create certificate request: %s", err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: csr})
+}
+
 func reenrollWithAppropriateUser(f textFixture, t *testing.T, enrolledUserData *msp.UserData) {
 	iManager, ok := f.identityManagerProvider.IdentityManager("org1")
 	if !ok {
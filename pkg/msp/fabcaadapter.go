@@ -57,6 +57,23 @@ func (c *fabricCAAdapter) Enroll(enrollmentID string, enrollmentSecret string) (
 	return caresp.Identity.GetECert().Cert(), nil
 }
 
+// EnrollWithCSR handles enrollment with a pre-built (PEM encoded) CSR.
+func (c *fabricCAAdapter) EnrollWithCSR(enrollmentID string, enrollmentSecret string, csr []byte) ([]byte, error) {
+
+	logger.Debugf("Enrolling user [%s] with CSR", enrollmentID)
+
+	careq := &caapi.EnrollmentRequest{
+		CAName: c.caClient.Config.CAName,
+		Name:   enrollmentID,
+		Secret: enrollmentSecret,
+	}
+	caresp, err := c.caClient.EnrollWithCSR(careq, csr)
+	if err != nil {
+		return nil, errors.WithMessage(err, "enroll with CSR failed")
+	}
+	return caresp.Identity.GetECert().Cert(), nil
+}
+
 // Reenroll handles re-enrollment
 func (c *fabricCAAdapter) Reenroll(key core.Key, cert []byte) ([]byte, error) {
 
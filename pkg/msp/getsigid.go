@@ -94,9 +94,15 @@ func (mgr *IdentityManager) GetUser(username string) (*User, error) { //nolint
 		if certBytes == nil {
 			return nil, msp.ErrUserNotFound
 		}
-		privateKey, err := mgr.getEmbeddedPrivateKey(username)
+		privateKey, err := mgr.getHSMPrivateKey(username, certBytes)
 		if err != nil {
-			return nil, errors.WithMessage(err, "fetching embedded private key failed")
+			return nil, errors.WithMessage(err, "fetching private key from HSM failed")
+		}
+		if privateKey == nil {
+			privateKey, err = mgr.getEmbeddedPrivateKey(username)
+			if err != nil {
+				return nil, errors.WithMessage(err, "fetching embedded private key failed")
+			}
 		}
 		if privateKey == nil {
 			privateKey, err = mgr.getPrivateKeyFromCert(username, certBytes)
@@ -0,0 +1,104 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package msp
+
+import (
+	"bytes"
+	"encoding/hex"
+	"strings"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/core"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+	"github.com/hyperledger/fabric-sdk-go/pkg/core/config/cryptoutil"
+	"github.com/pkg/errors"
+)
+
+// getHSMPrivateKey returns the private key of the given user if the key is configured to be held in a
+// PKCS#11 token (nil otherwise). The key is looked up through the crypto suite so that all private key
+// operations are performed by the token.
+func (mgr *IdentityManager) getHSMPrivateKey(username string, cert []byte) (core.Key, error) {
+	keyConfig, ok := mgr.hsmKeys[strings.ToLower(username)]
+	if !ok {
+		return nil, nil
+	}
+	return loadHSMKey(mgr.cryptoSuite, keyConfig, cert)
+}
+
+// tokenLabeler is implemented by crypto suites which use the slot of a PKCS#11 token
+type tokenLabeler interface {
+	TokenLabel() string
+}
+
+// loadHSMKey returns the private key identified by the given config from the PKCS#11 token
+// of the crypto suite and verifies that it is the key of the given certificate
+func loadHSMKey(cryptoSuite core.CryptoSuite, keyConfig fab.HSMKeyConfig, cert []byte) (core.Key, error) {
+	cryptoSuite, err := tokenSuite(cryptoSuite, keyConfig.Label)
+	if err != nil {
+		return nil, err
+	}
+
+	pubKey, err := cryptoutil.GetPublicKeyFromCert(cert, cryptoSuite)
+	if err != nil {
+		return nil, errors.WithMessage(err, "fetching public key from cert failed")
+	}
+
+	objectID := pubKey.SKI()
+	if keyConfig.ObjectID != "" {
+		objectID, err = hex.DecodeString(keyConfig.ObjectID)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid object ID [%s] for key in PKCS#11 token [%s]", keyConfig.ObjectID, keyConfig.Label)
+		}
+	}
+
+	key, err := cryptoSuite.GetKey(objectID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "key object [%x] not found in PKCS#11 token [%s]", objectID, keyConfig.Label)
+	}
+	if !key.Private() {
+		return nil, errors.Errorf("key object [%x] in PKCS#11 token [%s] is not a private key", objectID, keyConfig.Label)
+	}
+
+	if err := matchPublicKey(key, pubKey); err != nil {
+		return nil, errors.WithMessage(err, "key in PKCS#11 token doesn't match the certificate")
+	}
+	return key, nil
+}
+
+// tokenSuite returns the crypto suite which uses the slot of the PKCS#11 token with the given label.
+// An error is returned if none of the available tokens has the label.
+func tokenSuite(cryptoSuite core.CryptoSuite, label string) (core.CryptoSuite, error) {
+	if label == "" {
+		return nil, errors.New("PKCS#11 token label is required")
+	}
+	labeler, ok := cryptoSuite.(tokenLabeler)
+	if !ok || labeler.TokenLabel() == "" {
+		return nil, errors.Errorf("PKCS#11 token [%s] not found: the crypto suite is not backed by a PKCS#11 token", label)
+	}
+	if labeler.TokenLabel() != label {
+		return nil, errors.Errorf("PKCS#11 token [%s] not found: the crypto suite uses token [%s]", label, labeler.TokenLabel())
+	}
+	return cryptoSuite, nil
+}
+
+func matchPublicKey(privKey core.Key, pubKey core.Key) error {
+	keyPub, err := privKey.PublicKey()
+	if err != nil {
+		return errors.WithMessage(err, "fetching public key of private key failed")
+	}
+	keyPubBytes, err := keyPub.Bytes()
+	if err != nil {
+		return errors.WithMessage(err, "marshalling public key of private key failed")
+	}
+	certPubBytes, err := pubKey.Bytes()
+	if err != nil {
+		return errors.WithMessage(err, "marshalling public key of cert failed")
+	}
+	if !bytes.Equal(keyPubBytes, certPubBytes) {
+		return errors.New("public keys differ")
+	}
+	return nil
+}
@@ -0,0 +1,137 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package msp
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"testing"
+
+	fabricCaUtil "github.com/hyperledger/fabric-sdk-go/internal/github.com/hyperledger/fabric-ca/util"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/core"
+	providersFab "github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+	"github.com/hyperledger/fabric-sdk-go/pkg/core/config"
+	"github.com/hyperledger/fabric-sdk-go/pkg/core/cryptosuite/bccsp/sw"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab"
+)
+
+func TestLoadHSMKey(t *testing.T) {
+	cryptoConfig, _, _, _ := getConfigs(t)
+	cleanupTestPath(t, cryptoConfig.KeyStorePath())
+	defer cleanupTestPath(t, cryptoConfig.KeyStorePath())
+
+	swSuite, err := sw.GetSuiteByConfig(cryptoConfig)
+	if err != nil {
+		t.Fatalf("Failed to setup cryptoSuite: %s", err)
+	}
+	cryptoSuite := &tokenCryptoSuite{CryptoSuite: swSuite, label: "ForFabric"}
+	privateKey, err := fabricCaUtil.ImportBCCSPKeyFromPEMBytes([]byte(testPrivKey), cryptoSuite, false)
+	if err != nil {
+		t.Fatalf("Failed to import private key: %s", err)
+	}
+
+	key, err := loadHSMKey(cryptoSuite, providersFab.HSMKeyConfig{Label: "ForFabric"}, []byte(testCert))
+	if err != nil {
+		t.Fatalf("Failed to load key by certificate SKI: %s", err)
+	}
+	if !bytes.Equal(key.SKI(), privateKey.SKI()) {
+		t.Fatalf("Unexpected key loaded")
+	}
+
+	_, err = loadHSMKey(cryptoSuite, providersFab.HSMKeyConfig{Label: "ForFabric", ObjectID: hex.EncodeToString(privateKey.SKI())}, []byte(testCert))
+	if err != nil {
+		t.Fatalf("Failed to load key by object ID: %s", err)
+	}
+
+	_, err = loadHSMKey(cryptoSuite, providersFab.HSMKeyConfig{}, []byte(testCert))
+	if err == nil || !strings.Contains(err.Error(), "token label is required") {
+		t.Fatalf("Expected missing label error, got: %v", err)
+	}
+
+	_, err = loadHSMKey(cryptoSuite, providersFab.HSMKeyConfig{Label: "Other"}, []byte(testCert))
+	if err == nil || !strings.Contains(err.Error(), "PKCS#11 token [Other] not found") {
+		t.Fatalf("Expected token not found error, got: %v", err)
+	}
+
+	_, err = loadHSMKey(swSuite, providersFab.HSMKeyConfig{Label: "ForFabric"}, []byte(testCert))
+	if err == nil || !strings.Contains(err.Error(), "not backed by a PKCS#11 token") {
+		t.Fatalf("Expected token not found error, got: %v", err)
+	}
+
+	_, err = loadHSMKey(cryptoSuite, providersFab.HSMKeyConfig{Label: "ForFabric", ObjectID: "not-hex"}, []byte(testCert))
+	if err == nil || !strings.Contains(err.Error(), "invalid object ID") {
+		t.Fatalf("Expected invalid object ID error, got: %v", err)
+	}
+
+	_, err = loadHSMKey(cryptoSuite, providersFab.HSMKeyConfig{Label: "ForFabric", ObjectID: "0102030405"}, []byte(testCert))
+	if err == nil || !strings.Contains(err.Error(), "key object [0102030405] not found in PKCS#11 token [ForFabric]") {
+		t.Fatalf("Expected key not found error, got: %v", err)
+	}
+}
+
+func TestGetSigningIdentityFromHSM(t *testing.T) {
+	cryptoConfig, _, _, _ := getConfigs(t)
+	cleanupTestPath(t, cryptoConfig.KeyStorePath())
+	defer cleanupTestPath(t, cryptoConfig.KeyStorePath())
+
+	configBackend, err := config.FromFile("../../pkg/core/config/testdata/config_test_embedded_pems.yaml")()
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	endpointConfig, err := fab.ConfigFromBackend(configBackend...)
+	if err != nil {
+		panic(fmt.Sprintf("Failed to read config: %v", err))
+	}
+	identityConfig, err := ConfigFromBackend(configBackend...)
+	if err != nil {
+		panic(fmt.Sprintf("Failed to read config: %v", err))
+	}
+
+	swSuite, err := sw.GetSuiteByConfig(cryptoConfig)
+	if err != nil {
+		t.Fatalf("Failed to setup cryptoSuite: %s", err)
+	}
+	cryptoSuite := &tokenCryptoSuite{CryptoSuite: swSuite, label: "ForFabric"}
+	privateKey, err := fabricCaUtil.ImportBCCSPKeyFromPEMBytes([]byte(testPrivKey), cryptoSuite, false)
+	if err != nil {
+		t.Fatalf("Failed to import private key: %s", err)
+	}
+
+	mgr, err := NewIdentityManager(orgName, userStoreFromConfig(t, identityConfig), cryptoSuite, endpointConfig)
+	if err != nil {
+		t.Fatalf("Failed to setup credential manager: %s", err)
+	}
+	mgr.hsmKeys = map[string]providersFab.HSMKeyConfig{
+		"embeddeduser":          {Label: "ForFabric"},
+		"embeddeduserwithpaths": {Label: "ForFabric", ObjectID: "0102030405"},
+	}
+
+	id, err := mgr.GetSigningIdentity("EmbeddedUser")
+	if err != nil {
+		t.Fatalf("Failed to get signing identity: %s", err)
+	}
+	if !bytes.Equal(id.PrivateKey().SKI(), privateKey.SKI()) {
+		t.Fatalf("Expected private key to be loaded from the token")
+	}
+
+	_, err = mgr.GetSigningIdentity("EmbeddedUserWithPaths")
+	if err == nil || !strings.Contains(err.Error(), "not found in PKCS#11 token") {
+		t.Fatalf("Expected key not found error, got: %v", err)
+	}
+}
+
+// tokenCryptoSuite simulates a crypto suite which uses the slot of the PKCS#11 token with the given label
+type tokenCryptoSuite struct {
+	core.CryptoSuite
+	label string
+}
+
+func (s *tokenCryptoSuite) TokenLabel() string {
+	return s.label
+}
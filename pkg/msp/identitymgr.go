@@ -25,6 +25,7 @@ type IdentityManager struct {
 	config          fab.EndpointConfig
 	cryptoSuite     core.CryptoSuite
 	embeddedUsers   map[string]endpoint.TLSKeyPair
+	hsmKeys         map[string]fab.HSMKeyConfig
 	mspPrivKeyStore core.KVStore
 	mspCertStore    core.KVStore
 	userStore       msp.UserStore
@@ -76,6 +77,7 @@ func NewIdentityManager(orgName string, userStore msp.UserStore, cryptoSuite cor
 		mspPrivKeyStore: mspPrivKeyStore,
 		mspCertStore:    mspCertStore,
 		embeddedUsers:   orgConfig.Users,
+		hsmKeys:         orgConfig.HSMKeys,
 		userStore:       userStore,
 		// CA Client state is created lazily, when (if) needed
 	}
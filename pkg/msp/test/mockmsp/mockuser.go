@@ -52,6 +52,11 @@ func (m *MockSigningIdentity) EnrollmentCertificate() []byte {
 	return m.enrollmentCertificate
 }
 
+// Attributes returns the attributes of the identity
+func (m *MockSigningIdentity) Attributes() (map[string]string, error) {
+	return nil, nil
+}
+
 // Sign the message
 func (m *MockSigningIdentity) Sign(msg []byte) ([]byte, error) {
 	return nil, nil
@@ -8,6 +8,7 @@ import (
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
+	core "github.com/hyperledger/fabric-sdk-go/pkg/common/providers/core"
 	api "github.com/hyperledger/fabric-sdk-go/pkg/msp/api"
 )
 
@@ -46,6 +47,18 @@ func (mr *MockCAClientMockRecorder) Enroll(arg0, arg1 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Enroll", reflect.TypeOf((*MockCAClient)(nil).Enroll), arg0, arg1)
 }
 
+// EnrollWithCSR mocks base method
+func (m *MockCAClient) EnrollWithCSR(arg0, arg1 string, arg2 []byte, arg3 core.Key) error {
+	ret := m.ctrl.Call(m, "EnrollWithCSR", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnrollWithCSR indicates an expected call of EnrollWithCSR
+func (mr *MockCAClientMockRecorder) EnrollWithCSR(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnrollWithCSR", reflect.TypeOf((*MockCAClient)(nil).EnrollWithCSR), arg0, arg1, arg2, arg3)
+}
+
 // Reenroll mocks base method
 func (m *MockCAClient) Reenroll(arg0 string) error {
 	ret := m.ctrl.Call(m, "Reenroll", arg0)
@@ -51,6 +51,11 @@ func (u *User) EnrollmentCertificate() []byte {
 	return u.enrollmentCertificate
 }
 
+// Attributes returns the Fabric CA attributes embedded in the enrollment certificate
+func (u *User) Attributes() (map[string]string, error) {
+	return AttributesFromCert(u.enrollmentCertificate)
+}
+
 // PrivateKey returns the crypto suite representation of the private key
 func (u *User) PrivateKey() core.Key {
 	return u.privateKey
@@ -36,6 +36,7 @@ declare -a FILES=(
     "lib/util.go"
     "lib/serverrevoke.go"
     "lib/sdkpatch_serverstruct.go"
+    "lib/sdkpatch_enrollcsr.go"
 
     "lib/tls/tls.go"
 
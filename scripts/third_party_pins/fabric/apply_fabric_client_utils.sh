@@ -58,6 +58,7 @@ declare -a FILES=(
     "bccsp/keystore.go"
     "bccsp/opts.go"
     "bccsp/rsaopts.go"
+    "bccsp/sdkpatch_ed25519opts.go"
 
     "bccsp/factory/pkcs11/pkcs11factory.go"
     "bccsp/factory/sw/swfactory.go"
@@ -68,6 +69,7 @@ declare -a FILES=(
     "bccsp/pkcs11/ecdsakey.go"
     "bccsp/pkcs11/impl.go"
     "bccsp/pkcs11/pkcs11.go"
+    "bccsp/pkcs11/sdkpatch_tokenlabel.go"
 
     "bccsp/signer/signer.go"
 
@@ -86,6 +88,7 @@ declare -a FILES=(
     "bccsp/sw/keyimport.go"
     "bccsp/sw/rsa.go"
     "bccsp/sw/rsakey.go"
+    "bccsp/sw/sdkpatch_ed25519.go"
 
     "bccsp/utils/errs.go"
     "bccsp/utils/io.go"
@@ -127,11 +130,13 @@ declare -a FILES=(
     "msp/mspmgrimpl.go"
     "msp/mspimplsetup.go"
     "msp/mspimplvalidate.go"
+    "msp/sdkpatch_ed25519.go"
     "msp/cache/cache.go"
 
     "discovery/client/api.go"
     "discovery/client/client.go"
     "discovery/client/selection.go"
+    "discovery/client/sdkpatch_descriptor.go"
 
     "gossip/util/misc.go"
 )